@@ -98,7 +98,10 @@ func main() {
 	customerRepo := repository.NewCustomerRepository(db, rdb)
 	customerHandler := handlers.NewCustomerHandler(customerRepo)
 
-	linksHandler := handlers.NewLinksHandler(linksClientWrite, linksClientRead)
+	// Rendered QR codes only change with their parameters, so they can be kept for long.
+	qrCodes := cache.NewQRCodeCache(rdb, 24*time.Hour)
+
+	linksHandler := handlers.NewLinksHandler(linksClientWrite, linksClientRead, qrCodes)
 	eventsHandler := handlers.NewEventsHandler(eventsClient)
 
 	app := server.InitFiber(customerHandler, linksHandler, eventsHandler, rdb)
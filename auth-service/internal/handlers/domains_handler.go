@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+
+	"auth-service/internal/infra/grpc/links/pb/proto"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RegisterDomainHTTP registers a branded domain for the authenticated customer. The
+// response names the TXT record to publish before calling VerifyDomainHTTP.
+func (h *LinksHandler) RegisterDomainHTTP(c *fiber.Ctx) error {
+	var req proto.RegisterDomainRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request payload",
+		})
+	}
+
+	customerId := c.Locals("user_id")
+	if customerId == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "User not authenticated",
+		})
+	}
+	req.CustomerId = customerId.(string)
+
+	resp, err := h.RegisterDomain(c.Context(), &req)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(resp)
+}
+
+// VerifyDomainHTTP checks the TXT record of one of the authenticated customer's domains,
+// after which links can be created on it.
+func (h *LinksHandler) VerifyDomainHTTP(c *fiber.Ctx) error {
+	domain := c.Params("domain")
+	if domain == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "domain is required",
+		})
+	}
+
+	customerId := c.Locals("user_id")
+	if customerId == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "User not authenticated",
+		})
+	}
+
+	req := &proto.VerifyDomainRequest{
+		CustomerId: customerId.(string),
+		Domain:     domain,
+	}
+
+	resp, err := h.VerifyDomain(c.Context(), req)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// GetCustomerDomainsHTTP lists the authenticated customer's branded domains.
+func (h *LinksHandler) GetCustomerDomainsHTTP(c *fiber.Ctx) error {
+	customerId := c.Locals("user_id")
+	if customerId == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "User not authenticated",
+		})
+	}
+
+	req := &proto.GetCustomerDomainsRequest{
+		CustomerId: customerId.(string),
+	}
+
+	resp, err := h.GetCustomerDomains(c.Context(), req)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// DeleteDomainHTTP deletes one of the authenticated customer's branded domains, so that
+// it can be registered again. Links created on it keep their short URLs.
+func (h *LinksHandler) DeleteDomainHTTP(c *fiber.Ctx) error {
+	domain := c.Params("domain")
+	if domain == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "domain is required",
+		})
+	}
+
+	customerId := c.Locals("user_id")
+	if customerId == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "User not authenticated",
+		})
+	}
+
+	req := &proto.DeleteDomainRequest{
+		CustomerId: customerId.(string),
+		Domain:     domain,
+	}
+
+	resp, err := h.DeleteDomain(c.Context(), req)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+func (h *LinksHandler) RegisterDomain(ctx context.Context, req *proto.RegisterDomainRequest) (*proto.DomainResponse, error) {
+	if req.Domain == "" {
+		return nil, errors.New("domain is required")
+	}
+	if req.CustomerId == "" {
+		return nil, errors.New("customer_id is required")
+	}
+
+	resp, err := h.linksClientWrite.RegisterDomain(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (h *LinksHandler) VerifyDomain(ctx context.Context, req *proto.VerifyDomainRequest) (*proto.DomainResponse, error) {
+	if req.Domain == "" {
+		return nil, errors.New("domain is required")
+	}
+	if req.CustomerId == "" {
+		return nil, errors.New("customer_id is required")
+	}
+
+	resp, err := h.linksClientWrite.VerifyDomain(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (h *LinksHandler) GetCustomerDomains(ctx context.Context, req *proto.GetCustomerDomainsRequest) (*proto.GetCustomerDomainsResponse, error) {
+	if req.CustomerId == "" {
+		return nil, errors.New("customer_id is required")
+	}
+
+	resp, err := h.linksClientWrite.GetCustomerDomains(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (h *LinksHandler) DeleteDomain(ctx context.Context, req *proto.DeleteDomainRequest) (*proto.DeleteDomainResponse, error) {
+	if req.Domain == "" {
+		return nil, errors.New("domain is required")
+	}
+	if req.CustomerId == "" {
+		return nil, errors.New("customer_id is required")
+	}
+
+	resp, err := h.linksClientWrite.DeleteDomain(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
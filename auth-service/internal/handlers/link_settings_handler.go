@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+
+	"auth-service/internal/infra/grpc/links/pb/proto"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetLinkSettingsHTTP returns the settings the authenticated customer applies to all of
+// their links.
+func (h *LinksHandler) GetLinkSettingsHTTP(c *fiber.Ctx) error {
+	customerId := c.Locals("user_id")
+	if customerId == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "User not authenticated",
+		})
+	}
+
+	req := &proto.GetCustomerLinkSettingsRequest{
+		CustomerId: customerId.(string),
+	}
+
+	resp, err := h.GetCustomerLinkSettings(c.Context(), req)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// UpdateLinkSettingsHTTP replaces the settings the authenticated customer applies to all
+// of their links: the fallback URL of their inactive links, and whether visitors are
+// redirected to it ("redirect") or shown a page linking to it ("page").
+func (h *LinksHandler) UpdateLinkSettingsHTTP(c *fiber.Ctx) error {
+	var req proto.UpdateCustomerLinkSettingsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request payload",
+		})
+	}
+
+	customerId := c.Locals("user_id")
+	if customerId == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "User not authenticated",
+		})
+	}
+	req.CustomerId = customerId.(string)
+
+	resp, err := h.UpdateCustomerLinkSettings(c.Context(), &req)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+func (h *LinksHandler) GetCustomerLinkSettings(ctx context.Context, req *proto.GetCustomerLinkSettingsRequest) (*proto.CustomerLinkSettingsResponse, error) {
+	if req.CustomerId == "" {
+		return nil, errors.New("customer_id is required")
+	}
+
+	resp, err := h.linksClientWrite.GetCustomerLinkSettings(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (h *LinksHandler) UpdateCustomerLinkSettings(ctx context.Context, req *proto.UpdateCustomerLinkSettingsRequest) (*proto.CustomerLinkSettingsResponse, error) {
+	if req.CustomerId == "" {
+		return nil, errors.New("customer_id is required")
+	}
+
+	resp, err := h.linksClientWrite.UpdateCustomerLinkSettings(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,366 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"auth-service/internal/infra/grpc/links/pb/proto"
+	"auth-service/internal/logger"
+	"auth-service/internal/xlsx"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// exportColumns are the columns of CSV and XLSX link exports, in the order of
+// ExportedLink.values. Tags are separated by semicolons, as in CSV imports.
+var exportColumns = []string{
+	"id",
+	"short_url",
+	"original_url",
+	"custom_slug",
+	"slug_type",
+	"domain",
+	"title",
+	"tags",
+	"status",
+	"clicks",
+	"bot_clicks",
+	"unique_visitors",
+	"created_at",
+	"updated_at",
+	"expiration_date",
+}
+
+// exportContentTypes maps the formats of link exports to their content types.
+var exportContentTypes = map[string]string{
+	"csv":  "text/csv; charset=utf-8",
+	"json": fiber.MIMEApplicationJSONCharsetUTF8,
+	"xlsx": xlsx.ContentType,
+}
+
+// ExportedLink is a link of an export. Unlike GetLinkResponse, every field is always
+// present, so that reports see zero clicks rather than a missing key.
+type ExportedLink struct {
+	ID             string   `json:"id"`
+	ShortURL       string   `json:"short_url"`
+	OriginalURL    string   `json:"original_url"`
+	CustomSlug     string   `json:"custom_slug"`
+	SlugType       string   `json:"slug_type"`
+	Domain         string   `json:"domain"`
+	Title          string   `json:"title"`
+	Tags           []string `json:"tags"`
+	Status         string   `json:"status"`
+	Clicks         int32    `json:"clicks"`
+	BotClicks      int32    `json:"bot_clicks"`
+	UniqueVisitors int64    `json:"unique_visitors"`
+	CreatedAt      string   `json:"created_at"`
+	UpdatedAt      string   `json:"updated_at"`
+	ExpirationDate *string  `json:"expiration_date"`
+}
+
+func newExportedLink(link *proto.GetLinkResponse) ExportedLink {
+	tags := link.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	return ExportedLink{
+		ID:             link.Id,
+		ShortURL:       link.ShortUrl,
+		OriginalURL:    link.OriginalUrl,
+		CustomSlug:     link.CustomSlug,
+		SlugType:       link.SlugType,
+		Domain:         link.Domain,
+		Title:          link.Title,
+		Tags:           tags,
+		Status:         link.Status,
+		Clicks:         link.Clicks,
+		BotClicks:      link.BotClicks,
+		UniqueVisitors: link.UniqueVisitors,
+		CreatedAt:      link.CreatedAt,
+		UpdatedAt:      link.UpdatedAt,
+		ExpirationDate: link.ExpirationDate,
+	}
+}
+
+// values returns the cells of the link in the order of exportColumns.
+func (l ExportedLink) values() []any {
+	expirationDate := ""
+	if l.ExpirationDate != nil {
+		expirationDate = *l.ExpirationDate
+	}
+	return []any{
+		l.ID,
+		l.ShortURL,
+		l.OriginalURL,
+		l.CustomSlug,
+		l.SlugType,
+		l.Domain,
+		l.Title,
+		strings.Join(l.Tags, ";"),
+		l.Status,
+		l.Clicks,
+		l.BotClicks,
+		l.UniqueVisitors,
+		l.CreatedAt,
+		l.UpdatedAt,
+		expirationDate,
+	}
+}
+
+// linkExporter writes the links of an export in one of the export formats.
+type linkExporter interface {
+	Write(link ExportedLink) error
+	// Close completes the export. The output is only valid once Close succeeds.
+	Close() error
+}
+
+// newLinkExporter returns the exporter of the given format writing to w.
+func newLinkExporter(format string, w io.Writer) (linkExporter, error) {
+	switch format {
+	case "csv":
+		return newCSVLinkExporter(w)
+	case "json":
+		return &jsonLinkExporter{w: w}, nil
+	case "xlsx":
+		return newXLSXLinkExporter(w)
+	}
+	return nil, fmt.Errorf("invalid format '%s'", format)
+}
+
+type csvLinkExporter struct {
+	w *csv.Writer
+}
+
+func newCSVLinkExporter(w io.Writer) (*csvLinkExporter, error) {
+	e := &csvLinkExporter{w: csv.NewWriter(w)}
+	if err := e.w.Write(exportColumns); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (e *csvLinkExporter) Write(link ExportedLink) error {
+	values := link.values()
+	record := make([]string, len(values))
+	for i, value := range values {
+		switch v := value.(type) {
+		case string:
+			record[i] = v
+		case int32:
+			record[i] = strconv.FormatInt(int64(v), 10)
+		case int64:
+			record[i] = strconv.FormatInt(v, 10)
+		}
+	}
+	return e.w.Write(record)
+}
+
+func (e *csvLinkExporter) Close() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// jsonLinkExporter writes the links as a JSON array, one link per line.
+type jsonLinkExporter struct {
+	w       io.Writer
+	written bool
+}
+
+func (e *jsonLinkExporter) Write(link ExportedLink) error {
+	payload, err := json.Marshal(link)
+	if err != nil {
+		return err
+	}
+
+	separator := ",\n"
+	if !e.written {
+		separator = "[\n"
+	}
+	if _, err := io.WriteString(e.w, separator); err != nil {
+		return err
+	}
+	e.written = true
+
+	_, err = e.w.Write(payload)
+	return err
+}
+
+func (e *jsonLinkExporter) Close() error {
+	closing := "\n]\n"
+	if !e.written {
+		closing = "[]\n"
+	}
+	_, err := io.WriteString(e.w, closing)
+	return err
+}
+
+type xlsxLinkExporter struct {
+	w *xlsx.Writer
+}
+
+func newXLSXLinkExporter(w io.Writer) (*xlsxLinkExporter, error) {
+	workbook, err := xlsx.NewWriter(w, "Links")
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]any, len(exportColumns))
+	for i, column := range exportColumns {
+		header[i] = column
+	}
+	if err := workbook.WriteRow(header...); err != nil {
+		return nil, err
+	}
+	return &xlsxLinkExporter{w: workbook}, nil
+}
+
+func (e *xlsxLinkExporter) Write(link ExportedLink) error {
+	return e.w.WriteRow(link.values()...)
+}
+
+func (e *xlsxLinkExporter) Close() error {
+	return e.w.Close()
+}
+
+// ExportLinksHTTP downloads all the links of the authenticated customer with their click
+// totals, as a CSV (the default), JSON or XLSX file depending on the format query
+// parameter. The search, status, slug_type, sort_by and sort_direction query parameters
+// filter and order the links like they do for GetCustomerLinksHTTP.
+//
+// The links are streamed from links-service-read as the file is written, so exports are
+// not held in memory. Like other file downloads, they are not encrypted. If the stream
+// fails once the download has started, the file is left incomplete and invalid: CSV
+// exports are truncated, JSON arrays and XLSX workbooks are not closed.
+func (h *LinksHandler) ExportLinksHTTP(c *fiber.Ctx) error {
+	customerId := c.Locals("user_id")
+	if customerId == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "User not authenticated",
+		})
+	}
+
+	format := strings.ToLower(c.Query("format", "csv"))
+	contentType, ok := exportContentTypes[format]
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "format must be one of csv, json or xlsx",
+		})
+	}
+
+	req := &proto.GetCustomerLinksRequest{
+		CustomerId: customerId.(string),
+	}
+	if search := c.Query("search"); search != "" {
+		req.Search = &search
+	}
+	if status := c.Query("status"); status != "" {
+		req.Status = &status
+	}
+	if slugType := c.Query("slug_type"); slugType != "" {
+		req.SlugType = &slugType
+	}
+	if sortBy := c.Query("sort_by"); sortBy != "" {
+		req.SortBy = &sortBy
+	}
+	if sortDirection := c.Query("sort_direction"); sortDirection != "" {
+		req.SortDirection = &sortDirection
+	}
+
+	// The stream outlives the handler, so it cannot use the request context.
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, first, err := h.ExportLinks(ctx, req)
+	if err != nil {
+		cancel()
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	filename := fmt.Sprintf("links-%s.%s", time.Now().UTC().Format("2006-01-02"), format)
+	c.Set(fiber.HeaderContentType, contentType)
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Set(fiber.HeaderCacheControl, "no-store")
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer cancel()
+		exported, err := writeLinksExport(w, format, stream, first)
+		if err != nil {
+			logger.Log.Error("Links export failed",
+				zap.String("customer_id", req.CustomerId),
+				zap.Int("exported", exported),
+				zap.Error(err),
+			)
+			return
+		}
+		logger.Log.Info("Links exported",
+			zap.String("customer_id", req.CustomerId),
+			zap.Int("exported", exported),
+		)
+	})
+	return nil
+}
+
+// writeLinksExport writes the links received from the export stream, starting with
+// first (nil for an empty export), and returns the number of links written.
+func writeLinksExport(w *bufio.Writer, format string, stream proto.LinksServiceRead_ExportCustomerLinksClient, first *proto.GetLinkResponse) (int, error) {
+	exporter, err := newLinkExporter(format, w)
+	if err != nil {
+		return 0, err
+	}
+
+	exported := 0
+	for link := first; link != nil; {
+		if err := exporter.Write(newExportedLink(link)); err != nil {
+			return exported, err
+		}
+		exported++
+
+		link, err = stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return exported, err
+		}
+	}
+
+	if err := exporter.Close(); err != nil {
+		return exported, err
+	}
+	return exported, w.Flush()
+}
+
+// ExportLinks opens the export stream and waits for its first link, so that an invalid
+// request fails here instead of in the middle of the download.
+//
+// Returns the stream, the first link (nil if the customer has no matching link), and
+// an error if the request is invalid or the stream cannot be opened.
+func (h *LinksHandler) ExportLinks(ctx context.Context, req *proto.GetCustomerLinksRequest) (proto.LinksServiceRead_ExportCustomerLinksClient, *proto.GetLinkResponse, error) {
+	if req.CustomerId == "" {
+		return nil, nil, errors.New("customer_id is required")
+	}
+
+	stream, err := h.linksClientRead.ExportCustomerLinks(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	first, err := stream.Recv()
+	if errors.Is(err, io.EOF) {
+		return stream, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return stream, first, nil
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+
+	"auth-service/internal/infra/grpc/links/pb/proto"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// fakeExportStream replays links, then ends with err (io.EOF if nil).
+type fakeExportStream struct {
+	grpc.ClientStream
+	links []*proto.GetLinkResponse
+	err   error
+}
+
+func (s *fakeExportStream) Recv() (*proto.GetLinkResponse, error) {
+	if len(s.links) == 0 {
+		if s.err != nil {
+			return nil, s.err
+		}
+		return nil, io.EOF
+	}
+	link := s.links[0]
+	s.links = s.links[1:]
+	return link, nil
+}
+
+func exportLinks() []*proto.GetLinkResponse {
+	expiration := "2030-01-01T00:00:00Z"
+	return []*proto.GetLinkResponse{
+		{Id: "1", ShortUrl: "https://gobizz.com/promo", OriginalUrl: "https://example.com/a,b", CustomSlug: "promo", Clicks: 12, Tags: []string{"summer", "sale"}, ExpirationDate: &expiration},
+		{Id: "2", ShortUrl: "https://go.acme.com/x1", OriginalUrl: "https://example.com/c", Domain: "go.acme.com"},
+	}
+}
+
+func export(t *testing.T, format string, stream *fakeExportStream) ([]byte, int, error) {
+	t.Helper()
+	var buf bytes.Buffer
+	first, err := stream.Recv()
+	if errors.Is(err, io.EOF) {
+		first = nil
+	}
+	w := bufio.NewWriter(&buf)
+	exported, err := writeLinksExport(w, format, stream, first)
+	require.NoError(t, w.Flush())
+	return buf.Bytes(), exported, err
+}
+
+func TestWriteLinksExport(t *testing.T) {
+	t.Run("CSV", func(t *testing.T) {
+		output, exported, err := export(t, "csv", &fakeExportStream{links: exportLinks()})
+		require.NoError(t, err)
+		require.Equal(t, 2, exported)
+
+		records, err := csv.NewReader(bytes.NewReader(output)).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 3)
+		require.Equal(t, exportColumns, records[0])
+		require.Equal(t, "https://example.com/a,b", records[1][2])
+		require.Equal(t, "summer;sale", records[1][7])
+		require.Equal(t, "12", records[1][9])
+		require.Equal(t, "2030-01-01T00:00:00Z", records[1][14])
+		require.Equal(t, "0", records[2][9])
+		require.Equal(t, "", records[2][14])
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		output, _, err := export(t, "json", &fakeExportStream{links: exportLinks()})
+		require.NoError(t, err)
+
+		var links []map[string]any
+		require.NoError(t, json.Unmarshal(output, &links))
+		require.Len(t, links, 2)
+		require.Equal(t, float64(12), links[0]["clicks"])
+		require.Equal(t, float64(0), links[1]["clicks"], "Zero values are kept")
+		require.Equal(t, []any{}, links[1]["tags"])
+		require.Nil(t, links[1]["expiration_date"])
+
+		output, exported, err := export(t, "json", &fakeExportStream{})
+		require.NoError(t, err)
+		require.Equal(t, 0, exported)
+		require.JSONEq(t, "[]", string(output))
+	})
+
+	t.Run("XLSX", func(t *testing.T) {
+		output, exported, err := export(t, "xlsx", &fakeExportStream{links: exportLinks()})
+		require.NoError(t, err)
+		require.Equal(t, 2, exported)
+
+		archive, err := zip.NewReader(bytes.NewReader(output), int64(len(output)))
+		require.NoError(t, err)
+		require.Len(t, archive.File, 5)
+	})
+
+	t.Run("Stream failures are reported", func(t *testing.T) {
+		output, exported, err := export(t, "json", &fakeExportStream{links: exportLinks(), err: errors.New("stream reset")})
+		require.Error(t, err)
+		require.Equal(t, 2, exported)
+		require.NotEmpty(t, output)
+		require.False(t, json.Valid(output), "Incomplete exports must not look complete")
+	})
+
+	t.Run("Unknown formats are rejected", func(t *testing.T) {
+		_, _, err := export(t, "pdf", &fakeExportStream{})
+		require.Error(t, err)
+	})
+}
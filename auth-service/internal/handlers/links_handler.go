@@ -1,24 +1,38 @@
 package handlers
 
 import (
+	"bufio"
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"time"
 
+	"auth-service/internal/infra/cache"
 	"auth-service/internal/infra/grpc/links"
 	"auth-service/internal/infra/grpc/links/pb/proto"
+	"auth-service/internal/logger"
+	"auth-service/utils"
 
 	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
 )
 
+// liveHeartbeat is how often an idle click stream sends a comment line, which keeps
+// proxies from closing the connection and detects clients that went away.
+const liveHeartbeat = 15 * time.Second
+
 type LinksHandler struct {
 	linksClientWrite *links.Client
 	linksClientRead  *links.Client
+	qrCodes          *cache.QRCodeCache
 }
 
-func NewLinksHandler(linksClientWrite *links.Client, linksClientRead *links.Client) *LinksHandler {
+func NewLinksHandler(linksClientWrite *links.Client, linksClientRead *links.Client, qrCodes *cache.QRCodeCache) *LinksHandler {
 	return &LinksHandler{
 		linksClientWrite: linksClientWrite,
 		linksClientRead:  linksClientRead,
+		qrCodes:          qrCodes,
 	}
 }
 
@@ -83,6 +97,9 @@ func (h *LinksHandler) GetCustomerLinksHTTP(c *fiber.Ctx) error {
 		offset32 := int32(offset)
 		req.Offset = &offset32
 	}
+	if cursor := c.Query("cursor"); cursor != "" {
+		req.Cursor = &cursor
+	}
 
 	if search := c.Query("search"); search != "" {
 		req.Search = &search
@@ -110,6 +127,169 @@ func (h *LinksHandler) GetCustomerLinksHTTP(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusOK).JSON(resp)
 }
 
+func (h *LinksHandler) GetLinkAnalyticsHTTP(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "id is required",
+		})
+	}
+
+	customerId := c.Locals("user_id")
+	if customerId == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "User not authenticated",
+		})
+	}
+
+	req := &proto.GetLinkAnalyticsRequest{
+		LinkId:     id,
+		CustomerId: customerId.(string),
+	}
+
+	if granularity := c.Query("granularity"); granularity != "" {
+		req.Granularity = &granularity
+	}
+	if start := c.Query("start"); start != "" {
+		req.Start = &start
+	}
+	if end := c.Query("end"); end != "" {
+		req.End = &end
+	}
+	if includeBots := c.QueryBool("include_bots"); includeBots {
+		req.IncludeBots = &includeBots
+	}
+
+	resp, err := h.GetLinkAnalytics(c.Context(), req)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// WatchClicksHTTP streams the clicks on the authenticated customer's links as
+// Server-Sent Events. Mounted on /links/:id/live it watches a single link, on /links/live
+// all of them; include_bots=true also streams clicks from bots and link previewers.
+//
+// Each click is sent as a "click" event whose id is the click event ID and whose data is
+// the JSON notification, encrypted into {"Data": "..."} like every other JSON response.
+func (h *LinksHandler) WatchClicksHTTP(c *fiber.Ctx) error {
+	customerId := c.Locals("user_id")
+	if customerId == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "User not authenticated",
+		})
+	}
+
+	req := &proto.WatchClicksRequest{
+		CustomerId: customerId.(string),
+	}
+	if id := c.Params("id"); id != "" {
+		req.LinkId = &id
+	}
+	if includeBots := c.QueryBool("include_bots"); includeBots {
+		req.IncludeBots = &includeBots
+	}
+
+	// The stream outlives the handler, so it cannot use the request context.
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := h.WatchClicks(ctx, req)
+	if err != nil {
+		cancel()
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer cancel()
+		streamClicks(w, stream)
+	})
+	return nil
+}
+
+// streamClicks relays clicks from the gRPC stream to an SSE response until either side
+// goes away.
+func streamClicks(w *bufio.Writer, stream proto.LinksServiceRead_WatchClicksClient) {
+	notifications := make(chan *proto.ClickNotification)
+	done := make(chan error, 1)
+	go func() {
+		for {
+			notification, err := stream.Recv()
+			if err != nil {
+				done <- err
+				return
+			}
+			select {
+			case notifications <- notification:
+			case <-stream.Context().Done():
+				done <- stream.Context().Err()
+				return
+			}
+		}
+	}()
+
+	heartbeat := time.NewTicker(liveHeartbeat)
+	defer heartbeat.Stop()
+
+	if _, err := w.WriteString(": connected\n\n"); err != nil || w.Flush() != nil {
+		return
+	}
+
+	for {
+		select {
+		case notification := <-notifications:
+			event, err := clickEvent(notification)
+			if err != nil {
+				logger.Log.Error("Failed to encode click event", zap.Error(err))
+				continue
+			}
+			if _, err := w.WriteString(event); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := w.WriteString(": ping\n\n"); err != nil {
+				return
+			}
+		case err := <-done:
+			logger.Log.Info("Click stream ended", zap.Error(err))
+			return
+		}
+
+		if err := w.Flush(); err != nil {
+			logger.Log.Info("Click stream client disconnected")
+			return
+		}
+	}
+}
+
+func clickEvent(notification *proto.ClickNotification) (string, error) {
+	payload, err := json.Marshal(notification)
+	if err != nil {
+		return "", err
+	}
+
+	encrypted, err := utils.Encrypt(string(payload), utils.ConfigInstance.MasterKey)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := json.Marshal(struct{ Data string }{Data: encrypted})
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("id: %s\nevent: click\ndata: %s\n\n", notification.EventId, data), nil
+}
+
 func (h *LinksHandler) DeleteLinkHTTP(c *fiber.Ctx) error {
 	id := c.Params("id")
 	if id == "" {
@@ -191,7 +371,8 @@ func (h *LinksHandler) UpdateLinkClicksHTTP(c *fiber.Ctx) error {
 
 // gRPC Handlers
 func (h *LinksHandler) CreateLink(ctx context.Context, req *proto.CreateLinkRequest) (*proto.CreateLinkResponse, error) {
-	if req.OriginalUrl == "" {
+	// Links split between variants take their original URL from the first one.
+	if req.OriginalUrl == "" && len(req.Variants) == 0 {
 		return nil, errors.New("original_url is required")
 	}
 
@@ -229,6 +410,40 @@ func (h *LinksHandler) GetCustomerLinks(ctx context.Context, req *proto.GetCusto
 	return resp, nil
 }
 
+func (h *LinksHandler) GetLinkAnalytics(ctx context.Context, req *proto.GetLinkAnalyticsRequest) (*proto.GetLinkAnalyticsResponse, error) {
+	if req.LinkId == "" {
+		return nil, errors.New("link_id is required")
+	}
+	if req.CustomerId == "" {
+		return nil, errors.New("customer_id is required")
+	}
+
+	resp, err := h.linksClientRead.GetLinkAnalytics(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// WatchClicks opens the click stream and waits for links-service-read to accept it, so
+// that an invalid request fails here instead of on the first click.
+func (h *LinksHandler) WatchClicks(ctx context.Context, req *proto.WatchClicksRequest) (proto.LinksServiceRead_WatchClicksClient, error) {
+	if req.CustomerId == "" {
+		return nil, errors.New("customer_id is required")
+	}
+
+	stream, err := h.linksClientRead.WatchClicks(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := stream.Header(); err != nil {
+		return nil, err
+	}
+
+	return stream, nil
+}
+
 func (h *LinksHandler) DeleteLink(ctx context.Context, req *proto.DeleteLinkRequest) (*proto.DeleteLinkResponse, error) {
 	if req.Id == "" {
 		return nil, errors.New("id is required")
@@ -250,7 +465,7 @@ func (h *LinksHandler) UpdateLink(ctx context.Context, req *proto.UpdateLinkRequ
 		return nil, errors.New("id is required")
 	}
 
-	if req.OriginalUrl == "" {
+	if req.OriginalUrl == "" && len(req.GetVariants().GetVariants()) == 0 {
 		return nil, errors.New("original_url is required")
 	}
 
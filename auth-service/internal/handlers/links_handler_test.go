@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"auth-service/internal/infra/grpc/links"
+	proto "auth-service/internal/infra/grpc/links/pb/proto"
+	"auth-service/utils"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// fakeLinksWrite is a links-service-write that records the links it is asked to create
+// and update.
+type fakeLinksWrite struct {
+	proto.UnimplementedLinksServiceWriteServer
+	created []*proto.CreateLinkRequest
+	updated []*proto.UpdateLinkRequest
+}
+
+func (f *fakeLinksWrite) CreateLink(_ context.Context, req *proto.CreateLinkRequest) (*proto.CreateLinkResponse, error) {
+	f.created = append(f.created, req)
+	return &proto.CreateLinkResponse{Id: "link-1", Variants: req.Variants}, nil
+}
+
+func (f *fakeLinksWrite) UpdateLink(_ context.Context, req *proto.UpdateLinkRequest) (*proto.UpdateLinkResponse, error) {
+	f.updated = append(f.updated, req)
+	return &proto.UpdateLinkResponse{Id: req.Id}, nil
+}
+
+// newTestLinksHandler returns a LinksHandler whose links-service-write is fake.
+func newTestLinksHandler(t *testing.T, fake *fakeLinksWrite) *LinksHandler {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	srv := grpc.NewServer()
+	proto.RegisterLinksServiceWriteServer(srv, fake)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	config := utils.ConfigInstance
+	t.Cleanup(func() { utils.ConfigInstance = config })
+	utils.ConfigInstance.LinksServiceReadUrl = lis.Addr().String()
+	utils.ConfigInstance.LinksServiceWriteUrl = lis.Addr().String()
+
+	client, err := links.NewClient()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		client.CloseRead()
+		client.CloseWrite()
+	})
+	return NewLinksHandler(client, client, nil)
+}
+
+func TestLinksHandlerVariants(t *testing.T) {
+	variants := []*proto.DestinationVariant{
+		{Url: "https://example.com/a", Weight: 50},
+		{Url: "https://example.com/b", Weight: 50},
+	}
+
+	t.Run("Create with variants only", func(t *testing.T) {
+		fake := &fakeLinksWrite{}
+		h := newTestLinksHandler(t, fake)
+
+		resp, err := h.CreateLink(context.Background(), &proto.CreateLinkRequest{CustomerId: "customer-1", Variants: variants})
+		require.NoError(t, err)
+		require.Equal(t, "link-1", resp.Id)
+		require.Len(t, fake.created, 1)
+		require.Len(t, fake.created[0].Variants, 2)
+	})
+
+	t.Run("Create without original URL or variants", func(t *testing.T) {
+		fake := &fakeLinksWrite{}
+		h := newTestLinksHandler(t, fake)
+
+		_, err := h.CreateLink(context.Background(), &proto.CreateLinkRequest{CustomerId: "customer-1"})
+		require.EqualError(t, err, "original_url is required")
+		require.Empty(t, fake.created)
+	})
+
+	t.Run("Update with variants only", func(t *testing.T) {
+		fake := &fakeLinksWrite{}
+		h := newTestLinksHandler(t, fake)
+
+		_, err := h.UpdateLink(context.Background(), &proto.UpdateLinkRequest{
+			Id:         "link-1",
+			CustomerId: "customer-1",
+			Variants:   &proto.DestinationVariants{Variants: variants},
+		})
+		require.NoError(t, err)
+		require.Len(t, fake.updated, 1)
+	})
+
+	t.Run("Update clearing variants without original URL", func(t *testing.T) {
+		fake := &fakeLinksWrite{}
+		h := newTestLinksHandler(t, fake)
+
+		_, err := h.UpdateLink(context.Background(), &proto.UpdateLinkRequest{
+			Id:         "link-1",
+			CustomerId: "customer-1",
+			Variants:   &proto.DestinationVariants{},
+		})
+		require.EqualError(t, err, "original_url is required")
+		require.Empty(t, fake.updated)
+	})
+}
@@ -0,0 +1,273 @@
+package handlers
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"auth-service/internal/infra/grpc/links/pb/proto"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// maxImportRows is the maximum number of links of a CSV import, matching the limit of
+// links-service-write's BulkCreateLinks.
+const maxImportRows = 1000
+
+// importColumns are the columns a links CSV can have; only original_url is required.
+// Tags are separated by semicolons.
+var importColumns = map[string]bool{
+	"original_url":    true,
+	"custom_slug":     true,
+	"title":           true,
+	"tags":            true,
+	"expiration_date": true,
+	"activates_at":    true,
+	"fallback_url":    true,
+	"domain":          true,
+	"utm_source":      true,
+	"utm_medium":      true,
+	"utm_campaign":    true,
+	"utm_term":        true,
+	"utm_content":     true,
+	"utm_template_id": true,
+}
+
+// importRow is a link read from a CSV import, with the line it was read from.
+type importRow struct {
+	Line int
+	Link *proto.CreateLinkRequest
+}
+
+// ImportResult is the outcome of a row of a CSV import: the created link, or the gRPC
+// status code and message it failed with.
+type ImportResult struct {
+	Line  int                       `json:"line"`
+	Link  *proto.CreateLinkResponse `json:"link,omitempty"`
+	Code  string                    `json:"code,omitempty"`
+	Error string                    `json:"error,omitempty"`
+}
+
+// ImportResponse is the response of a CSV import, with one result per row in file order.
+type ImportResponse struct {
+	DryRun  bool           `json:"dry_run"`
+	Created int            `json:"created"`
+	Failed  int            `json:"failed"`
+	Results []ImportResult `json:"results"`
+}
+
+// ImportLinksHTTP creates the links listed in the CSV file uploaded in the "file" field
+// of a multipart form, for the authenticated customer. The first line of the file names
+// the columns, see importColumns. Rows that cannot be read or fail validation are
+// reported in the results without preventing the others from being created.
+//
+// With dry_run=true (query or form field), the rows are validated but nothing is created.
+func (h *LinksHandler) ImportLinksHTTP(c *fiber.Ctx) error {
+	customerId := c.Locals("user_id")
+	if customerId == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "User not authenticated",
+		})
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "file is required",
+		})
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Failed to read file",
+		})
+	}
+	defer file.Close()
+
+	rows, rejected, err := parseLinksCSV(file)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	dryRun := c.QueryBool("dry_run") || c.FormValue("dry_run") == "true"
+
+	resp, err := h.ImportLinks(c.Context(), customerId.(string), rows, rejected, dryRun)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	status := fiber.StatusCreated
+	if dryRun || resp.Created == 0 {
+		status = fiber.StatusOK
+	}
+	return c.Status(status).JSON(resp)
+}
+
+// ImportLinks sends the readable rows of a CSV import to links-service-write in a single
+// BulkCreateLinks call, and merges its results with the rows rejected while reading.
+func (h *LinksHandler) ImportLinks(ctx context.Context, customerId string, rows []importRow, rejected []ImportResult, dryRun bool) (*ImportResponse, error) {
+	if customerId == "" {
+		return nil, errors.New("customer_id is required")
+	}
+
+	resp := &ImportResponse{DryRun: dryRun}
+
+	if len(rows) > 0 {
+		req := &proto.BulkCreateLinksRequest{
+			CustomerId: customerId,
+			DryRun:     dryRun,
+		}
+		for _, row := range rows {
+			req.Links = append(req.Links, row.Link)
+		}
+
+		bulk, err := h.linksClientWrite.BulkCreateLinks(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, result := range bulk.Results {
+			resp.Results = append(resp.Results, ImportResult{
+				Line:  rows[result.Index].Line,
+				Link:  result.Link,
+				Code:  result.Code,
+				Error: result.Error,
+			})
+		}
+	}
+
+	resp.Results = mergeImportResults(resp.Results, rejected)
+	for _, result := range resp.Results {
+		if result.Code == "" {
+			resp.Created++
+		} else {
+			resp.Failed++
+		}
+	}
+	return resp, nil
+}
+
+// parseLinksCSV reads the links of a CSV import.
+//
+// Returns:
+//   - The rows read, in file order.
+//   - The rows that could not be read, such as rows with a wrong number of fields or
+//     without original_url, as failed results.
+//   - An error if the file is unreadable, its header is invalid or it has more than
+//     maxImportRows rows.
+func parseLinksCSV(r io.Reader) ([]importRow, []ImportResult, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil, errors.New("file is empty")
+		}
+		return nil, nil, fmt.Errorf("invalid CSV header: %v", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if !importColumns[name] {
+			return nil, nil, fmt.Errorf("unknown column %q", name)
+		}
+		if _, ok := columns[name]; ok {
+			return nil, nil, fmt.Errorf("duplicate column %q", name)
+		}
+		columns[name] = i
+	}
+	if _, ok := columns["original_url"]; !ok {
+		return nil, nil, errors.New("column \"original_url\" is required")
+	}
+
+	var rows []importRow
+	var rejected []ImportResult
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) && errors.Is(parseErr.Err, csv.ErrFieldCount) {
+				rejected = append(rejected, ImportResult{Line: parseErr.StartLine, Code: "InvalidArgument",
+					Error: fmt.Sprintf("expected %d fields, got %d", len(header), len(record))})
+				continue
+			}
+			return nil, nil, fmt.Errorf("invalid CSV: %v", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		if len(rows)+len(rejected) >= maxImportRows {
+			return nil, nil, fmt.Errorf("at most %d links can be imported at once", maxImportRows)
+		}
+
+		field := func(name string) string {
+			if i, ok := columns[name]; ok {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		link := &proto.CreateLinkRequest{
+			OriginalUrl:   field("original_url"),
+			CustomSlug:    field("custom_slug"),
+			Title:         field("title"),
+			Domain:        field("domain"),
+			UtmTemplateId: field("utm_template_id"),
+			FallbackUrl:   field("fallback_url"),
+		}
+		if link.OriginalUrl == "" {
+			rejected = append(rejected, ImportResult{Line: line, Code: "InvalidArgument", Error: "original_url is required"})
+			continue
+		}
+		if tags := field("tags"); tags != "" {
+			for _, tag := range strings.Split(tags, ";") {
+				link.Tags = append(link.Tags, strings.TrimSpace(tag))
+			}
+		}
+		if expirationDate := field("expiration_date"); expirationDate != "" {
+			link.ExpirationDate = &expirationDate
+		}
+		if activatesAt := field("activates_at"); activatesAt != "" {
+			link.ActivatesAt = &activatesAt
+		}
+		utm := &proto.UTMParams{
+			Source:   field("utm_source"),
+			Medium:   field("utm_medium"),
+			Campaign: field("utm_campaign"),
+			Term:     field("utm_term"),
+			Content:  field("utm_content"),
+		}
+		if utm.Source != "" || utm.Medium != "" || utm.Campaign != "" || utm.Term != "" || utm.Content != "" {
+			link.Utm = utm
+		}
+
+		rows = append(rows, importRow{Line: line, Link: link})
+	}
+
+	return rows, rejected, nil
+}
+
+// mergeImportResults merges two lists of results sorted by line into one.
+func mergeImportResults(a, b []ImportResult) []ImportResult {
+	merged := make([]ImportResult, 0, len(a)+len(b))
+	for len(a) > 0 && len(b) > 0 {
+		if a[0].Line <= b[0].Line {
+			merged, a = append(merged, a[0]), a[1:]
+		} else {
+			merged, b = append(merged, b[0]), b[1:]
+		}
+	}
+	return append(append(merged, a...), b...)
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLinksCSV(t *testing.T) {
+	t.Run("Reads rows and rejects unreadable ones", func(t *testing.T) {
+		input := "\ufeffOriginal_URL,custom_slug,tags,expiration_date\n" +
+			"https://example.com/a,promo,summer; sale,2030-01-01T00:00:00Z\n" +
+			"https://example.com/b,,,\n" +
+			"https://example.com/c,too,many,fields,here\n" +
+			",orphan,,\n" +
+			"\"https://example.com/d?x=1,2\",,,\n"
+
+		rows, rejected, err := parseLinksCSV(strings.NewReader(input))
+		require.NoError(t, err)
+
+		require.Len(t, rows, 3)
+		require.Equal(t, 2, rows[0].Line)
+		require.Equal(t, "promo", rows[0].Link.CustomSlug)
+		require.Equal(t, []string{"summer", "sale"}, rows[0].Link.Tags)
+		require.Equal(t, "2030-01-01T00:00:00Z", *rows[0].Link.ExpirationDate)
+		require.Nil(t, rows[1].Link.ExpirationDate)
+		require.Equal(t, 6, rows[2].Line)
+		require.Equal(t, "https://example.com/d?x=1,2", rows[2].Link.OriginalUrl)
+
+		require.Len(t, rejected, 2)
+		require.Equal(t, 4, rejected[0].Line)
+		require.Equal(t, 5, rejected[1].Line)
+		require.Equal(t, "original_url is required", rejected[1].Error)
+	})
+
+	t.Run("Reads UTM parameters", func(t *testing.T) {
+		input := "original_url,utm_source,utm_campaign,utm_template_id\n" +
+			"https://example.com/a,newsletter,summer_sale,\n" +
+			"https://example.com/b,,,tpl1\n"
+
+		rows, rejected, err := parseLinksCSV(strings.NewReader(input))
+		require.NoError(t, err)
+		require.Empty(t, rejected)
+
+		require.Len(t, rows, 2)
+		require.Equal(t, "newsletter", rows[0].Link.Utm.Source)
+		require.Equal(t, "summer_sale", rows[0].Link.Utm.Campaign)
+		require.Nil(t, rows[1].Link.Utm)
+		require.Equal(t, "tpl1", rows[1].Link.UtmTemplateId)
+	})
+
+	t.Run("Reads activation dates and fallback URLs", func(t *testing.T) {
+		input := "original_url,activates_at,fallback_url\n" +
+			"https://example.com/launch,2030-01-01T09:00:00Z,https://example.com/soon\n" +
+			"https://example.com/now,,\n"
+
+		rows, rejected, err := parseLinksCSV(strings.NewReader(input))
+		require.NoError(t, err)
+		require.Empty(t, rejected)
+
+		require.Len(t, rows, 2)
+		require.Equal(t, "2030-01-01T09:00:00Z", rows[0].Link.GetActivatesAt())
+		require.Equal(t, "https://example.com/soon", rows[0].Link.FallbackUrl)
+		require.Nil(t, rows[1].Link.ActivatesAt)
+		require.Empty(t, rows[1].Link.FallbackUrl)
+	})
+
+	t.Run("Rejects invalid headers", func(t *testing.T) {
+		for _, input := range []string{"", "custom_slug\npromo\n", "original_url,url\n", "original_url,title,Title\n"} {
+			_, _, err := parseLinksCSV(strings.NewReader(input))
+			require.Error(t, err, input)
+		}
+	})
+
+	t.Run("Limits the number of rows", func(t *testing.T) {
+		input := "original_url\n" + strings.Repeat("https://example.com\n", maxImportRows+1)
+		_, _, err := parseLinksCSV(strings.NewReader(input))
+		require.Error(t, err)
+	})
+}
+
+func TestMergeImportResults(t *testing.T) {
+	merged := mergeImportResults(
+		[]ImportResult{{Line: 2}, {Line: 5}},
+		[]ImportResult{{Line: 3}, {Line: 4}, {Line: 9}},
+	)
+
+	var lines []int
+	for _, result := range merged {
+		lines = append(lines, result.Line)
+	}
+	require.Equal(t, []int{2, 3, 4, 5, 9}, lines)
+}
@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"auth-service/internal/infra/grpc/links/pb/proto"
+	"auth-service/internal/logger"
+	"auth-service/internal/qrcode"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// Bounds of the QR code rendering parameters.
+const (
+	defaultQRCodeSize   = 512
+	minQRCodeSize       = 64
+	maxQRCodeSize       = 2048
+	defaultQRCodeMargin = 4
+	maxQRCodeMargin     = 16
+	// maxQRCodeLogoBytes bounds the size of uploaded logos.
+	maxQRCodeLogoBytes = 512 << 10
+)
+
+// qrCodeContentTypes maps the formats of QR codes to their content types.
+var qrCodeContentTypes = map[string]string{
+	"png": "image/png",
+	"svg": "image/svg+xml",
+}
+
+// qrCodeParams are the rendering parameters of a QR code request.
+type qrCodeParams struct {
+	Format  string
+	Level   qrcode.Level
+	Options qrcode.Options
+}
+
+// parseQRCodeParams reads the rendering parameters of a QR code request from its query
+// string, and the logo from the "logo" field of a multipart form, if any.
+//
+// Query parameters (all optional):
+//   - format: "png" (default) or "svg".
+//   - size: The width and height of the image in pixels, from 64 to 2048 (default 512).
+//   - margin: The quiet zone in modules, from 0 to 16 (default 4).
+//   - ecc: The error correction level, L, M, Q or H (default M, or H with a logo).
+//   - fg, bg: The colors of the dark and light modules, as hexadecimal RGB (default
+//     black on white). The foreground must be darker than the background.
+func parseQRCodeParams(c *fiber.Ctx) (*qrCodeParams, error) {
+	params := &qrCodeParams{
+		Format: strings.ToLower(c.Query("format", "png")),
+		Level:  qrcode.M,
+		Options: qrcode.Options{
+			Size:   c.QueryInt("size", defaultQRCodeSize),
+			Margin: c.QueryInt("margin", defaultQRCodeMargin),
+		},
+	}
+	if _, ok := qrCodeContentTypes[params.Format]; !ok {
+		return nil, errors.New("format must be png or svg")
+	}
+	if params.Options.Size < minQRCodeSize || params.Options.Size > maxQRCodeSize {
+		return nil, fmt.Errorf("size must be between %d and %d", minQRCodeSize, maxQRCodeSize)
+	}
+	if params.Options.Margin < 0 || params.Options.Margin > maxQRCodeMargin {
+		return nil, fmt.Errorf("margin must be between 0 and %d", maxQRCodeMargin)
+	}
+
+	var err error
+	if params.Options.Foreground, err = qrcode.ParseColor(c.Query("fg", "#000000")); err != nil {
+		return nil, err
+	}
+	if params.Options.Background, err = qrcode.ParseColor(c.Query("bg", "#ffffff")); err != nil {
+		return nil, err
+	}
+
+	if fileHeader, err := c.FormFile("logo"); err == nil {
+		if fileHeader.Size > maxQRCodeLogoBytes {
+			return nil, fmt.Errorf("logo must not exceed %d KB", maxQRCodeLogoBytes>>10)
+		}
+		file, err := fileHeader.Open()
+		if err != nil {
+			return nil, errors.New("failed to read logo")
+		}
+		defer file.Close()
+
+		data, err := io.ReadAll(io.LimitReader(file, maxQRCodeLogoBytes))
+		if err != nil {
+			return nil, errors.New("failed to read logo")
+		}
+		if params.Options.Logo, err = qrcode.DecodeLogo(data); err != nil {
+			return nil, err
+		}
+		params.Level = qrcode.H
+	}
+
+	if ecc := c.Query("ecc"); ecc != "" {
+		if params.Level, err = qrcode.ParseLevel(ecc); err != nil {
+			return nil, err
+		}
+	}
+	return params, nil
+}
+
+// hash returns the hash of everything that determines the QR code of shortURL, which
+// identifies the rendered image in the cache and in its ETag.
+func (p *qrCodeParams) hash(shortURL string) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n%s\n%s\n%d\n%d\n%s\n%s\n",
+		shortURL,
+		p.Format,
+		p.Level,
+		p.Options.Size,
+		p.Options.Margin,
+		qrcode.FormatColor(p.Options.Foreground),
+		qrcode.FormatColor(p.Options.Background),
+	)
+	if p.Options.Logo != nil {
+		h.Write(p.Options.Logo.Data)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// render encodes shortURL as a QR code and renders it in the requested format.
+func (p *qrCodeParams) render(shortURL string) ([]byte, error) {
+	code, err := qrcode.Encode([]byte(shortURL), p.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if p.Format == "svg" {
+		err = code.SVG(&buf, p.Options)
+	} else {
+		err = code.PNG(&buf, p.Options)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// QRCodeHTTP renders the short URL of one of the authenticated customer's links as a QR
+// code, see parseQRCodeParams for the parameters. Mounted with GET, and with POST to
+// upload a PNG or JPEG logo, drawn at the center, in the "logo" field of a multipart form.
+//
+// Images are cached by the hash of the short URL and the rendering parameters, which is
+// also their ETag, so requests repeating a previous one are not rendered again and
+// conditional requests get a 304 Not Modified.
+func (h *LinksHandler) QRCodeHTTP(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "id is required",
+		})
+	}
+
+	customerId := c.Locals("user_id")
+	if customerId == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "User not authenticated",
+		})
+	}
+
+	params, err := parseQRCodeParams(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	link, err := h.GetLinkByID(c.Context(), &proto.GetLinkByIDRequest{
+		Id:         id,
+		CustomerId: customerId.(string),
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	hash := params.hash(link.ShortUrl)
+	etag := `"` + hash + `"`
+	c.Set(fiber.HeaderETag, etag)
+	c.Set(fiber.HeaderCacheControl, "private, max-age=86400")
+	if c.Get(fiber.HeaderIfNoneMatch) == etag {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
+	image, cached, err := h.qrCodes.Get(c.Context(), hash)
+	if err != nil {
+		logger.Log.Warn("Failed to read cached QR code", zap.Error(err))
+	}
+	if !cached {
+		image, err = params.render(link.ShortUrl)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		if err := h.qrCodes.Set(c.Context(), hash, image); err != nil {
+			logger.Log.Warn("Failed to cache QR code", zap.Error(err))
+		}
+	}
+
+	c.Set(fiber.HeaderContentType, qrCodeContentTypes[params.Format])
+	return c.Status(fiber.StatusOK).Send(image)
+}
+
+func (h *LinksHandler) GetLinkByID(ctx context.Context, req *proto.GetLinkByIDRequest) (*proto.GetLinkResponse, error) {
+	if req.Id == "" {
+		return nil, errors.New("id is required")
+	}
+	if req.CustomerId == "" {
+		return nil, errors.New("customer_id is required")
+	}
+
+	resp, err := h.linksClientRead.GetLinkByID(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
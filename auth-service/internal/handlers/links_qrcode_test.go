@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"mime/multipart"
+	"net/http/httptest"
+	"testing"
+
+	"auth-service/internal/qrcode"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+// parseQRCodeRequest runs parseQRCodeParams on a request to target, with body as a
+// multipart form holding a logo if set.
+func parseQRCodeRequest(t *testing.T, target string, logo []byte) (*qrCodeParams, error) {
+	t.Helper()
+
+	var params *qrCodeParams
+	var parseErr error
+	app := fiber.New()
+	app.All("/qrcode", func(c *fiber.Ctx) error {
+		params, parseErr = parseQRCodeParams(c)
+		return nil
+	})
+
+	req := httptest.NewRequest(fiber.MethodGet, target, nil)
+	if logo != nil {
+		var body bytes.Buffer
+		form := multipart.NewWriter(&body)
+		part, err := form.CreateFormFile("logo", "logo.png")
+		require.NoError(t, err)
+		_, err = part.Write(logo)
+		require.NoError(t, err)
+		require.NoError(t, form.Close())
+
+		req = httptest.NewRequest(fiber.MethodPost, target, &body)
+		req.Header.Set(fiber.HeaderContentType, form.FormDataContentType())
+	}
+
+	_, err := app.Test(req)
+	require.NoError(t, err)
+	return params, parseErr
+}
+
+func TestParseQRCodeParams(t *testing.T) {
+	var logo bytes.Buffer
+	require.NoError(t, png.Encode(&logo, image.NewRGBA(image.Rect(0, 0, 10, 10))))
+
+	t.Run("Defaults", func(t *testing.T) {
+		params, err := parseQRCodeRequest(t, "/qrcode", nil)
+		require.NoError(t, err)
+		require.Equal(t, "png", params.Format)
+		require.Equal(t, qrcode.M, params.Level)
+		require.Equal(t, defaultQRCodeSize, params.Options.Size)
+		require.Equal(t, defaultQRCodeMargin, params.Options.Margin)
+		require.Equal(t, "#000000", qrcode.FormatColor(params.Options.Foreground))
+		require.Equal(t, "#ffffff", qrcode.FormatColor(params.Options.Background))
+		require.Nil(t, params.Options.Logo)
+	})
+
+	t.Run("Custom parameters", func(t *testing.T) {
+		params, err := parseQRCodeRequest(t, "/qrcode?format=SVG&size=300&margin=2&ecc=q&fg=%23123456&bg=fffff0", nil)
+		require.NoError(t, err)
+		require.Equal(t, "svg", params.Format)
+		require.Equal(t, qrcode.Q, params.Level)
+		require.Equal(t, 300, params.Options.Size)
+		require.Equal(t, 2, params.Options.Margin)
+		require.Equal(t, "#123456", qrcode.FormatColor(params.Options.Foreground))
+		require.Equal(t, "#fffff0", qrcode.FormatColor(params.Options.Background))
+	})
+
+	t.Run("Logos raise the default level to H", func(t *testing.T) {
+		params, err := parseQRCodeRequest(t, "/qrcode", logo.Bytes())
+		require.NoError(t, err)
+		require.NotNil(t, params.Options.Logo)
+		require.Equal(t, qrcode.H, params.Level)
+
+		params, err = parseQRCodeRequest(t, "/qrcode?ecc=Q", logo.Bytes())
+		require.NoError(t, err)
+		require.Equal(t, qrcode.Q, params.Level)
+	})
+
+	t.Run("Invalid parameters are rejected", func(t *testing.T) {
+		for _, target := range []string{
+			"/qrcode?format=gif",
+			"/qrcode?size=10",
+			"/qrcode?size=5000",
+			"/qrcode?margin=-1",
+			"/qrcode?margin=50",
+			"/qrcode?ecc=X",
+			"/qrcode?fg=blue",
+		} {
+			_, err := parseQRCodeRequest(t, target, nil)
+			require.Error(t, err, target)
+		}
+
+		_, err := parseQRCodeRequest(t, "/qrcode", []byte("not an image"))
+		require.Error(t, err)
+	})
+}
+
+func TestQRCodeParams(t *testing.T) {
+	params, err := parseQRCodeRequest(t, "/qrcode", nil)
+	require.NoError(t, err)
+
+	t.Run("The hash covers the content and every parameter", func(t *testing.T) {
+		hash := params.hash("https://gobizz.com/promo")
+		require.Equal(t, hash, params.hash("https://gobizz.com/promo"))
+		require.NotEqual(t, hash, params.hash("https://gobizz.com/other"))
+
+		other := *params
+		other.Options.Margin++
+		require.NotEqual(t, hash, other.hash("https://gobizz.com/promo"))
+
+		other = *params
+		other.Format = "svg"
+		require.NotEqual(t, hash, other.hash("https://gobizz.com/promo"))
+	})
+
+	t.Run("Rendering", func(t *testing.T) {
+		image, err := params.render("https://gobizz.com/promo")
+		require.NoError(t, err)
+		config, err := png.DecodeConfig(bytes.NewReader(image))
+		require.NoError(t, err)
+		require.Equal(t, defaultQRCodeSize, config.Width)
+
+		svg := *params
+		svg.Format = "svg"
+		image, err = svg.render("https://gobizz.com/promo")
+		require.NoError(t, err)
+		require.True(t, bytes.HasPrefix(image, []byte("<svg")))
+
+		inverted := *params
+		inverted.Options.Foreground, inverted.Options.Background = params.Options.Background, params.Options.Foreground
+		_, err = inverted.render("https://gobizz.com/promo")
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+
+	"auth-service/internal/infra/grpc/links/pb/proto"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// CreateUTMTemplateHTTP stores a named set of UTM parameters for the authenticated
+// customer. Links pick them up by passing the template ID as utm_template_id.
+func (h *LinksHandler) CreateUTMTemplateHTTP(c *fiber.Ctx) error {
+	var req proto.CreateUTMTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request payload",
+		})
+	}
+
+	customerId := c.Locals("user_id")
+	if customerId == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "User not authenticated",
+		})
+	}
+	req.CustomerId = customerId.(string)
+
+	resp, err := h.CreateUTMTemplate(c.Context(), &req)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(resp)
+}
+
+// GetCustomerUTMTemplatesHTTP lists the authenticated customer's UTM templates.
+func (h *LinksHandler) GetCustomerUTMTemplatesHTTP(c *fiber.Ctx) error {
+	customerId := c.Locals("user_id")
+	if customerId == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "User not authenticated",
+		})
+	}
+
+	req := &proto.GetCustomerUTMTemplatesRequest{
+		CustomerId: customerId.(string),
+	}
+
+	resp, err := h.GetCustomerUTMTemplates(c.Context(), req)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// DeleteUTMTemplateHTTP deletes one of the authenticated customer's UTM templates. Links
+// created with it keep their UTM parameters.
+func (h *LinksHandler) DeleteUTMTemplateHTTP(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "id is required",
+		})
+	}
+
+	customerId := c.Locals("user_id")
+	if customerId == nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "User not authenticated",
+		})
+	}
+
+	req := &proto.DeleteUTMTemplateRequest{
+		Id:         id,
+		CustomerId: customerId.(string),
+	}
+
+	resp, err := h.DeleteUTMTemplate(c.Context(), req)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+func (h *LinksHandler) CreateUTMTemplate(ctx context.Context, req *proto.CreateUTMTemplateRequest) (*proto.UTMTemplateResponse, error) {
+	if req.Name == "" {
+		return nil, errors.New("name is required")
+	}
+	if req.CustomerId == "" {
+		return nil, errors.New("customer_id is required")
+	}
+
+	resp, err := h.linksClientWrite.CreateUTMTemplate(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (h *LinksHandler) GetCustomerUTMTemplates(ctx context.Context, req *proto.GetCustomerUTMTemplatesRequest) (*proto.GetCustomerUTMTemplatesResponse, error) {
+	if req.CustomerId == "" {
+		return nil, errors.New("customer_id is required")
+	}
+
+	resp, err := h.linksClientWrite.GetCustomerUTMTemplates(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (h *LinksHandler) DeleteUTMTemplate(ctx context.Context, req *proto.DeleteUTMTemplateRequest) (*proto.DeleteUTMTemplateResponse, error) {
+	if req.Id == "" {
+		return nil, errors.New("id is required")
+	}
+	if req.CustomerId == "" {
+		return nil, errors.New("customer_id is required")
+	}
+
+	resp, err := h.linksClientWrite.DeleteUTMTemplate(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,59 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// QRCodeCache stores rendered QR code images in Redis, keyed by the hash of everything
+// that determines their content, so that identical requests are rendered once.
+type QRCodeCache struct {
+	redis *redis.Client
+	ttl   time.Duration
+}
+
+// NewQRCodeCache creates a QRCodeCache keeping images for ttl after they are rendered.
+//
+// Parameters:
+//   - redis: A pointer to the Redis client; nil disables the cache.
+//   - ttl: How long rendered images are kept.
+//
+// Returns:
+//   - A pointer to the QRCodeCache.
+func NewQRCodeCache(redis *redis.Client, ttl time.Duration) *QRCodeCache {
+	return &QRCodeCache{redis: redis, ttl: ttl}
+}
+
+func qrCodeKey(hash string) string {
+	return "qrcode:" + hash
+}
+
+// Get returns the image cached under hash.
+//
+// Returns:
+//   - The image, and true if it is cached.
+//   - An error if Redis cannot be reached.
+func (c *QRCodeCache) Get(ctx context.Context, hash string) ([]byte, bool, error) {
+	if c == nil || c.redis == nil {
+		return nil, false, nil
+	}
+
+	image, err := c.redis.Get(ctx, qrCodeKey(hash)).Bytes()
+	if err == redis.Nil {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return image, true, nil
+}
+
+// Set caches image under hash.
+func (c *QRCodeCache) Set(ctx context.Context, hash string, image []byte) error {
+	if c == nil || c.redis == nil {
+		return nil
+	}
+	return c.redis.Set(ctx, qrCodeKey(hash), image, c.ttl).Err()
+}
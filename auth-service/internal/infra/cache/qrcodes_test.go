@@ -0,0 +1,43 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQRCodeCache(t *testing.T) {
+	s, err := miniredis.Run()
+	require.NoError(t, err)
+	defer s.Close()
+
+	ctx := context.Background()
+	qrCodes := NewQRCodeCache(redis.NewClient(&redis.Options{Addr: s.Addr()}), time.Hour)
+
+	_, ok, err := qrCodes.Get(ctx, "abc")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	require.NoError(t, qrCodes.Set(ctx, "abc", []byte("image")))
+	image, ok, err := qrCodes.Get(ctx, "abc")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, []byte("image"), image)
+
+	s.FastForward(2 * time.Hour)
+	_, ok, err = qrCodes.Get(ctx, "abc")
+	require.NoError(t, err)
+	require.False(t, ok, "Images expire")
+
+	t.Run("A nil client disables the cache", func(t *testing.T) {
+		disabled := NewQRCodeCache(nil, time.Hour)
+		require.NoError(t, disabled.Set(ctx, "abc", []byte("image")))
+		_, ok, err := disabled.Get(ctx, "abc")
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+}
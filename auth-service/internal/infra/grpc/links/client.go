@@ -59,10 +59,26 @@ func (c *Client) GetLink(ctx context.Context, request *proto.GetLinkRequest) (*p
 	return c.linksRead.GetLink(ctx, request)
 }
 
+func (c *Client) GetLinkByID(ctx context.Context, request *proto.GetLinkByIDRequest) (*proto.GetLinkResponse, error) {
+	return c.linksRead.GetLinkByID(ctx, request)
+}
+
 func (c *Client) GetCustomerLinks(ctx context.Context, request *proto.GetCustomerLinksRequest) (*proto.GetCustomerLinksResponse, error) {
 	return c.linksRead.GetCustomerLinks(ctx, request)
 }
 
+func (c *Client) GetLinkAnalytics(ctx context.Context, request *proto.GetLinkAnalyticsRequest) (*proto.GetLinkAnalyticsResponse, error) {
+	return c.linksRead.GetLinkAnalytics(ctx, request)
+}
+
+func (c *Client) WatchClicks(ctx context.Context, request *proto.WatchClicksRequest) (proto.LinksServiceRead_WatchClicksClient, error) {
+	return c.linksRead.WatchClicks(ctx, request)
+}
+
+func (c *Client) ExportCustomerLinks(ctx context.Context, request *proto.GetCustomerLinksRequest) (proto.LinksServiceRead_ExportCustomerLinksClient, error) {
+	return c.linksRead.ExportCustomerLinks(ctx, request)
+}
+
 func (c *Client) DeleteLink(ctx context.Context, request *proto.DeleteLinkRequest) (*proto.DeleteLinkResponse, error) {
 	return c.linksWrite.DeleteLink(ctx, request)
 }
@@ -74,3 +90,43 @@ func (c *Client) UpdateLink(ctx context.Context, request *proto.UpdateLinkReques
 func (c *Client) UpdateLinkClicks(ctx context.Context, request *proto.UpdateLinkClicksRequest) (*proto.UpdateLinkClicksResponse, error) {
 	return c.linksWrite.UpdateLinkClicks(ctx, request)
 }
+
+func (c *Client) RegisterDomain(ctx context.Context, request *proto.RegisterDomainRequest) (*proto.DomainResponse, error) {
+	return c.linksWrite.RegisterDomain(ctx, request)
+}
+
+func (c *Client) VerifyDomain(ctx context.Context, request *proto.VerifyDomainRequest) (*proto.DomainResponse, error) {
+	return c.linksWrite.VerifyDomain(ctx, request)
+}
+
+func (c *Client) GetCustomerDomains(ctx context.Context, request *proto.GetCustomerDomainsRequest) (*proto.GetCustomerDomainsResponse, error) {
+	return c.linksWrite.GetCustomerDomains(ctx, request)
+}
+
+func (c *Client) DeleteDomain(ctx context.Context, request *proto.DeleteDomainRequest) (*proto.DeleteDomainResponse, error) {
+	return c.linksWrite.DeleteDomain(ctx, request)
+}
+
+func (c *Client) BulkCreateLinks(ctx context.Context, request *proto.BulkCreateLinksRequest) (*proto.BulkCreateLinksResponse, error) {
+	return c.linksWrite.BulkCreateLinks(ctx, request)
+}
+
+func (c *Client) CreateUTMTemplate(ctx context.Context, request *proto.CreateUTMTemplateRequest) (*proto.UTMTemplateResponse, error) {
+	return c.linksWrite.CreateUTMTemplate(ctx, request)
+}
+
+func (c *Client) GetCustomerUTMTemplates(ctx context.Context, request *proto.GetCustomerUTMTemplatesRequest) (*proto.GetCustomerUTMTemplatesResponse, error) {
+	return c.linksWrite.GetCustomerUTMTemplates(ctx, request)
+}
+
+func (c *Client) DeleteUTMTemplate(ctx context.Context, request *proto.DeleteUTMTemplateRequest) (*proto.DeleteUTMTemplateResponse, error) {
+	return c.linksWrite.DeleteUTMTemplate(ctx, request)
+}
+
+func (c *Client) GetCustomerLinkSettings(ctx context.Context, request *proto.GetCustomerLinkSettingsRequest) (*proto.CustomerLinkSettingsResponse, error) {
+	return c.linksWrite.GetCustomerLinkSettings(ctx, request)
+}
+
+func (c *Client) UpdateCustomerLinkSettings(ctx context.Context, request *proto.UpdateCustomerLinkSettingsRequest) (*proto.CustomerLinkSettingsResponse, error) {
+	return c.linksWrite.UpdateCustomerLinkSettings(ctx, request)
+}
@@ -5,6 +5,7 @@ import (
 	"auth-service/utils"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
@@ -59,6 +60,13 @@ func decryptRequest(c *fiber.Ctx, key string) error {
 		return nil
 	}
 
+	// File uploads (e.g. CSV link imports) are sent as multipart forms, which cannot be
+	// wrapped in an encrypted payload without breaking their parsing.
+	if strings.HasPrefix(c.Get(fiber.HeaderContentType), fiber.MIMEMultipartForm) {
+		logger.Log.Info("Request body is a multipart form, skipping decryption")
+		return nil
+	}
+
 	var payload struct {
 		Data string `json:"data"`
 	}
@@ -88,11 +96,18 @@ func decryptRequest(c *fiber.Ctx, key string) error {
 }
 
 func encryptResponse(c *fiber.Ctx, key string) error {
-	originalBody := c.Response().Body()
 	contentType := c.Response().Header.ContentType()
 
-	if len(originalBody) == 0 || string(contentType) != fiber.MIMEApplicationJSON {
-		logger.Log.Info("Response body is empty or not JSON, skipping encryption")
+	// Streamed responses (e.g. Server-Sent Events) encrypt their own payloads; reading
+	// their body here would block until the stream ends.
+	if c.Response().IsBodyStream() || string(contentType) != fiber.MIMEApplicationJSON {
+		logger.Log.Info("Response body is streamed or not JSON, skipping encryption")
+		return nil
+	}
+
+	originalBody := c.Response().Body()
+	if len(originalBody) == 0 {
+		logger.Log.Info("Response body is empty, skipping encryption")
 		return nil
 	}
 
@@ -3,8 +3,11 @@ package server
 import (
 	"auth-service/internal/logger"
 	"auth-service/utils"
+	"bufio"
 	"bytes"
 	"encoding/json"
+	"io"
+	"mime/multipart"
 	"net/http/httptest"
 	"os"
 	"testing"
@@ -32,6 +35,20 @@ func TestEncryptionMiddleware(t *testing.T) {
 	app.Post("/test", func(c *fiber.Ctx) error {
 		return c.JSON(testResponse{Message: "success"})
 	})
+	app.Post("/upload", func(c *fiber.Ctx) error {
+		file, err := c.FormFile("file")
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).SendString(err.Error())
+		}
+		return c.Status(fiber.StatusNoContent).SendString(file.Filename)
+	})
+	app.Get("/stream", func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, "text/event-stream")
+		c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+			w.WriteString("data: hello\n\n")
+		})
+		return nil
+	})
 
 	t.Run("Should encrypt/decrypt complete flow", func(t *testing.T) {
 		originalPayload := "secret message"
@@ -83,4 +100,35 @@ func TestEncryptionMiddleware(t *testing.T) {
 
 		require.Equal(t, fiber.StatusOK, resp.StatusCode, "Should handle empty body")
 	})
+
+	t.Run("Should not encrypt streamed responses", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/stream", nil)
+
+		resp, err := app.Test(req)
+		require.NoError(t, err, "Request should succeed")
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err, "Reading the stream should succeed")
+		require.Equal(t, "data: hello\n\n", string(body), "Stream should be passed through untouched")
+	})
+
+	t.Run("Should not decrypt multipart uploads", func(t *testing.T) {
+		var body bytes.Buffer
+		form := multipart.NewWriter(&body)
+		part, err := form.CreateFormFile("file", "links.csv")
+		require.NoError(t, err)
+		_, err = part.Write([]byte("original_url\nhttps://example.com\n"))
+		require.NoError(t, err)
+		require.NoError(t, form.Close())
+
+		req := httptest.NewRequest("POST", "/upload", &body)
+		req.Header.Set("Content-Type", form.FormDataContentType())
+
+		resp, err := app.Test(req)
+		require.NoError(t, err, "Request should succeed")
+		defer resp.Body.Close()
+
+		require.Equal(t, fiber.StatusNoContent, resp.StatusCode, "The form should reach the handler intact")
+	})
 }
@@ -26,12 +26,37 @@ func setupRoutes(app *fiber.App, customerHandler *handlers.CustomerHandler, link
 	// Links routes - protected by auth middleware
 	links := v1.Group("/links", middleware.AuthMiddleware(rdb))
 	links.Post("/", linksHandler.CreateLinkHTTP)
+	links.Post("/import", linksHandler.ImportLinksHTTP)
 	links.Put("/:id", linksHandler.UpdateLinkHTTP)
 	links.Put("/:id/clicks", linksHandler.UpdateLinkClicksHTTP)
+	links.Get("/live", linksHandler.WatchClicksHTTP)
+	links.Get("/export", linksHandler.ExportLinksHTTP)
+	links.Get("/:id/analytics", linksHandler.GetLinkAnalyticsHTTP)
+	links.Get("/:id/live", linksHandler.WatchClicksHTTP)
+	links.Get("/:id/qrcode", linksHandler.QRCodeHTTP)
+	links.Post("/:id/qrcode", linksHandler.QRCodeHTTP)
 	links.Get("/:shortUrl", linksHandler.GetLinkHTTP)
 	links.Get("/customer/:customerId", linksHandler.GetCustomerLinksHTTP)
 	links.Delete("/:id", linksHandler.DeleteLinkHTTP)
 
+	// Branded domains routes - protected by auth middleware
+	domains := v1.Group("/domains", middleware.AuthMiddleware(rdb))
+	domains.Post("/", linksHandler.RegisterDomainHTTP)
+	domains.Get("/", linksHandler.GetCustomerDomainsHTTP)
+	domains.Post("/:domain/verify", linksHandler.VerifyDomainHTTP)
+	domains.Delete("/:domain", linksHandler.DeleteDomainHTTP)
+
+	// UTM templates routes - protected by auth middleware
+	utmTemplates := v1.Group("/utm-templates", middleware.AuthMiddleware(rdb))
+	utmTemplates.Post("/", linksHandler.CreateUTMTemplateHTTP)
+	utmTemplates.Get("/", linksHandler.GetCustomerUTMTemplatesHTTP)
+	utmTemplates.Delete("/:id", linksHandler.DeleteUTMTemplateHTTP)
+
+	// Link settings routes - protected by auth middleware
+	linkSettings := v1.Group("/link-settings", middleware.AuthMiddleware(rdb))
+	linkSettings.Get("/", linksHandler.GetLinkSettingsHTTP)
+	linkSettings.Put("/", linksHandler.UpdateLinkSettingsHTTP)
+
 	// Events routes - protected by auth middleware
 	events := v1.Group("/events", middleware.AuthMiddleware(rdb))
 	events.Get("/occurrences", eventsHandler.ListOccurrencesHTTP)
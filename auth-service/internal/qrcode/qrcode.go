@@ -0,0 +1,512 @@
+// Package qrcode encodes QR codes (ISO/IEC 18004, model 2) and renders them as PNG or
+// SVG images. Content is always encoded in byte mode, which suits URLs; the smallest
+// version holding the content at the requested error correction level is used, with
+// the mask scoring the lowest penalty.
+package qrcode
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Level is the error correction level of a QR code: the share of the symbol that can be
+// damaged or covered, e.g. by a logo, while keeping it readable.
+type Level int
+
+const (
+	// L recovers about 7% of the codewords.
+	L Level = iota
+	// M recovers about 15% of the codewords.
+	M
+	// Q recovers about 25% of the codewords.
+	Q
+	// H recovers about 30% of the codewords.
+	H
+)
+
+// ParseLevel parses an error correction level name: "L", "M", "Q" or "H", in any case.
+func ParseLevel(s string) (Level, error) {
+	switch strings.ToUpper(s) {
+	case "L":
+		return L, nil
+	case "M":
+		return M, nil
+	case "Q":
+		return Q, nil
+	case "H":
+		return H, nil
+	}
+	return 0, fmt.Errorf("invalid error correction level '%s'", s)
+}
+
+func (l Level) String() string {
+	return [...]string{"L", "M", "Q", "H"}[l]
+}
+
+// formatBits are the two bits identifying the level in the format information.
+func (l Level) formatBits() int {
+	return [...]int{1, 0, 3, 2}[l]
+}
+
+const (
+	minVersion = 1
+	maxVersion = 40
+)
+
+// eccCodewordsPerBlock is the number of error correction codewords of each block, by
+// level and version (index 0 is unused).
+var eccCodewordsPerBlock = [4][41]int{
+	{-1, 7, 10, 15, 20, 26, 18, 20, 24, 30, 18, 20, 24, 26, 30, 22, 24, 28, 30, 28, 28, 28, 28, 30, 30, 26, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	{-1, 10, 16, 26, 18, 24, 16, 18, 22, 22, 26, 30, 22, 22, 24, 24, 28, 28, 26, 26, 26, 26, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28},
+	{-1, 13, 22, 18, 26, 18, 24, 18, 22, 20, 24, 28, 26, 24, 20, 30, 24, 28, 28, 26, 30, 28, 30, 30, 30, 30, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	{-1, 17, 28, 22, 16, 22, 28, 26, 26, 24, 28, 24, 28, 22, 24, 24, 30, 28, 28, 26, 28, 30, 24, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+}
+
+// eccBlocks is the number of error correction blocks, by level and version (index 0 is
+// unused).
+var eccBlocks = [4][41]int{
+	{-1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 4, 4, 4, 4, 4, 6, 6, 6, 6, 7, 8, 8, 9, 9, 10, 12, 12, 12, 13, 14, 15, 16, 17, 18, 19, 19, 20, 21, 22, 24, 25},
+	{-1, 1, 1, 1, 2, 2, 4, 4, 4, 5, 5, 5, 8, 9, 9, 10, 10, 11, 13, 14, 16, 17, 17, 18, 20, 21, 23, 25, 26, 28, 29, 31, 33, 35, 37, 38, 40, 43, 45, 47, 49},
+	{-1, 1, 1, 2, 2, 4, 4, 6, 6, 8, 8, 8, 10, 12, 16, 12, 17, 16, 18, 21, 20, 23, 23, 25, 27, 29, 34, 34, 35, 38, 40, 43, 45, 48, 51, 53, 56, 59, 62, 65, 68},
+	{-1, 1, 1, 2, 4, 4, 4, 5, 6, 8, 8, 11, 11, 16, 16, 18, 16, 19, 21, 25, 25, 25, 34, 30, 32, 35, 37, 40, 42, 45, 48, 51, 54, 57, 60, 63, 66, 70, 74, 77, 81},
+}
+
+// Code is an encoded QR code: a square of Size×Size dark or light modules, without the
+// quiet zone.
+type Code struct {
+	Version int
+	Level   Level
+	Size    int
+	Mask    int
+
+	modules    [][]bool
+	isFunction [][]bool
+}
+
+// Encode encodes content as a QR code.
+//
+// Parameters:
+//   - content: The bytes to encode, typically a URL.
+//   - level: The error correction level.
+//
+// Returns:
+//   - A pointer to the encoded Code, of the smallest version that holds the content.
+//   - An error if the content does not fit in a version 40 symbol at that level.
+func Encode(content []byte, level Level) (*Code, error) {
+	if level < L || level > H {
+		return nil, fmt.Errorf("invalid error correction level %d", level)
+	}
+
+	version := minVersion
+	for ; ; version++ {
+		if version > maxVersion {
+			return nil, fmt.Errorf("content too long: %d bytes do not fit at level %s", len(content), level)
+		}
+		if 4+charCountBits(version)+8*len(content) <= numDataCodewords(version, level)*8 {
+			break
+		}
+	}
+
+	var bits bitBuffer
+	bits.append(0b0100, 4) // Byte mode
+	bits.append(len(content), charCountBits(version))
+	for _, b := range content {
+		bits.append(int(b), 8)
+	}
+
+	capacity := numDataCodewords(version, level) * 8
+	bits.append(0, min(4, capacity-len(bits)))
+	bits.append(0, (8-len(bits)%8)%8)
+	for pad := 0xEC; len(bits) < capacity; pad ^= 0xEC ^ 0x11 {
+		bits.append(pad, 8)
+	}
+
+	data := make([]byte, len(bits)/8)
+	for i, bit := range bits {
+		if bit {
+			data[i>>3] |= 1 << (7 - i&7)
+		}
+	}
+
+	size := version*4 + 17
+	c := &Code{
+		Version:    version,
+		Level:      level,
+		Size:       size,
+		modules:    newGrid(size),
+		isFunction: newGrid(size),
+	}
+	c.drawFunctionPatterns()
+	c.drawCodewords(c.addECCAndInterleave(data))
+
+	bestPenalty := -1
+	for mask := range 8 {
+		c.applyMask(mask)
+		c.drawFormatBits(mask)
+		penalty := c.penalty()
+		if bestPenalty < 0 || penalty < bestPenalty {
+			c.Mask, bestPenalty = mask, penalty
+		}
+		c.applyMask(mask) // XOR is its own inverse
+	}
+	c.applyMask(c.Mask)
+	c.drawFormatBits(c.Mask)
+
+	return c, nil
+}
+
+// Dark reports whether the module at column x and row y is dark. Modules outside the
+// symbol, in the quiet zone, are light.
+func (c *Code) Dark(x, y int) bool {
+	return x >= 0 && x < c.Size && y >= 0 && y < c.Size && c.modules[y][x]
+}
+
+func newGrid(size int) [][]bool {
+	grid := make([][]bool, size)
+	for i := range grid {
+		grid[i] = make([]bool, size)
+	}
+	return grid
+}
+
+// charCountBits is the length of the character count of byte mode segments.
+func charCountBits(version int) int {
+	if version <= 9 {
+		return 8
+	}
+	return 16
+}
+
+// numRawDataModules is the number of modules available for data and error correction
+// in a symbol of the given version, once the function patterns are drawn.
+func numRawDataModules(version int) int {
+	result := (16*version+128)*version + 64
+	if version >= 2 {
+		numAlign := version/7 + 2
+		result -= (25*numAlign-10)*numAlign - 55
+		if version >= 7 {
+			result -= 36
+		}
+	}
+	return result
+}
+
+// numDataCodewords is the number of data codewords of a symbol, without the error
+// correction codewords and the remainder bits.
+func numDataCodewords(version int, level Level) int {
+	return numRawDataModules(version)/8 - eccCodewordsPerBlock[level][version]*eccBlocks[level][version]
+}
+
+// alignmentPatternPositions returns the coordinates of the centers of the alignment
+// patterns on each axis.
+func alignmentPatternPositions(version int) []int {
+	if version == 1 {
+		return nil
+	}
+	numAlign := version/7 + 2
+	step := (version*8 + numAlign*3 + 5) / (numAlign*4 - 4) * 2
+	positions := make([]int, numAlign)
+	positions[0] = 6
+	for i, pos := numAlign-1, version*4+17-7; i >= 1; i, pos = i-1, pos-step {
+		positions[i] = pos
+	}
+	return positions
+}
+
+func (c *Code) setFunction(x, y int, dark bool) {
+	c.modules[y][x] = dark
+	c.isFunction[y][x] = true
+}
+
+func (c *Code) drawFunctionPatterns() {
+	for i := range c.Size {
+		c.setFunction(6, i, i%2 == 0)
+		c.setFunction(i, 6, i%2 == 0)
+	}
+
+	c.drawFinderPattern(3, 3)
+	c.drawFinderPattern(c.Size-4, 3)
+	c.drawFinderPattern(3, c.Size-4)
+
+	positions := alignmentPatternPositions(c.Version)
+	last := len(positions) - 1
+	for i, x := range positions {
+		for j, y := range positions {
+			// The corners holding finder patterns have no alignment pattern.
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			c.drawAlignmentPattern(x, y)
+		}
+	}
+
+	// Reserve the format areas; their content depends on the mask.
+	c.drawFormatBits(0)
+	c.drawVersion()
+}
+
+// drawFinderPattern draws a finder pattern centered on (x, y), with its separator.
+func (c *Code) drawFinderPattern(x, y int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			xx, yy := x+dx, y+dy
+			if xx < 0 || xx >= c.Size || yy < 0 || yy >= c.Size {
+				continue
+			}
+			dist := max(abs(dx), abs(dy))
+			c.setFunction(xx, yy, dist != 2 && dist != 4)
+		}
+	}
+}
+
+func (c *Code) drawAlignmentPattern(x, y int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			c.setFunction(x+dx, y+dy, max(abs(dx), abs(dy)) != 1)
+		}
+	}
+}
+
+// formatBits returns the 15 bits of format information of the level and mask, BCH
+// protected and masked.
+func formatBits(level Level, mask int) int {
+	data := level.formatBits()<<3 | mask
+	rem := data
+	for range 10 {
+		rem = rem<<1 ^ (rem>>9)*0x537
+	}
+	return (data<<10 | rem) ^ 0x5412
+}
+
+func (c *Code) drawFormatBits(mask int) {
+	bits := formatBits(c.Level, mask)
+	bit := func(i int) bool { return bits>>i&1 != 0 }
+
+	// First copy, around the top left finder pattern.
+	for i := range 6 {
+		c.setFunction(8, i, bit(i))
+	}
+	c.setFunction(8, 7, bit(6))
+	c.setFunction(8, 8, bit(7))
+	c.setFunction(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		c.setFunction(14-i, 8, bit(i))
+	}
+
+	// Second copy, split between the other two finder patterns.
+	for i := range 8 {
+		c.setFunction(c.Size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		c.setFunction(8, c.Size-15+i, bit(i))
+	}
+	c.setFunction(8, c.Size-8, true) // Dark module
+}
+
+// versionBits returns the 18 bits of version information, BCH protected.
+func versionBits(version int) int {
+	rem := version
+	for range 12 {
+		rem = rem<<1 ^ (rem>>11)*0x1F25
+	}
+	return version<<12 | rem
+}
+
+func (c *Code) drawVersion() {
+	if c.Version < 7 {
+		return
+	}
+	bits := versionBits(c.Version)
+	for i := range 18 {
+		dark := bits>>i&1 != 0
+		a, b := c.Size-11+i%3, i/3
+		c.setFunction(a, b, dark)
+		c.setFunction(b, a, dark)
+	}
+}
+
+// addECCAndInterleave splits the data codewords into blocks, appends the error
+// correction codewords of each block, and interleaves the blocks.
+func (c *Code) addECCAndInterleave(data []byte) []byte {
+	numBlocks := eccBlocks[c.Level][c.Version]
+	blockECCLen := eccCodewordsPerBlock[c.Level][c.Version]
+	rawCodewords := numRawDataModules(c.Version) / 8
+	numShortBlocks := numBlocks - rawCodewords%numBlocks
+	shortBlockLen := rawCodewords / numBlocks
+
+	divisor := reedSolomonDivisor(blockECCLen)
+	blocks := make([][]byte, numBlocks)
+	for i, k := 0, 0; i < numBlocks; i++ {
+		dataLen := shortBlockLen - blockECCLen
+		if i >= numShortBlocks {
+			dataLen++
+		}
+		block := make([]byte, 0, shortBlockLen+1)
+		block = append(block, data[k:k+dataLen]...)
+		k += dataLen
+		ecc := reedSolomonRemainder(block, divisor)
+		if i < numShortBlocks {
+			block = append(block, 0) // Placeholder, skipped when interleaving
+		}
+		blocks[i] = append(block, ecc...)
+	}
+
+	result := make([]byte, 0, rawCodewords)
+	for i := range shortBlockLen + 1 {
+		for j, block := range blocks {
+			if i != shortBlockLen-blockECCLen || j >= numShortBlocks {
+				result = append(result, block[i])
+			}
+		}
+	}
+	return result
+}
+
+// drawCodewords places the codewords in the zigzag order of the specification, two
+// columns at a time from the bottom right corner, skipping the function modules.
+func (c *Code) drawCodewords(codewords []byte) {
+	i := 0
+	for right := c.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5 // Skip the vertical timing pattern
+		}
+		upward := (right+1)&2 == 0
+		for vert := range c.Size {
+			y := vert
+			if upward {
+				y = c.Size - 1 - vert
+			}
+			for j := range 2 {
+				x := right - j
+				if c.isFunction[y][x] || i >= len(codewords)*8 {
+					continue
+				}
+				c.modules[y][x] = codewords[i>>3]>>(7-i&7)&1 != 0
+				i++
+			}
+		}
+	}
+}
+
+// maskFuncs are the eight data masks; a module is flipped where its mask returns true.
+var maskFuncs = [8]func(x, y int) bool{
+	func(x, y int) bool { return (x+y)%2 == 0 },
+	func(x, y int) bool { return y%2 == 0 },
+	func(x, y int) bool { return x%3 == 0 },
+	func(x, y int) bool { return (x+y)%3 == 0 },
+	func(x, y int) bool { return (x/3+y/2)%2 == 0 },
+	func(x, y int) bool { return x*y%2+x*y%3 == 0 },
+	func(x, y int) bool { return (x*y%2+x*y%3)%2 == 0 },
+	func(x, y int) bool { return ((x+y)%2+x*y%3)%2 == 0 },
+}
+
+func (c *Code) applyMask(mask int) {
+	for y := range c.Size {
+		for x := range c.Size {
+			if !c.isFunction[y][x] && maskFuncs[mask](x, y) {
+				c.modules[y][x] = !c.modules[y][x]
+			}
+		}
+	}
+}
+
+// Penalty weights of the mask evaluation rules.
+const (
+	penaltyRun    = 3
+	penaltyBlock  = 3
+	penaltyFinder = 40
+	penaltyDark   = 10
+)
+
+// penalty scores the symbol against the mask evaluation rules; the mask with the lowest
+// score is the easiest to read.
+func (c *Code) penalty() int {
+	result := 0
+	for i := range c.Size {
+		result += linePenalty(func(j int) bool { return c.modules[i][j] }, c.Size)
+		result += linePenalty(func(j int) bool { return c.modules[j][i] }, c.Size)
+	}
+
+	dark := 0
+	for y := range c.Size {
+		for x := range c.Size {
+			if c.modules[y][x] {
+				dark++
+			}
+			if x+1 < c.Size && y+1 < c.Size {
+				color := c.modules[y][x]
+				if color == c.modules[y][x+1] && color == c.modules[y+1][x] && color == c.modules[y+1][x+1] {
+					result += penaltyBlock
+				}
+			}
+		}
+	}
+
+	// Deviation of the proportion of dark modules from 50%, by steps of 5%.
+	total := c.Size * c.Size
+	k := (abs(dark*20-total*10)+total-1)/total - 1
+	return result + max(k, 0)*penaltyDark
+}
+
+// finderLike is the 1:1:3:1:1 pattern of finder patterns, which must not be mimicked
+// next to four light modules.
+var finderLike = []bool{true, false, true, true, true, false, true}
+
+// linePenalty scores a row or column of the symbol for runs of five or more modules of
+// the same color, and for finder-like patterns preceded or followed by light modules.
+// Modules outside the symbol count as light.
+func linePenalty(module func(int) bool, size int) int {
+	at := func(i int) bool { return i >= 0 && i < size && module(i) }
+
+	result := 0
+	run := 0
+	for i := range size {
+		if i > 0 && module(i) == module(i-1) {
+			run++
+		} else {
+			run = 1
+		}
+		if run == 5 {
+			result += penaltyRun
+		} else if run > 5 {
+			result++
+		}
+	}
+
+	for i := range size - len(finderLike) + 1 {
+		matches := true
+		for j, dark := range finderLike {
+			if module(i+j) != dark {
+				matches = false
+				break
+			}
+		}
+		if !matches {
+			continue
+		}
+		if !at(i-1) && !at(i-2) && !at(i-3) && !at(i-4) {
+			result += penaltyFinder
+		}
+		end := i + len(finderLike)
+		if !at(end) && !at(end+1) && !at(end+2) && !at(end+3) {
+			result += penaltyFinder
+		}
+	}
+	return result
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// bitBuffer is a sequence of bits, most significant first.
+type bitBuffer []bool
+
+func (b *bitBuffer) append(value, length int) {
+	for i := length - 1; i >= 0; i-- {
+		*b = append(*b, value>>i&1 != 0)
+	}
+}
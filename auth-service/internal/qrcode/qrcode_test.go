@@ -0,0 +1,181 @@
+package qrcode
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// readContent reads the content back from a code: it checks the format information,
+// removes the mask, collects the codewords, checks the error correction codewords of
+// each block and parses the byte mode segment.
+func readContent(t *testing.T, c *Code) []byte {
+	t.Helper()
+
+	format := 0
+	for i := 14; i >= 9; i-- {
+		format = format<<1 | bit(c.Dark(14-i, 8))
+	}
+	format = format<<1 | bit(c.Dark(7, 8))
+	format = format<<1 | bit(c.Dark(8, 8))
+	format = format<<1 | bit(c.Dark(8, 7))
+	for i := 5; i >= 0; i-- {
+		format = format<<1 | bit(c.Dark(8, i))
+	}
+	require.Equal(t, formatBits(c.Level, c.Mask), format, "Format information")
+
+	var bits []bool
+	for right := c.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := range c.Size {
+			y := vert
+			if (right+1)&2 == 0 {
+				y = c.Size - 1 - vert
+			}
+			for x := right; x >= right-1; x-- {
+				if !c.isFunction[y][x] {
+					bits = append(bits, c.Dark(x, y) != maskFuncs[c.Mask](x, y))
+				}
+			}
+		}
+	}
+	codewords := make([]byte, len(bits)/8)
+	for i := range codewords {
+		for _, b := range bits[i*8 : i*8+8] {
+			codewords[i] = codewords[i]<<1 | byte(bit(b))
+		}
+	}
+
+	numBlocks := eccBlocks[c.Level][c.Version]
+	eccLen := eccCodewordsPerBlock[c.Level][c.Version]
+	blocks := make([][]byte, numBlocks)
+	numShortBlocks := numBlocks - len(codewords)%numBlocks
+	dataLen := len(codewords)/numBlocks - eccLen
+	k := 0
+	for i := range dataLen + 1 {
+		for j := range blocks {
+			if i < dataLen || j >= numShortBlocks {
+				blocks[j] = append(blocks[j], codewords[k])
+				k++
+			}
+		}
+	}
+	for range eccLen {
+		for j := range blocks {
+			blocks[j] = append(blocks[j], codewords[k])
+			k++
+		}
+	}
+
+	var data []byte
+	divisor := reedSolomonDivisor(eccLen)
+	for _, block := range blocks {
+		n := len(block) - eccLen
+		require.Equal(t, reedSolomonRemainder(block[:n], divisor), block[n:], "Error correction codewords")
+		data = append(data, block[:n]...)
+	}
+
+	require.Equal(t, byte(0b0100), data[0]>>4, "Byte mode")
+	var length, offset int
+	if c.Version <= 9 {
+		length = int(data[0]&0x0F)<<4 | int(data[1]>>4)
+		offset = 1
+	} else {
+		length = int(data[0]&0x0F)<<12 | int(data[1])<<4 | int(data[2]>>4)
+		offset = 2
+	}
+	content := make([]byte, length)
+	for i := range content {
+		content[i] = data[offset+i]<<4 | data[offset+i+1]>>4
+	}
+	return content
+}
+
+func bit(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func TestEncode(t *testing.T) {
+	t.Run("Contents can be read back", func(t *testing.T) {
+		for _, n := range []int{1, 20, 100, 400, 1000, 2000} {
+			for level := L; level <= H; level++ {
+				content := []byte(strings.Repeat("https://gobizz.com/summer-sale?", n/31+1)[:n])
+				code, err := Encode(content, level)
+				if level >= Q && n == 2000 {
+					require.Error(t, err, "Too long for levels Q and H")
+					continue
+				}
+				require.NoError(t, err)
+				require.Equal(t, code.Version*4+17, code.Size)
+				require.Equal(t, content, readContent(t, code), "%d bytes at level %s", n, level)
+			}
+		}
+	})
+
+	t.Run("The smallest version holding the content is used", func(t *testing.T) {
+		// Byte mode capacities of versions 1 and 40.
+		for level, capacity := range map[Level]int{L: 17, M: 14, Q: 11, H: 7} {
+			code, err := Encode(make([]byte, capacity), level)
+			require.NoError(t, err)
+			require.Equal(t, 1, code.Version)
+
+			code, err = Encode(make([]byte, capacity+1), level)
+			require.NoError(t, err)
+			require.Equal(t, 2, code.Version)
+		}
+		for level, capacity := range map[Level]int{L: 2953, M: 2331, Q: 1663, H: 1273} {
+			code, err := Encode(make([]byte, capacity), level)
+			require.NoError(t, err)
+			require.Equal(t, 40, code.Version)
+
+			_, err = Encode(make([]byte, capacity+1), level)
+			require.Error(t, err)
+		}
+	})
+
+	t.Run("Finder patterns are drawn in three corners", func(t *testing.T) {
+		code, err := Encode([]byte("https://gobizz.com/promo"), M)
+		require.NoError(t, err)
+		for _, corner := range [][2]int{{0, 0}, {code.Size - 7, 0}, {0, code.Size - 7}} {
+			for i := range 7 {
+				require.True(t, code.Dark(corner[0]+i, corner[1]))
+				require.True(t, code.Dark(corner[0], corner[1]+i))
+			}
+			require.False(t, code.Dark(corner[0]+1, corner[1]+1))
+			require.True(t, code.Dark(corner[0]+3, corner[1]+3))
+		}
+		require.False(t, code.Dark(-1, 0), "Quiet zone")
+	})
+}
+
+func TestReedSolomon(t *testing.T) {
+	// The codewords of "HELLO WORLD" at 1-Q, a well-known worked example.
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236}
+	ecc := []byte{168, 72, 22, 82, 217, 54, 156, 0, 46, 15, 180, 122, 16}
+	require.Equal(t, ecc, reedSolomonRemainder(data, reedSolomonDivisor(len(ecc))))
+}
+
+func TestFormatAndVersionBits(t *testing.T) {
+	require.Equal(t, 0b101010000010010, formatBits(M, 0))
+	require.Equal(t, 0b111011111000100, formatBits(L, 0))
+	require.Equal(t, 0b001011010001001, formatBits(H, 0))
+	require.Equal(t, 0b000100000111011, formatBits(H, 7))
+	require.Equal(t, 0b000111110010010100, versionBits(7))
+	require.Equal(t, 0b101000110001101001, versionBits(40))
+}
+
+func TestParseLevel(t *testing.T) {
+	level, err := ParseLevel("q")
+	require.NoError(t, err)
+	require.Equal(t, Q, level)
+	require.Equal(t, "Q", level.String())
+
+	_, err = ParseLevel("X")
+	require.Error(t, err)
+}
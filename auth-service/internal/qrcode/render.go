@@ -0,0 +1,299 @@
+package qrcode
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/jpeg" // Logos can be JPEG images
+	"image/png"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	// LogoRatio is the width of the area cleared for a logo, relative to the width of
+	// the symbol. At 20% of the width the logo covers 4% of the symbol, well within what
+	// levels Q and H recover.
+	LogoRatio = 0.2
+
+	// maxLogoPixels bounds the dimensions of logos, which are decoded in memory.
+	maxLogoPixels = 2048
+)
+
+// Options are the rendering options of a QR code.
+type Options struct {
+	// Size is the width and height of the image, in pixels. Each module is drawn with
+	// the same whole number of pixels, the remainder being added to the margin.
+	Size int
+	// Margin is the width of the quiet zone around the symbol, in modules. Readers
+	// expect at least 4.
+	Margin int
+	// Foreground is the color of the dark modules; it must be darker than Background.
+	Foreground color.RGBA
+	Background color.RGBA
+	// Logo is drawn at the center of the symbol, if set. It requires level Q or H.
+	Logo *Logo
+}
+
+// Logo is an image drawn at the center of a QR code.
+type Logo struct {
+	Image       image.Image
+	Data        []byte
+	ContentType string
+}
+
+// DecodeLogo decodes a PNG or JPEG logo.
+//
+// Parameters:
+//   - data: The encoded image.
+//
+// Returns:
+//   - A pointer to the decoded Logo.
+//   - An error if the image is not a PNG or JPEG image, or is larger than 2048 pixels
+//     on a side.
+func DecodeLogo(data []byte) (*Logo, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid logo: %v", err)
+	}
+	if config.Width > maxLogoPixels || config.Height > maxLogoPixels {
+		return nil, fmt.Errorf("invalid logo: larger than %dx%d pixels", maxLogoPixels, maxLogoPixels)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid logo: %v", err)
+	}
+	return &Logo{Image: img, Data: data, ContentType: "image/" + format}, nil
+}
+
+// ParseColor parses a hexadecimal RGB color: "#rgb" or "#rrggbb", the "#" being
+// optional.
+func ParseColor(s string) (color.RGBA, error) {
+	hex := strings.TrimPrefix(s, "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) != 6 {
+		return color.RGBA{}, fmt.Errorf("invalid color '%s'", s)
+	}
+	value, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return color.RGBA{}, fmt.Errorf("invalid color '%s'", s)
+	}
+	return color.RGBA{R: uint8(value >> 16), G: uint8(value >> 8), B: uint8(value), A: 0xFF}, nil
+}
+
+// FormatColor formats a color as "#rrggbb", the format ParseColor reads.
+func FormatColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// layout is the geometry of a rendered QR code, in pixels.
+type layout struct {
+	scale  int // Pixels per module
+	offset int // Position of the first module of the quiet zone
+	// logo is the area cleared for the logo, in modules of the symbol; empty without logo.
+	logo image.Rectangle
+}
+
+func (c *Code) layout(opts Options) (layout, error) {
+	if opts.Margin < 0 {
+		return layout{}, fmt.Errorf("invalid margin %d", opts.Margin)
+	}
+	total := c.Size + 2*opts.Margin
+	if opts.Size < total {
+		return layout{}, fmt.Errorf("size %d is too small for %d modules, the minimum is %d", opts.Size, total, total)
+	}
+	if luminance(opts.Foreground) >= luminance(opts.Background) {
+		return layout{}, fmt.Errorf("the foreground color must be darker than the background color")
+	}
+
+	l := layout{scale: opts.Size / total}
+	l.offset = (opts.Size - l.scale*total) / 2
+	if opts.Logo != nil {
+		if c.Level < Q {
+			return layout{}, fmt.Errorf("a logo requires error correction level Q or H")
+		}
+		// Keep the parity of the symbol width so that the area is centered on a module.
+		width := int(float64(c.Size) * LogoRatio)
+		if width%2 != c.Size%2 {
+			width--
+		}
+		start := (c.Size - width) / 2
+		l.logo = image.Rect(start, start, start+width, start+width)
+	}
+	return l, nil
+}
+
+// visible reports whether the module at (x, y) is drawn, i.e. not under the logo.
+func (l layout) visible(x, y int) bool {
+	return !image.Pt(x, y).In(l.logo)
+}
+
+// PNG renders the QR code as a PNG image.
+//
+// Parameters:
+//   - w: The destination of the image.
+//   - opts: The rendering options.
+//
+// Returns:
+//   - An error if the options are invalid for this code or the image cannot be written.
+func (c *Code) PNG(w io.Writer, opts Options) error {
+	l, err := c.layout(opts)
+	if err != nil {
+		return err
+	}
+
+	bounds := image.Rect(0, 0, opts.Size, opts.Size)
+	var img draw.Image
+	if opts.Logo == nil {
+		img = image.NewPaletted(bounds, color.Palette{opts.Background, opts.Foreground})
+	} else {
+		img = image.NewRGBA(bounds)
+	}
+	fill(img, bounds, opts.Background)
+
+	origin := l.offset + opts.Margin*l.scale
+	for y := range c.Size {
+		for x := range c.Size {
+			if c.Dark(x, y) && l.visible(x, y) {
+				px, py := origin+x*l.scale, origin+y*l.scale
+				fill(img, image.Rect(px, py, px+l.scale, py+l.scale), opts.Foreground)
+			}
+		}
+	}
+
+	if opts.Logo != nil {
+		inner := l.logo.Inset(1)
+		area := image.Rectangle{Min: inner.Min.Mul(l.scale), Max: inner.Max.Mul(l.scale)}.Add(image.Pt(origin, origin))
+		drawLogo(img.(*image.RGBA), fitRect(area, opts.Logo.Image.Bounds()), opts.Logo.Image)
+	}
+
+	encoder := png.Encoder{CompressionLevel: png.BestCompression}
+	return encoder.Encode(w, img)
+}
+
+// SVG renders the QR code as an SVG image, whose modules are drawn as a single path.
+//
+// Parameters:
+//   - w: The destination of the image.
+//   - opts: The rendering options.
+//
+// Returns:
+//   - An error if the options are invalid for this code or the image cannot be written.
+func (c *Code) SVG(w io.Writer, opts Options) error {
+	l, err := c.layout(opts)
+	if err != nil {
+		return err
+	}
+
+	total := c.Size + 2*opts.Margin
+	b := bufio.NewWriter(w)
+	fmt.Fprintf(b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`,
+		opts.Size, opts.Size, total, total)
+	fmt.Fprintf(b, `<rect width="%d" height="%d" fill="%s"/>`, total, total, FormatColor(opts.Background))
+
+	// One subpath per horizontal run of dark modules.
+	fmt.Fprintf(b, `<path fill="%s" d="`, FormatColor(opts.Foreground))
+	for y := range c.Size {
+		for x := 0; x < c.Size; {
+			if !c.Dark(x, y) || !l.visible(x, y) {
+				x++
+				continue
+			}
+			run := 1
+			for c.Dark(x+run, y) && l.visible(x+run, y) {
+				run++
+			}
+			fmt.Fprintf(b, "M%d %dh%dv1h-%dz", x+opts.Margin, y+opts.Margin, run, run)
+			x += run
+		}
+	}
+	b.WriteString(`"/>`)
+
+	if opts.Logo != nil {
+		area := l.logo.Inset(1).Add(image.Pt(opts.Margin, opts.Margin))
+		fmt.Fprintf(b, `<image x="%d" y="%d" width="%d" height="%d" preserveAspectRatio="xMidYMid meet" href="data:%s;base64,%s"/>`,
+			area.Min.X, area.Min.Y, area.Dx(), area.Dy(), opts.Logo.ContentType, base64.StdEncoding.EncodeToString(opts.Logo.Data))
+	}
+
+	b.WriteString(`</svg>`)
+	return b.Flush()
+}
+
+func fill(img draw.Image, r image.Rectangle, c color.Color) {
+	draw.Draw(img, r, image.NewUniform(c), image.Point{}, draw.Src)
+}
+
+// fitRect returns the largest rectangle with the aspect ratio of src that fits in
+// area, centered in it.
+func fitRect(area, src image.Rectangle) image.Rectangle {
+	width, height := area.Dx(), area.Dy()
+	if src.Dx()*height > src.Dy()*width {
+		height = max(1, src.Dy()*width/src.Dx())
+	} else {
+		width = max(1, src.Dx()*height/src.Dy())
+	}
+	corner := area.Min.Add(image.Pt((area.Dx()-width)/2, (area.Dy()-height)/2))
+	return image.Rectangle{Min: corner, Max: corner.Add(image.Pt(width, height))}
+}
+
+// drawLogo scales src into r of dst with bilinear interpolation, and composites it
+// over the background already drawn.
+func drawLogo(dst *image.RGBA, r image.Rectangle, src image.Image) {
+	sb := src.Bounds()
+	sx := float64(sb.Dx()) / float64(r.Dx())
+	sy := float64(sb.Dy()) / float64(r.Dy())
+
+	at := func(x, y int) [4]float64 {
+		x = min(max(x, sb.Min.X), sb.Max.X-1)
+		y = min(max(y, sb.Min.Y), sb.Max.Y-1)
+		r, g, b, a := src.At(x, y).RGBA() // Premultiplied
+		return [4]float64{float64(r), float64(g), float64(b), float64(a)}
+	}
+
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		fy := (float64(y-r.Min.Y)+0.5)*sy - 0.5 + float64(sb.Min.Y)
+		y0 := int(math.Floor(fy))
+		wy := fy - float64(y0)
+		for x := r.Min.X; x < r.Max.X; x++ {
+			fx := (float64(x-r.Min.X)+0.5)*sx - 0.5 + float64(sb.Min.X)
+			x0 := int(math.Floor(fx))
+			wx := fx - float64(x0)
+
+			p00, p10, p01, p11 := at(x0, y0), at(x0+1, y0), at(x0, y0+1), at(x0+1, y0+1)
+			var p [4]float64
+			for i := range p {
+				top := p00[i]*(1-wx) + p10[i]*wx
+				bottom := p01[i]*(1-wx) + p11[i]*wx
+				p[i] = top*(1-wy) + bottom*wy
+			}
+
+			bg := dst.RGBAAt(x, y)
+			alpha := p[3] / 0xFFFF
+			blend := func(src float64, bg uint8) uint8 {
+				return uint8(math.Round(src/0xFFFF*0xFF + float64(bg)*(1-alpha)))
+			}
+			dst.SetRGBA(x, y, color.RGBA{R: blend(p[0], bg.R), G: blend(p[1], bg.G), B: blend(p[2], bg.B), A: 0xFF})
+		}
+	}
+}
+
+// luminance returns the relative luminance of an sRGB color, from 0 (black) to 1 (white).
+func luminance(c color.RGBA) float64 {
+	linear := func(v uint8) float64 {
+		s := float64(v) / 0xFF
+		if s <= 0.04045 {
+			return s / 12.92
+		}
+		return math.Pow((s+0.055)/1.055, 2.4)
+	}
+	return 0.2126*linear(c.R) + 0.7152*linear(c.G) + 0.0722*linear(c.B)
+}
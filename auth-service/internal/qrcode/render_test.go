@@ -0,0 +1,126 @@
+package qrcode
+
+import (
+	"bytes"
+	"encoding/xml"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	black = color.RGBA{A: 0xFF}
+	white = color.RGBA{R: 0xFF, G: 0xFF, B: 0xFF, A: 0xFF}
+	red   = color.RGBA{R: 0xFF, A: 0xFF}
+)
+
+func testLogo(t *testing.T) *Logo {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, 40, 20))
+	for y := range 20 {
+		for x := range 40 {
+			img.Set(x, y, red)
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+
+	logo, err := DecodeLogo(buf.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, "image/png", logo.ContentType)
+	return logo
+}
+
+func TestPNG(t *testing.T) {
+	code, err := Encode([]byte("https://gobizz.com/promo"), H)
+	require.NoError(t, err)
+	total := code.Size + 8
+
+	t.Run("Modules are drawn at a whole scale, centered", func(t *testing.T) {
+		var buf bytes.Buffer
+		size := total*10 + 3
+		require.NoError(t, code.PNG(&buf, Options{Size: size, Margin: 4, Foreground: black, Background: white}))
+
+		img, err := png.Decode(&buf)
+		require.NoError(t, err)
+		require.Equal(t, image.Rect(0, 0, size, size), img.Bounds())
+
+		origin := 1 + 4*10
+		rgba := func(x, y int) color.RGBA { return color.RGBAModel.Convert(img.At(x, y)).(color.RGBA) }
+		require.Equal(t, white, rgba(origin-1, origin), "Quiet zone")
+		require.Equal(t, black, rgba(origin, origin), "Finder pattern")
+		require.Equal(t, black, rgba(origin+9, origin+9))
+		require.Equal(t, white, rgba(origin+10, origin+10))
+	})
+
+	t.Run("Logos are drawn over a cleared center", func(t *testing.T) {
+		var buf bytes.Buffer
+		size := total * 10
+		require.NoError(t, code.PNG(&buf, Options{Size: size, Margin: 4, Foreground: black, Background: white, Logo: testLogo(t)}))
+
+		img, err := png.Decode(&buf)
+		require.NoError(t, err)
+		center := color.RGBAModel.Convert(img.At(size/2, size/2)).(color.RGBA)
+		require.Equal(t, red, center)
+	})
+
+	t.Run("Invalid options are rejected", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.Error(t, code.PNG(&buf, Options{Size: total - 1, Margin: 4, Foreground: black, Background: white}))
+		require.Error(t, code.PNG(&buf, Options{Size: 500, Margin: -1, Foreground: black, Background: white}))
+		require.Error(t, code.PNG(&buf, Options{Size: 500, Margin: 4, Foreground: white, Background: black}), "Inverted colors")
+
+		low, err := Encode([]byte("https://gobizz.com/promo"), M)
+		require.NoError(t, err)
+		require.Error(t, low.PNG(&buf, Options{Size: 500, Margin: 4, Foreground: black, Background: white, Logo: testLogo(t)}))
+	})
+}
+
+func TestSVG(t *testing.T) {
+	code, err := Encode([]byte("https://gobizz.com/promo"), Q)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, code.SVG(&buf, Options{Size: 300, Margin: 2, Foreground: black, Background: color.RGBA{R: 0xFF, G: 0xEE, B: 0xDD, A: 0xFF}}))
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), new(struct{})))
+
+	svg := buf.String()
+	require.Contains(t, svg, `width="300"`)
+	require.Contains(t, svg, `fill="#ffeedd"`)
+	require.Contains(t, svg, `M2 2h7v1h-7z`, "Top row of the top left finder pattern")
+	require.NotContains(t, svg, "<image")
+
+	buf.Reset()
+	require.NoError(t, code.SVG(&buf, Options{Size: 300, Margin: 2, Foreground: black, Background: white, Logo: testLogo(t)}))
+	require.True(t, strings.Contains(buf.String(), `href="data:image/png;base64,`))
+}
+
+func TestParseColor(t *testing.T) {
+	c, err := ParseColor("#1a2B3c")
+	require.NoError(t, err)
+	require.Equal(t, color.RGBA{R: 0x1A, G: 0x2B, B: 0x3C, A: 0xFF}, c)
+	require.Equal(t, "#1a2b3c", FormatColor(c))
+
+	c, err = ParseColor("f00")
+	require.NoError(t, err)
+	require.Equal(t, red, c)
+
+	for _, s := range []string{"", "#12345", "#gggggg", "red"} {
+		_, err := ParseColor(s)
+		require.Error(t, err, s)
+	}
+}
+
+func TestDecodeLogo(t *testing.T) {
+	_, err := DecodeLogo([]byte("not an image"))
+	require.Error(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewGray(image.Rect(0, 0, maxLogoPixels+1, 1))))
+	_, err = DecodeLogo(buf.Bytes())
+	require.Error(t, err)
+}
@@ -0,0 +1,179 @@
+// Package xlsx writes single-sheet Office Open XML workbooks row by row, without holding
+// the sheet in memory. It only supports what exports need: inline strings and numbers,
+// no styles, formulas or shared strings.
+package xlsx
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ContentType is the MIME type of the workbooks written by Writer.
+const ContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+// maxRows is the number of rows a worksheet can hold.
+const maxRows = 1048576
+
+const contentTypesXML = xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+	`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+	`<Default Extension="xml" ContentType="application/xml"/>` +
+	`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+	`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+	`</Types>`
+
+const rootRelsXML = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+const workbookRelsXML = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+	`</Relationships>`
+
+const workbookXML = xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+	`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+	`<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets></workbook>`
+
+const sheetHeaderXML = xml.Header + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+
+const sheetFooterXML = `</sheetData></worksheet>`
+
+// Writer writes a workbook with a single sheet. The package parts are written when the
+// Writer is created, except the sheet, which is written as rows are added and completed
+// by Close.
+type Writer struct {
+	zip   *zip.Writer
+	sheet *bufio.Writer
+	rows  int
+}
+
+// NewWriter starts a workbook on w, whose only sheet is named sheetName.
+//
+// Parameters:
+//   - w: The destination of the workbook. It does not need to be seekable.
+//   - sheetName: The name of the sheet, at most 31 characters long.
+//
+// Returns:
+//   - A pointer to the Writer to add rows with.
+//   - An error if the sheet name is invalid or the package parts cannot be written.
+func NewWriter(w io.Writer, sheetName string) (*Writer, error) {
+	if sheetName == "" || len([]rune(sheetName)) > 31 || strings.ContainsAny(sheetName, `[]:*?/\`) {
+		return nil, fmt.Errorf("invalid sheet name '%s'", sheetName)
+	}
+
+	var name strings.Builder
+	if err := xml.EscapeText(&name, []byte(sheetName)); err != nil {
+		return nil, err
+	}
+
+	archive := zip.NewWriter(w)
+	parts := []struct{ name, content string }{
+		{"[Content_Types].xml", contentTypesXML},
+		{"_rels/.rels", rootRelsXML},
+		{"xl/workbook.xml", fmt.Sprintf(workbookXML, name.String())},
+		{"xl/_rels/workbook.xml.rels", workbookRelsXML},
+	}
+	for _, part := range parts {
+		f, err := archive.Create(part.name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create %s: %v", part.name, err)
+		}
+		if _, err := io.WriteString(f, part.content); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %v", part.name, err)
+		}
+	}
+
+	f, err := archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sheet: %v", err)
+	}
+	sheet := bufio.NewWriter(f)
+	if _, err := sheet.WriteString(sheetHeaderXML); err != nil {
+		return nil, fmt.Errorf("failed to write sheet: %v", err)
+	}
+
+	return &Writer{zip: archive, sheet: sheet}, nil
+}
+
+// WriteRow appends a row to the sheet. Strings are written as inline strings, integers
+// and floats as numbers, and nil values as empty cells.
+//
+// Parameters:
+//   - values: The values of the cells of the row, from the first column.
+//
+// Returns:
+//   - An error if a value has an unsupported type, the sheet is full, or the row cannot
+//     be written.
+func (w *Writer) WriteRow(values ...any) error {
+	if w.rows == maxRows {
+		return fmt.Errorf("sheet is full")
+	}
+	number := strconv.Itoa(w.rows + 1)
+
+	var row strings.Builder
+	fmt.Fprintf(&row, `<row r="%s">`, number)
+	for i, value := range values {
+		ref := columnName(i) + number
+		switch v := value.(type) {
+		case nil:
+		case string:
+			if v == "" {
+				continue
+			}
+			fmt.Fprintf(&row, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">`, ref)
+			if err := xml.EscapeText(&row, []byte(v)); err != nil {
+				return err
+			}
+			row.WriteString(`</t></is></c>`)
+		case int:
+			fmt.Fprintf(&row, `<c r="%s"><v>%d</v></c>`, ref, v)
+		case int32:
+			fmt.Fprintf(&row, `<c r="%s"><v>%d</v></c>`, ref, v)
+		case int64:
+			fmt.Fprintf(&row, `<c r="%s"><v>%d</v></c>`, ref, v)
+		case float64:
+			fmt.Fprintf(&row, `<c r="%s"><v>%s</v></c>`, ref, strconv.FormatFloat(v, 'g', -1, 64))
+		default:
+			return fmt.Errorf("unsupported cell value of type %T", value)
+		}
+	}
+	row.WriteString(`</row>`)
+
+	if _, err := w.sheet.WriteString(row.String()); err != nil {
+		return fmt.Errorf("failed to write row: %v", err)
+	}
+	w.rows++
+	return nil
+}
+
+// Flush writes the buffered rows to the underlying writer.
+func (w *Writer) Flush() error {
+	if err := w.sheet.Flush(); err != nil {
+		return err
+	}
+	return w.zip.Flush()
+}
+
+// Close completes the sheet and the workbook. It does not close the underlying writer.
+func (w *Writer) Close() error {
+	if _, err := w.sheet.WriteString(sheetFooterXML); err != nil {
+		return fmt.Errorf("failed to write sheet: %v", err)
+	}
+	if err := w.sheet.Flush(); err != nil {
+		return fmt.Errorf("failed to write sheet: %v", err)
+	}
+	return w.zip.Close()
+}
+
+// columnName returns the letters of the zero-based column index: A, B, ..., Z, AA, ...
+func columnName(index int) string {
+	name := ""
+	for index++; index > 0; index = (index - 1) / 26 {
+		name = string(rune('A'+(index-1)%26)) + name
+	}
+	return name
+}
@@ -0,0 +1,83 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type sheet struct {
+	Rows []struct {
+		R     string `xml:"r,attr"`
+		Cells []struct {
+			R      string `xml:"r,attr"`
+			T      string `xml:"t,attr"`
+			Value  string `xml:"v"`
+			Inline string `xml:"is>t"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, "Links & more")
+	require.NoError(t, err)
+	require.NoError(t, w.WriteRow("id", "title", "clicks"))
+	require.NoError(t, w.WriteRow("1", "<Sale> & \"deals\"", int64(42)))
+	require.Error(t, w.WriteRow("2", struct{}{}))
+	require.NoError(t, w.WriteRow("2", nil, 0, 1.5))
+	require.NoError(t, w.Close())
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	files := map[string][]byte{}
+	for _, f := range archive.File {
+		r, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(r)
+		require.NoError(t, err)
+		files[f.Name] = content
+	}
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/worksheets/sheet1.xml"} {
+		require.Contains(t, files, name)
+		require.NoError(t, xml.Unmarshal(files[name], new(struct{})), name)
+	}
+	require.Contains(t, string(files["xl/workbook.xml"]), `name="Links &amp; more"`)
+
+	var s sheet
+	require.NoError(t, xml.Unmarshal(files["xl/worksheets/sheet1.xml"], &s))
+	require.Len(t, s.Rows, 3)
+	require.Equal(t, "2", s.Rows[1].R)
+	require.Equal(t, "B2", s.Rows[1].Cells[1].R)
+	require.Equal(t, "inlineStr", s.Rows[1].Cells[1].T)
+	require.Equal(t, `<Sale> & "deals"`, s.Rows[1].Cells[1].Inline)
+	require.Equal(t, "42", s.Rows[1].Cells[2].Value)
+
+	t.Run("Empty cells are omitted", func(t *testing.T) {
+		require.Equal(t, "3", s.Rows[2].R, "Rejected rows are not numbered")
+		require.Len(t, s.Rows[2].Cells, 3)
+		require.Equal(t, "C3", s.Rows[2].Cells[1].R)
+		require.Equal(t, "1.5", s.Rows[2].Cells[2].Value)
+	})
+
+	t.Run("Invalid sheet names are rejected", func(t *testing.T) {
+		_, err := NewWriter(io.Discard, "a/b")
+		require.Error(t, err)
+		_, err = NewWriter(io.Discard, "")
+		require.Error(t, err)
+	})
+}
+
+func TestColumnName(t *testing.T) {
+	require.Equal(t, "A", columnName(0))
+	require.Equal(t, "Z", columnName(25))
+	require.Equal(t, "AA", columnName(26))
+	require.Equal(t, "AZ", columnName(51))
+	require.Equal(t, "BA", columnName(52))
+	require.Equal(t, "XFD", columnName(16383))
+}
@@ -0,0 +1,26 @@
+-- Drop click tables
+DROP TABLE IF EXISTS click_rollups;
+DROP TABLE IF EXISTS click_events;
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_links_customer_created_at;
+DROP INDEX IF EXISTS idx_links_customer_clicks;
+DROP INDEX IF EXISTS idx_links_customer_expiration_sort;
+DROP INDEX IF EXISTS idx_links_customer_original_url_sort;
+
+-- Drop columns
+ALTER TABLE links
+    DROP COLUMN IF EXISTS bot_clicks,
+    DROP COLUMN IF EXISTS slug_type,
+    DROP COLUMN IF EXISTS disabled,
+    DROP COLUMN IF EXISTS activates_at,
+    DROP COLUMN IF EXISTS title,
+    DROP COLUMN IF EXISTS tags,
+    DROP COLUMN IF EXISTS expiration_sort,
+    DROP COLUMN IF EXISTS original_url_sort,
+    DROP COLUMN IF EXISTS search_text;
+
+-- Restore UUID link IDs; links created by the links services cannot be converted
+DELETE FROM links WHERE id !~ '^[0-9a-fA-F-]{36}$';
+ALTER TABLE links ALTER COLUMN id TYPE UUID USING id::uuid;
+ALTER TABLE links ALTER COLUMN id SET DEFAULT gen_random_uuid();
@@ -0,0 +1,57 @@
+-- Adapt the links table to the Postgres backend of links-service-read/write:
+-- link IDs are short random strings rather than UUIDs
+ALTER TABLE links ALTER COLUMN id DROP DEFAULT;
+ALTER TABLE links ALTER COLUMN id TYPE TEXT USING id::text;
+
+-- Lifecycle, metadata and read model columns, mirroring the DynamoDB items
+ALTER TABLE links
+    ADD COLUMN bot_clicks INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN slug_type TEXT NOT NULL DEFAULT 'generated',
+    ADD COLUMN disabled BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN activates_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN title TEXT NOT NULL DEFAULT '',
+    ADD COLUMN tags TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN expiration_sort TEXT NOT NULL DEFAULT '9999-12-31T23:59:59Z',
+    ADD COLUMN original_url_sort TEXT NOT NULL DEFAULT '',
+    ADD COLUMN search_text TEXT NOT NULL DEFAULT '';
+
+-- Derive the read model columns of existing links; their search_text is left empty
+UPDATE links SET
+    slug_type = CASE WHEN custom_slug IS NOT NULL AND custom_slug <> '' THEN 'custom' ELSE 'generated' END,
+    expiration_sort = COALESCE(to_char(expires_at AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS"Z"'), '9999-12-31T23:59:59Z'),
+    original_url_sort = lower(left(original_url, 512));
+
+-- Create one index per sort order of a customer's links
+CREATE INDEX idx_links_customer_created_at ON links(customer_id, created_at, short_url);
+CREATE INDEX idx_links_customer_clicks ON links(customer_id, clicks, short_url);
+CREATE INDEX idx_links_customer_expiration_sort ON links(customer_id, expiration_sort, short_url);
+CREATE INDEX idx_links_customer_original_url_sort ON links(customer_id, original_url_sort, short_url);
+
+-- Create click events table, human and automated clicks alike
+CREATE TABLE click_events (
+    link_id TEXT NOT NULL,
+    event_id TEXT NOT NULL,
+    customer_id TEXT NOT NULL,
+    clicked_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    referrer TEXT NOT NULL DEFAULT '',
+    referrer_domain TEXT NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    device_class TEXT NOT NULL DEFAULT '',
+    ip_hash TEXT NOT NULL DEFAULT '',
+    accept_language TEXT NOT NULL DEFAULT '',
+    country TEXT NOT NULL DEFAULT '',
+    region TEXT NOT NULL DEFAULT '',
+    city TEXT NOT NULL DEFAULT '',
+    asn BIGINT NOT NULL DEFAULT 0,
+    bot_category TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (link_id, event_id)
+);
+
+-- Create click rollups table, one counter of a link's time bucket per row
+CREATE TABLE click_rollups (
+    link_id TEXT NOT NULL,
+    bucket TEXT NOT NULL,
+    counter TEXT NOT NULL,
+    count BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (link_id, bucket, counter)
+);
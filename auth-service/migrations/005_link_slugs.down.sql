@@ -0,0 +1,2 @@
+-- Drop slug reservations table
+DROP TABLE IF EXISTS link_slugs;
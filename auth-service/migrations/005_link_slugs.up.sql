@@ -0,0 +1,18 @@
+-- Create slug reservations table: every short URL and custom slug of a link, so that
+-- no two links can hold the same slug, whichever column it is stored in
+CREATE TABLE link_slugs (
+    slug TEXT PRIMARY KEY,
+    link_id TEXT NOT NULL REFERENCES links(id) ON DELETE CASCADE
+);
+
+-- Create index on link_id for faster lookups
+CREATE INDEX idx_link_slugs_link_id ON link_slugs(link_id);
+
+-- Reserve the slugs of existing links; the first link holding a slug keeps it
+INSERT INTO link_slugs (slug, link_id)
+SELECT short_url, id FROM links
+ON CONFLICT (slug) DO NOTHING;
+
+INSERT INTO link_slugs (slug, link_id)
+SELECT custom_slug, id FROM links WHERE custom_slug IS NOT NULL AND custom_slug <> ''
+ON CONFLICT (slug) DO NOTHING;
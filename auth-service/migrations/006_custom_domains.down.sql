@@ -0,0 +1,7 @@
+-- Restore global custom slug uniqueness
+ALTER TABLE links DROP CONSTRAINT IF EXISTS links_domain_custom_slug_key;
+ALTER TABLE links ADD CONSTRAINT links_custom_slug_key UNIQUE (custom_slug);
+
+-- Drop branded domains
+ALTER TABLE links DROP COLUMN IF EXISTS domain;
+DROP TABLE IF EXISTS customer_domains;
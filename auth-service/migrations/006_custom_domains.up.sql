@@ -0,0 +1,20 @@
+-- Create branded domains table: a domain serves the links of the customer who
+-- registered it once verified_at is set, after its DNS TXT record has been checked
+CREATE TABLE customer_domains (
+    domain TEXT PRIMARY KEY,
+    customer_id UUID NOT NULL REFERENCES customer(id) ON DELETE CASCADE,
+    token TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    verified_at TIMESTAMPTZ
+);
+
+-- Create index on customer_id for faster lookups
+CREATE INDEX idx_customer_domains_customer_id ON customer_domains(customer_id);
+
+-- Add the branded domain of links, '' for the default one
+ALTER TABLE links ADD COLUMN domain TEXT NOT NULL DEFAULT '';
+
+-- Custom slugs are unique per domain; short_url stays unique as branded short URLs
+-- are prefixed with their domain
+ALTER TABLE links DROP CONSTRAINT IF EXISTS links_custom_slug_key;
+ALTER TABLE links ADD CONSTRAINT links_domain_custom_slug_key UNIQUE (domain, custom_slug);
@@ -0,0 +1,11 @@
+-- Drop UTM templates
+DROP TABLE IF EXISTS utm_templates;
+
+-- Drop UTM parameters of links and clicks
+ALTER TABLE click_events DROP COLUMN IF EXISTS utm_campaign;
+ALTER TABLE links
+    DROP COLUMN IF EXISTS utm_source,
+    DROP COLUMN IF EXISTS utm_medium,
+    DROP COLUMN IF EXISTS utm_campaign,
+    DROP COLUMN IF EXISTS utm_term,
+    DROP COLUMN IF EXISTS utm_content;
@@ -0,0 +1,27 @@
+-- Add the UTM parameters links-service-read appends to the original URL of links on
+-- redirect, '' when not set
+ALTER TABLE links
+    ADD COLUMN utm_source TEXT NOT NULL DEFAULT '',
+    ADD COLUMN utm_medium TEXT NOT NULL DEFAULT '',
+    ADD COLUMN utm_campaign TEXT NOT NULL DEFAULT '',
+    ADD COLUMN utm_term TEXT NOT NULL DEFAULT '',
+    ADD COLUMN utm_content TEXT NOT NULL DEFAULT '';
+
+-- Record the UTM campaign of the link on each click, so clicks can be grouped by campaign
+ALTER TABLE click_events ADD COLUMN utm_campaign TEXT NOT NULL DEFAULT '';
+
+-- Create UTM templates table: named sets of UTM parameters a customer reuses across links
+CREATE TABLE utm_templates (
+    id TEXT PRIMARY KEY,
+    customer_id UUID NOT NULL REFERENCES customer(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    utm_source TEXT NOT NULL DEFAULT '',
+    utm_medium TEXT NOT NULL DEFAULT '',
+    utm_campaign TEXT NOT NULL DEFAULT '',
+    utm_term TEXT NOT NULL DEFAULT '',
+    utm_content TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Create index on customer_id for faster lookups
+CREATE INDEX idx_utm_templates_customer_id ON utm_templates(customer_id);
@@ -0,0 +1,2 @@
+-- Drop the password of links
+ALTER TABLE links DROP COLUMN IF EXISTS password_hash;
@@ -0,0 +1,2 @@
+-- Add the bcrypt hash of the password protecting links, '' for unprotected links
+ALTER TABLE links ADD COLUMN password_hash TEXT NOT NULL DEFAULT '';
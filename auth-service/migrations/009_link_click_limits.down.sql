@@ -0,0 +1,2 @@
+-- Drop the click limit of links
+ALTER TABLE links DROP COLUMN IF EXISTS max_clicks;
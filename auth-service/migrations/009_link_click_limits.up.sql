@@ -0,0 +1,3 @@
+-- Add the number of clicks after which links behave as expired, 0 for unlimited; one-time
+-- links have a limit of 1
+ALTER TABLE links ADD COLUMN max_clicks INTEGER NOT NULL DEFAULT 0 CHECK (max_clicks >= 0);
@@ -0,0 +1,2 @@
+-- Drop the fallback URL of links
+ALTER TABLE links DROP COLUMN IF EXISTS fallback_url;
@@ -0,0 +1,2 @@
+-- Add the URL visitors of links are sent to while they are not active yet, '' for none
+ALTER TABLE links ADD COLUMN fallback_url TEXT NOT NULL DEFAULT '';
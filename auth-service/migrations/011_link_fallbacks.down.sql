@@ -0,0 +1,3 @@
+-- Drop the link status of click events and the link settings table
+ALTER TABLE click_events DROP COLUMN IF EXISTS link_status;
+DROP TABLE IF EXISTS customer_link_settings;
@@ -0,0 +1,11 @@
+-- Create link settings table: the settings a customer applies to all of their links,
+-- such as the fallback URL of their inactive links and how visitors are sent to it
+CREATE TABLE customer_link_settings (
+    customer_id UUID PRIMARY KEY REFERENCES customer(id) ON DELETE CASCADE,
+    fallback_url TEXT NOT NULL DEFAULT '',
+    fallback_mode TEXT NOT NULL DEFAULT 'redirect' CHECK (fallback_mode IN ('redirect', 'page')),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Record the status of the link on hits while it was inactive, '' for clicks
+ALTER TABLE click_events ADD COLUMN link_status TEXT NOT NULL DEFAULT '';
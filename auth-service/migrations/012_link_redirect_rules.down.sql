@@ -0,0 +1,2 @@
+-- Drop the redirect rules of links
+ALTER TABLE links DROP COLUMN IF EXISTS redirect_rules;
@@ -0,0 +1,3 @@
+-- Add the redirect rules of links: an ordered JSON array of rules, each sending the
+-- visitors meeting its conditions to another destination than the original URL
+ALTER TABLE links ADD COLUMN redirect_rules JSONB NOT NULL DEFAULT '[]';
@@ -0,0 +1,4 @@
+-- Drop the variants of links and of clicks
+ALTER TABLE click_events DROP COLUMN IF EXISTS variant;
+ALTER TABLE links DROP COLUMN IF EXISTS sticky_variants;
+ALTER TABLE links DROP COLUMN IF EXISTS variants;
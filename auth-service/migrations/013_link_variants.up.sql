@@ -0,0 +1,7 @@
+-- Add the variants of links: an ordered JSON array of weighted destinations the visitors
+-- of a link are split between, and whether visitors keep the variant they were assigned
+ALTER TABLE links ADD COLUMN variants JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE links ADD COLUMN sticky_variants BOOLEAN NOT NULL DEFAULT false;
+
+-- Record the variant of the link each click was sent to, '' for none
+ALTER TABLE click_events ADD COLUMN variant TEXT NOT NULL DEFAULT '';
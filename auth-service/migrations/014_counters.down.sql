@@ -0,0 +1,2 @@
+-- Drop the named counters
+DROP TABLE IF EXISTS counters;
@@ -0,0 +1,6 @@
+-- Add named counters shared by the instances of links-service-write, such as the counter
+-- hashids short codes are drawn from
+CREATE TABLE counters (
+    name TEXT PRIMARY KEY,
+    value BIGINT NOT NULL
+);
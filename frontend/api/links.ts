@@ -4,6 +4,7 @@ import { apiConfig } from './config';
 export interface Link {
     id: string;
     clicks: number;
+    unique_visitors?: number;
     short_url: string;
     custom_slug: string;
     created_at: string;
@@ -11,6 +12,10 @@ export interface Link {
     customer_id: string;
     original_url: string;
     expiration_date?: string;
+    status?: 'active' | 'expired' | 'scheduled' | 'disabled';
+    slug_type?: 'custom' | 'generated';
+    title?: string;
+    tags?: string[];
 }
 
 export interface CreateLinkRequest {
@@ -18,6 +23,8 @@ export interface CreateLinkRequest {
     original_url: string;
     custom_slug?: string;
     expiration_date?: string;
+    title?: string;
+    tags?: string[];
 }
 
 export interface UpdateLinkRequest {
@@ -26,6 +33,9 @@ export interface UpdateLinkRequest {
     custom_slug?: string;
     original_url?: string;
     expiration_date?: string;
+    disabled?: boolean;
+    title?: string;
+    tags?: string[];
 }
 
 export interface PaginatedResponse<T> {
@@ -39,17 +49,19 @@ export interface PaginatedResponse<T> {
 export interface GetCustomerLinksParams {
     limit?: number;
     offset?: number;
-    sort_by?: keyof Link;
+    cursor?: string;
+    sort_by?: 'created_at' | 'clicks' | 'expiration_date' | 'original_url';
     sort_direction?: 'asc' | 'desc';
     search?: string;
-    status?: 'all' | 'active' | 'expired';
+    status?: 'all' | 'active' | 'expired' | 'scheduled' | 'disabled';
     customerId: string;
-    slug_type?: 'all' | 'custom' | 'auto';
+    slug_type?: 'all' | 'custom' | 'generated' | 'auto';
 }
 
 export interface GetCustomerLinksResponse {
     links: Link[];
     total: number;
+    next_cursor?: string;
 }
 
 export interface GetCustomerLinksApiResponse {
@@ -59,6 +71,7 @@ export interface GetCustomerLinksApiResponse {
         page: number;
         per_page: number;
         total_pages: number;
+        next_cursor?: string;
     };
     success: boolean;
 }
@@ -83,6 +96,7 @@ export const linksApi = {
         customerId,
         limit,
         offset,
+        cursor,
         sort_by,
         sort_direction,
         search,
@@ -98,6 +112,7 @@ export const linksApi = {
 
         if (limit) queryParams.append('limit', limit.toString());
         if (offset) queryParams.append('offset', offset.toString());
+        if (cursor) queryParams.append('cursor', cursor);
         if (status) queryParams.append('status', status);
         if (sort_by) queryParams.append('sort_by', sort_by);
         if (slug_type) queryParams.append('slug_type', slug_type);
@@ -116,7 +131,8 @@ export const linksApi = {
                     total: response.data.total || 0,
                     page: 1,
                     per_page: limit || 0,
-                    total_pages: limit ? Math.ceil((response.data.total || 0) / limit) : 1
+                    total_pages: limit ? Math.ceil((response.data.total || 0) / limit) : 1,
+                    next_cursor: response.data.next_cursor
                 }
             };
             return apiResponse;
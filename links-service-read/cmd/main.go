@@ -3,10 +3,16 @@ package main
 import (
 	"context"
 	"fmt"
+	"links-service-read/internal/clickstream"
+	"links-service-read/internal/geoip"
+	"links-service-read/internal/infra/cache"
 	"links-service-read/internal/infra/database"
+	"links-service-read/internal/infra/grpc/links"
 	"links-service-read/internal/infra/repository"
+	"links-service-read/internal/linkaccess"
 	"links-service-read/internal/logger"
 	"links-service-read/internal/server"
+	"links-service-read/internal/visitors"
 	"links-service-read/utils"
 	"os"
 	"os/signal"
@@ -14,6 +20,7 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/go-redis/redis/v8"
 	"github.com/joho/godotenv"
 	"go.uber.org/zap"
 )
@@ -48,6 +55,54 @@ func initDynamo() (*dynamodb.Client, error) {
 	return client, nil
 }
 
+// initLinkStore creates the storage backend selected by LINKS_STORE, which must match
+// the one of links-service-write. The returned function releases the backend's
+// connections.
+func initLinkStore() (repository.LinkStore, func(), error) {
+	switch utils.ConfigInstance.LinksStore {
+	case repository.StoreDynamoDB:
+		db, err := initDynamo()
+		if err != nil {
+			return nil, nil, err
+		}
+		return repository.NewDynamoLinkStore(db), func() {}, nil
+
+	case repository.StorePostgres:
+		pool, err := database.NewPostgresConnection()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to connect to PostgreSQL: %w", err)
+		}
+		return repository.NewPostgresLinkStore(pool), pool.Close, nil
+
+	case repository.StoreMemory:
+		logger.Log.Warn("Using the in-memory links store, it starts empty and does not see links created by links-service-write",
+			zap.String("component", "repository"),
+		)
+		return repository.NewMemoryLinkStore(), func() {}, nil
+
+	default:
+		return nil, nil, fmt.Errorf("unknown LINKS_STORE %q", utils.ConfigInstance.LinksStore)
+	}
+}
+
+// initRedis connects to the Redis server holding unique visitor counters and carrying
+// live click notifications. Redis is optional: without REDIS_HOST, unique visitor counts
+// are reported as zero and live clicks are unavailable.
+func initRedis() (*redis.Client, error) {
+	if utils.ConfigInstance.RedisHost == "" {
+		logger.Log.Warn("Redis not configured, unique visitor counts and live clicks disabled",
+			zap.String("component", "cache"),
+		)
+		return nil, nil
+	}
+
+	rdb, err := cache.NewRedisClient(utils.ConfigInstance.RedisHost, utils.ConfigInstance.RedisPort)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Redis client: %v", err)
+	}
+	return rdb, nil
+}
+
 func main() {
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
@@ -55,21 +110,67 @@ func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	db, err := initDynamo()
+	linksRepo, closeStore, err := initLinkStore()
 	if err != nil {
-		logger.Log.Fatal("Failed to connect to DynamoDB",
+		logger.Log.Fatal("Failed to initialize links store",
 			zap.Error(err),
 			zap.String("component", "database"),
 		)
 	}
+	defer closeStore()
+	logger.Log.Info("Links store initialized",
+		zap.String("store", utils.ConfigInstance.LinksStore),
+		zap.String("component", "repository"),
+	)
 
-	logger.Log.Info("Successfully connected to DynamoDB",
-		zap.String("component", "database"),
+	linksClient, err := links.NewClient()
+	if err != nil {
+		logger.Log.Fatal("Failed to connect to links write service",
+			zap.Error(err),
+			zap.String("component", "grpc"),
+		)
+	}
+	defer linksClient.CloseWrite()
+	logger.Log.Info("Successfully connected to links write service",
+		zap.String("component", "grpc"),
 	)
 
-	linksRepo := repository.NewLinksRepository(db)
-	logger.Log.Info("LinksRepository initialized",
-		zap.String("component", "repository"),
+	rdb, err := initRedis()
+	if err != nil {
+		logger.Log.Fatal("Failed to connect to Redis",
+			zap.Error(err),
+			zap.String("component", "cache"),
+		)
+	}
+	if rdb != nil {
+		defer rdb.Close()
+	}
+	visitorReader := visitors.NewReader(rdb)
+	clickSubscriber := clickstream.NewSubscriber(rdb)
+
+	if utils.ConfigInstance.LinkAccessSecret == "" {
+		logger.Log.Warn("LINK_ACCESS_SECRET not set, access cookies of password-protected links are only valid on this instance",
+			zap.String("component", "config"),
+		)
+	}
+	linkAccess, err := linkaccess.NewSigner(utils.ConfigInstance.LinkAccessSecret)
+	if err != nil {
+		logger.Log.Fatal("Failed to initialize link access signer",
+			zap.Error(err),
+			zap.String("component", "config"),
+		)
+	}
+	linkPasswordAttempts := utils.ConfigInstance.LinkPasswordAttempts
+	if linkPasswordAttempts == 0 {
+		linkPasswordAttempts = linkaccess.MaxLinkAttempts
+	}
+	passwordAttempts := linkaccess.NewLimiter(rdb, linkaccess.MaxAttempts, linkPasswordAttempts, linkaccess.AttemptWindow)
+
+	geo := geoip.NewResolver(utils.ConfigInstance.GeoIPPath, utils.ConfigInstance.GeoIPReload)
+	defer geo.Close()
+	go geo.Watch(ctx)
+	logger.Log.Info("GeoIP resolver initialized",
+		zap.String("component", "geoip"),
 	)
 
 	go func() {
@@ -77,7 +178,7 @@ func main() {
 			zap.String("port", "50051"),
 			zap.String("component", "server"),
 		)
-		if err := server.StartGRPCServer("50051", linksRepo); err != nil {
+		if err := server.StartGRPCServer("50051", linksRepo, visitorReader, clickSubscriber); err != nil {
 			logger.Log.Error("Failed to start gRPC server",
 				zap.Error(err),
 				zap.String("component", "server"),
@@ -86,6 +187,20 @@ func main() {
 		}
 	}()
 
+	go func() {
+		logger.Log.Info("Starting HTTP redirect server",
+			zap.String("port", "8080"),
+			zap.String("component", "server"),
+		)
+		if err := server.StartHTTPServer("8080", linksRepo, linksClient, linkAccess, passwordAttempts, geo); err != nil {
+			logger.Log.Error("Failed to start HTTP redirect server",
+				zap.Error(err),
+				zap.String("component", "server"),
+			)
+			cancel()
+		}
+	}()
+
 	select {
 	case sig := <-sigChan:
 		logger.Log.Info("Received shutdown signal",
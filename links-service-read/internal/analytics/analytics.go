@@ -0,0 +1,72 @@
+package analytics
+
+import (
+	"time"
+)
+
+const (
+	GranularityHour = "hour"
+	GranularityDay  = "day"
+	GranularityWeek = "week"
+)
+
+// ValidGranularity reports whether granularity is one of the bucket sizes maintained
+// by links-service-write for click rollups.
+func ValidGranularity(granularity string) bool {
+	switch granularity {
+	case GranularityHour, GranularityDay, GranularityWeek:
+		return true
+	default:
+		return false
+	}
+}
+
+// DefaultSpan returns the range covered by an analytics query that does not specify a start.
+func DefaultSpan(granularity string) time.Duration {
+	switch granularity {
+	case GranularityHour:
+		return 24 * time.Hour
+	case GranularityWeek:
+		return 12 * 7 * 24 * time.Hour
+	default:
+		return 30 * 24 * time.Hour
+	}
+}
+
+// BucketStart truncates t to the start of the UTC bucket that contains it.
+// Weeks start on Monday, following ISO 8601.
+func BucketStart(granularity string, t time.Time) time.Time {
+	t = t.UTC()
+	switch granularity {
+	case GranularityHour:
+		return t.Truncate(time.Hour)
+	case GranularityWeek:
+		offset := (int(t.Weekday()) + 6) % 7
+		return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, time.UTC)
+	default:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// NextBucket returns the start of the bucket following the one that starts at start.
+func NextBucket(granularity string, start time.Time) time.Time {
+	switch granularity {
+	case GranularityHour:
+		return start.Add(time.Hour)
+	case GranularityWeek:
+		return start.AddDate(0, 0, 7)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
+
+// BucketKey returns the rollup sort key of the bucket containing t. It must match the
+// keys written by links-service-write, e.g. "hour#2006-01-02T15", "day#2006-01-02"
+// and "week#2006-01-02" (the Monday starting the week).
+func BucketKey(granularity string, t time.Time) string {
+	start := BucketStart(granularity, t)
+	if granularity == GranularityHour {
+		return GranularityHour + "#" + start.Format("2006-01-02T15")
+	}
+	return granularity + "#" + start.Format("2006-01-02")
+}
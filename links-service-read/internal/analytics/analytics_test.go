@@ -0,0 +1,38 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBucketKey(t *testing.T) {
+	clickedAt := time.Date(2025, time.January, 2, 15, 4, 5, 0, time.UTC)
+
+	t.Run("Keys match the format written by links-service-write", func(t *testing.T) {
+		require.Equal(t, "hour#2025-01-02T15", BucketKey(GranularityHour, clickedAt))
+		require.Equal(t, "day#2025-01-02", BucketKey(GranularityDay, clickedAt))
+		require.Equal(t, "week#2024-12-30", BucketKey(GranularityWeek, clickedAt))
+	})
+}
+
+func TestNextBucket(t *testing.T) {
+	t.Run("Iterates whole weeks from Monday", func(t *testing.T) {
+		start := BucketStart(GranularityWeek, time.Date(2025, time.January, 2, 0, 0, 0, 0, time.UTC))
+		require.Equal(t, time.Monday, start.Weekday())
+		require.Equal(t, "week#2025-01-06", BucketKey(GranularityWeek, NextBucket(GranularityWeek, start)))
+	})
+
+	t.Run("Hour buckets cross day boundaries", func(t *testing.T) {
+		start := BucketStart(GranularityHour, time.Date(2025, time.January, 2, 23, 30, 0, 0, time.UTC))
+		require.Equal(t, "hour#2025-01-03T00", BucketKey(GranularityHour, NextBucket(GranularityHour, start)))
+	})
+}
+
+func TestValidGranularity(t *testing.T) {
+	require.True(t, ValidGranularity("hour"))
+	require.True(t, ValidGranularity("day"))
+	require.True(t, ValidGranularity("week"))
+	require.False(t, ValidGranularity("month"))
+}
@@ -0,0 +1,106 @@
+package clickstream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"links-service-read/internal/logger"
+
+	"github.com/go-redis/redis/v8"
+	"go.uber.org/zap"
+)
+
+// ErrDisabled is returned by Watch when no Redis server is configured.
+var ErrDisabled = errors.New("live clicks are disabled")
+
+// Notification is the message published by links-service-write for every recorded click.
+type Notification struct {
+	EventID        string `json:"event_id"`
+	LinkID         string `json:"link_id"`
+	CustomerID     string `json:"customer_id"`
+	ClickedAt      string `json:"clicked_at"`
+	ReferrerDomain string `json:"referrer_domain,omitempty"`
+	DeviceClass    string `json:"device_class,omitempty"`
+	Country        string `json:"country,omitempty"`
+	Region         string `json:"region,omitempty"`
+	City           string `json:"city,omitempty"`
+	BotCategory    string `json:"bot_category,omitempty"`
+}
+
+// Channel returns the Redis Pub/Sub channel carrying the clicks on a customer's links.
+// It must match the channel published to by links-service-write.
+func Channel(customerID string) string {
+	return "clicks:" + customerID
+}
+
+// Subscriber listens to the clicks announced by links-service-write over Redis Pub/Sub.
+//
+// A Subscriber without a Redis client is disabled and Watch fails with ErrDisabled.
+type Subscriber struct {
+	rdb *redis.Client
+}
+
+// NewSubscriber creates a Subscriber backed by the given Redis client.
+//
+// Parameters:
+//   - rdb: The Redis client to subscribe with, or nil to disable live clicks.
+//
+// Returns:
+//   - A pointer to the initialized Subscriber.
+func NewSubscriber(rdb *redis.Client) *Subscriber {
+	return &Subscriber{rdb: rdb}
+}
+
+// Watch subscribes to the clicks on a customer's links and hands each of them to handle,
+// in the order they are received, until ctx is cancelled or handle fails.
+//
+// Parameters:
+//   - ctx: The context bounding the subscription.
+//   - customerID: The customer whose clicks are watched.
+//   - ready: Called once the subscription is established, before any click is handled.
+//   - handle: Called for every click; returning an error ends the subscription.
+//
+// Returns:
+//   - nil when ctx is cancelled.
+//   - ErrDisabled if no Redis server is configured, the error returned by handle, or an
+//     error if the subscription fails.
+func (s *Subscriber) Watch(ctx context.Context, customerID string, ready func() error, handle func(Notification) error) error {
+	if s.rdb == nil {
+		return ErrDisabled
+	}
+
+	pubsub := s.rdb.Subscribe(ctx, Channel(customerID))
+	defer pubsub.Close()
+
+	if _, err := pubsub.Receive(ctx); err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return fmt.Errorf("failed to subscribe to clicks: %v", err)
+	}
+	if err := ready(); err != nil {
+		return err
+	}
+
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case message, ok := <-messages:
+			if !ok {
+				return fmt.Errorf("click subscription closed")
+			}
+
+			var notification Notification
+			if err := json.Unmarshal([]byte(message.Payload), &notification); err != nil {
+				logger.Log.Warn("Failed to decode click notification", zap.Error(err))
+				continue
+			}
+			if err := handle(notification); err != nil {
+				return err
+			}
+		}
+	}
+}
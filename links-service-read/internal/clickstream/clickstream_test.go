@@ -0,0 +1,73 @@
+package clickstream
+
+import (
+	"context"
+	"errors"
+	"links-service-read/internal/logger"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	logger.Initialize("development")
+	code := m.Run()
+	logger.Sync()
+	os.Exit(code)
+}
+
+func TestSubscriberWatch(t *testing.T) {
+	s, err := miniredis.Run()
+	require.NoError(t, err, "Failed to start miniredis")
+	defer s.Close()
+
+	rdb := redis.NewClient(&redis.Options{Addr: s.Addr()})
+	defer rdb.Close()
+
+	t.Run("Delivers the customer's clicks in order", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		ready := func() error {
+			rdb.Publish(ctx, Channel("other"), `{"event_id":"x","link_id":"l9"}`)
+			rdb.Publish(ctx, Channel("c1"), `not json`)
+			rdb.Publish(ctx, Channel("c1"), `{"event_id":"e1","link_id":"l1","customer_id":"c1","country":"BR"}`)
+			rdb.Publish(ctx, Channel("c1"), `{"event_id":"e2","link_id":"l2","customer_id":"c1","bot_category":"bot"}`)
+			return nil
+		}
+
+		received := make([]Notification, 0)
+		stop := errors.New("stop")
+		err := NewSubscriber(rdb).Watch(ctx, "c1", ready, func(n Notification) error {
+			received = append(received, n)
+			if len(received) == 2 {
+				return stop
+			}
+			return nil
+		})
+
+		require.ErrorIs(t, err, stop)
+		require.Equal(t, []Notification{
+			{EventID: "e1", LinkID: "l1", CustomerID: "c1", Country: "BR"},
+			{EventID: "e2", LinkID: "l2", CustomerID: "c1", BotCategory: "bot"},
+		}, received)
+	})
+
+	t.Run("Returns once the context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		err := NewSubscriber(rdb).Watch(ctx, "c1", func() error {
+			cancel()
+			return nil
+		}, func(Notification) error { return nil })
+		require.NoError(t, err)
+	})
+
+	t.Run("Disabled subscriber fails", func(t *testing.T) {
+		err := NewSubscriber(nil).Watch(context.Background(), "c1", func() error { return nil }, func(Notification) error { return nil })
+		require.ErrorIs(t, err, ErrDisabled)
+	})
+}
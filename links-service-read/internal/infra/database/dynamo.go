@@ -85,6 +85,9 @@ func ensureLinksTable(ctx context.Context, db *dynamodb.Client) error {
 		{AttributeName: aws.String("customer_id"), AttributeType: types.ScalarAttributeTypeS},
 		{AttributeName: aws.String("created_at"), AttributeType: types.ScalarAttributeTypeS},
 		{AttributeName: aws.String("id"), AttributeType: types.ScalarAttributeTypeS},
+		{AttributeName: aws.String("clicks"), AttributeType: types.ScalarAttributeTypeN},
+		{AttributeName: aws.String("expiration_sort"), AttributeType: types.ScalarAttributeTypeS},
+		{AttributeName: aws.String("original_url_sort"), AttributeType: types.ScalarAttributeTypeS},
 	}
 
 	createInput := &dynamodb.CreateTableInput{
@@ -132,6 +135,42 @@ func ensureLinksTable(ctx context.Context, db *dynamodb.Client) error {
 					WriteCapacityUnits: aws.Int64(2),
 				},
 			},
+			{
+				IndexName: aws.String("ByCustomerClicks"),
+				KeySchema: []types.KeySchemaElement{
+					{AttributeName: aws.String("customer_id"), KeyType: types.KeyTypeHash},
+					{AttributeName: aws.String("clicks"), KeyType: types.KeyTypeRange},
+				},
+				Projection: &types.Projection{ProjectionType: types.ProjectionTypeAll},
+				ProvisionedThroughput: &types.ProvisionedThroughput{
+					ReadCapacityUnits:  aws.Int64(2),
+					WriteCapacityUnits: aws.Int64(2),
+				},
+			},
+			{
+				IndexName: aws.String("ByCustomerExpiration"),
+				KeySchema: []types.KeySchemaElement{
+					{AttributeName: aws.String("customer_id"), KeyType: types.KeyTypeHash},
+					{AttributeName: aws.String("expiration_sort"), KeyType: types.KeyTypeRange},
+				},
+				Projection: &types.Projection{ProjectionType: types.ProjectionTypeAll},
+				ProvisionedThroughput: &types.ProvisionedThroughput{
+					ReadCapacityUnits:  aws.Int64(2),
+					WriteCapacityUnits: aws.Int64(2),
+				},
+			},
+			{
+				IndexName: aws.String("ByCustomerOriginalURL"),
+				KeySchema: []types.KeySchemaElement{
+					{AttributeName: aws.String("customer_id"), KeyType: types.KeyTypeHash},
+					{AttributeName: aws.String("original_url_sort"), KeyType: types.KeyTypeRange},
+				},
+				Projection: &types.Projection{ProjectionType: types.ProjectionTypeAll},
+				ProvisionedThroughput: &types.ProvisionedThroughput{
+					ReadCapacityUnits:  aws.Int64(2),
+					WriteCapacityUnits: aws.Int64(2),
+				},
+			},
 		},
 	}
 
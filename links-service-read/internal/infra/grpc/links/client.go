@@ -0,0 +1,47 @@
+package links
+
+import (
+	"context"
+
+	pb "links-service-read/proto"
+	"links-service-read/utils"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+type Client struct {
+	connWrite  *grpc.ClientConn
+	linksWrite pb.LinksServiceWriteClient
+}
+
+// NewClient initializes and returns a new instance of Client, which provides
+// a gRPC connection to links-service-write. The read service only needs the
+// write side to record clicks served by the redirect endpoint.
+//
+// Returns:
+//   - *Client: A pointer to the initialized Client instance.
+//   - error: An error if the gRPC client connection could not be established.
+func NewClient() (*Client, error) {
+	write, err := grpc.NewClient(utils.ConfigInstance.LinksServiceWriteUrl, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		linksWrite: pb.NewLinksServiceWriteClient(write),
+		connWrite:  write,
+	}, nil
+}
+
+func (c *Client) CloseWrite() error {
+	return c.connWrite.Close()
+}
+
+func (c *Client) UpdateLinkClicks(ctx context.Context, request *pb.UpdateLinkClicksRequest) (*pb.UpdateLinkClicksResponse, error) {
+	return c.linksWrite.UpdateLinkClicks(ctx, request)
+}
+
+func (c *Client) RecordClick(ctx context.Context, request *pb.RecordClickRequest) (*pb.RecordClickResponse, error) {
+	return c.linksWrite.RecordClick(ctx, request)
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"links-service-read/internal/logger"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"go.uber.org/zap"
+)
+
+type ClickRollup struct {
+	Bucket    string
+	Total     int64
+	Referrers map[string]int64
+	Devices   map[string]int64
+	Countries map[string]int64
+	Campaigns map[string]int64
+	Variants  map[string]int64
+	Bots      int64
+	BotKinds  map[string]int64
+
+	// Inactive counts the human hits on the link while it was expired, at its click
+	// limit, disabled or not active yet, broken down by status in InactiveStatuses. They
+	// are not part of Total.
+	Inactive         int64
+	InactiveStatuses map[string]int64
+}
+
+// GetClickRollups retrieves the pre-aggregated click buckets of a link from the
+// "ClickRollups" table, between two bucket keys (inclusive).
+//
+// Rollups are maintained by links-service-write whenever a click is recorded, so this
+// query never touches the raw "ClickEvents" table. Breakdown counters are stored as
+// top-level attributes prefixed with "ref:", "dev:", "cty:", "cmp:" and "var:" (the
+// variant of the link the visitor was sent to, for links with variants). Each bucket
+// counts at most 100 referrer domains apart, and the clicks from further domains under
+// "ref:other". Automated clicks
+// are counted apart, under "bots" and "bot:<category>", and so are hits on the link while
+// it was inactive, under "inactive" and "inactive:<status>"; neither is part of "total".
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - linkID: The ID of the link whose rollups are requested.
+//   - fromBucket: The first bucket key of the range, e.g. "day#2025-01-01".
+//   - toBucket: The last bucket key of the range, e.g. "day#2025-01-31".
+//
+// Returns:
+//   - A slice of ClickRollup ordered by bucket. Buckets without clicks are absent.
+//   - An error if the query fails or an item cannot be decoded.
+func (r *DynamoLinkStore) GetClickRollups(ctx context.Context, linkID, fromBucket, toBucket string) ([]*ClickRollup, error) {
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String("ClickRollups"),
+		KeyConditionExpression: aws.String("link_id = :link AND #bucket BETWEEN :from AND :to"),
+		ExpressionAttributeNames: map[string]string{
+			"#bucket": "bucket",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":link": &types.AttributeValueMemberS{Value: linkID},
+			":from": &types.AttributeValueMemberS{Value: fromBucket},
+			":to":   &types.AttributeValueMemberS{Value: toBucket},
+		},
+	}
+
+	rollups := make([]*ClickRollup, 0)
+	paginator := dynamodb.NewQueryPaginator(r.db, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			logger.Log.Error("Failed to query click rollups", zap.Error(err))
+			return nil, fmt.Errorf("failed to query click rollups: %v", err)
+		}
+
+		for _, item := range page.Items {
+			rollup, err := decodeClickRollup(item)
+			if err != nil {
+				logger.Log.Error("Failed to decode click rollup", zap.Error(err))
+				return nil, err
+			}
+			rollups = append(rollups, rollup)
+		}
+	}
+
+	logger.Log.Info("Click rollups retrieved successfully",
+		zap.String("link_id", linkID),
+		zap.Int("buckets", len(rollups)),
+	)
+	return rollups, nil
+}
+
+func decodeClickRollup(item map[string]types.AttributeValue) (*ClickRollup, error) {
+	rollup := newClickRollup("")
+
+	for name, value := range item {
+		if name == "bucket" {
+			if s, ok := value.(*types.AttributeValueMemberS); ok {
+				rollup.Bucket = s.Value
+			}
+			continue
+		}
+
+		n, ok := value.(*types.AttributeValueMemberN)
+		if !ok {
+			continue
+		}
+		count, err := strconv.ParseInt(n.Value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode click rollup counter %q: %v", name, err)
+		}
+		rollup.setCounter(name, count)
+	}
+
+	return rollup, nil
+}
+
+// newClickRollup creates an empty rollup of the given bucket.
+func newClickRollup(bucket string) *ClickRollup {
+	return &ClickRollup{
+		Bucket:    bucket,
+		Referrers: map[string]int64{},
+		Devices:   map[string]int64{},
+		Countries: map[string]int64{},
+		Campaigns: map[string]int64{},
+		Variants:  map[string]int64{},
+		BotKinds:  map[string]int64{},
+
+		InactiveStatuses: map[string]int64{},
+	}
+}
+
+// setCounter sets the rollup figure stored under a counter name, such as "total" or
+// "ref:google.com". Unknown counters are ignored.
+func (r *ClickRollup) setCounter(name string, count int64) {
+	switch {
+	case name == "total":
+		r.Total = count
+	case name == "bots":
+		r.Bots = count
+	case name == "inactive":
+		r.Inactive = count
+	case strings.HasPrefix(name, "inactive:"):
+		r.InactiveStatuses[strings.TrimPrefix(name, "inactive:")] = count
+	case strings.HasPrefix(name, "bot:"):
+		r.BotKinds[strings.TrimPrefix(name, "bot:")] = count
+	case strings.HasPrefix(name, "ref:"):
+		r.Referrers[strings.TrimPrefix(name, "ref:")] = count
+	case strings.HasPrefix(name, "dev:"):
+		r.Devices[strings.TrimPrefix(name, "dev:")] = count
+	case strings.HasPrefix(name, "cty:"):
+		r.Countries[strings.TrimPrefix(name, "cty:")] = count
+	case strings.HasPrefix(name, "cmp:"):
+		r.Campaigns[strings.TrimPrefix(name, "cmp:")] = count
+	case strings.HasPrefix(name, "var:"):
+		r.Variants[strings.TrimPrefix(name, "var:")] = count
+	}
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// cursorValue is the JSON form of a string or number key attribute.
+type cursorValue struct {
+	S *string `json:"s,omitempty"`
+	N *string `json:"n,omitempty"`
+}
+
+// encodeCursor turns a DynamoDB key into an opaque, URL-safe pagination cursor.
+// Only string and number attributes can be part of a key, so no other types are handled.
+func encodeCursor(key map[string]types.AttributeValue) (string, error) {
+	values := make(map[string]cursorValue, len(key))
+	for name, value := range key {
+		switch v := value.(type) {
+		case *types.AttributeValueMemberS:
+			values[name] = cursorValue{S: &v.Value}
+		case *types.AttributeValueMemberN:
+			values[name] = cursorValue{N: &v.Value}
+		default:
+			return "", fmt.Errorf("unsupported cursor attribute %q", name)
+		}
+	}
+
+	data, err := json.Marshal(values)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode cursor: %v", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodeCursor turns a cursor produced by encodeCursor back into a DynamoDB key.
+func decodeCursor(cursor string) (map[string]types.AttributeValue, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %v", err)
+	}
+
+	var values map[string]cursorValue
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("invalid cursor: %v", err)
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("invalid cursor: empty key")
+	}
+
+	key := make(map[string]types.AttributeValue, len(values))
+	for name, value := range values {
+		switch {
+		case value.S != nil && value.N == nil:
+			key[name] = &types.AttributeValueMemberS{Value: *value.S}
+		case value.N != nil && value.S == nil:
+			key[name] = &types.AttributeValueMemberN{Value: *value.N}
+		default:
+			return nil, fmt.Errorf("invalid cursor: malformed attribute %q", name)
+		}
+	}
+	return key, nil
+}
+
+// itemKey extracts the given key attributes from an item, to resume a query right
+// after it.
+func itemKey(item map[string]types.AttributeValue, attributes ...string) map[string]types.AttributeValue {
+	key := make(map[string]types.AttributeValue, len(attributes))
+	for _, attribute := range attributes {
+		if value, ok := item[attribute]; ok {
+			key[attribute] = value
+		}
+	}
+	return key
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor(t *testing.T) {
+	t.Run("Round trips string and number keys", func(t *testing.T) {
+		key := map[string]types.AttributeValue{
+			"short_url":   &types.AttributeValueMemberS{Value: "abc123"},
+			"customer_id": &types.AttributeValueMemberS{Value: "customer-1"},
+			"clicks":      &types.AttributeValueMemberN{Value: "42"},
+		}
+
+		cursor, err := encodeCursor(key)
+		require.NoError(t, err)
+		require.NotContains(t, cursor, "abc123", "Cursor should be opaque")
+
+		decoded, err := decodeCursor(cursor)
+		require.NoError(t, err)
+		require.Equal(t, key, decoded)
+	})
+
+	t.Run("Rejects malformed cursors", func(t *testing.T) {
+		for _, cursor := range []string{"%%%", "bm90LWpzb24", "e30", "eyJhIjp7fX0"} {
+			_, err := decodeCursor(cursor)
+			require.Error(t, err, cursor)
+			require.Contains(t, err.Error(), "invalid cursor")
+		}
+	})
+
+	t.Run("Extracts the key attributes of an item", func(t *testing.T) {
+		item := map[string]types.AttributeValue{
+			"short_url":    &types.AttributeValueMemberS{Value: "abc123"},
+			"customer_id":  &types.AttributeValueMemberS{Value: "customer-1"},
+			"created_at":   &types.AttributeValueMemberS{Value: "2025-01-01T00:00:00Z"},
+			"original_url": &types.AttributeValueMemberS{Value: "https://example.com"},
+		}
+
+		require.Equal(t, map[string]types.AttributeValue{
+			"short_url":   item["short_url"],
+			"customer_id": item["customer_id"],
+			"created_at":  item["created_at"],
+		}, itemKey(item, "short_url", "customer_id", "created_at"))
+	})
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"links-service-read/internal/logger"
+	"links-service-read/internal/search"
+	"strings"
+	"time"
 
 	pb "links-service-read/proto"
 
@@ -16,32 +19,102 @@ import (
 )
 
 type Link struct {
-	ID             string  `dynamodbav:"id"`
-	ShortURL       string  `dynamodbav:"short_url"`
-	OriginalURL    string  `dynamodbav:"original_url"`
-	CustomSlug     string  `dynamodbav:"custom_slug"`
-	CustomerID     string  `dynamodbav:"customer_id"`
-	Clicks         int32   `dynamodbav:"clicks"`
-	CreatedAt      string  `dynamodbav:"created_at"`
-	UpdatedAt      string  `dynamodbav:"updated_at"`
-	ExpirationDate *string `dynamodbav:"expiration_date,omitempty"`
-	TTL            *int64  `dynamodbav:"ttl,omitempty"`
+	ID             string   `dynamodbav:"id"`
+	ShortURL       string   `dynamodbav:"short_url"`
+	OriginalURL    string   `dynamodbav:"original_url"`
+	CustomSlug     string   `dynamodbav:"custom_slug"`
+	CustomerID     string   `dynamodbav:"customer_id"`
+	Clicks         int32    `dynamodbav:"clicks"`
+	BotClicks      int32    `dynamodbav:"bot_clicks"`
+	CreatedAt      string   `dynamodbav:"created_at"`
+	UpdatedAt      string   `dynamodbav:"updated_at"`
+	ExpirationDate *string  `dynamodbav:"expiration_date,omitempty"`
+	TTL            *int64   `dynamodbav:"ttl,omitempty"`
+	SlugType       string   `dynamodbav:"slug_type"`
+	Disabled       bool     `dynamodbav:"disabled"`
+	ActivatesAt    *string  `dynamodbav:"activates_at,omitempty"`
+	Title          string   `dynamodbav:"title,omitempty"`
+	Tags           []string `dynamodbav:"tags,omitempty"`
+
+	// UTM holds the UTM parameters added to OriginalURL on redirect, nil if none. See
+	// Destination.
+	UTM *UTM `dynamodbav:"utm,omitempty"`
+
+	// PasswordHash is the bcrypt hash of the password visitors must enter before being
+	// redirected, "" if the link is not protected. It must never leave the service.
+	PasswordHash string `dynamodbav:"password_hash,omitempty"`
+
+	// MaxClicks is the number of clicks after which the link behaves as expired, 0 if
+	// unlimited. A one-time link has a limit of 1.
+	MaxClicks int32 `dynamodbav:"max_clicks,omitempty"`
+
+	// FallbackURL is where visitors are sent while the link is not active, that is when
+	// it is expired, at its click limit, disabled or not active yet, "" to fall back to
+	// the customer's fallback URL (see LinkSettings).
+	FallbackURL string `dynamodbav:"fallback_url,omitempty"`
+
+	// Rules send visitors meeting their conditions to other destinations than
+	// OriginalURL, nil if none. See Route.
+	Rules []RedirectRule `dynamodbav:"rules,omitempty"`
+
+	// Variants split the visitors of the link between several destinations, nil if it
+	// has a single one; OriginalURL is then the URL of the first variant. Visitors keep
+	// the variant they were assigned on their next visits if StickyVariants is set. See
+	// AssignVariant.
+	Variants       []Variant `dynamodbav:"variants,omitempty"`
+	StickyVariants bool      `dynamodbav:"sticky_variants,omitempty"`
+
+	// Domain is the branded domain the link is served on, "" for the default one. The
+	// short URL of a branded link is prefixed with it, see LinkKey.
+	Domain string `dynamodbav:"domain,omitempty"`
+
+	// Sort keys and search index of the link, derived by links-service-write. Only the
+	// PostgreSQL and in-memory stores read them back; DynamoDB uses them in its indexes
+	// and filter expressions.
+	ExpirationSort  string `dynamodbav:"expiration_sort"`
+	OriginalURLSort string `dynamodbav:"original_url_sort"`
+	SearchText      string `dynamodbav:"search_text"`
 }
 
-type LinksRepository struct {
+// LinkKey returns the short URL a slug is stored under on a domain: the slug itself on
+// the default domain, and "<domain>/<slug>" on a branded one, as links-service-write
+// stores them.
+func LinkKey(domain, slug string) string {
+	if domain == "" {
+		return slug
+	}
+	return domain + "/" + slug
+}
+
+// Slug returns the slug of a link's short URL, without its domain.
+func (l *Link) Slug() string {
+	if l.Domain == "" {
+		return l.ShortURL
+	}
+	return strings.TrimPrefix(l.ShortURL, l.Domain+"/")
+}
+
+const (
+	// DefaultCustomerLinksLimit is the page size of GetCustomerLinks when none is requested.
+	DefaultCustomerLinksLimit = 50
+	// MaxCustomerLinksLimit caps the page size of GetCustomerLinks.
+	MaxCustomerLinksLimit = 100
+)
+
+type DynamoLinkStore struct {
 	db *dynamodb.Client
 }
 
-// NewLinksRepository creates a new instance of LinksRepository with the provided DynamoDB client.
+// NewDynamoLinkStore creates a new instance of DynamoLinkStore with the provided DynamoDB client.
 // It initializes the repository to interact with the DynamoDB database.
 //
 // Parameters:
 //   - db: A pointer to a dynamodb.Client instance used to perform database operations.
 //
 // Returns:
-//   - A pointer to a LinksRepository instance.
-func NewLinksRepository(db *dynamodb.Client) *LinksRepository {
-	return &LinksRepository{db: db}
+//   - A pointer to a DynamoLinkStore instance.
+func NewDynamoLinkStore(db *dynamodb.Client) *DynamoLinkStore {
+	return &DynamoLinkStore{db: db}
 }
 
 // GetLinkByShortURL retrieves a link from the DynamoDB table "Links" using the provided short URL.
@@ -56,7 +129,7 @@ func NewLinksRepository(db *dynamodb.Client) *LinksRepository {
 // Returns:
 //   - *Link: A pointer to the Link struct containing the retrieved link data.
 //   - error: An error if the link is not found or if any other issue occurs during the operation.
-func (r *LinksRepository) GetLinkByShortURL(ctx context.Context, shortURL string) (*Link, error) {
+func (r *DynamoLinkStore) GetLinkByShortURL(ctx context.Context, shortURL string) (*Link, error) {
 	result, err := r.db.GetItem(ctx, &dynamodb.GetItemInput{
 		TableName: aws.String("Links"),
 		Key: map[string]types.AttributeValue{
@@ -83,9 +156,7 @@ func (r *LinksRepository) GetLinkByShortURL(ctx context.Context, shortURL string
 	return &link, nil
 }
 
-// GetLinkByID retrieves a link from the "Links" table in DynamoDB by its ID.
-// It uses a filter expression to search for the link with the specified ID.
-// If the link is found, it unmarshals the result into a Link struct and returns it.
+// GetLinkByID retrieves a link from the "Links" table by its ID using the "ByID" global secondary index (GSI).
 //
 // Parameters:
 //   - ctx: The context for managing request deadlines and cancellations.
@@ -94,26 +165,27 @@ func (r *LinksRepository) GetLinkByShortURL(ctx context.Context, shortURL string
 // Returns:
 //   - A pointer to the Link struct if the link is found.
 //   - An error if the link is not found, the expression fails to build,
-//     the scan operation fails, or unmarshaling the result fails.
-func (r *LinksRepository) GetLinkByID(ctx context.Context, id string) (*Link, error) {
+//     the query fails, or unmarshaling the result fails.
+func (r *DynamoLinkStore) GetLinkByID(ctx context.Context, id string) (*Link, error) {
 	expr, err := expression.NewBuilder().
-		WithFilter(expression.Name("id").Equal(expression.Value(id))).
+		WithKeyCondition(expression.Key("id").Equal(expression.Value(id))).
 		Build()
 	if err != nil {
 		logger.Log.Error("Failed to build expression", zap.Error(err))
 		return nil, fmt.Errorf("failed to build expression: %v", err)
 	}
 
-	result, err := r.db.Scan(ctx, &dynamodb.ScanInput{
+	result, err := r.db.Query(ctx, &dynamodb.QueryInput{
 		TableName:                 aws.String("Links"),
-		FilterExpression:          expr.Filter(),
+		IndexName:                 aws.String("ByID"),
+		KeyConditionExpression:    expr.KeyCondition(),
 		ExpressionAttributeNames:  expr.Names(),
 		ExpressionAttributeValues: expr.Values(),
 		Limit:                     aws.Int32(1),
 	})
 	if err != nil {
-		logger.Log.Error("Failed to scan for link", zap.Error(err))
-		return nil, fmt.Errorf("failed to scan for link: %v", err)
+		logger.Log.Error("Failed to query link by ID", zap.Error(err))
+		return nil, fmt.Errorf("failed to query link by ID: %v", err)
 	}
 
 	if len(result.Items) == 0 {
@@ -141,7 +213,7 @@ func (r *LinksRepository) GetLinkByID(ctx context.Context, id string) (*Link, er
 // Returns:
 //   - A pointer to the Link object if found.
 //   - An error if the query fails, the link is not found, or unmarshalling the result fails.
-func (r *LinksRepository) GetLinkByCustomSlug(ctx context.Context, customSlug string) (*Link, error) {
+func (r *DynamoLinkStore) GetLinkByCustomSlug(ctx context.Context, customSlug string) (*Link, error) {
 	result, err := r.db.Query(ctx, &dynamodb.QueryInput{
 		TableName:              aws.String("Links"),
 		IndexName:              aws.String("ByCustomSlug"),
@@ -171,8 +243,25 @@ func (r *LinksRepository) GetLinkByCustomSlug(ctx context.Context, customSlug st
 	return &link, nil
 }
 
-// GetCustomerLinks retrieves a list of links associated with a specific customer from the DynamoDB table.
-// It supports optional filtering by status, slug type, and sorting direction, as well as limiting the number of results.
+// searchFilter builds the filter expression matching links against a search query, and
+// adds its values to values. Each word of the query must be a prefix of a word of the
+// link's search_text, which links-service-write derives from the original URL, short
+// URL, custom slug, title and tags of the link; matching ignores case and accents.
+// It returns "" for queries without any word.
+func searchFilter(query string, values map[string]types.AttributeValue) string {
+	terms := search.Terms(query)
+	conditions := make([]string, 0, len(terms))
+	for i, term := range terms {
+		placeholder := fmt.Sprintf(":search%d", i)
+		values[placeholder] = &types.AttributeValueMemberS{Value: " " + term}
+		conditions = append(conditions, fmt.Sprintf("contains(search_text, %s)", placeholder))
+	}
+	return strings.Join(conditions, " AND ")
+}
+
+// GetCustomerLinks retrieves a page of the links associated with a specific customer from the DynamoDB table.
+// It supports optional filtering by status, slug type and search term, sorting by several attributes in
+// either direction, and pages through the results with opaque cursors.
 //
 // Parameters:
 //   - ctx: The context for managing request deadlines and cancellations.
@@ -180,21 +269,42 @@ func (r *LinksRepository) GetLinkByCustomSlug(ctx context.Context, customSlug st
 //
 // Returns:
 //   - A slice of pointers to Link objects representing the retrieved links.
-//   - An error if the query or unmarshalling process fails.
+//   - The cursor of the next page, or "" when there are no more links.
+//   - An error if a filter, the sort order or the cursor is invalid (these errors start with
+//     "invalid "), or if the query or unmarshalling process fails.
 //
 // Filters:
-//   - Status: If provided, filters links by their status.
-//   - SlugType: If provided, filters links by their slug type.
+//   - Status: active, expired, scheduled or disabled, as reported by Link.Status; "all" disables it.
+//   - SlugType: custom or generated ("auto" is an alias of generated); "all" disables it.
+//   - Search: If provided, keeps links matching every word of it, see searchFilter.
+//   - SortBy: created_at (default), clicks, expiration_date or original_url. Each order is served
+//     by its own index; links without an expiration date sort after every dated link.
 //   - SortDirection: Determines the sorting order of the results. Defaults to ascending if not specified or invalid.
-//   - Limit: Limits the number of results returned.
+//   - Limit: The page size. Defaults to DefaultCustomerLinksLimit and is capped at MaxCustomerLinksLimit.
+//   - Cursor: Resumes right after the last link of a previous page, which must have used the same sort order.
+//   - Offset: Skips that many matching links before the page starts.
+//
+// Pagination:
+//   - Status depends on the current time and is filtered in the service, and search is a DynamoDB filter
+//     expression applied after reading a page (the search index is scoped to the customer's partition), so a single Query may return fewer matching items than
+//     requested, or none at all. Pages are read until the requested number of links is collected or the
+//     index is exhausted, so every page except the last one is full.
+//   - The next cursor wraps the key of the last returned link rather than the LastEvaluatedKey of
+//     the last page read, so links that matched but did not fit in the page are not skipped.
 //
 // Logs:
 //   - Logs an error if the query or unmarshalling fails.
 //   - Logs an informational message upon successful retrieval of links.
-func (r *LinksRepository) GetCustomerLinks(ctx context.Context, req *pb.GetCustomerLinksRequest) ([]*Link, error) {
+func (r *DynamoLinkStore) GetCustomerLinks(ctx context.Context, req *pb.GetCustomerLinksRequest) ([]*Link, string, error) {
+	filter, err := newCustomerLinksFilter(req.GetStatus(), req.GetSlugType(), req.GetSortBy())
+	if err != nil {
+		logger.Log.Error("Invalid customer links filter", zap.Error(err))
+		return nil, "", err
+	}
+
 	input := &dynamodb.QueryInput{
 		TableName:              aws.String("Links"),
-		IndexName:              aws.String("ByCustomer"),
+		IndexName:              aws.String(filter.index.name),
 		KeyConditionExpression: aws.String("customer_id = :customer"),
 		ExpressionAttributeValues: map[string]types.AttributeValue{
 			":customer": &types.AttributeValueMemberS{Value: req.CustomerId},
@@ -202,49 +312,76 @@ func (r *LinksRepository) GetCustomerLinks(ctx context.Context, req *pb.GetCusto
 		ScanIndexForward: aws.Bool(req.SortDirection == nil || *req.SortDirection != "desc"),
 	}
 
-	if req.Status != nil {
-		input.FilterExpression = aws.String("status = :status")
-		input.ExpressionAttributeValues[":status"] = &types.AttributeValueMemberS{Value: *req.Status}
+	if req.Search != nil {
+		if filterExpression := searchFilter(*req.Search, input.ExpressionAttributeValues); filterExpression != "" {
+			input.FilterExpression = aws.String(filterExpression)
+		}
 	}
 
-	if req.SlugType != nil {
-		expr := "slug_type = :slugType"
-		if input.FilterExpression != nil {
-			expr = fmt.Sprintf("(%s) AND (%s)", *input.FilterExpression, expr)
-		}
-		input.FilterExpression = aws.String(expr)
-		input.ExpressionAttributeValues[":slugType"] = &types.AttributeValueMemberS{Value: *req.SlugType}
+	limit, skip := customerLinksPage(req)
+	input.Limit = aws.Int32(limit)
+
+	startKey, err := customerLinksStartKey(req, filter)
+	if err != nil {
+		return nil, "", err
 	}
+	input.ExclusiveStartKey = startKey
 
-	if req.Search != nil {
-		searchExpr := fmt.Sprintf("contains(%s, :search)", "custom_slug")
-		if input.FilterExpression != nil {
-			searchExpr = fmt.Sprintf("(%s) AND (%s)", *input.FilterExpression, searchExpr)
+	now := time.Now()
+	links := make([]*Link, 0, limit)
+	var nextKey map[string]types.AttributeValue
+	for {
+		result, err := r.db.Query(ctx, input)
+		if err != nil {
+			logger.Log.Error("Failed to query links by customer", zap.Error(err))
+			return nil, "", fmt.Errorf("failed to query links by customer: %v", err)
 		}
-		input.FilterExpression = aws.String(searchExpr)
-		input.ExpressionAttributeValues[":search"] = &types.AttributeValueMemberS{Value: *req.Search}
-	}
 
-	if req.Limit != nil {
-		input.Limit = req.Limit
-	}
+		for i, item := range result.Items {
+			var link Link
+			if err := attributevalue.UnmarshalMap(item, &link); err != nil {
+				logger.Log.Error("Failed to unmarshal link", zap.Error(err))
+				return nil, "", fmt.Errorf("failed to unmarshal link: %v", err)
+			}
+			if !filter.matches(&link, now) {
+				continue
+			}
 
-	result, err := r.db.Query(ctx, input)
-	if err != nil {
-		logger.Log.Error("Failed to query links by customer", zap.Error(err))
-		return nil, fmt.Errorf("failed to query links by customer: %v", err)
+			if skip > 0 {
+				skip--
+				continue
+			}
+			links = append(links, &link)
+
+			if int32(len(links)) == limit {
+				if i < len(result.Items)-1 || result.LastEvaluatedKey != nil {
+					nextKey = itemKey(item, "short_url", "customer_id", filter.index.rangeKey)
+				}
+				break
+			}
+		}
+
+		if int32(len(links)) == limit || result.LastEvaluatedKey == nil {
+			break
+		}
+		input.ExclusiveStartKey = result.LastEvaluatedKey
 	}
 
-	links := make([]*Link, 0, len(result.Items))
-	for _, item := range result.Items {
-		var link Link
-		if err := attributevalue.UnmarshalMap(item, &link); err != nil {
-			logger.Log.Error("Failed to unmarshal link", zap.Error(err))
-			return nil, fmt.Errorf("failed to unmarshal link: %v", err)
+	nextCursor := ""
+	if nextKey != nil {
+		cursor, err := encodeCursor(nextKey)
+		if err != nil {
+			logger.Log.Error("Failed to encode cursor", zap.Error(err))
+			return nil, "", err
 		}
-		links = append(links, &link)
+		nextCursor = cursor
 	}
 
-	logger.Log.Info("Links retrieved successfully", zap.String("customer_id", req.CustomerId))
-	return links, nil
+	logger.Log.Info("Links retrieved successfully",
+		zap.String("customer_id", req.CustomerId),
+		zap.String("index", filter.index.name),
+		zap.Int("count", len(links)),
+		zap.Bool("has_more", nextCursor != ""),
+	)
+	return links, nextCursor, nil
 }
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchFilter(t *testing.T) {
+	t.Run("Every word must prefix an indexed word", func(t *testing.T) {
+		values := map[string]types.AttributeValue{}
+
+		expr := searchFilter("Loja Promoção", values)
+		require.Equal(t, "contains(search_text, :search0) AND contains(search_text, :search1)", expr)
+		require.Equal(t, &types.AttributeValueMemberS{Value: " loja"}, values[":search0"])
+		require.Equal(t, &types.AttributeValueMemberS{Value: " promocao"}, values[":search1"])
+	})
+
+	t.Run("Blank queries do not filter", func(t *testing.T) {
+		values := map[string]types.AttributeValue{}
+
+		require.Empty(t, searchFilter("  ", values))
+		require.Empty(t, values)
+	})
+}
+
+func TestLinkKey(t *testing.T) {
+	require.Equal(t, "promo", LinkKey("", "promo"))
+	require.Equal(t, "go.example.com/promo", LinkKey("go.example.com", "promo"))
+
+	require.Equal(t, "promo", (&Link{ShortURL: "promo"}).Slug())
+	require.Equal(t, "promo", (&Link{ShortURL: "go.example.com/promo", Domain: "go.example.com"}).Slug())
+}
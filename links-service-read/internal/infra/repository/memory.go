@@ -0,0 +1,232 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"links-service-read/internal/search"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	pb "links-service-read/proto"
+)
+
+// MemoryLinkStore is a LinkStore keeping links, click rollups and link settings in
+// memory. It is meant for tests and local development: data is only added with PutLink,
+// AddRollupCount and PutLinkSettings, and is not shared with links-service-write.
+type MemoryLinkStore struct {
+	mu       sync.RWMutex
+	links    map[string]*Link            // by short URL
+	rollups  map[string]map[string]int64 // by link ID and bucket, then by counter
+	settings map[string]LinkSettings     // by customer ID
+}
+
+// NewMemoryLinkStore creates an empty MemoryLinkStore.
+//
+// Returns:
+//   - A pointer to a MemoryLinkStore instance.
+func NewMemoryLinkStore() *MemoryLinkStore {
+	return &MemoryLinkStore{
+		links:    make(map[string]*Link),
+		rollups:  make(map[string]map[string]int64),
+		settings: make(map[string]LinkSettings),
+	}
+}
+
+// PutLink stores a link, replacing any link with the same short URL. Its read model
+// attributes must already be set, as links-service-write does.
+func (s *MemoryLinkStore) PutLink(link Link) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.links[link.ShortURL] = copyLink(&link)
+}
+
+// AddRollupCount adds count to a counter, such as "total" or "ref:google.com", of a
+// link's rollup bucket, such as "day#2025-01-02".
+func (s *MemoryLinkStore) AddRollupCount(linkID, bucket, counter string, count int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := linkID + "#" + bucket
+	if s.rollups[key] == nil {
+		s.rollups[key] = make(map[string]int64)
+	}
+	s.rollups[key][counter] += count
+}
+
+// PutLinkSettings stores the link settings of a customer, replacing the previous ones.
+func (s *MemoryLinkStore) PutLinkSettings(settings LinkSettings) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.settings[settings.CustomerID] = settings
+}
+
+// GetLinkByShortURL returns the link served at the given short URL.
+func (s *MemoryLinkStore) GetLinkByShortURL(ctx context.Context, shortURL string) (*Link, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	link, ok := s.links[shortURL]
+	if !ok {
+		return nil, fmt.Errorf("link not found")
+	}
+	return copyLink(link), nil
+}
+
+// GetLinkByID returns the link with the given ID.
+func (s *MemoryLinkStore) GetLinkByID(ctx context.Context, id string) (*Link, error) {
+	return s.find(func(l *Link) bool { return l.ID == id })
+}
+
+// GetLinkByCustomSlug returns the link using the given custom slug.
+func (s *MemoryLinkStore) GetLinkByCustomSlug(ctx context.Context, customSlug string) (*Link, error) {
+	return s.find(func(l *Link) bool { return customSlug != "" && l.CustomSlug == customSlug })
+}
+
+// GetCustomerLinks returns a page of a customer's links, with the same filters, sort
+// orders and cursors as the other backends.
+func (s *MemoryLinkStore) GetCustomerLinks(ctx context.Context, req *pb.GetCustomerLinksRequest) ([]*Link, string, error) {
+	filter, err := newCustomerLinksFilter(req.GetStatus(), req.GetSlugType(), req.GetSortBy())
+	if err != nil {
+		return nil, "", err
+	}
+	startKey, err := customerLinksStartKey(req, filter)
+	if err != nil {
+		return nil, "", err
+	}
+	limit, skip := customerLinksPage(req)
+
+	var terms []string
+	if req.Search != nil {
+		terms = search.Terms(*req.Search)
+	}
+
+	rangeKey := filter.index.rangeKey
+	direction := 1
+	if req.SortDirection != nil && *req.SortDirection == "desc" {
+		direction = -1
+	}
+
+	now := time.Now()
+	var matching []*Link
+	s.mu.RLock()
+	for _, link := range s.links {
+		if link.CustomerID != req.CustomerId || !filter.matches(link, now) || !matchesTerms(link, terms) {
+			continue
+		}
+		if startKey != nil && direction*compareKey(link, rangeKey, keyValue(startKey[rangeKey]), keyValue(startKey["short_url"])) <= 0 {
+			continue
+		}
+		matching = append(matching, copyLink(link))
+	}
+	s.mu.RUnlock()
+
+	slices.SortFunc(matching, func(a, b *Link) int {
+		return direction * compareKey(a, rangeKey, keyValue(sortValue(b, rangeKey)), b.ShortURL)
+	})
+
+	matching = matching[min(int(skip), len(matching)):]
+	if len(matching) <= int(limit) {
+		return matching, "", nil
+	}
+
+	links := matching[:limit]
+	cursor, err := encodeCursor(linkKey(links[len(links)-1], rangeKey))
+	if err != nil {
+		return nil, "", err
+	}
+	return links, cursor, nil
+}
+
+// GetClickRollups returns the click rollups of a link between two bucket keys (inclusive).
+func (s *MemoryLinkStore) GetClickRollups(ctx context.Context, linkID, fromBucket, toBucket string) ([]*ClickRollup, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var rollups []*ClickRollup
+	for key, counters := range s.rollups {
+		bucket, ok := strings.CutPrefix(key, linkID+"#")
+		if !ok || bucket < fromBucket || bucket > toBucket {
+			continue
+		}
+
+		rollup := newClickRollup(bucket)
+		for counter, count := range counters {
+			rollup.setCounter(counter, count)
+		}
+		rollups = append(rollups, rollup)
+	}
+
+	slices.SortFunc(rollups, func(a, b *ClickRollup) int { return strings.Compare(a.Bucket, b.Bucket) })
+	return rollups, nil
+}
+
+// GetLinkSettings returns the link settings of a customer, empty if they never saved any.
+func (s *MemoryLinkStore) GetLinkSettings(ctx context.Context, customerID string) (*LinkSettings, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	settings, ok := s.settings[customerID]
+	if !ok {
+		settings = LinkSettings{CustomerID: customerID}
+	}
+	return &settings, nil
+}
+
+// find returns a copy of the first stored link matching the predicate.
+func (s *MemoryLinkStore) find(match func(*Link) bool) (*Link, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, link := range s.links {
+		if match(link) {
+			return copyLink(link), nil
+		}
+	}
+	return nil, fmt.Errorf("link not found")
+}
+
+// matchesTerms reports whether every search term prefixes a word of the link's search
+// text, like the search filter of the other backends.
+func matchesTerms(link *Link, terms []string) bool {
+	for _, term := range terms {
+		if !strings.Contains(link.SearchText, " "+term) {
+			return false
+		}
+	}
+	return true
+}
+
+// compareKey compares the (sort key, short URL) pair of a link with the given one.
+// Clicks compare as numbers, every other sort key as a string.
+func compareKey(link *Link, rangeKey, value, shortURL string) int {
+	var c int
+	if rangeKey == "clicks" {
+		clicks, _ := strconv.Atoi(value)
+		c = cmp.Compare(int(link.Clicks), clicks)
+	} else {
+		c = strings.Compare(keyValue(sortValue(link, rangeKey)), value)
+	}
+	if c != 0 {
+		return c
+	}
+	return strings.Compare(link.ShortURL, shortURL)
+}
+
+// copyLink returns a copy of link that shares no mutable state with it.
+func copyLink(link *Link) *Link {
+	c := *link
+	c.Tags = append([]string(nil), link.Tags...)
+	c.Rules = copyRules(link.Rules)
+	c.Variants = append([]Variant(nil), link.Variants...)
+	if link.UTM != nil {
+		utm := *link.UTM
+		c.UTM = &utm
+	}
+	return &c
+}
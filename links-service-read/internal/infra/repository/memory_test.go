@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"links-service-read/internal/logger"
+	"os"
+	"testing"
+
+	pb "links-service-read/proto"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	logger.Initialize("development")
+	code := m.Run()
+	logger.Sync()
+	os.Exit(code)
+}
+
+func TestMemoryLinkStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryLinkStore()
+	for i := 1; i <= 5; i++ {
+		store.PutLink(Link{
+			ID:          fmt.Sprintf("id-%d", i),
+			ShortURL:    fmt.Sprintf("link%d", i),
+			CustomerID:  "customer-1",
+			Clicks:      int32(10 - i),
+			CreatedAt:   fmt.Sprintf("2025-01-0%dT00:00:00Z", i),
+			SlugType:    SlugTypeGenerated,
+			SearchText:  fmt.Sprintf(" example com link%d", i),
+			Disabled:    i == 5,
+			OriginalURL: "https://example.com",
+		})
+	}
+	store.PutLink(Link{ID: "other", ShortURL: "other", CustomerID: "customer-2", CreatedAt: "2025-01-01T00:00:00Z"})
+
+	shortURLs := func(links []*Link) []string {
+		urls := make([]string, 0, len(links))
+		for _, link := range links {
+			urls = append(urls, link.ShortURL)
+		}
+		return urls
+	}
+
+	t.Run("Looks links up", func(t *testing.T) {
+		link, err := store.GetLinkByID(ctx, "id-2")
+		require.NoError(t, err)
+		require.Equal(t, "link2", link.ShortURL)
+
+		_, err = store.GetLinkByShortURL(ctx, "missing")
+		require.EqualError(t, err, "link not found")
+	})
+
+	t.Run("Pages through a customer's links with cursors", func(t *testing.T) {
+		req := &pb.GetCustomerLinksRequest{CustomerId: "customer-1", Limit: aws.Int32(2)}
+
+		links, cursor, err := store.GetCustomerLinks(ctx, req)
+		require.NoError(t, err)
+		require.Equal(t, []string{"link1", "link2"}, shortURLs(links))
+		require.NotEmpty(t, cursor)
+
+		req.Cursor = &cursor
+		links, cursor, err = store.GetCustomerLinks(ctx, req)
+		require.NoError(t, err)
+		require.Equal(t, []string{"link3", "link4"}, shortURLs(links))
+
+		req.Cursor = &cursor
+		links, cursor, err = store.GetCustomerLinks(ctx, req)
+		require.NoError(t, err)
+		require.Equal(t, []string{"link5"}, shortURLs(links))
+		require.Empty(t, cursor, "The last page should have no cursor")
+	})
+
+	t.Run("Sorts, filters and searches", func(t *testing.T) {
+		links, _, err := store.GetCustomerLinks(ctx, &pb.GetCustomerLinksRequest{
+			CustomerId:    "customer-1",
+			SortBy:        aws.String(SortByClicks),
+			SortDirection: aws.String("desc"),
+			Status:        aws.String(StatusActive),
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"link1", "link2", "link3", "link4"}, shortURLs(links))
+
+		links, _, err = store.GetCustomerLinks(ctx, &pb.GetCustomerLinksRequest{CustomerId: "customer-1", Search: aws.String("Link3")})
+		require.NoError(t, err)
+		require.Equal(t, []string{"link3"}, shortURLs(links))
+	})
+
+	t.Run("Rejects cursors of another sort order", func(t *testing.T) {
+		_, cursor, err := store.GetCustomerLinks(ctx, &pb.GetCustomerLinksRequest{CustomerId: "customer-1", Limit: aws.Int32(1)})
+		require.NoError(t, err)
+
+		_, _, err = store.GetCustomerLinks(ctx, &pb.GetCustomerLinksRequest{
+			CustomerId: "customer-1",
+			SortBy:     aws.String(SortByClicks),
+			Cursor:     &cursor,
+		})
+		require.ErrorContains(t, err, "invalid cursor")
+	})
+
+	t.Run("Groups rollup counters by bucket", func(t *testing.T) {
+		store.AddRollupCount("id-1", "day#2025-01-02", "total", 3)
+		store.AddRollupCount("id-1", "day#2025-01-02", "ref:google.com", 2)
+		store.AddRollupCount("id-1", "day#2025-01-02", "cmp:summer_sale", 3)
+		store.AddRollupCount("id-1", "day#2025-01-01", "bots", 1)
+		store.AddRollupCount("id-1", "day#2025-02-01", "total", 7)
+
+		rollups, err := store.GetClickRollups(ctx, "id-1", "day#2025-01-01", "day#2025-01-31")
+		require.NoError(t, err)
+		require.Len(t, rollups, 2)
+		require.Equal(t, "day#2025-01-01", rollups[0].Bucket)
+		require.Equal(t, int64(1), rollups[0].Bots)
+		require.Equal(t, int64(3), rollups[1].Total)
+		require.Equal(t, map[string]int64{"google.com": 2}, rollups[1].Referrers)
+		require.Equal(t, map[string]int64{"summer_sale": 3}, rollups[1].Campaigns)
+	})
+}
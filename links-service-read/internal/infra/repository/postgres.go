@@ -0,0 +1,361 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"links-service-read/internal/logger"
+	"links-service-read/internal/search"
+	"strings"
+	"time"
+
+	pb "links-service-read/proto"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// linkColumns lists the columns of the "links" table read by scanLink, in scan order.
+const linkColumns = `id, short_url, original_url, COALESCE(custom_slug, ''), customer_id::text,
+	clicks, bot_clicks, created_at, updated_at, expires_at, slug_type, disabled, activates_at,
+	title, tags, expiration_sort, original_url_sort, search_text, domain,
+	utm_source, utm_medium, utm_campaign, utm_term, utm_content, password_hash, max_clicks,
+	fallback_url, redirect_rules, variants, sticky_variants`
+
+// postgresSortTypes maps the sort key of each sort order of GetCustomerLinks to the type
+// of its column, which cursor values are cast to.
+var postgresSortTypes = map[string]string{
+	"created_at":        "timestamptz",
+	"clicks":            "integer",
+	"expiration_sort":   "text",
+	"original_url_sort": "text",
+}
+
+// PostgresLinkStore is a LinkStore backed by PostgreSQL, reading the "links",
+// "click_rollups" and "customer_link_settings" tables maintained by the PostgreSQL
+// backend of links-service-write.
+type PostgresLinkStore struct {
+	db *pgxpool.Pool
+}
+
+// NewPostgresLinkStore creates a new instance of PostgresLinkStore with the provided
+// connection pool.
+//
+// Parameters:
+//   - db: A pointer to a pgxpool.Pool connected to the database holding the "links" table.
+//
+// Returns:
+//   - A pointer to a PostgresLinkStore instance.
+func NewPostgresLinkStore(db *pgxpool.Pool) *PostgresLinkStore {
+	return &PostgresLinkStore{db: db}
+}
+
+// GetLinkByShortURL retrieves a link from the "links" table by its short URL.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - shortURL: The short URL of the link to retrieve.
+//
+// Returns:
+//   - *Link: A pointer to the Link struct containing the retrieved link data.
+//   - error: An error if the link is not found or the query fails.
+func (r *PostgresLinkStore) GetLinkByShortURL(ctx context.Context, shortURL string) (*Link, error) {
+	return r.getLink(ctx, "short_url", shortURL)
+}
+
+// GetLinkByID retrieves a link from the "links" table by its ID.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - id: The ID of the link to retrieve.
+//
+// Returns:
+//   - A pointer to the Link struct if the link is found.
+//   - An error if the link is not found or the query fails.
+func (r *PostgresLinkStore) GetLinkByID(ctx context.Context, id string) (*Link, error) {
+	return r.getLink(ctx, "id", id)
+}
+
+// GetLinkByCustomSlug retrieves a link from the "links" table by its custom slug.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - customSlug: The custom slug used to query the link.
+//
+// Returns:
+//   - A pointer to the Link object if found.
+//   - An error if the link is not found or the query fails.
+func (r *PostgresLinkStore) GetLinkByCustomSlug(ctx context.Context, customSlug string) (*Link, error) {
+	return r.getLink(ctx, "custom_slug", customSlug)
+}
+
+// getLink retrieves the link whose column equals value. column is one of the unique
+// columns of the "links" table and never comes from user input.
+func (r *PostgresLinkStore) getLink(ctx context.Context, column, value string) (*Link, error) {
+	link, err := scanLink(r.db.QueryRow(ctx, "SELECT "+linkColumns+" FROM links WHERE "+column+" = $1", value))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logger.Log.Info("Link not found", zap.String(column, value))
+			return nil, fmt.Errorf("link not found")
+		}
+		logger.Log.Error("Failed to get link", zap.Error(err))
+		return nil, fmt.Errorf("failed to get link: %v", err)
+	}
+
+	logger.Log.Info("Link retrieved successfully", zap.String(column, value))
+	return link, nil
+}
+
+// GetCustomerLinks retrieves a page of the links associated with a specific customer from
+// the "links" table. It takes the same filters, sort orders and cursors as
+// DynamoLinkStore.GetCustomerLinks, but evaluates every filter in the query, so a single
+// round trip fills the page. Pages are read by key, after the (sort key, short URL) pair
+// of the cursor, using the customer sort indexes of the table.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - req: A pointer to a GetCustomerLinksRequest containing the customer ID and optional filters.
+//
+// Returns:
+//   - A slice of pointers to Link objects representing the retrieved links.
+//   - The cursor of the next page, or "" when there are no more links.
+//   - An error if a filter, the sort order or the cursor is invalid (these errors start with
+//     "invalid "), or if the query fails.
+func (r *PostgresLinkStore) GetCustomerLinks(ctx context.Context, req *pb.GetCustomerLinksRequest) ([]*Link, string, error) {
+	filter, err := newCustomerLinksFilter(req.GetStatus(), req.GetSlugType(), req.GetSortBy())
+	if err != nil {
+		logger.Log.Error("Invalid customer links filter", zap.Error(err))
+		return nil, "", err
+	}
+
+	startKey, err := customerLinksStartKey(req, filter)
+	if err != nil {
+		return nil, "", err
+	}
+	limit, skip := customerLinksPage(req)
+
+	args := []any{req.CustomerId}
+	arg := func(value any) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	conditions := []string{"customer_id = $1"}
+	if filter.status != "" {
+		conditions = append(conditions, statusCondition(filter.status, arg(time.Now())))
+	}
+	if filter.slugType != "" {
+		conditions = append(conditions, "slug_type = "+arg(filter.slugType))
+	}
+	if req.Search != nil {
+		for _, term := range search.Terms(*req.Search) {
+			conditions = append(conditions, "strpos(search_text, "+arg(" "+term)+") > 0")
+		}
+	}
+
+	rangeKey := filter.index.rangeKey
+	ascending := req.SortDirection == nil || *req.SortDirection != "desc"
+	direction, comparison := "ASC", ">"
+	if !ascending {
+		direction, comparison = "DESC", "<"
+	}
+	if startKey != nil {
+		conditions = append(conditions, fmt.Sprintf("(%s, short_url) %s (%s::%s, %s)",
+			rangeKey, comparison, arg(keyValue(startKey[rangeKey])), postgresSortTypes[rangeKey],
+			arg(keyValue(startKey["short_url"]))))
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM links WHERE %s ORDER BY %s %s, short_url %s LIMIT %s OFFSET %s",
+		linkColumns, strings.Join(conditions, " AND "), rangeKey, direction, direction, arg(limit+1), arg(skip))
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		logger.Log.Error("Failed to query links by customer", zap.Error(err))
+		return nil, "", fmt.Errorf("failed to query links by customer: %v", err)
+	}
+	defer rows.Close()
+
+	links := make([]*Link, 0, limit)
+	hasMore := false
+	for rows.Next() {
+		if int32(len(links)) == limit {
+			hasMore = true
+			break
+		}
+		link, err := scanLink(rows)
+		if err != nil {
+			logger.Log.Error("Failed to scan link", zap.Error(err))
+			return nil, "", fmt.Errorf("failed to scan link: %v", err)
+		}
+		links = append(links, link)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Log.Error("Failed to query links by customer", zap.Error(err))
+		return nil, "", fmt.Errorf("failed to query links by customer: %v", err)
+	}
+
+	nextCursor := ""
+	if hasMore {
+		cursor, err := encodeCursor(linkKey(links[len(links)-1], rangeKey))
+		if err != nil {
+			logger.Log.Error("Failed to encode cursor", zap.Error(err))
+			return nil, "", err
+		}
+		nextCursor = cursor
+	}
+
+	logger.Log.Info("Links retrieved successfully",
+		zap.String("customer_id", req.CustomerId),
+		zap.String("sort_by", rangeKey),
+		zap.Int("count", len(links)),
+		zap.Bool("has_more", nextCursor != ""),
+	)
+	return links, nextCursor, nil
+}
+
+// statusCondition returns the SQL condition matching the links that have the given
+// status at the time bound to the now placeholder, mirroring Link.Status.
+func statusCondition(status, now string) string {
+	expired := "((expires_at IS NOT NULL AND expires_at < " + now + ") OR (max_clicks > 0 AND clicks >= max_clicks))"
+	scheduled := "(activates_at IS NOT NULL AND activates_at > " + now + ")"
+
+	switch status {
+	case StatusDisabled:
+		return "disabled"
+	case StatusExpired:
+		return "(NOT disabled AND " + expired + ")"
+	case StatusScheduled:
+		return "(NOT disabled AND NOT " + expired + " AND " + scheduled + ")"
+	default:
+		return "(NOT disabled AND NOT " + expired + " AND NOT " + scheduled + ")"
+	}
+}
+
+// GetClickRollups retrieves the click rollups of a link from the "click_rollups" table,
+// between two bucket keys (inclusive). Each row holds one counter of a bucket, named as
+// the attributes of the DynamoDB "ClickRollups" items.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - linkID: The ID of the link whose rollups are requested.
+//   - fromBucket: The first bucket key of the range, e.g. "day#2025-01-01".
+//   - toBucket: The last bucket key of the range, e.g. "day#2025-01-31".
+//
+// Returns:
+//   - A slice of ClickRollup ordered by bucket. Buckets without clicks are absent.
+//   - An error if the query fails.
+func (r *PostgresLinkStore) GetClickRollups(ctx context.Context, linkID, fromBucket, toBucket string) ([]*ClickRollup, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT bucket, counter, count FROM click_rollups
+		WHERE link_id = $1 AND bucket BETWEEN $2 AND $3
+		ORDER BY bucket`,
+		linkID, fromBucket, toBucket,
+	)
+	if err != nil {
+		logger.Log.Error("Failed to query click rollups", zap.Error(err))
+		return nil, fmt.Errorf("failed to query click rollups: %v", err)
+	}
+	defer rows.Close()
+
+	var rollups []*ClickRollup
+	for rows.Next() {
+		var bucket, counter string
+		var count int64
+		if err := rows.Scan(&bucket, &counter, &count); err != nil {
+			logger.Log.Error("Failed to scan click rollup", zap.Error(err))
+			return nil, fmt.Errorf("failed to scan click rollup: %v", err)
+		}
+
+		if len(rollups) == 0 || rollups[len(rollups)-1].Bucket != bucket {
+			rollups = append(rollups, newClickRollup(bucket))
+		}
+		rollups[len(rollups)-1].setCounter(counter, count)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Log.Error("Failed to query click rollups", zap.Error(err))
+		return nil, fmt.Errorf("failed to query click rollups: %v", err)
+	}
+
+	logger.Log.Info("Click rollups retrieved successfully",
+		zap.String("link_id", linkID),
+		zap.Int("buckets", len(rollups)),
+	)
+	return rollups, nil
+}
+
+// GetLinkSettings retrieves the link settings of a customer from the
+// "customer_link_settings" table.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - customerID: The ID of the customer.
+//
+// Returns:
+//   - A pointer to the customer's LinkSettings, empty if they never saved any.
+//   - An error if the query fails.
+func (r *PostgresLinkStore) GetLinkSettings(ctx context.Context, customerID string) (*LinkSettings, error) {
+	settings := LinkSettings{CustomerID: customerID}
+	var updatedAt time.Time
+
+	err := r.db.QueryRow(ctx, `
+		SELECT fallback_url, fallback_mode, updated_at FROM customer_link_settings WHERE customer_id = $1`,
+		customerID,
+	).Scan(&settings.FallbackURL, &settings.FallbackMode, &updatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &settings, nil
+		}
+		logger.Log.Error("Failed to get link settings", zap.Error(err))
+		return nil, fmt.Errorf("failed to get link settings: %v", err)
+	}
+
+	settings.UpdatedAt = updatedAt.UTC().Format(time.RFC3339)
+	return &settings, nil
+}
+
+// scanLink reads a row of linkColumns into a Link, formatting dates as RFC3339 in UTC
+// like the DynamoDB backend stores them.
+func scanLink(row pgx.Row) (*Link, error) {
+	var link Link
+	var createdAt, updatedAt time.Time
+	var expiresAt, activatesAt *time.Time
+	var utm UTM
+
+	err := row.Scan(
+		&link.ID, &link.ShortURL, &link.OriginalURL, &link.CustomSlug, &link.CustomerID,
+		&link.Clicks, &link.BotClicks, &createdAt, &updatedAt, &expiresAt, &link.SlugType, &link.Disabled, &activatesAt,
+		&link.Title, &link.Tags, &link.ExpirationSort, &link.OriginalURLSort, &link.SearchText, &link.Domain,
+		&utm.Source, &utm.Medium, &utm.Campaign, &utm.Term, &utm.Content, &link.PasswordHash,
+		&link.MaxClicks, &link.FallbackURL, &link.Rules, &link.Variants, &link.StickyVariants,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	link.CreatedAt = createdAt.UTC().Format(time.RFC3339)
+	link.UpdatedAt = updatedAt.UTC().Format(time.RFC3339)
+	link.ExpirationDate = formatOptionalTime(expiresAt)
+	link.ActivatesAt = formatOptionalTime(activatesAt)
+	if len(link.Tags) == 0 {
+		link.Tags = nil
+	}
+	if len(link.Rules) == 0 {
+		link.Rules = nil
+	}
+	if len(link.Variants) == 0 {
+		link.Variants = nil
+	}
+	if !utm.IsZero() {
+		link.UTM = &utm
+	}
+	return &link, nil
+}
+
+func formatOptionalTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.UTC().Format(time.RFC3339)
+	return &formatted
+}
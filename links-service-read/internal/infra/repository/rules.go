@@ -0,0 +1,234 @@
+package repository
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	_ "time/tzdata" // rule time zones must load on images without zoneinfo
+
+	"golang.org/x/text/language"
+)
+
+// Devices matched by RedirectRule.Devices, see DeviceOf.
+const (
+	DeviceIOS     = "ios"
+	DeviceAndroid = "android"
+	DeviceDesktop = "desktop"
+)
+
+// ruleDays maps the days of RedirectRule.Days to their time.Weekday.
+var ruleDays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// ruleLocations caches the time zones of redirect rules by name.
+var ruleLocations sync.Map
+
+// RedirectRule sends the visitors of a link who meet all of its conditions to another
+// destination than its original URL, as stored by links-service-write. A condition left
+// empty matches every visitor. See Link.Route.
+type RedirectRule struct {
+	Destination string `dynamodbav:"destination" json:"destination"`
+
+	// Devices lists the devices matched: DeviceIOS, DeviceAndroid or DeviceDesktop.
+	Devices []string `dynamodbav:"devices,omitempty" json:"devices,omitempty"`
+
+	// Countries lists the ISO 3166-1 alpha-2 codes of the countries matched, in upper case.
+	Countries []string `dynamodbav:"countries,omitempty" json:"countries,omitempty"`
+
+	// Languages lists the languages matched against the visitor's preferred language, in
+	// lower case: "pt" matches every variant of Portuguese, "pt-br" only Brazilian Portuguese.
+	Languages []string `dynamodbav:"languages,omitempty" json:"languages,omitempty"`
+
+	// Days lists the days of the week matched in Timezone, "mon" to "sun".
+	Days []string `dynamodbav:"days,omitempty" json:"days,omitempty"`
+
+	// StartTime and EndTime bound the time of day matched in Timezone, "HH:MM", from
+	// StartTime included to EndTime excluded. An EndTime before StartTime spans midnight.
+	StartTime string `dynamodbav:"start_time,omitempty" json:"start_time,omitempty"`
+	EndTime   string `dynamodbav:"end_time,omitempty" json:"end_time,omitempty"`
+
+	// Timezone is the IANA time zone of Days, StartTime and EndTime, "" for UTC.
+	Timezone string `dynamodbav:"timezone,omitempty" json:"timezone,omitempty"`
+}
+
+// Visitor describes the request of a visitor of a link, as matched by redirect rules.
+type Visitor struct {
+	// Device is the visitor's device, see DeviceOf.
+	Device string
+	// Country is the ISO 3166-1 alpha-2 code of the visitor's country, "" if unknown.
+	Country string
+	// Language is the visitor's preferred language, see PreferredLanguage.
+	Language string
+	// Time is when the visit happened.
+	Time time.Time
+	// Variant is the ID of the variant of the link the visitor is assigned, see
+	// Link.AssignVariant.
+	Variant string
+}
+
+// DeviceOf classifies a User-Agent header into DeviceIOS, DeviceAndroid or DeviceDesktop,
+// or "" for other mobile devices and empty headers.
+//
+// Notes:
+//   - iPads on iPadOS 13 and later announce themselves as Macs, and so are desktops.
+func DeviceOf(userAgent string) string {
+	ua := strings.ToLower(userAgent)
+	switch {
+	case ua == "":
+		return ""
+	case strings.Contains(ua, "iphone"), strings.Contains(ua, "ipad"), strings.Contains(ua, "ipod"):
+		return DeviceIOS
+	case strings.Contains(ua, "android"):
+		return DeviceAndroid
+	case strings.Contains(ua, "mobile"), strings.Contains(ua, "windows phone"):
+		return ""
+	default:
+		return DeviceDesktop
+	}
+}
+
+// PreferredLanguage returns the language of an Accept-Language header with the highest
+// weight, in lower case (e.g. "pt-br"), or "" if there is none or the header is malformed.
+func PreferredLanguage(acceptLanguage string) string {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return ""
+	}
+	return strings.ToLower(tags[0].String())
+}
+
+// Route returns the URL a visitor of the link is redirected to: the destination of the
+// first of its rules the visitor matches, or else the URL of the variant they are
+// assigned, or else its original URL, with its UTM parameters added in every case (see
+// Destination).
+//
+// Returns:
+//   - The URL to redirect the visitor to.
+//   - The ID of the variant the visitor is sent to, "" if a rule or the original URL
+//     applies.
+func (l *Link) Route(visitor Visitor) (string, string) {
+	for _, rule := range l.Rules {
+		if rule.Matches(visitor) {
+			return l.withUTM(rule.Destination), ""
+		}
+	}
+	if variant := l.variant(visitor.Variant); variant != nil {
+		return l.withUTM(variant.URL), variant.ID
+	}
+	return l.Destination(), ""
+}
+
+// Matches reports whether the visitor meets all of the conditions of the rule.
+//
+// Notes:
+//   - Visitors whose device, country or language is unknown do not match rules with a
+//     condition on it.
+//   - Days are those of the visitor's time in the rule's time zone, so a window spanning
+//     midnight on "fri" covers Friday evening and the start of Friday morning.
+func (r RedirectRule) Matches(visitor Visitor) bool {
+	if len(r.Devices) > 0 && !slices.Contains(r.Devices, visitor.Device) {
+		return false
+	}
+	if len(r.Countries) > 0 && !slices.Contains(r.Countries, strings.ToUpper(visitor.Country)) {
+		return false
+	}
+	if len(r.Languages) > 0 && !r.matchesLanguage(visitor.Language) {
+		return false
+	}
+	if len(r.Days) == 0 && r.StartTime == "" {
+		return true
+	}
+
+	local := visitor.Time.In(ruleLocation(r.Timezone))
+	if len(r.Days) > 0 && !r.matchesDay(local.Weekday()) {
+		return false
+	}
+	return r.StartTime == "" || r.matchesTime(local)
+}
+
+// matchesLanguage reports whether lang is one of the rule's languages, or a variant of one.
+func (r RedirectRule) matchesLanguage(lang string) bool {
+	if lang == "" {
+		return false
+	}
+	for _, ruleLang := range r.Languages {
+		if lang == ruleLang || strings.HasPrefix(lang, ruleLang+"-") {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesDay reports whether day is one of the rule's days.
+func (r RedirectRule) matchesDay(day time.Weekday) bool {
+	for _, ruleDay := range r.Days {
+		if weekday, ok := ruleDays[ruleDay]; ok && weekday == day {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesTime reports whether the time of day of t falls in the rule's time window.
+// Malformed windows never match.
+func (r RedirectRule) matchesTime(t time.Time) bool {
+	start, ok := minuteOfDay(r.StartTime)
+	if !ok {
+		return false
+	}
+	end, ok := minuteOfDay(r.EndTime)
+	if !ok {
+		return false
+	}
+
+	minute := t.Hour()*60 + t.Minute()
+	if start < end {
+		return minute >= start && minute < end
+	}
+	return minute >= start || minute < end
+}
+
+// minuteOfDay converts a "HH:MM" time into minutes since midnight.
+func minuteOfDay(value string) (int, bool) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, false
+	}
+	return t.Hour()*60 + t.Minute(), true
+}
+
+// ruleLocation returns the time zone of the given name, UTC for "" or an unknown name.
+func ruleLocation(name string) *time.Location {
+	if name == "" {
+		return time.UTC
+	}
+	if loc, ok := ruleLocations.Load(name); ok {
+		return loc.(*time.Location)
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	ruleLocations.Store(name, loc)
+	return loc
+}
+
+// copyRules returns a copy of rules that shares no mutable state with it.
+func copyRules(rules []RedirectRule) []RedirectRule {
+	if rules == nil {
+		return nil
+	}
+	c := make([]RedirectRule, len(rules))
+	for i, rule := range rules {
+		rule.Devices = append([]string(nil), rule.Devices...)
+		rule.Countries = append([]string(nil), rule.Countries...)
+		rule.Languages = append([]string(nil), rule.Languages...)
+		rule.Days = append([]string(nil), rule.Days...)
+		c[i] = rule
+	}
+	return c
+}
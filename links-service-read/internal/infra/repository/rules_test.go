@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoute(t *testing.T) {
+	link := &Link{
+		OriginalURL: "https://example.com",
+		UTM:         &UTM{Source: "qr"},
+		Rules: []RedirectRule{
+			{Destination: "https://apps.apple.com/app", Devices: []string{DeviceIOS}},
+			{Destination: "https://example.com.br", Countries: []string{"BR"}, Languages: []string{"pt"}},
+			{Destination: "https://example.com/night", StartTime: "22:00", EndTime: "06:00", Timezone: "America/Sao_Paulo"},
+		},
+	}
+	noon := time.Date(2025, 1, 6, 15, 0, 0, 0, time.UTC) // 12:00 in São Paulo
+	route := func(visitor Visitor) string {
+		destination, _ := link.Route(visitor)
+		return destination
+	}
+
+	t.Run("The first matching rule wins, with the UTM parameters of the link", func(t *testing.T) {
+		visitor := Visitor{Device: DeviceIOS, Country: "BR", Language: "pt-br", Time: noon}
+		require.Equal(t, "https://apps.apple.com/app?utm_source=qr", route(visitor))
+
+		visitor.Device = DeviceAndroid
+		require.Equal(t, "https://example.com.br?utm_source=qr", route(visitor))
+	})
+
+	t.Run("Visitors matching no rule get the original URL", func(t *testing.T) {
+		require.Equal(t, "https://example.com?utm_source=qr", route(Visitor{Device: DeviceDesktop, Country: "BR", Language: "en", Time: noon}))
+		require.Equal(t, "https://example.com?utm_source=qr", route(Visitor{Language: "pt", Time: noon}), "Unknown countries should not match")
+	})
+
+	t.Run("Time windows can span midnight in the rule's time zone", func(t *testing.T) {
+		require.Equal(t, "https://example.com/night?utm_source=qr", route(Visitor{Time: time.Date(2025, 1, 7, 2, 0, 0, 0, time.UTC)}))
+		require.Equal(t, "https://example.com/night?utm_source=qr", route(Visitor{Time: time.Date(2025, 1, 7, 8, 59, 0, 0, time.UTC)}))
+		require.Equal(t, "https://example.com?utm_source=qr", route(Visitor{Time: time.Date(2025, 1, 7, 9, 0, 0, 0, time.UTC)}))
+	})
+}
+
+func TestRedirectRuleMatches(t *testing.T) {
+	t.Run("Days are those of the rule's time zone", func(t *testing.T) {
+		rule := RedirectRule{Days: []string{"sat", "sun"}, Timezone: "Asia/Tokyo"}
+		require.True(t, rule.Matches(Visitor{Time: time.Date(2025, 1, 10, 16, 0, 0, 0, time.UTC)}), "Friday 16:00 UTC is Saturday in Tokyo")
+		require.False(t, rule.Matches(Visitor{Time: time.Date(2025, 1, 10, 14, 0, 0, 0, time.UTC)}))
+	})
+
+	t.Run("Languages match their regional variants", func(t *testing.T) {
+		require.True(t, RedirectRule{Languages: []string{"pt"}}.Matches(Visitor{Language: "pt-br"}))
+		require.True(t, RedirectRule{Languages: []string{"pt-br"}}.Matches(Visitor{Language: "pt-br"}))
+		require.False(t, RedirectRule{Languages: []string{"pt-br"}}.Matches(Visitor{Language: "pt-pt"}))
+		require.False(t, RedirectRule{Languages: []string{"pt"}}.Matches(Visitor{}))
+	})
+}
+
+func TestDeviceOf(t *testing.T) {
+	require.Equal(t, DeviceIOS, DeviceOf("Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15 Mobile/15E148"))
+	require.Equal(t, DeviceAndroid, DeviceOf("Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 Chrome/120.0 Mobile Safari/537.36"))
+	require.Equal(t, DeviceDesktop, DeviceOf("Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 Chrome/120.0 Safari/537.36"))
+	require.Equal(t, "", DeviceOf(""))
+}
+
+func TestPreferredLanguage(t *testing.T) {
+	require.Equal(t, "pt-br", PreferredLanguage("en;q=0.8, pt-BR, pt;q=0.9"))
+	require.Equal(t, "", PreferredLanguage(""))
+}
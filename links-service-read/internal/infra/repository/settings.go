@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"links-service-read/internal/logger"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"go.uber.org/zap"
+)
+
+// Fallback modes of LinkSettings, see Mode.
+const (
+	FallbackRedirect = "redirect"
+	FallbackPage     = "page"
+)
+
+// LinkSettings are the settings a customer applies to all of their links, as stored by
+// links-service-write. Customers who never saved any have empty settings.
+type LinkSettings struct {
+	CustomerID string `dynamodbav:"customer_id"`
+
+	// FallbackURL is where visitors of the customer's inactive links are sent when the
+	// link has no fallback URL of its own, "" for none.
+	FallbackURL  string `dynamodbav:"fallback_url,omitempty"`
+	FallbackMode string `dynamodbav:"fallback_mode,omitempty"`
+	UpdatedAt    string `dynamodbav:"updated_at,omitempty"`
+}
+
+// Mode returns how visitors of the customer's inactive links are answered when there is
+// a fallback URL: FallbackRedirect (the default) redirects them to it, and FallbackPage
+// answers with a page saying the link is not available, which links to it.
+func (s *LinkSettings) Mode() string {
+	if s.FallbackMode == FallbackPage {
+		return FallbackPage
+	}
+	return FallbackRedirect
+}
+
+// GetLinkSettings retrieves the link settings of a customer from the
+// "CustomerLinkSettings" table.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - customerID: The ID of the customer.
+//
+// Returns:
+//   - A pointer to the customer's LinkSettings, empty if they never saved any.
+//   - An error if the read fails.
+func (r *DynamoLinkStore) GetLinkSettings(ctx context.Context, customerID string) (*LinkSettings, error) {
+	result, err := r.db.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String("CustomerLinkSettings"),
+		Key: map[string]types.AttributeValue{
+			"customer_id": &types.AttributeValueMemberS{Value: customerID},
+		},
+	})
+	if err != nil {
+		logger.Log.Error("Failed to get link settings", zap.Error(err))
+		return nil, fmt.Errorf("failed to get link settings: %v", err)
+	}
+
+	settings := LinkSettings{CustomerID: customerID}
+	if len(result.Item) == 0 {
+		return &settings, nil
+	}
+	if err := attributevalue.UnmarshalMap(result.Item, &settings); err != nil {
+		logger.Log.Error("Failed to unmarshal link settings", zap.Error(err))
+		return nil, fmt.Errorf("failed to unmarshal link settings: %v", err)
+	}
+	return &settings, nil
+}
+
+// Fallback returns where visitors of one of the customer's links are sent while it is not
+// active: the link's own fallback URL, or else the customer's, "" if neither is set.
+func (s *LinkSettings) Fallback(link *Link) string {
+	if link.FallbackURL != "" {
+		return link.FallbackURL
+	}
+	return s.FallbackURL
+}
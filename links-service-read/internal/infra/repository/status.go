@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+)
+
+// Link statuses. A status is not stored: it is derived from the lifecycle fields of a
+// link at read time, see Link.Status.
+const (
+	StatusActive    = "active"
+	StatusExpired   = "expired"
+	StatusScheduled = "scheduled"
+	StatusDisabled  = "disabled"
+)
+
+// Slug types, as derived by links-service-write when a link is written.
+const (
+	SlugTypeCustom    = "custom"
+	SlugTypeGenerated = "generated"
+)
+
+// Sort orders of GetCustomerLinks.
+const (
+	SortByCreatedAt      = "created_at"
+	SortByClicks         = "clicks"
+	SortByExpirationDate = "expiration_date"
+	SortByOriginalURL    = "original_url"
+)
+
+// customerSortIndex is the GSI backing a sort order of GetCustomerLinks, keyed by
+// customer_id and rangeKey.
+type customerSortIndex struct {
+	name     string
+	rangeKey string
+}
+
+var customerSortIndexes = map[string]customerSortIndex{
+	SortByCreatedAt:      {name: "ByCustomer", rangeKey: "created_at"},
+	SortByClicks:         {name: "ByCustomerClicks", rangeKey: "clicks"},
+	SortByExpirationDate: {name: "ByCustomerExpiration", rangeKey: "expiration_sort"},
+	SortByOriginalURL:    {name: "ByCustomerOriginalURL", rangeKey: "original_url_sort"},
+}
+
+// Status reports the status of the link at the given time. A disabled link is disabled
+// whatever its dates; otherwise a link past its expiration date or that has reached its
+// click limit is expired, and a link whose activation date is still ahead is scheduled.
+// Dates that cannot be parsed are ignored.
+func (l *Link) Status(now time.Time) string {
+	if l.Disabled {
+		return StatusDisabled
+	}
+
+	if l.ClickLimitReached() {
+		return StatusExpired
+	}
+
+	if l.ExpirationDate != nil && *l.ExpirationDate != "" {
+		if expirationTime, err := time.Parse(time.RFC3339, *l.ExpirationDate); err == nil && expirationTime.Before(now) {
+			return StatusExpired
+		}
+	}
+
+	if l.ActivatesAt != nil && *l.ActivatesAt != "" {
+		if activationTime, err := time.Parse(time.RFC3339, *l.ActivatesAt); err == nil && activationTime.After(now) {
+			return StatusScheduled
+		}
+	}
+
+	return StatusActive
+}
+
+// ResolvedSlugType returns the slug type of the link, deriving it from the custom slug
+// for links written before slug types were stored.
+func (l *Link) ResolvedSlugType() string {
+	if l.SlugType != "" {
+		return l.SlugType
+	}
+	if l.CustomSlug != "" {
+		return SlugTypeCustom
+	}
+	return SlugTypeGenerated
+}
+
+// ClickLimitReached reports whether the link has been clicked as many times as its click
+// limit allows.
+func (l *Link) ClickLimitReached() bool {
+	return l.MaxClicks > 0 && l.Clicks >= l.MaxClicks
+}
+
+// PasswordProtected reports whether visitors must enter a password before being
+// redirected by the link.
+func (l *Link) PasswordProtected() bool {
+	return l.PasswordHash != ""
+}
+
+// customerLinksFilter holds the validated filters and sort order of a GetCustomerLinks call.
+type customerLinksFilter struct {
+	status   string
+	slugType string
+	index    customerSortIndex
+}
+
+// newCustomerLinksFilter validates the status, slug type and sort order of a
+// GetCustomerLinks call. Empty values and "all" disable a filter, "auto" is accepted as
+// an alias of the generated slug type, and the sort order defaults to created_at.
+// Errors start with "invalid " so that callers can report them as invalid arguments.
+func newCustomerLinksFilter(status, slugType, sortBy string) (customerLinksFilter, error) {
+	filter := customerLinksFilter{}
+
+	switch status {
+	case "", "all":
+	case StatusActive, StatusExpired, StatusScheduled, StatusDisabled:
+		filter.status = status
+	default:
+		return filter, fmt.Errorf("invalid status %q", status)
+	}
+
+	switch slugType {
+	case "", "all":
+	case SlugTypeCustom, SlugTypeGenerated:
+		filter.slugType = slugType
+	case "auto":
+		filter.slugType = SlugTypeGenerated
+	default:
+		return filter, fmt.Errorf("invalid slug_type %q", slugType)
+	}
+
+	if sortBy == "" {
+		sortBy = SortByCreatedAt
+	}
+	index, ok := customerSortIndexes[sortBy]
+	if !ok {
+		return filter, fmt.Errorf("invalid sort_by %q", sortBy)
+	}
+	filter.index = index
+
+	return filter, nil
+}
+
+// matches reports whether the link passes the status and slug type filters at the given time.
+func (f customerLinksFilter) matches(link *Link, now time.Time) bool {
+	if f.status != "" && link.Status(now) != f.status {
+		return false
+	}
+	if f.slugType != "" && link.ResolvedSlugType() != f.slugType {
+		return false
+	}
+	return true
+}
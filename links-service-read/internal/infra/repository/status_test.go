@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinkStatus(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	past := "2025-05-01T00:00:00Z"
+	future := "2025-07-01T00:00:00Z"
+	invalid := "not-a-date"
+
+	tests := []struct {
+		name string
+		link Link
+		want string
+	}{
+		{"No lifecycle fields", Link{}, StatusActive},
+		{"Expiration ahead", Link{ExpirationDate: &future}, StatusActive},
+		{"Expiration passed", Link{ExpirationDate: &past}, StatusExpired},
+		{"Activation ahead", Link{ActivatesAt: &future}, StatusScheduled},
+		{"Activation passed", Link{ActivatesAt: &past}, StatusActive},
+		{"Unparseable dates are ignored", Link{ExpirationDate: &invalid, ActivatesAt: &invalid}, StatusActive},
+		{"Expired wins over scheduled", Link{ExpirationDate: &past, ActivatesAt: &future}, StatusExpired},
+		{"Below the click limit", Link{Clicks: 2, MaxClicks: 3}, StatusActive},
+		{"Click limit reached", Link{Clicks: 1, MaxClicks: 1, ActivatesAt: &future}, StatusExpired},
+		{"Disabled wins over everything", Link{Disabled: true, ExpirationDate: &past}, StatusDisabled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.link.Status(now))
+		})
+	}
+}
+
+func TestResolvedSlugType(t *testing.T) {
+	require.Equal(t, SlugTypeCustom, (&Link{SlugType: SlugTypeCustom}).ResolvedSlugType())
+	require.Equal(t, SlugTypeCustom, (&Link{CustomSlug: "promo"}).ResolvedSlugType(), "Legacy links should derive it from the custom slug")
+	require.Equal(t, SlugTypeGenerated, (&Link{}).ResolvedSlugType())
+}
+
+func TestCustomerLinksFilter(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		filter, err := newCustomerLinksFilter("", "all", "")
+		require.NoError(t, err)
+		require.Equal(t, "ByCustomer", filter.index.name)
+		require.True(t, filter.matches(&Link{Disabled: true, CustomSlug: "promo"}, time.Now()))
+	})
+
+	t.Run("Picks the index of the sort order", func(t *testing.T) {
+		for sortBy, index := range map[string]string{
+			SortByClicks:         "ByCustomerClicks",
+			SortByExpirationDate: "ByCustomerExpiration",
+			SortByOriginalURL:    "ByCustomerOriginalURL",
+		} {
+			filter, err := newCustomerLinksFilter("", "", sortBy)
+			require.NoError(t, err)
+			require.Equal(t, index, filter.index.name)
+		}
+	})
+
+	t.Run("Filters by status and slug type", func(t *testing.T) {
+		filter, err := newCustomerLinksFilter(StatusActive, "auto", "")
+		require.NoError(t, err)
+		require.True(t, filter.matches(&Link{}, time.Now()))
+		require.False(t, filter.matches(&Link{CustomSlug: "promo"}, time.Now()))
+		require.False(t, filter.matches(&Link{Disabled: true}, time.Now()))
+	})
+
+	t.Run("Rejects unknown values", func(t *testing.T) {
+		for _, args := range [][3]string{{"archived", "", ""}, {"", "vanity", ""}, {"", "", "updated_at"}} {
+			_, err := newCustomerLinksFilter(args[0], args[1], args[2])
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "invalid ")
+		}
+	})
+}
+
+func TestLinkSettingsFallback(t *testing.T) {
+	settings := &LinkSettings{CustomerID: "customer-1", FallbackURL: "https://example.com/customer"}
+	require.Equal(t, "https://example.com/customer", settings.Fallback(&Link{}))
+	require.Equal(t, "https://example.com/link", settings.Fallback(&Link{FallbackURL: "https://example.com/link"}))
+	require.Empty(t, (&LinkSettings{}).Fallback(&Link{}))
+
+	require.Equal(t, FallbackRedirect, settings.Mode())
+	require.Equal(t, FallbackPage, (&LinkSettings{FallbackMode: FallbackPage}).Mode())
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"links-service-read/internal/logger"
+	"strconv"
+
+	pb "links-service-read/proto"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"go.uber.org/zap"
+)
+
+// Storage backends selectable with the LINKS_STORE setting. It must name the backend
+// links-service-write stores links and clicks in.
+const (
+	StoreDynamoDB = "dynamodb"
+	StorePostgres = "postgres"
+	StoreMemory   = "memory"
+)
+
+// LinkStore is the read side of the storage backend of links and of their click
+// rollups. It is implemented by DynamoLinkStore (the default), PostgresLinkStore and
+// MemoryLinkStore.
+//
+// Implementations report a missing link with the "link not found" message, and invalid
+// GetCustomerLinks arguments with messages starting with "invalid ", which callers
+// match on. Cursors are only valid with the backend that issued them.
+type LinkStore interface {
+	// GetLinkByShortURL returns the link served at the given short URL.
+	GetLinkByShortURL(ctx context.Context, shortURL string) (*Link, error)
+	// GetLinkByID returns the link with the given ID.
+	GetLinkByID(ctx context.Context, id string) (*Link, error)
+	// GetLinkByCustomSlug returns the link using the given custom slug.
+	GetLinkByCustomSlug(ctx context.Context, customSlug string) (*Link, error)
+	// GetCustomerLinks returns a page of a customer's links and the cursor of the next
+	// page, "" on the last one.
+	GetCustomerLinks(ctx context.Context, req *pb.GetCustomerLinksRequest) ([]*Link, string, error)
+	// GetClickRollups returns the click rollups of a link between two bucket keys
+	// (inclusive), ordered by bucket.
+	GetClickRollups(ctx context.Context, linkID, fromBucket, toBucket string) ([]*ClickRollup, error)
+	// GetLinkSettings returns the link settings of a customer, empty if they never saved any.
+	GetLinkSettings(ctx context.Context, customerID string) (*LinkSettings, error)
+}
+
+var (
+	_ LinkStore = (*DynamoLinkStore)(nil)
+	_ LinkStore = (*PostgresLinkStore)(nil)
+	_ LinkStore = (*MemoryLinkStore)(nil)
+)
+
+// customerLinksPage returns the page size and the number of matching links to skip of
+// a GetCustomerLinks call, see DefaultCustomerLinksLimit and MaxCustomerLinksLimit.
+func customerLinksPage(req *pb.GetCustomerLinksRequest) (limit, skip int32) {
+	limit = DefaultCustomerLinksLimit
+	if req.Limit != nil && *req.Limit > 0 {
+		limit = min(*req.Limit, MaxCustomerLinksLimit)
+	}
+	if req.Offset != nil && *req.Offset > 0 {
+		skip = *req.Offset
+	}
+	return limit, skip
+}
+
+// customerLinksStartKey decodes the cursor of a GetCustomerLinks call, checking that it
+// was issued for the same customer and sort order. It returns nil when no cursor is set.
+func customerLinksStartKey(req *pb.GetCustomerLinksRequest, filter customerLinksFilter) (map[string]types.AttributeValue, error) {
+	if req.Cursor == nil || *req.Cursor == "" {
+		return nil, nil
+	}
+
+	startKey, err := decodeCursor(*req.Cursor)
+	if err != nil {
+		logger.Log.Error("Invalid cursor", zap.Error(err))
+		return nil, err
+	}
+	if customer, ok := startKey["customer_id"].(*types.AttributeValueMemberS); !ok || customer.Value != req.CustomerId {
+		logger.Log.Error("Cursor does not belong to this customer", zap.String("customer_id", req.CustomerId))
+		return nil, fmt.Errorf("invalid cursor: issued for another customer")
+	}
+	if _, ok := startKey[filter.index.rangeKey]; !ok {
+		logger.Log.Error("Cursor was issued for another sort order", zap.String("index", filter.index.name))
+		return nil, fmt.Errorf("invalid cursor: issued for another sort order")
+	}
+	if _, ok := startKey["short_url"].(*types.AttributeValueMemberS); !ok {
+		logger.Log.Error("Cursor has no short URL")
+		return nil, fmt.Errorf("invalid cursor: missing short_url")
+	}
+	return startKey, nil
+}
+
+// linkKey builds the cursor key of a link in the given sort order, with the same
+// attributes as the DynamoDB index keys, so that every backend issues cursors alike.
+func linkKey(link *Link, rangeKey string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"short_url":   &types.AttributeValueMemberS{Value: link.ShortURL},
+		"customer_id": &types.AttributeValueMemberS{Value: link.CustomerID},
+		rangeKey:      sortValue(link, rangeKey),
+	}
+}
+
+// sortValue returns the value of the link's attribute for the given sort key.
+func sortValue(link *Link, rangeKey string) types.AttributeValue {
+	switch rangeKey {
+	case "clicks":
+		return &types.AttributeValueMemberN{Value: strconv.Itoa(int(link.Clicks))}
+	case "expiration_sort":
+		return &types.AttributeValueMemberS{Value: link.ExpirationSort}
+	case "original_url_sort":
+		return &types.AttributeValueMemberS{Value: link.OriginalURLSort}
+	default:
+		return &types.AttributeValueMemberS{Value: link.CreatedAt}
+	}
+}
+
+// keyValue returns the string form of a string or number key attribute.
+func keyValue(value types.AttributeValue) string {
+	switch v := value.(type) {
+	case *types.AttributeValueMemberS:
+		return v.Value
+	case *types.AttributeValueMemberN:
+		return v.Value
+	default:
+		return ""
+	}
+}
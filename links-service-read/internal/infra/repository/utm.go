@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"net/url"
+	"strings"
+)
+
+// UTM holds the UTM parameters of a link, as stored by links-service-write. Empty fields
+// are not set.
+type UTM struct {
+	Source   string `dynamodbav:"source,omitempty"`
+	Medium   string `dynamodbav:"medium,omitempty"`
+	Campaign string `dynamodbav:"campaign,omitempty"`
+	Term     string `dynamodbav:"term,omitempty"`
+	Content  string `dynamodbav:"content,omitempty"`
+}
+
+// IsZero reports whether no UTM parameter is set.
+func (u UTM) IsZero() bool {
+	return u == UTM{}
+}
+
+// Destination returns the URL visitors of the link are redirected to: its original URL
+// with its UTM parameters added to the query string.
+//
+// Notes:
+//   - UTM parameters already in the original URL are replaced by the link's, when it sets
+//     them; the other query parameters are kept as they are, in their original order.
+//   - The original URL is returned unchanged if the link has no UTM parameters, or if it
+//     cannot be parsed.
+func (l *Link) Destination() string {
+	return l.withUTM(l.OriginalURL)
+}
+
+// withUTM returns rawURL with the UTM parameters of the link added to its query string,
+// like Destination does for the original URL.
+func (l *Link) withUTM(rawURL string) string {
+	if l.UTM == nil || l.UTM.IsZero() {
+		return rawURL
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	params := []struct{ key, value string }{
+		{"utm_source", l.UTM.Source},
+		{"utm_medium", l.UTM.Medium},
+		{"utm_campaign", l.UTM.Campaign},
+		{"utm_term", l.UTM.Term},
+		{"utm_content", l.UTM.Content},
+	}
+	replaced := make(map[string]bool, len(params))
+	for _, param := range params {
+		if param.value != "" {
+			replaced[param.key] = true
+		}
+	}
+
+	var query []string
+	for _, pair := range strings.Split(u.RawQuery, "&") {
+		if pair == "" {
+			continue
+		}
+		key, _, _ := strings.Cut(pair, "=")
+		if name, err := url.QueryUnescape(key); err == nil && replaced[name] {
+			continue
+		}
+		query = append(query, pair)
+	}
+	for _, param := range params {
+		if param.value != "" {
+			query = append(query, param.key+"="+url.QueryEscape(param.value))
+		}
+	}
+
+	u.RawQuery = strings.Join(query, "&")
+	u.ForceQuery = false
+	return u.String()
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDestination(t *testing.T) {
+	t.Run("Links without UTM parameters keep their original URL", func(t *testing.T) {
+		link := &Link{OriginalURL: "https://example.com/shop?b=2&a=1"}
+		require.Equal(t, "https://example.com/shop?b=2&a=1", link.Destination())
+
+		link.UTM = &UTM{}
+		require.Equal(t, "https://example.com/shop?b=2&a=1", link.Destination())
+	})
+
+	t.Run("UTM parameters are appended to the query string", func(t *testing.T) {
+		link := &Link{
+			OriginalURL: "https://example.com/shop?b=2&a=1#top",
+			UTM:         &UTM{Source: "newsletter", Medium: "email", Campaign: "summer sale"},
+		}
+		require.Equal(t,
+			"https://example.com/shop?b=2&a=1&utm_source=newsletter&utm_medium=email&utm_campaign=summer+sale#top",
+			link.Destination())
+
+		link.OriginalURL = "https://example.com"
+		require.Equal(t, "https://example.com?utm_source=newsletter&utm_medium=email&utm_campaign=summer+sale", link.Destination())
+	})
+
+	t.Run("The link's parameters replace the ones in the original URL", func(t *testing.T) {
+		link := &Link{
+			OriginalURL: "https://example.com/?utm_source=Newsleter&utm_term=shoes&id=7",
+			UTM:         &UTM{Source: "newsletter"},
+		}
+		require.Equal(t, "https://example.com/?utm_term=shoes&id=7&utm_source=newsletter", link.Destination())
+	})
+}
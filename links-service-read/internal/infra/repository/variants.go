@@ -0,0 +1,64 @@
+package repository
+
+import "math/rand/v2"
+
+// Variant is one of the destinations a link splits its visitors between, for A/B tests,
+// as stored by links-service-write. See Link.AssignVariant.
+type Variant struct {
+	// ID names the variant in clicks, rollups and assignment cookies.
+	ID  string `dynamodbav:"id" json:"id"`
+	URL string `dynamodbav:"url" json:"url"`
+
+	// Weight is the share of visitors sent to the variant, relative to the weights of the
+	// other variants of the link.
+	Weight int32 `dynamodbav:"weight" json:"weight"`
+}
+
+// AssignVariant returns the ID of the variant of the link a visitor is sent to: the
+// variant they were assigned before, if it is still one of the link's, or else one drawn
+// at random in proportion to the weights of the variants. It returns "" if the link has
+// no variants.
+//
+// Parameters:
+//   - assigned: The ID of the variant the visitor was assigned on a previous visit, ""
+//     for none.
+func (l *Link) AssignVariant(assigned string) string {
+	if l.variant(assigned) != nil {
+		return assigned
+	}
+
+	var total int
+	for _, variant := range l.Variants {
+		total += int(max(variant.Weight, 0))
+	}
+	if total == 0 {
+		return ""
+	}
+	return pickVariant(l.Variants, rand.IntN(total))
+}
+
+// pickVariant returns the ID of the variant a draw falls on, the variants sharing
+// [0, total weight) in order, in proportion to their weights.
+func pickVariant(variants []Variant, draw int) string {
+	for _, variant := range variants {
+		weight := int(max(variant.Weight, 0))
+		if draw < weight {
+			return variant.ID
+		}
+		draw -= weight
+	}
+	return ""
+}
+
+// variant returns the variant of the link with the given ID, nil if there is none.
+func (l *Link) variant(id string) *Variant {
+	if id == "" {
+		return nil
+	}
+	for i := range l.Variants {
+		if l.Variants[i].ID == id {
+			return &l.Variants[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssignVariant(t *testing.T) {
+	link := &Link{
+		OriginalURL: "https://example.com/a",
+		Variants: []Variant{
+			{ID: "a", URL: "https://example.com/a", Weight: 75},
+			{ID: "b", URL: "https://example.com/b", Weight: 25},
+		},
+	}
+
+	t.Run("Draws split the variants by weight", func(t *testing.T) {
+		require.Equal(t, "a", pickVariant(link.Variants, 0))
+		require.Equal(t, "a", pickVariant(link.Variants, 74))
+		require.Equal(t, "b", pickVariant(link.Variants, 75))
+		require.Equal(t, "b", pickVariant(link.Variants, 99))
+	})
+
+	t.Run("Visitors keep the variant they were assigned", func(t *testing.T) {
+		for i := 0; i < 20; i++ {
+			require.Equal(t, "b", link.AssignVariant("b"))
+		}
+		require.Contains(t, []string{"a", "b"}, link.AssignVariant("removed"), "Unknown variants should be drawn again")
+	})
+
+	t.Run("Links without variants assign none", func(t *testing.T) {
+		require.Equal(t, "", (&Link{OriginalURL: "https://example.com"}).AssignVariant(""))
+	})
+}
+
+func TestRouteVariants(t *testing.T) {
+	link := &Link{
+		OriginalURL: "https://example.com/a",
+		UTM:         &UTM{Campaign: "launch"},
+		Rules:       []RedirectRule{{Destination: "https://apps.apple.com/app", Devices: []string{DeviceIOS}}},
+		Variants: []Variant{
+			{ID: "a", URL: "https://example.com/a", Weight: 1},
+			{ID: "b", URL: "https://example.com/b", Weight: 1},
+		},
+	}
+
+	t.Run("Visitors are sent to their variant with the UTM parameters of the link", func(t *testing.T) {
+		destination, variant := link.Route(Visitor{Variant: "b"})
+		require.Equal(t, "https://example.com/b?utm_campaign=launch", destination)
+		require.Equal(t, "b", variant)
+	})
+
+	t.Run("Redirect rules take precedence over variants", func(t *testing.T) {
+		destination, variant := link.Route(Visitor{Device: DeviceIOS, Variant: "b"})
+		require.Equal(t, "https://apps.apple.com/app?utm_campaign=launch", destination)
+		require.Empty(t, variant)
+	})
+}
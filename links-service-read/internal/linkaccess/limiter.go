@@ -0,0 +1,282 @@
+package linkaccess
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Default bounds of the password attempts of a visitor, and of the failed attempts of
+// all the visitors of a link together.
+const (
+	MaxAttempts     = 5
+	MaxLinkAttempts = 100
+	AttemptWindow   = 15 * time.Minute
+)
+
+// KnownAddressTTL is how long an IP address that unlocked a link is exempt from the
+// link's budget of failed attempts.
+const KnownAddressTTL = 24 * time.Hour
+
+// maxTrackedKeys bounds the number of windows and known addresses a Limiter without Redis
+// keeps; expired ones are dropped once it is reached.
+const maxTrackedKeys = 10000
+
+// AttemptsKey returns the Redis key counting the password attempts of an IP address in
+// the current window.
+func AttemptsKey(ip string) string {
+	return "link_password_attempts:" + ip
+}
+
+// LinkAttemptsKey returns the Redis key counting the failed password attempts on a link
+// in the current window, from every IP address.
+func LinkAttemptsKey(linkID string) string {
+	return "link_password_attempts:link:" + linkID
+}
+
+// KnownAddressKey returns the Redis key recording that an IP address unlocked a link.
+func KnownAddressKey(linkID, ip string) string {
+	return "link_password_known:" + linkID + ":" + ip
+}
+
+// Limiter bounds the number of password attempts each IP address can make on protected
+// links, and the number of failed attempts on each link, in fixed windows, so that
+// passwords cannot be brute forced, even from many IP addresses.
+//
+// Once a link's budget is spent, only the IP addresses that unlocked it in the last
+// KnownAddressTTL can still try its password. The budget trades how many guesses a
+// distributed attack gets on a link for how easily it can lock new visitors out of it:
+// with the defaults, 20 IP addresses making 5 wrong guesses each spend it.
+//
+// Windows are kept in Redis so that they are shared by every instance of the service. A
+// Limiter without a Redis client keeps them in memory, for this instance only.
+type Limiter struct {
+	rdb     *redis.Client
+	max     int64
+	linkMax int64
+	window  time.Duration
+
+	mu       sync.Mutex
+	attempts map[string]*attemptWindow
+	known    map[string]time.Time // expiry by KnownAddressKey
+}
+
+// attemptWindow counts the attempts of an IP address, or the failed attempts on a link,
+// until resetAt.
+type attemptWindow struct {
+	count   int64
+	resetAt time.Time
+}
+
+// NewLimiter creates a Limiter allowing max attempts per IP address and linkMax failed
+// attempts per link in each window.
+//
+// Parameters:
+//   - rdb: The Redis client holding the windows, or nil to keep them in memory.
+//   - max: The number of attempts allowed from an IP address in a window.
+//   - linkMax: The number of failed attempts on a link in a window after which only the
+//     IP addresses known to have unlocked it can try its password.
+//   - window: The length of a window, starting with the first attempt.
+//
+// Returns:
+//   - A pointer to the initialized Limiter.
+func NewLimiter(rdb *redis.Client, max, linkMax int, window time.Duration) *Limiter {
+	return &Limiter{
+		rdb:      rdb,
+		max:      int64(max),
+		linkMax:  int64(linkMax),
+		window:   window,
+		attempts: make(map[string]*attemptWindow),
+		known:    make(map[string]time.Time),
+	}
+}
+
+// Allow counts a password attempt from ip on a link and reports whether it may go ahead.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - ip: The IP address of the visitor.
+//   - linkID: The ID of the link.
+//   - now: The time of the attempt. Windows kept in Redis follow the clock of Redis.
+//
+// Returns:
+//   - Whether the attempt is allowed.
+//   - When it is not, how long until the window that refused it ends.
+//   - An error if Redis cannot be reached.
+//
+// Notes:
+//   - Only failed attempts count against the link, see Fail, so that visitors who know
+//     the password do not spend its budget.
+func (l *Limiter) Allow(ctx context.Context, ip, linkID string, now time.Time) (bool, time.Duration, error) {
+	count, retryAfter, err := l.count(ctx, AttemptsKey(ip), now)
+	if err != nil || count > l.max {
+		return false, retryAfter, err
+	}
+
+	count, retryAfter, err = l.peek(ctx, LinkAttemptsKey(linkID), now)
+	if err != nil {
+		return false, 0, err
+	}
+	if count < l.linkMax {
+		return true, 0, nil
+	}
+
+	known, err := l.isKnown(ctx, KnownAddressKey(linkID, ip), now)
+	if err != nil {
+		return false, 0, err
+	}
+	if known {
+		return true, 0, nil
+	}
+	return false, retryAfter, nil
+}
+
+// Fail counts a failed password attempt on a link against its budget.
+func (l *Limiter) Fail(ctx context.Context, linkID string, now time.Time) error {
+	_, _, err := l.count(ctx, LinkAttemptsKey(linkID), now)
+	return err
+}
+
+// Succeed records that ip unlocked a link, which exempts it from the link's budget for
+// KnownAddressTTL.
+func (l *Limiter) Succeed(ctx context.Context, ip, linkID string, now time.Time) error {
+	key := KnownAddressKey(linkID, ip)
+	if l.rdb == nil {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		l.evictLocked(now)
+		l.known[key] = now.Add(KnownAddressTTL)
+		return nil
+	}
+
+	if err := l.rdb.Set(ctx, key, 1, KnownAddressTTL).Err(); err != nil {
+		return fmt.Errorf("failed to record known address: %v", err)
+	}
+	return nil
+}
+
+// count counts an attempt in the window of key.
+//
+// Returns:
+//   - The number of attempts in the window, this one included.
+//   - How long until the window ends.
+//   - An error if Redis cannot be reached.
+func (l *Limiter) count(ctx context.Context, key string, now time.Time) (int64, time.Duration, error) {
+	if l.rdb == nil {
+		return l.countInMemory(key, now, 1)
+	}
+
+	var count *redis.IntCmd
+	var ttl *redis.DurationCmd
+	_, err := l.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		count = pipe.Incr(ctx, key)
+		ttl = pipe.PTTL(ctx, key)
+		return nil
+	})
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to count password attempts: %v", err)
+	}
+
+	retryAfter := ttl.Val()
+	if retryAfter <= 0 {
+		if err := l.rdb.PExpire(ctx, key, l.window).Err(); err != nil {
+			return 0, 0, fmt.Errorf("failed to count password attempts: %v", err)
+		}
+		retryAfter = l.window
+	}
+	return count.Val(), retryAfter, nil
+}
+
+// peek returns the number of attempts in the window of key, and how long until it ends,
+// without counting one.
+func (l *Limiter) peek(ctx context.Context, key string, now time.Time) (int64, time.Duration, error) {
+	if l.rdb == nil {
+		return l.countInMemory(key, now, 0)
+	}
+
+	var count *redis.StringCmd
+	var ttl *redis.DurationCmd
+	_, err := l.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		count = pipe.Get(ctx, key)
+		ttl = pipe.PTTL(ctx, key)
+		return nil
+	})
+	if errors.Is(err, redis.Nil) {
+		return 0, 0, nil
+	}
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read password attempts: %v", err)
+	}
+
+	n, err := count.Int64()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read password attempts: %v", err)
+	}
+	retryAfter := ttl.Val()
+	if retryAfter <= 0 {
+		retryAfter = l.window
+	}
+	return n, retryAfter, nil
+}
+
+// isKnown reports whether the IP address of key unlocked its link in the last
+// KnownAddressTTL.
+func (l *Limiter) isKnown(ctx context.Context, key string, now time.Time) (bool, error) {
+	if l.rdb == nil {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		expiresAt, ok := l.known[key]
+		return ok && now.Before(expiresAt), nil
+	}
+
+	n, err := l.rdb.Exists(ctx, key).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to check known address: %v", err)
+	}
+	return n > 0, nil
+}
+
+// countInMemory adds n attempts to the window of key kept in memory, starting a new
+// window if n is not 0 and there is none, and returns the attempts in it and how long
+// until it ends.
+func (l *Limiter) countInMemory(key string, now time.Time, n int64) (int64, time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	window, ok := l.attempts[key]
+	if !ok || !now.Before(window.resetAt) {
+		if n == 0 {
+			return 0, 0, nil
+		}
+		l.evictLocked(now)
+		window = &attemptWindow{resetAt: now.Add(l.window)}
+		l.attempts[key] = window
+	}
+
+	window.count += n
+	return window.count, window.resetAt.Sub(now), nil
+}
+
+// evictLocked drops the expired windows and known addresses kept in memory once there
+// are maxTrackedKeys of them. l.mu must be held.
+func (l *Limiter) evictLocked(now time.Time) {
+	if len(l.attempts)+len(l.known) < maxTrackedKeys {
+		return
+	}
+	for key, window := range l.attempts {
+		if !now.Before(window.resetAt) {
+			delete(l.attempts, key)
+		}
+	}
+	for key, expiresAt := range l.known {
+		if !now.Before(expiresAt) {
+			delete(l.known, key)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package linkaccess
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiter(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	t.Run("In memory", func(t *testing.T) {
+		limiter := NewLimiter(nil, 2, 10, time.Minute)
+
+		for i := 0; i < 2; i++ {
+			allowed, _, err := limiter.Allow(ctx, "1.2.3.4", "link-1", now)
+			require.NoError(t, err)
+			require.True(t, allowed)
+		}
+
+		allowed, retryAfter, err := limiter.Allow(ctx, "1.2.3.4", "link-1", now.Add(20*time.Second))
+		require.NoError(t, err)
+		require.False(t, allowed)
+		require.Equal(t, 40*time.Second, retryAfter)
+
+		allowed, _, err = limiter.Allow(ctx, "5.6.7.8", "link-1", now)
+		require.NoError(t, err)
+		require.True(t, allowed, "IP addresses are limited separately")
+
+		allowed, _, err = limiter.Allow(ctx, "1.2.3.4", "link-1", now.Add(time.Minute))
+		require.NoError(t, err)
+		require.True(t, allowed, "A new window starts once the previous one ends")
+	})
+
+	t.Run("In Redis", func(t *testing.T) {
+		s, err := miniredis.Run()
+		require.NoError(t, err, "Failed to start miniredis")
+		defer s.Close()
+
+		rdb := redis.NewClient(&redis.Options{Addr: s.Addr()})
+		defer rdb.Close()
+
+		limiter := NewLimiter(rdb, 2, 10, time.Minute)
+		for i := 0; i < 2; i++ {
+			allowed, _, err := limiter.Allow(ctx, "1.2.3.4", "link-1", now)
+			require.NoError(t, err)
+			require.True(t, allowed)
+		}
+		require.Equal(t, time.Minute, s.TTL(AttemptsKey("1.2.3.4")))
+
+		allowed, retryAfter, err := limiter.Allow(ctx, "1.2.3.4", "link-1", now)
+		require.NoError(t, err)
+		require.False(t, allowed)
+		require.Equal(t, time.Minute, retryAfter)
+
+		require.NoError(t, limiter.Fail(ctx, "link-1", now))
+		require.Equal(t, time.Minute, s.TTL(LinkAttemptsKey("link-1")))
+
+		s.FastForward(time.Minute)
+		allowed, _, err = limiter.Allow(ctx, "1.2.3.4", "link-1", now)
+		require.NoError(t, err)
+		require.True(t, allowed, "A new window starts once the key expires")
+	})
+
+	t.Run("Per link", func(t *testing.T) {
+		limiter := NewLimiter(nil, 2, 3, time.Minute)
+
+		for i := 0; i < 3; i++ {
+			allowed, _, err := limiter.Allow(ctx, fmt.Sprintf("10.0.0.%d", i), "link-1", now)
+			require.NoError(t, err)
+			require.True(t, allowed)
+			require.NoError(t, limiter.Fail(ctx, "link-1", now))
+		}
+
+		allowed, retryAfter, err := limiter.Allow(ctx, "10.0.0.9", "link-1", now.Add(30*time.Second))
+		require.NoError(t, err)
+		require.False(t, allowed, "Attempts from new IP addresses are limited once the link's budget is spent")
+		require.Equal(t, 30*time.Second, retryAfter)
+
+		allowed, _, err = limiter.Allow(ctx, "10.0.0.9", "link-2", now)
+		require.NoError(t, err)
+		require.True(t, allowed, "Links are limited separately")
+
+		allowed, _, err = limiter.Allow(ctx, "10.0.0.10", "link-1", now.Add(time.Minute))
+		require.NoError(t, err)
+		require.True(t, allowed, "A new window starts once the previous one ends")
+	})
+
+	t.Run("Only failed attempts count against the link", func(t *testing.T) {
+		limiter := NewLimiter(nil, 100, 2, time.Minute)
+
+		for i := 0; i < 10; i++ {
+			allowed, _, err := limiter.Allow(ctx, "1.2.3.4", "link-1", now)
+			require.NoError(t, err)
+			require.True(t, allowed)
+		}
+	})
+
+	t.Run("Refused attempts do not count against the link", func(t *testing.T) {
+		limiter := NewLimiter(nil, 1, 2, time.Minute)
+
+		for i := 0; i < 5; i++ {
+			_, _, err := limiter.Allow(ctx, "1.2.3.4", "link-1", now)
+			require.NoError(t, err)
+		}
+
+		allowed, _, err := limiter.Allow(ctx, "5.6.7.8", "link-1", now)
+		require.NoError(t, err)
+		require.True(t, allowed)
+	})
+
+	t.Run("Known addresses are exempt from the link's budget", func(t *testing.T) {
+		s, err := miniredis.Run()
+		require.NoError(t, err, "Failed to start miniredis")
+		defer s.Close()
+
+		rdb := redis.NewClient(&redis.Options{Addr: s.Addr()})
+		defer rdb.Close()
+
+		for name, limiter := range map[string]*Limiter{
+			"In memory": NewLimiter(nil, 5, 2, time.Minute),
+			"In Redis":  NewLimiter(rdb, 5, 2, time.Minute),
+		} {
+			require.NoError(t, limiter.Succeed(ctx, "1.2.3.4", "link-1", now), name)
+			require.NoError(t, limiter.Fail(ctx, "link-1", now), name)
+			require.NoError(t, limiter.Fail(ctx, "link-1", now), name)
+
+			allowed, retryAfter, err := limiter.Allow(ctx, "5.6.7.8", "link-1", now)
+			require.NoError(t, err, name)
+			require.False(t, allowed, name)
+			require.Equal(t, time.Minute, retryAfter, name)
+
+			allowed, _, err = limiter.Allow(ctx, "1.2.3.4", "link-1", now)
+			require.NoError(t, err, name)
+			require.True(t, allowed, name)
+
+			allowed, _, err = limiter.Allow(ctx, "1.2.3.4", "link-2", now)
+			require.NoError(t, err, name)
+			require.True(t, allowed, name)
+		}
+		require.Equal(t, KnownAddressTTL, s.TTL(KnownAddressKey("link-1", "1.2.3.4")))
+	})
+}
@@ -0,0 +1,81 @@
+package linkaccess
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TokenTTL is how long an access token lets a visitor through a password-protected link.
+const TokenTTL = 15 * time.Minute
+
+// Signer issues and verifies the access tokens handed to visitors who entered the
+// password of a protected link, so that they are not asked again until the token expires.
+//
+// A token is bound to the link and to its password hash: it is worthless for other
+// links, and changing or removing the password of a link revokes the tokens issued for it.
+type Signer struct {
+	key []byte
+}
+
+// NewSigner creates a Signer with the given secret.
+//
+// Parameters:
+//   - secret: The HMAC key signing the tokens, shared by every instance of the service.
+//     When empty, a random key is drawn: tokens are then only valid on this instance,
+//     until it restarts.
+//
+// Returns:
+//   - A pointer to the initialized Signer.
+//   - An error if no random key can be drawn.
+func NewSigner(secret string) (*Signer, error) {
+	key := []byte(secret)
+	if len(key) == 0 {
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, fmt.Errorf("failed to generate link access key: %v", err)
+		}
+	}
+	return &Signer{key: key}, nil
+}
+
+// Issue returns a token granting access to a protected link until TokenTTL after now.
+//
+// Parameters:
+//   - linkID: The ID of the link.
+//   - passwordHash: The current password hash of the link.
+//   - now: The time the token is issued at.
+//
+// Returns:
+//   - The token, made of its expiry as a Unix timestamp and its signature.
+//   - The time the token expires at.
+func (s *Signer) Issue(linkID, passwordHash string, now time.Time) (string, time.Time) {
+	expiresAt := now.Add(TokenTTL).Truncate(time.Second)
+	expiry := strconv.FormatInt(expiresAt.Unix(), 10)
+	return expiry + "." + s.sign(linkID, passwordHash, expiry), expiresAt
+}
+
+// Verify reports whether token was issued for the link with its current password hash
+// and has not expired at now.
+func (s *Signer) Verify(token, linkID, passwordHash string, now time.Time) bool {
+	expiry, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return false
+	}
+	seconds, err := strconv.ParseInt(expiry, 10, 64)
+	if err != nil || !now.Before(time.Unix(seconds, 0)) {
+		return false
+	}
+	return hmac.Equal([]byte(signature), []byte(s.sign(linkID, passwordHash, expiry)))
+}
+
+func (s *Signer) sign(linkID, passwordHash, expiry string) string {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(linkID + "\x00" + passwordHash + "\x00" + expiry))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,42 @@
+package linkaccess
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigner(t *testing.T) {
+	signer, err := NewSigner("secret")
+	require.NoError(t, err)
+
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	token, expiresAt := signer.Issue("link1", "hash1", now)
+	require.Equal(t, now.Add(TokenTTL), expiresAt)
+
+	t.Run("Tokens are valid for their link until they expire", func(t *testing.T) {
+		require.True(t, signer.Verify(token, "link1", "hash1", now))
+		require.True(t, signer.Verify(token, "link1", "hash1", expiresAt.Add(-time.Second)))
+		require.False(t, signer.Verify(token, "link1", "hash1", expiresAt))
+	})
+
+	t.Run("Tokens are bound to the link and its password", func(t *testing.T) {
+		require.False(t, signer.Verify(token, "link2", "hash1", now))
+		require.False(t, signer.Verify(token, "link1", "hash2", now))
+	})
+
+	t.Run("Tampered and foreign tokens are rejected", func(t *testing.T) {
+		later, _ := signer.Issue("link1", "hash1", now.Add(time.Hour))
+		_, signature, _ := strings.Cut(later, ".")
+		expiry, _, _ := strings.Cut(token, ".")
+		require.False(t, signer.Verify(expiry+"."+signature, "link1", "hash1", now))
+		require.False(t, signer.Verify("", "link1", "hash1", now))
+		require.False(t, signer.Verify("garbage", "link1", "hash1", now))
+
+		other, err := NewSigner("")
+		require.NoError(t, err)
+		require.False(t, other.Verify(token, "link1", "hash1", now))
+	})
+}
@@ -0,0 +1,53 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Normalize lowercases s and strips its diacritics, so that "Promoção" and "promocao"
+// match alike. It must match the normalization applied to indexed links by
+// links-service-write.
+func Normalize(s string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	normalized, _, err := transform.String(t, s)
+	if err != nil {
+		normalized = s
+	}
+	return strings.ToLower(normalized)
+}
+
+// Tokens splits the normalized form of s into words, breaking on every rune that is
+// neither a letter nor a digit: "https://Example.com/Promoção" yields "https",
+// "example", "com" and "promocao".
+func Tokens(s string) []string {
+	return strings.FieldsFunc(Normalize(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// MaxTerms caps the number of words of a search query; each one adds a condition to
+// the filter expression of the query.
+const MaxTerms = 8
+
+// Terms splits a search query into the distinct words that must all prefix a word of
+// a link's search_text, in query order and capped at MaxTerms.
+func Terms(query string) []string {
+	var terms []string
+	seen := make(map[string]bool)
+	for _, token := range Tokens(query) {
+		if seen[token] {
+			continue
+		}
+		seen[token] = true
+		terms = append(terms, token)
+		if len(terms) == MaxTerms {
+			break
+		}
+	}
+	return terms
+}
@@ -0,0 +1,26 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalize(t *testing.T) {
+	require.Equal(t, "promocao de verao", Normalize("Promoção de VERÃO"))
+}
+
+func TestTerms(t *testing.T) {
+	t.Run("Splits queries like indexed fields", func(t *testing.T) {
+		require.Equal(t, []string{"loja", "com", "br"}, Terms("  Loja.com.BR "))
+		require.Equal(t, []string{"promocao"}, Terms("PROMOÇÃO promoção"), "Terms should be distinct")
+	})
+
+	t.Run("Blank queries have no terms", func(t *testing.T) {
+		require.Empty(t, Terms(" -/ "))
+	})
+
+	t.Run("Caps the number of terms", func(t *testing.T) {
+		require.Len(t, Terms("a b c d e f g h i j"), MaxTerms)
+	})
+}
@@ -0,0 +1,147 @@
+package server
+
+import (
+	"errors"
+	"html/template"
+	"links-service-read/internal/infra/repository"
+	"links-service-read/internal/linkaccess"
+	"links-service-read/internal/logger"
+	"links-service-read/utils"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// accessCookieName is the name of the cookie holding the access token of a protected
+// link. Each cookie is scoped to the path of its link's slug.
+const accessCookieName = "link_access"
+
+// maxChallengeFormBytes bounds the size of a submitted password challenge.
+const maxChallengeFormBytes = 4 << 10
+
+var challengePage = template.Must(template.New("challenge").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>Password required</title>
+</head>
+<body>
+<main>
+<h1>Password required</h1>
+<p>This link is password protected. Enter its password to continue.</p>
+{{if .}}<p role="alert">{{.}}</p>{{end}}
+<form method="post">
+<input type="password" name="password" autocomplete="off" autofocus required>
+<button type="submit">Continue</button>
+</form>
+</main>
+</body>
+</html>
+`))
+
+// Unlock checks the password submitted through the challenge of a protected link. On
+// success, the visitor gets an access cookie valid for linkaccess.TokenTTL, scoped to the
+// link, and is sent back to it with a 303 See Other, where Redirect lets them through.
+//
+// Responses:
+//   - 303 See Other to the link when the password matches, or the link is not protected.
+//   - 401 Unauthorized with the challenge if the password does not match.
+//   - 429 Too Many Requests with the challenge and a Retry-After header if the visitor's
+//     IP address (see connectionIP) has made too many attempts, or all visitors of the
+//     link together too many failed ones, see linkaccess.Limiter.
+//   - 404, 410 or 500 like Redirect if the link cannot be resolved or is not active.
+//
+// Notes:
+//   - Every attempt counts against the visitor's limit, whether it succeeds or not. Only
+//     failed attempts count against the link's, which visitors who unlocked the link from
+//     the same IP address are exempt from.
+func (s *HTTPServer) Unlock(w http.ResponseWriter, r *http.Request) {
+	link, ok := s.activeLink(w, r)
+	if !ok {
+		return
+	}
+	if !link.PasswordProtected() {
+		http.Redirect(w, r, r.URL.EscapedPath(), http.StatusSeeOther)
+		return
+	}
+
+	now := time.Now()
+	ip := connectionIP(r)
+	allowed, retryAfter, err := s.attempts.Allow(r.Context(), ip, link.ID, now)
+	if err != nil {
+		logger.Log.Error("failed to check password attempts", zap.Error(err))
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if !allowed {
+		logger.Log.Warn("password attempts exceeded", zap.String("link_id", link.ID))
+		w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter.Round(time.Second)/time.Second)))
+		s.challenge(w, r, http.StatusTooManyRequests, "Too many attempts. Try again later.")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxChallengeFormBytes)
+	if err := utils.CheckPassword(r.PostFormValue("password"), link.PasswordHash); err != nil {
+		if !errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			logger.Log.Error("failed to check link password", zap.String("link_id", link.ID), zap.Error(err))
+		}
+		if err := s.attempts.Fail(r.Context(), link.ID, now); err != nil {
+			logger.Log.Error("failed to count failed password attempt", zap.String("link_id", link.ID), zap.Error(err))
+		}
+		s.challenge(w, r, http.StatusUnauthorized, "Incorrect password.")
+		return
+	}
+	if err := s.attempts.Succeed(r.Context(), ip, link.ID, now); err != nil {
+		logger.Log.Error("failed to record link unlock", zap.String("link_id", link.ID), zap.Error(err))
+	}
+
+	token, expiresAt := s.access.Issue(link.ID, link.PasswordHash, now)
+	http.SetCookie(w, &http.Cookie{
+		Name:     accessCookieName,
+		Value:    token,
+		Path:     r.URL.EscapedPath(),
+		Expires:  expiresAt,
+		MaxAge:   int(linkaccess.TokenTTL / time.Second),
+		HttpOnly: true,
+		Secure:   isHTTPS(r),
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	logger.Log.Info("link unlocked", zap.String("link_id", link.ID))
+	http.Redirect(w, r, r.URL.EscapedPath(), http.StatusSeeOther)
+}
+
+// hasAccess reports whether the request carries a valid access cookie for the link.
+func (s *HTTPServer) hasAccess(r *http.Request, link *repository.Link) bool {
+	cookie, err := r.Cookie(accessCookieName)
+	if err != nil {
+		return false
+	}
+	return s.access.Verify(cookie.Value, link.ID, link.PasswordHash, time.Now())
+}
+
+// challenge answers with the password form of a protected link, with an optional
+// message for the visitor. The page is never cached and never reveals the destination.
+func (s *HTTPServer) challenge(w http.ResponseWriter, r *http.Request, code int, message string) {
+	w.Header().Set("Cache-Control", "private, no-store")
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(code)
+	if r.Method == http.MethodHead {
+		return
+	}
+
+	if err := challengePage.Execute(w, message); err != nil {
+		logger.Log.Error("failed to render password challenge", zap.Error(err))
+	}
+}
+
+// isHTTPS reports whether the visitor reached the service over HTTPS, directly or
+// through the platform proxy.
+func isHTTPS(r *http.Request) bool {
+	return r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https"
+}
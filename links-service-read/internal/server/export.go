@@ -0,0 +1,93 @@
+package server
+
+import (
+	"links-service-read/internal/infra/repository"
+	"links-service-read/internal/logger"
+	pb "links-service-read/proto"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ExportCustomerLinks streams all the links of a customer matching the filters of the
+// request, one message per link, for reporting.
+//
+// Parameters:
+//   - req: A pointer to a GetCustomerLinksRequest containing the customer ID, and
+//     optionally the search, status, slug_type, sort_by and sort_direction filters.
+//   - stream: The server stream the links are sent on.
+//
+// Returns:
+//   - nil once every matching link has been sent.
+//   - An error if the request is invalid, a page of links cannot be fetched, or the
+//     client goes away.
+//
+// Possible Errors:
+//   - codes.InvalidArgument: Returned if the customer ID is missing, or if the status,
+//     slug_type or sort_by is unknown.
+//   - codes.Internal: Returned if there is an internal error while fetching the links.
+//
+// Notes:
+//   - The pagination fields (limit, offset and cursor) are ignored: the links are read
+//     page by page of MaxCustomerLinksLimit links, so only one page is held in memory
+//     at a time whatever the number of links.
+//   - An error may be returned after some links have been sent, in which case the
+//     export is incomplete.
+func (s *GRPCServer) ExportCustomerLinks(req *pb.GetCustomerLinksRequest, stream pb.LinksServiceRead_ExportCustomerLinksServer) error {
+	if req.CustomerId == "" {
+		logger.Log.Error("customer_id is required")
+		return status.Error(codes.InvalidArgument, "customer_id is required")
+	}
+
+	ctx := stream.Context()
+	page := &pb.GetCustomerLinksRequest{
+		CustomerId:    req.CustomerId,
+		Limit:         aws.Int32(repository.MaxCustomerLinksLimit),
+		Search:        req.Search,
+		Status:        req.Status,
+		SlugType:      req.SlugType,
+		SortBy:        req.SortBy,
+		SortDirection: req.SortDirection,
+	}
+
+	exported := 0
+	for {
+		links, nextCursor, err := s.repo.GetCustomerLinks(ctx, page)
+		if err != nil {
+			return customerLinksError(err)
+		}
+
+		linkIDs := make([]string, 0, len(links))
+		for _, link := range links {
+			linkIDs = append(linkIDs, link.ID)
+		}
+		uniqueVisitors := s.uniqueVisitors(ctx, linkIDs...)
+
+		now := time.Now()
+		for _, link := range links {
+			if err := stream.Send(linkResponse(link, uniqueVisitors[link.ID], now)); err != nil {
+				logger.Log.Warn("customer links export interrupted",
+					zap.String("customer_id", req.CustomerId),
+					zap.Int("exported", exported),
+					zap.Error(err),
+				)
+				return err
+			}
+			exported++
+		}
+
+		if nextCursor == "" {
+			break
+		}
+		page.Cursor = &nextCursor
+	}
+
+	logger.Log.Info("customer links exported successfully",
+		zap.String("customer_id", req.CustomerId),
+		zap.Int("exported", exported),
+	)
+	return nil
+}
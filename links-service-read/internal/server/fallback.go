@@ -0,0 +1,131 @@
+package server
+
+import (
+	"html/template"
+	"links-service-read/internal/infra/repository"
+	"links-service-read/internal/logger"
+	"net/http"
+
+	"go.uber.org/zap"
+)
+
+var unavailablePage = template.Must(template.New("unavailable").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>Link not available</title>
+</head>
+<body>
+<main>
+<h1>Link not available</h1>
+<p>{{.Message}}</p>
+{{if .Fallback}}<p><a href="{{.Fallback}}">Continue</a></p>{{end}}
+</main>
+</body>
+</html>
+`))
+
+// automatedPage is the page shown to bots, link previewers and headless browsers visiting
+// a link with a click limit, in place of the redirect, which would hand them the
+// destination without counting the click.
+const automatedPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>Open this link in a browser</title>
+</head>
+<body>
+<main>
+<h1>Open this link in a browser</h1>
+<p>This link can only be opened a limited number of times, and only by people.</p>
+</main>
+</body>
+</html>
+`
+
+// clickLimitMessage is the message shown to visitors of a link at its click limit.
+const clickLimitMessage = "This link has reached its click limit."
+
+// inactiveMessages are the messages shown to visitors of inactive links, by status.
+var inactiveMessages = map[string]string{
+	repository.StatusExpired:   "This link has expired.",
+	repository.StatusDisabled:  "This link is disabled.",
+	repository.StatusScheduled: "This link is not available yet.",
+}
+
+// fallback answers a visitor of a link that is not active, in the given status, instead
+// of redirecting them to its destination, and records the hit on links-service-write.
+//
+// The visitor is redirected to the fallback URL of the link, or else of its customer
+// (see repository.LinkSettings.Fallback), if the customer's fallback mode is "redirect".
+// Otherwise, or without a fallback URL, they get a page saying the link is not available,
+// which links to the fallback URL if there is one.
+//
+// Responses:
+//   - 302 Found to the fallback URL, marked as non-cacheable since the link may become
+//     active again.
+//   - 404 Not Found with the page if the link is not active yet.
+//   - 410 Gone with the page if the link has expired, reached its click limit or is disabled.
+//
+// Notes:
+//   - If the customer's settings cannot be read, the link's own fallback URL is still
+//     honored, with the default "redirect" mode.
+//   - HEAD requests are answered the same way but are not recorded.
+func (s *HTTPServer) fallback(w http.ResponseWriter, r *http.Request, link *repository.Link, linkStatus, message string) {
+	if r.Method == http.MethodGet {
+		s.recordInactiveHit(r, link.ID, linkStatus)
+	}
+
+	settings, err := s.repo.GetLinkSettings(r.Context(), link.CustomerID)
+	if err != nil {
+		logger.Log.Warn("failed to get link settings", zap.String("customer_id", link.CustomerID), zap.Error(err))
+		settings = &repository.LinkSettings{CustomerID: link.CustomerID}
+	}
+	target := settings.Fallback(link)
+
+	w.Header().Set("Cache-Control", "private, no-store")
+	if target != "" && settings.Mode() == repository.FallbackRedirect {
+		logger.Log.Info("inactive link redirected to its fallback URL",
+			zap.String("link_id", link.ID),
+			zap.String("link_status", linkStatus),
+		)
+		http.Redirect(w, r, target, http.StatusFound)
+		return
+	}
+
+	code := http.StatusGone
+	if linkStatus == repository.StatusScheduled {
+		code = http.StatusNotFound
+	}
+	logger.Log.Info("redirect refused for inactive link",
+		zap.String("link_id", link.ID),
+		zap.String("link_status", linkStatus),
+	)
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(code)
+	if err := unavailablePage.Execute(w, struct{ Message, Fallback string }{message, target}); err != nil {
+		logger.Log.Error("failed to render unavailable page", zap.Error(err))
+	}
+}
+
+// recordInactiveHit records, like recordClick, a visit to a link that is not active, in
+// the given status. links-service-write keeps such hits apart from clicks.
+func (s *HTTPServer) recordInactiveHit(r *http.Request, linkID, linkStatus string) {
+	req := clickRequest(r, linkID)
+	req.LinkStatus = linkStatus
+	s.sendClick(req)
+}
+
+// inactiveMessage returns the message shown to visitors of a link in the given status,
+// which is not active.
+func inactiveMessage(link *repository.Link, linkStatus string) string {
+	if linkStatus == repository.StatusExpired && link.ClickLimitReached() {
+		return clickLimitMessage
+	}
+	return inactiveMessages[linkStatus]
+}
@@ -2,38 +2,49 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"links-service-read/internal/analytics"
+	"links-service-read/internal/clickstream"
 	"links-service-read/internal/infra/repository"
 	"links-service-read/internal/logger"
+	"links-service-read/internal/visitors"
 	pb "links-service-read/proto"
 	"links-service-read/utils"
 	"net"
+	"net/url"
+	"sort"
 	"strings"
 	"time"
 
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
 )
 
 type GRPCServer struct {
 	pb.UnimplementedLinksServiceReadServer
-	repo *repository.LinksRepository
+	repo     repository.LinkStore
+	visitors *visitors.Reader
+	clicks   *clickstream.Subscriber
 }
 
-// NewGRPCServer creates a new instance of GRPCServer with the provided LinksRepository.
+// NewGRPCServer creates a new instance of GRPCServer with the provided LinkStore.
 // It initializes the GRPCServer with the given repository to handle gRPC requests.
 //
 // Parameters:
-//   - repo: A pointer to a LinksRepository instance that provides access to the data layer.
+//   - repo: The LinkStore that provides access to the data layer.
+//   - visitors: A pointer to the reader of unique visitor counts.
+//   - clicks: A pointer to the subscriber receiving clicks as they are recorded.
 //
 // Returns:
 //
 //	A pointer to a newly created GRPCServer instance.
-func NewGRPCServer(repo *repository.LinksRepository) *GRPCServer {
-	return &GRPCServer{repo: repo}
+func NewGRPCServer(repo repository.LinkStore, visitors *visitors.Reader, clicks *clickstream.Subscriber) *GRPCServer {
+	return &GRPCServer{repo: repo, visitors: visitors, clicks: clicks}
 }
 
 // GetLink handles the retrieval of a link based on its short URL.
@@ -55,12 +66,21 @@ func NewGRPCServer(repo *repository.LinksRepository) *GRPCServer {
 // Possible Errors:
 //   - codes.InvalidArgument: Returned if the short URL is missing in the request.
 //   - codes.NotFound: Returned if the link corresponding to the short URL is not found.
-//   - codes.FailedPrecondition: Returned if the link has expired.
+//   - codes.FailedPrecondition: Returned if the link has expired, reached its click limit, is
+//     disabled or is not active yet, and neither it nor its customer has a fallback URL.
 //   - codes.Internal: Returned if an internal error occurs while fetching the link.
 //
 // Notes:
-//   - If the short URL includes the domain, it is stripped before processing.
-//   - Expiration is checked against the current time, and an error is returned if the link has expired.
+//   - The short URL can be the bare slug of a default-domain link, or a full URL; the
+//     link is then looked up on the domain of its host, see utils.LinkDomain.
+//   - Expiration is checked against the current time, and an error is returned if the link has
+//     expired, unless it has a fallback URL.
+//   - The original URL, UTM parameters, redirect rules and variants of password-protected
+//     links are left out, see HTTPServer.Unlock.
+//   - A link that is not active but has a fallback URL, of its own or its customer's (see
+//     repository.LinkSettings), is returned with its status, without its original URL, UTM
+//     parameters, redirect rules and variants, and with the fallback URL and the customer's fallback mode, so that
+//     visitors can be sent to the fallback instead.
 func (s *GRPCServer) GetLink(ctx context.Context, req *pb.GetLinkRequest) (*pb.GetLinkResponse, error) {
 	if req.ShortUrl == "" {
 		logger.Log.Error("short_url is required")
@@ -68,9 +88,8 @@ func (s *GRPCServer) GetLink(ctx context.Context, req *pb.GetLinkRequest) (*pb.G
 	}
 
 	shortURL := req.ShortUrl
-	baseURL := utils.ConfigInstance.FrontendSource
-	if strings.HasPrefix(shortURL, baseURL+"/") {
-		shortURL = strings.TrimPrefix(shortURL, baseURL+"/")
+	if u, err := url.Parse(shortURL); err == nil && u.Host != "" {
+		shortURL = repository.LinkKey(utils.LinkDomain(u.Host), strings.TrimPrefix(u.Path, "/"))
 	}
 
 	link, err := s.repo.GetLinkByShortURL(ctx, shortURL)
@@ -84,29 +103,213 @@ func (s *GRPCServer) GetLink(ctx context.Context, req *pb.GetLinkRequest) (*pb.G
 		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to get link: %v", err))
 	}
 
-	if link.ExpirationDate != nil && *link.ExpirationDate != "" {
-		expirationTime, err := time.Parse(time.RFC3339, *link.ExpirationDate)
-		if err == nil && expirationTime.Before(time.Now()) {
-			logger.Log.Error("link has expired", zap.String("expiration_date", *link.ExpirationDate))
-			return nil, status.Error(codes.FailedPrecondition, "link has expired")
+	now := time.Now()
+	linkStatus := link.Status(now)
+	var settings *repository.LinkSettings
+	if linkStatus != repository.StatusActive {
+		if settings, err = s.repo.GetLinkSettings(ctx, link.CustomerID); err != nil {
+			logger.Log.Error("failed to get link settings", zap.Error(err))
+			return nil, status.Error(codes.Internal, fmt.Sprintf("failed to get link settings: %v", err))
+		}
+		if settings.Fallback(link) == "" {
+			return nil, inactiveLinkError(link, linkStatus)
 		}
 	}
 
+	uniqueVisitors := s.uniqueVisitors(ctx, link.ID)
+
 	logger.Log.Info("link retrieved successfully", zap.String("short_url", shortURL))
 
+	response := linkResponse(link, uniqueVisitors[link.ID], now)
+	if settings != nil {
+		// Visitors of an inactive link are sent to its fallback, and do not learn its
+		// destination.
+		response.OriginalUrl = ""
+		response.Utm = nil
+		response.Rules = nil
+		response.Variants = nil
+		response.FallbackUrl = settings.Fallback(link)
+		response.FallbackMode = settings.Mode()
+	}
+	if link.PasswordProtected() {
+		// Only the redirect endpoint reveals the destination of a protected link, to
+		// visitors who passed its password challenge.
+		response.OriginalUrl = ""
+		response.Utm = nil
+		response.Rules = nil
+		response.Variants = nil
+	}
+	return response, nil
+}
+
+// inactiveLinkError returns the FailedPrecondition error GetLink answers with for a link
+// that is not active, in the given status, and has no fallback URL.
+func inactiveLinkError(link *repository.Link, linkStatus string) error {
+	switch {
+	case linkStatus == repository.StatusExpired && link.ClickLimitReached():
+		logger.Log.Error("link has reached its click limit", zap.Int32("max_clicks", link.MaxClicks))
+		return status.Error(codes.FailedPrecondition, "link has reached its click limit")
+	case linkStatus == repository.StatusExpired:
+		logger.Log.Error("link has expired", zap.String("link_id", link.ID))
+		return status.Error(codes.FailedPrecondition, "link has expired")
+	case linkStatus == repository.StatusDisabled:
+		logger.Log.Error("link is disabled", zap.String("link_id", link.ID))
+		return status.Error(codes.FailedPrecondition, "link is disabled")
+	default:
+		logger.Log.Error("link is not active yet", zap.String("link_id", link.ID))
+		return status.Error(codes.FailedPrecondition, "link is not active yet")
+	}
+}
+
+// GetLinkByID retrieves one of a customer's links by its ID, for the customer's own
+// views of the link (e.g. its QR code).
+//
+// Parameters:
+//   - ctx: The context for the request, used for cancellation and deadlines.
+//   - req: The request containing the link ID and the customer ID that must own the link.
+//
+// Returns:
+//   - *pb.GetLinkResponse: The response containing the link details.
+//   - error: An error if the request is invalid, the link is not found or belongs to
+//     another customer, or an internal error occurs.
+//
+// Possible Errors:
+//   - codes.InvalidArgument: Returned if the link ID or the customer ID is missing.
+//   - codes.NotFound: Returned if the link does not exist.
+//   - codes.PermissionDenied: Returned if the link belongs to another customer.
+//   - codes.Internal: Returned if an internal error occurs while fetching the link.
+//
+// Notes:
+//   - Unlike GetLink, the link is returned whatever its status; expired, disabled and
+//     scheduled links are reported as such in the status field.
+func (s *GRPCServer) GetLinkByID(ctx context.Context, req *pb.GetLinkByIDRequest) (*pb.GetLinkResponse, error) {
+	if req.Id == "" {
+		logger.Log.Error("id is required")
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	if req.CustomerId == "" {
+		logger.Log.Error("customer_id is required")
+		return nil, status.Error(codes.InvalidArgument, "customer_id is required")
+	}
+
+	link, err := s.repo.GetLinkByID(ctx, req.Id)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			logger.Log.Error("link not found", zap.String("link_id", req.Id))
+			return nil, status.Error(codes.NotFound, "link not found")
+		}
+		logger.Log.Error("failed to get link", zap.Error(err))
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to get link: %v", err))
+	}
+	if link.CustomerID != req.CustomerId {
+		logger.Log.Error("link does not belong to this customer", zap.String("customer_id", req.CustomerId))
+		return nil, status.Error(codes.PermissionDenied, "link does not belong to this customer")
+	}
+
+	uniqueVisitors := s.uniqueVisitors(ctx, link.ID)
+
+	logger.Log.Info("link retrieved successfully", zap.String("link_id", link.ID))
+	return linkResponse(link, uniqueVisitors[link.ID], time.Now()), nil
+}
+
+// linkResponse builds the response describing a link, with its status as of now.
+func linkResponse(link *repository.Link, uniqueVisitors int64, now time.Time) *pb.GetLinkResponse {
 	return &pb.GetLinkResponse{
-		Id:             link.ID,
-		OriginalUrl:    link.OriginalURL,
-		ShortUrl:       baseURL + "/" + link.ShortURL,
-		CustomSlug:     link.CustomSlug,
-		Clicks:         link.Clicks,
-		CreatedAt:      link.CreatedAt,
-		UpdatedAt:      link.UpdatedAt,
-		ExpirationDate: link.ExpirationDate,
-	}, nil
-}
-
-// GetCustomerLinks retrieves a list of links associated with a specific customer ID.
+		Id:                link.ID,
+		OriginalUrl:       link.OriginalURL,
+		ShortUrl:          utils.ShortURL(link.Domain, link.Slug()),
+		CustomSlug:        link.CustomSlug,
+		Clicks:            link.Clicks,
+		BotClicks:         link.BotClicks,
+		UniqueVisitors:    uniqueVisitors,
+		CreatedAt:         link.CreatedAt,
+		UpdatedAt:         link.UpdatedAt,
+		ExpirationDate:    link.ExpirationDate,
+		Status:            link.Status(now),
+		SlugType:          link.ResolvedSlugType(),
+		Title:             link.Title,
+		Tags:              link.Tags,
+		Domain:            link.Domain,
+		Utm:               utmResponse(link.UTM),
+		PasswordProtected: link.PasswordProtected(),
+		MaxClicks:         optionalClicks(link.MaxClicks),
+		ActivatesAt:       link.ActivatesAt,
+		FallbackUrl:       link.FallbackURL,
+		Rules:             rulesResponse(link.Rules),
+		Variants:          variantsResponse(link.Variants),
+		StickyVariants:    link.StickyVariants,
+	}
+}
+
+// optionalClicks returns a click limit as an optional field, nil if unlimited.
+func optionalClicks(maxClicks int32) *int32 {
+	if maxClicks == 0 {
+		return nil
+	}
+	return &maxClicks
+}
+
+// utmResponse converts the UTM parameters of a link into their gRPC representation,
+// nil if the link has none.
+func utmResponse(utm *repository.UTM) *pb.LinkUTM {
+	if utm == nil {
+		return nil
+	}
+	return &pb.LinkUTM{
+		Source:   utm.Source,
+		Medium:   utm.Medium,
+		Campaign: utm.Campaign,
+		Term:     utm.Term,
+		Content:  utm.Content,
+	}
+}
+
+// rulesResponse converts the redirect rules of a link into their gRPC representation.
+func rulesResponse(rules []repository.RedirectRule) []*pb.LinkRedirectRule {
+	if len(rules) == 0 {
+		return nil
+	}
+	result := make([]*pb.LinkRedirectRule, len(rules))
+	for i, rule := range rules {
+		result[i] = &pb.LinkRedirectRule{
+			Destination: rule.Destination,
+			Devices:     rule.Devices,
+			Countries:   rule.Countries,
+			Languages:   rule.Languages,
+			Days:        rule.Days,
+			StartTime:   rule.StartTime,
+			EndTime:     rule.EndTime,
+			Timezone:    rule.Timezone,
+		}
+	}
+	return result
+}
+
+// variantsResponse converts the variants of a link into their gRPC representation.
+func variantsResponse(variants []repository.Variant) []*pb.LinkVariant {
+	if len(variants) == 0 {
+		return nil
+	}
+	result := make([]*pb.LinkVariant, len(variants))
+	for i, variant := range variants {
+		result[i] = &pb.LinkVariant{Id: variant.ID, Url: variant.URL, Weight: variant.Weight}
+	}
+	return result
+}
+
+// uniqueVisitors returns the unique visitor counts of the given links. The counts are
+// auxiliary data, so a Redis failure is logged and reported as zero visitors instead of
+// failing the request.
+func (s *GRPCServer) uniqueVisitors(ctx context.Context, linkIDs ...string) map[string]int64 {
+	counts, err := s.visitors.Total(ctx, linkIDs...)
+	if err != nil {
+		logger.Log.Warn("failed to get unique visitors", zap.Error(err))
+		return map[string]int64{}
+	}
+	return counts
+}
+
+// GetCustomerLinks retrieves a page of the links associated with a specific customer ID.
 // It validates the input request to ensure the customer ID is provided, fetches the links
 // from the repository, and constructs a response containing the link details.
 //
@@ -115,75 +318,426 @@ func (s *GRPCServer) GetLink(ctx context.Context, req *pb.GetLinkRequest) (*pb.G
 //   - req: A pointer to a GetCustomerLinksRequest containing the customer ID.
 //
 // Returns:
-//   - A pointer to a GetCustomerLinksResponse containing the list of links associated with the customer.
+//   - A pointer to a GetCustomerLinksResponse containing the page of links associated with the customer,
+//     and the next_cursor to pass back as cursor to get the following page (absent on the last page).
 //   - An error if the customer ID is missing, or if there is an issue retrieving the links from the repository.
 //
 // Errors:
-//   - codes.InvalidArgument: Returned if the customer ID is not provided in the request.
+//   - codes.InvalidArgument: Returned if the customer ID is not provided in the request, if the
+//     status, slug_type or sort_by is unknown, or if the cursor is malformed or was issued for
+//     another customer or sort order.
 //   - codes.Internal: Returned if there is an internal error while fetching the links.
 //
 // The response includes details such as the link ID, original URL, short URL, custom slug,
-// click count, creation and update timestamps, and expiration date.
+// click count, creation and update timestamps, expiration date, status, slug type, title and tags.
 func (s *GRPCServer) GetCustomerLinks(ctx context.Context, req *pb.GetCustomerLinksRequest) (*pb.GetCustomerLinksResponse, error) {
 	if req.CustomerId == "" {
 		logger.Log.Error("customer_id is required")
 		return nil, status.Error(codes.InvalidArgument, "customer_id is required")
 	}
 
-	links, err := s.repo.GetCustomerLinks(ctx, req)
+	links, nextCursor, err := s.repo.GetCustomerLinks(ctx, req)
 	if err != nil {
-		logger.Log.Error("failed to get customer links", zap.Error(err))
-		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to get customer links: %v", err))
+		return nil, customerLinksError(err)
 	}
 
-	baseURL := utils.ConfigInstance.FrontendSource
+	linkIDs := make([]string, 0, len(links))
+	for _, link := range links {
+		linkIDs = append(linkIDs, link.ID)
+	}
+	uniqueVisitors := s.uniqueVisitors(ctx, linkIDs...)
+
+	now := time.Now()
 	response := &pb.GetCustomerLinksResponse{
 		Links: make([]*pb.GetLinkResponse, 0, len(links)),
 	}
+	if nextCursor != "" {
+		response.NextCursor = &nextCursor
+	}
 
 	for _, link := range links {
-		linkResponse := &pb.GetLinkResponse{
-			Id:             link.ID,
-			OriginalUrl:    link.OriginalURL,
-			ShortUrl:       baseURL + "/" + link.ShortURL,
-			CustomSlug:     link.CustomSlug,
-			Clicks:         link.Clicks,
-			CreatedAt:      link.CreatedAt,
-			UpdatedAt:      link.UpdatedAt,
-			ExpirationDate: link.ExpirationDate,
+		response.Links = append(response.Links, linkResponse(link, uniqueVisitors[link.ID], now))
+	}
+
+	logger.Log.Info("customer links retrieved successfully", zap.String("customer_id", req.CustomerId))
+	return response, nil
+}
+
+// customerLinksError maps an error of the repository's GetCustomerLinks to a gRPC status:
+// malformed cursors and unknown filters are the caller's fault, anything else is internal.
+func customerLinksError(err error) error {
+	if strings.HasPrefix(err.Error(), "invalid cursor") {
+		logger.Log.Error("invalid cursor", zap.Error(err))
+		return status.Error(codes.InvalidArgument, "invalid cursor")
+	}
+	if strings.HasPrefix(err.Error(), "invalid ") {
+		logger.Log.Error("invalid customer links filter", zap.Error(err))
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	logger.Log.Error("failed to get customer links", zap.Error(err))
+	return status.Error(codes.Internal, fmt.Sprintf("failed to get customer links: %v", err))
+}
+
+// GetLinkAnalytics returns the click time series of a link, bucketed by hour, day or week,
+// together with breakdowns by referrer domain, device class, country, UTM campaign and
+// variant. Clicks are attributed to the campaign the link had when they were recorded, and
+// to the variant the visitor was sent to; clicks that went to the destination of a
+// redirect rule, or on links without variants, count towards no variant.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - req: A pointer to a GetLinkAnalyticsRequest containing the link ID, the customer ID
+//     that must own the link, and optionally the granularity and an RFC3339 range.
+//
+// Returns:
+//   - A pointer to a GetLinkAnalyticsResponse with one bucket per period in the range
+//     (periods without clicks are reported with zero clicks) and the range-wide breakdowns.
+//   - An error if the request is invalid or the analytics cannot be retrieved.
+//
+// Defaults:
+//   - granularity: "day".
+//   - end: now; start: end minus 24 hours, 30 days or 12 weeks depending on the granularity.
+//
+// Possible Errors:
+//   - codes.InvalidArgument: Missing IDs, unknown granularity, malformed dates, an empty
+//     range, or a range spanning more than maxAnalyticsBuckets buckets.
+//   - codes.NotFound: Returned if the link does not exist.
+//   - codes.PermissionDenied: Returned if the link belongs to another customer.
+//   - codes.Internal: Returned if the link or its rollups cannot be fetched.
+//
+// Notes:
+//   - Counts are served from the pre-aggregated "ClickRollups" table; raw events are not scanned.
+//   - Clicks from bots, link previewers and headless browsers are always reported in
+//     bot_clicks and the bots breakdown. They are added to clicks and total_clicks only when
+//     include_bots is set; the referrer, device and country breakdowns are human-only.
+//   - Visits while the link was expired, at its click limit, disabled or not active yet are
+//     not clicks: they are reported in inactive_hits and total_inactive_hits, broken down by
+//     the status of the link in inactive_statuses.
+//   - Unique visitors are tracked per UTC day, so they are reported for day and week
+//     buckets and for the whole range, but not for hour buckets.
+func (s *GRPCServer) GetLinkAnalytics(ctx context.Context, req *pb.GetLinkAnalyticsRequest) (*pb.GetLinkAnalyticsResponse, error) {
+	if req.LinkId == "" {
+		logger.Log.Error("link_id is required")
+		return nil, status.Error(codes.InvalidArgument, "link_id is required")
+	}
+	if req.CustomerId == "" {
+		logger.Log.Error("customer_id is required")
+		return nil, status.Error(codes.InvalidArgument, "customer_id is required")
+	}
+
+	granularity := analytics.GranularityDay
+	if req.Granularity != nil && *req.Granularity != "" {
+		granularity = *req.Granularity
+	}
+	if !analytics.ValidGranularity(granularity) {
+		logger.Log.Error("invalid granularity", zap.String("granularity", granularity))
+		return nil, status.Error(codes.InvalidArgument, "granularity must be one of: hour, day, week")
+	}
+
+	end := time.Now().UTC()
+	if req.End != nil && *req.End != "" {
+		parsed, err := time.Parse(time.RFC3339, *req.End)
+		if err != nil {
+			logger.Log.Error("invalid end date format", zap.Error(err))
+			return nil, status.Error(codes.InvalidArgument,
+				"invalid end date format. Use RFC3339 format (e.g., 2024-12-31T23:59:59Z)")
 		}
+		end = parsed.UTC()
+	}
 
-		response.Links = append(response.Links, linkResponse)
+	start := end.Add(-analytics.DefaultSpan(granularity))
+	if req.Start != nil && *req.Start != "" {
+		parsed, err := time.Parse(time.RFC3339, *req.Start)
+		if err != nil {
+			logger.Log.Error("invalid start date format", zap.Error(err))
+			return nil, status.Error(codes.InvalidArgument,
+				"invalid start date format. Use RFC3339 format (e.g., 2024-12-01T00:00:00Z)")
+		}
+		start = parsed.UTC()
 	}
 
-	logger.Log.Info("customer links retrieved successfully", zap.String("customer_id", req.CustomerId))
+	if !start.Before(end) {
+		logger.Log.Error("start must be before end")
+		return nil, status.Error(codes.InvalidArgument, "start must be before end")
+	}
+
+	bucketStarts := make([]time.Time, 0)
+	for t := analytics.BucketStart(granularity, start); !t.After(end); t = analytics.NextBucket(granularity, t) {
+		if len(bucketStarts) == maxAnalyticsBuckets {
+			logger.Log.Error("analytics range too large", zap.String("granularity", granularity))
+			return nil, status.Error(codes.InvalidArgument,
+				fmt.Sprintf("range too large: at most %d %s buckets per request", maxAnalyticsBuckets, granularity))
+		}
+		bucketStarts = append(bucketStarts, t)
+	}
+
+	link, err := s.repo.GetLinkByID(ctx, req.LinkId)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			logger.Log.Error("link not found", zap.String("link_id", req.LinkId))
+			return nil, status.Error(codes.NotFound, "link not found")
+		}
+		logger.Log.Error("failed to get link", zap.Error(err))
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to get link: %v", err))
+	}
+
+	if link.CustomerID != req.CustomerId {
+		logger.Log.Error("link does not belong to this customer", zap.String("customer_id", req.CustomerId))
+		return nil, status.Error(codes.PermissionDenied, "link does not belong to this customer")
+	}
+
+	rollups, err := s.repo.GetClickRollups(ctx, link.ID,
+		analytics.BucketKey(granularity, start), analytics.BucketKey(granularity, end))
+	if err != nil {
+		logger.Log.Error("failed to get click rollups", zap.Error(err))
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to get link analytics: %v", err))
+	}
+
+	rollupsByBucket := make(map[string]*repository.ClickRollup, len(rollups))
+	for _, rollup := range rollups {
+		rollupsByBucket[rollup.Bucket] = rollup
+	}
+
+	response := &pb.GetLinkAnalyticsResponse{
+		LinkId:      link.ID,
+		Granularity: granularity,
+		Start:       start.Format(time.RFC3339),
+		End:         end.Format(time.RFC3339),
+		Buckets:     make([]*pb.AnalyticsBucket, 0, len(bucketStarts)),
+	}
+
+	includeBots := req.IncludeBots != nil && *req.IncludeBots
+
+	referrers := map[string]int64{}
+	devices := map[string]int64{}
+	countries := map[string]int64{}
+	campaigns := map[string]int64{}
+	variants := map[string]int64{}
+	bots := map[string]int64{}
+	inactive := map[string]int64{}
+
+	for _, bucketStart := range bucketStarts {
+		bucket := &pb.AnalyticsBucket{Start: bucketStart.Format(time.RFC3339)}
+
+		if rollup, ok := rollupsByBucket[analytics.BucketKey(granularity, bucketStart)]; ok {
+			bucket.Clicks = rollup.Total
+			if includeBots {
+				bucket.Clicks += rollup.Bots
+			}
+			bucket.Referrers = breakdownEntries(rollup.Referrers)
+			bucket.Devices = breakdownEntries(rollup.Devices)
+			bucket.Countries = breakdownEntries(rollup.Countries)
+			bucket.Campaigns = breakdownEntries(rollup.Campaigns)
+			bucket.Variants = breakdownEntries(rollup.Variants)
+			bucket.BotClicks = rollup.Bots
+			bucket.Bots = breakdownEntries(rollup.BotKinds)
+			bucket.InactiveHits = rollup.Inactive
+
+			response.TotalClicks += bucket.Clicks
+			response.TotalBotClicks += rollup.Bots
+			response.TotalInactiveHits += rollup.Inactive
+			mergeCounts(referrers, rollup.Referrers)
+			mergeCounts(devices, rollup.Devices)
+			mergeCounts(countries, rollup.Countries)
+			mergeCounts(campaigns, rollup.Campaigns)
+			mergeCounts(variants, rollup.Variants)
+			mergeCounts(bots, rollup.BotKinds)
+			mergeCounts(inactive, rollup.InactiveStatuses)
+		}
+
+		response.Buckets = append(response.Buckets, bucket)
+	}
+
+	response.Referrers = breakdownEntries(referrers)
+	response.Devices = breakdownEntries(devices)
+	response.Countries = breakdownEntries(countries)
+	response.Campaigns = breakdownEntries(campaigns)
+	response.Variants = breakdownEntries(variants)
+	response.Bots = breakdownEntries(bots)
+	response.InactiveStatuses = breakdownEntries(inactive)
+
+	ranges := []visitors.Range{{Start: start, End: end}}
+	if granularity != analytics.GranularityHour {
+		for i, bucketStart := range bucketStarts {
+			bucketEnd := analytics.NextBucket(granularity, bucketStart).Add(-time.Nanosecond)
+			if i == 0 && bucketStart.Before(start) {
+				bucketStart = start
+			}
+			if bucketEnd.After(end) {
+				bucketEnd = end
+			}
+			ranges = append(ranges, visitors.Range{Start: bucketStart, End: bucketEnd})
+		}
+	}
+
+	uniqueVisitors, err := s.visitors.Between(ctx, link.ID, ranges...)
+	if err != nil {
+		logger.Log.Warn("failed to get unique visitors", zap.Error(err))
+	} else {
+		response.UniqueVisitors = uniqueVisitors[0]
+		for i, count := range uniqueVisitors[1:] {
+			response.Buckets[i].UniqueVisitors = count
+		}
+	}
+
+	logger.Log.Info("link analytics retrieved successfully",
+		zap.String("link_id", link.ID),
+		zap.String("granularity", granularity),
+	)
 	return response, nil
 }
 
+// WatchClicks streams the clicks on a customer's links as they are recorded, optionally
+// narrowed down to a single link. The stream stays open until the client cancels it.
+//
+// Parameters:
+//   - req: A pointer to a WatchClicksRequest containing the customer ID, and optionally
+//     the link ID to watch and whether clicks from bots should be included.
+//   - stream: The server stream the clicks are sent on.
+//
+// Returns:
+//   - nil when the client goes away.
+//   - An error if the request is invalid or the subscription fails.
+//
+// Possible Errors:
+//   - codes.InvalidArgument: Returned if the customer ID is missing.
+//   - codes.NotFound: Returned if the link does not exist.
+//   - codes.PermissionDenied: Returned if the link belongs to another customer.
+//   - codes.Unavailable: Returned if live clicks are disabled or the subscription drops.
+//   - codes.Internal: Returned if the link cannot be fetched.
+//
+// Notes:
+//   - Clicks are fed by links-service-write over Redis Pub/Sub (see clickstream.Subscriber).
+//     Delivery is best effort; clicks recorded while no stream is open are not replayed.
+//   - Response headers are sent as soon as the subscription is established, so clients
+//     can tell a valid stream from a rejected one before the first click arrives.
+//   - Clicks from bots, link previewers and headless browsers are skipped unless
+//     include_bots is set.
+func (s *GRPCServer) WatchClicks(req *pb.WatchClicksRequest, stream pb.LinksServiceRead_WatchClicksServer) error {
+	if req.CustomerId == "" {
+		logger.Log.Error("customer_id is required")
+		return status.Error(codes.InvalidArgument, "customer_id is required")
+	}
+
+	ctx := stream.Context()
+	linkID := ""
+	if req.LinkId != nil && *req.LinkId != "" {
+		link, err := s.repo.GetLinkByID(ctx, *req.LinkId)
+		if err != nil {
+			if strings.Contains(err.Error(), "not found") {
+				logger.Log.Error("link not found", zap.String("link_id", *req.LinkId))
+				return status.Error(codes.NotFound, "link not found")
+			}
+			logger.Log.Error("failed to get link", zap.Error(err))
+			return status.Error(codes.Internal, fmt.Sprintf("failed to get link: %v", err))
+		}
+		if link.CustomerID != req.CustomerId {
+			logger.Log.Error("link does not belong to this customer", zap.String("customer_id", req.CustomerId))
+			return status.Error(codes.PermissionDenied, "link does not belong to this customer")
+		}
+		linkID = link.ID
+	}
+	includeBots := req.IncludeBots != nil && *req.IncludeBots
+
+	logger.Log.Info("click stream opened",
+		zap.String("customer_id", req.CustomerId),
+		zap.String("link_id", linkID),
+	)
+
+	ready := func() error {
+		return stream.SendHeader(metadata.MD{})
+	}
+	err := s.clicks.Watch(ctx, req.CustomerId, ready, func(n clickstream.Notification) error {
+		if linkID != "" && n.LinkID != linkID {
+			return nil
+		}
+		if n.BotCategory != "" && !includeBots {
+			return nil
+		}
+
+		notification := &pb.ClickNotification{
+			EventId:        n.EventID,
+			LinkId:         n.LinkID,
+			ClickedAt:      n.ClickedAt,
+			ReferrerDomain: n.ReferrerDomain,
+			DeviceClass:    n.DeviceClass,
+			Country:        n.Country,
+			Region:         n.Region,
+			City:           n.City,
+		}
+		if n.BotCategory != "" {
+			notification.BotCategory = &n.BotCategory
+		}
+		return stream.Send(notification)
+	})
+	if err != nil {
+		if errors.Is(err, clickstream.ErrDisabled) {
+			logger.Log.Error("live clicks are disabled")
+			return status.Error(codes.Unavailable, "live clicks are disabled")
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+		logger.Log.Error("click stream failed", zap.Error(err))
+		return status.Error(codes.Unavailable, fmt.Sprintf("click stream failed: %v", err))
+	}
+
+	logger.Log.Info("click stream closed", zap.String("customer_id", req.CustomerId))
+	return nil
+}
+
+// maxAnalyticsBuckets bounds the number of buckets a single analytics request may span.
+const maxAnalyticsBuckets = 1000
+
+// breakdownEntries converts a breakdown map into entries sorted by clicks (descending),
+// then by key.
+func breakdownEntries(counts map[string]int64) []*pb.AnalyticsBreakdownEntry {
+	entries := make([]*pb.AnalyticsBreakdownEntry, 0, len(counts))
+	for key, clicks := range counts {
+		entries = append(entries, &pb.AnalyticsBreakdownEntry{Key: key, Clicks: clicks})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Clicks != entries[j].Clicks {
+			return entries[i].Clicks > entries[j].Clicks
+		}
+		return entries[i].Key < entries[j].Key
+	})
+	return entries
+}
+
+func mergeCounts(dst, src map[string]int64) {
+	for key, count := range src {
+		dst[key] += count
+	}
+}
+
 // StartGRPCServer starts a gRPC server on the specified port and registers the LinksServiceReadServer.
 // It also enables server reflection for tools like grpcurl.
 //
 // Parameters:
 //   - port: The port on which the gRPC server will listen.
-//   - repo: A pointer to the LinksRepository, which provides the necessary data access layer.
+//   - repo: The LinkStore, which provides the necessary data access layer.
+//   - visitors: A pointer to the reader of unique visitor counts.
+//   - clicks: A pointer to the subscriber receiving clicks as they are recorded.
 //
 // Returns:
 //   - error: An error if the server fails to start or listen on the specified port.
 //
 // Example usage:
 //
-//	err := StartGRPCServer("50051", repo)
+//	err := StartGRPCServer("50051", repo, visitors, clicks)
 //	if err != nil {
 //	    log.Fatalf("Failed to start gRPC server: %v", err)
 //	}
-func StartGRPCServer(port string, repo *repository.LinksRepository) error {
+func StartGRPCServer(port string, repo repository.LinkStore, visitors *visitors.Reader, clicks *clickstream.Subscriber) error {
 	lis, err := net.Listen("tcp", ":"+port)
 	if err != nil {
 		return fmt.Errorf("failed to listen: %v", err)
 	}
 
 	server := grpc.NewServer()
-	pb.RegisterLinksServiceReadServer(server, NewGRPCServer(repo))
+	pb.RegisterLinksServiceReadServer(server, NewGRPCServer(repo, visitors, clicks))
 
 	// Habilitar reflection para ferramentas como grpcurl
 	reflection.Register(server)
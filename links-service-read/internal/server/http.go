@@ -0,0 +1,329 @@
+package server
+
+import (
+	"context"
+	"links-service-read/internal/geoip"
+	"links-service-read/internal/infra/grpc/links"
+	"links-service-read/internal/infra/repository"
+	"links-service-read/internal/linkaccess"
+	"links-service-read/internal/logger"
+	pb "links-service-read/proto"
+	"links-service-read/utils"
+	"net/http"
+	"net/netip"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type HTTPServer struct {
+	repo        repository.LinkStore
+	linksClient *links.Client
+	access      *linkaccess.Signer
+	attempts    *linkaccess.Limiter
+	geo         *geoip.Resolver
+}
+
+// NewHTTPServer creates a new instance of HTTPServer, the public listener that resolves
+// short links into HTTP redirects.
+//
+// Parameters:
+//   - repo: The LinkStore used to look up links.
+//   - linksClient: A gRPC client for links-service-write, used to record clicks.
+//   - access: The signer of the access cookies of password-protected links.
+//   - attempts: The limiter of the password attempts of each visitor and on each link.
+//   - geo: The GeoIP resolver locating visitors for the country conditions of redirect rules.
+//
+// Returns:
+//
+//	A pointer to a newly created HTTPServer instance.
+func NewHTTPServer(repo repository.LinkStore, linksClient *links.Client, access *linkaccess.Signer, attempts *linkaccess.Limiter, geo *geoip.Resolver) *HTTPServer {
+	return &HTTPServer{repo: repo, linksClient: linksClient, access: access, attempts: attempts, geo: geo}
+}
+
+// Routes returns the HTTP handler serving the redirect endpoint, and the password
+// challenge of protected links. No authentication is applied: short links must be
+// followable by anonymous visitors, crawlers and curl.
+func (s *HTTPServer) Routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{slug}", s.Redirect)
+	mux.HandleFunc("POST /{slug}", s.Unlock)
+	return mux
+}
+
+// Redirect resolves the slug in the request path and answers with a redirect to the
+// link's original URL, or to the destination of the first of its redirect rules the
+// visitor matches, or to the variant of the link they are assigned, with its UTM
+// parameters added (see repository.Link.Route), recording the click on
+// links-service-write. The slug is looked up
+// on the domain of the request host (see utils.LinkDomain), so that the same slug can
+// lead to different links on the default domain and on each branded one.
+//
+// Responses:
+//   - 302 Found (or 301 Moved Permanently when REDIRECT_PERMANENT is enabled) on success.
+//   - 401 Unauthorized with the password challenge if the link is password protected and
+//     the visitor has no valid access cookie, see Unlock.
+//   - 302 Found to the fallback URL of the link or its customer, or a 404 or 410 page, if
+//     the link is expired, at its click limit, disabled or not active yet, see fallback.
+//   - 404 Not Found if the slug does not match any link.
+//   - 405 Method Not Allowed for HEAD requests on links with a click limit.
+//   - 500 Internal Server Error if the lookup fails, or the click on a link with a click
+//     limit cannot be counted.
+//
+// Notes:
+//   - HEAD requests are answered the same way but are not counted as clicks, except on
+//     links with a click limit, where they would reveal the destination for free.
+//   - 302 responses are marked as non-cacheable so every visit reaches the server and is counted.
+//   - Redirects of password-protected links, links with a click limit and links with
+//     redirect rules or variants are never permanent, so that browsers ask the server, and
+//     so check the access cookie, the limit, the rules and the variants, on every visit.
+//   - Visitors of links with variants are assigned one at random, by weight, recorded
+//     with their click. With sticky variants, they keep it through a cookie scoped to the
+//     link, see assignedVariant.
+//   - The clicks on links with a click limit (such as one-time links) are counted before
+//     the visitor is redirected, see claimClick. Bots, link previewers and headless
+//     browsers are not counted there, and get a page without the destination instead.
+//   - Visits to inactive links are recorded apart from clicks, see recordInactiveHit.
+func (s *HTTPServer) Redirect(w http.ResponseWriter, r *http.Request) {
+	link, ok := s.activeLink(w, r)
+	if !ok {
+		return
+	}
+
+	if link.PasswordProtected() && !s.hasAccess(r, link) {
+		s.challenge(w, r, http.StatusUnauthorized, "")
+		return
+	}
+
+	destination, variant := link.Route(s.visitor(r, link))
+
+	if link.MaxClicks > 0 {
+		if !s.claimClick(w, r, link, variant) {
+			return
+		}
+	} else if r.Method != http.MethodHead {
+		s.recordClick(r, link.ID, variant, false)
+	}
+
+	code := http.StatusFound
+	if utils.ConfigInstance.RedirectPermanent && !link.PasswordProtected() && link.MaxClicks == 0 &&
+		len(link.Rules) == 0 && len(link.Variants) == 0 {
+		code = http.StatusMovedPermanently
+	} else {
+		w.Header().Set("Cache-Control", "private, no-store")
+	}
+	if variant != "" && link.StickyVariants {
+		setVariantCookie(w, r, variant)
+	}
+
+	http.Redirect(w, r, destination, code)
+}
+
+// visitor describes the visitor of a link for its redirect rules and variants. The
+// visitor is only located when a rule has a country condition, sparing the GeoIP lookup
+// otherwise.
+func (s *HTTPServer) visitor(r *http.Request, link *repository.Link) repository.Visitor {
+	visitor := repository.Visitor{
+		Device:   repository.DeviceOf(r.UserAgent()),
+		Language: repository.PreferredLanguage(r.Header.Get("Accept-Language")),
+		Time:     time.Now(),
+		Variant:  link.AssignVariant(assignedVariant(r, link)),
+	}
+	for _, rule := range link.Rules {
+		if len(rule.Countries) > 0 {
+			visitor.Country = s.geo.Lookup(connectionIP(r)).Country
+			break
+		}
+	}
+	return visitor
+}
+
+// activeLink looks up the link of the slug in the request path on the domain of the
+// request host, and answers the request itself if there is no such link, or with its
+// fallback (see fallback) if it is not active.
+//
+// Returns:
+//   - The link, and true if it is active. Otherwise false, once the error is written.
+func (s *HTTPServer) activeLink(w http.ResponseWriter, r *http.Request) (*repository.Link, bool) {
+	slug := strings.TrimSpace(r.PathValue("slug"))
+	if slug == "" {
+		http.NotFound(w, r)
+		return nil, false
+	}
+
+	shortURL := repository.LinkKey(utils.LinkDomain(r.Host), slug)
+
+	link, err := s.repo.GetLinkByShortURL(r.Context(), shortURL)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			http.Error(w, "link not found", http.StatusNotFound)
+			return nil, false
+		}
+
+		logger.Log.Error("failed to get link", zap.Error(err))
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return nil, false
+	}
+
+	if linkStatus := link.Status(time.Now()); linkStatus != repository.StatusActive {
+		s.fallback(w, r, link, linkStatus, inactiveMessage(link, linkStatus))
+		return nil, false
+	}
+
+	return link, true
+}
+
+// claimClick counts the click of the visitor on a link with a click limit through
+// UpdateLinkClicks, which checks the limit atomically with the increment, so that two
+// visitors racing for the last click cannot both be redirected. It then records the
+// click like recordClick, without counting it again.
+//
+// UpdateLinkClicks classifies the visitor first and does not count automated clicks, so
+// that link previewers and mail scanners do not use up one-time links. They are answered
+// with automatedPage rather than redirected, since a user agent is easily faked, and their
+// click is recorded, under the bot rollups.
+//
+// Returns:
+//   - true if the visitor may be redirected. Otherwise false, once the answer is written.
+func (s *HTTPServer) claimClick(w http.ResponseWriter, r *http.Request, link *repository.Link, variant string) bool {
+	if r.Method == http.MethodHead {
+		w.Header().Set("Allow", "GET")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return false
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := s.linksClient.UpdateLinkClicks(ctx, &pb.UpdateLinkClicksRequest{
+		Id:        link.ID,
+		UserAgent: r.UserAgent(),
+		IpAddress: connectionIP(r),
+	})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.FailedPrecondition:
+			s.fallback(w, r, link, repository.StatusExpired, clickLimitMessage)
+		case codes.NotFound:
+			http.Error(w, "link not found", http.StatusNotFound)
+		default:
+			logger.Log.Error("failed to count click", zap.String("link_id", link.ID), zap.Error(err))
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+		}
+		return false
+	}
+
+	if resp.GetBotCategory() != "" {
+		s.recordClick(r, link.ID, variant, false)
+		w.Header().Set("Cache-Control", "private, no-store")
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write([]byte(automatedPage)); err != nil {
+			logger.Log.Error("failed to write automated visit page", zap.Error(err))
+		}
+		return false
+	}
+
+	s.recordClick(r, link.ID, variant, true)
+	return true
+}
+
+// recordClick stores the click with the visitor's request metadata, and the variant of the
+// link they were sent to if any, on links-service-write in the background, so that the
+// visitor is redirected without waiting on the write path. Clicks already counted by
+// claimClick are marked as such, so that they are only stored.
+func (s *HTTPServer) recordClick(r *http.Request, linkID, variant string, counted bool) {
+	req := clickRequest(r, linkID)
+	req.Variant = variant
+	req.Counted = counted
+	s.sendClick(req)
+}
+
+// clickRequest builds the RecordClick request of a visit to a link, with the visitor's
+// request metadata.
+func clickRequest(r *http.Request, linkID string) *pb.RecordClickRequest {
+	return &pb.RecordClickRequest{
+		LinkId:         linkID,
+		Referrer:       r.Referer(),
+		UserAgent:      r.UserAgent(),
+		IpAddress:      connectionIP(r),
+		AcceptLanguage: r.Header.Get("Accept-Language"),
+	}
+}
+
+// sendClick sends a RecordClick request to links-service-write in the background.
+func (s *HTTPServer) sendClick(req *pb.RecordClickRequest) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if _, err := s.linksClient.RecordClick(ctx, req); err != nil {
+			logger.Log.Error("failed to record click", zap.String("link_id", req.LinkId), zap.Error(err))
+		}
+	}()
+}
+
+// connectionIP returns the IP address of the visitor as far as the service can trust it:
+// the connection address, or, when the connection comes from one of the trusted proxies
+// (see utils.Config.TrustedProxies), the right-most X-Forwarded-For hop that was not
+// appended by a trusted proxy. It cannot be chosen by the visitor, and so is what limits,
+// bot checks and the recorded click data are based on.
+func connectionIP(r *http.Request) string {
+	addr, err := netip.ParseAddrPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	ip := addr.Addr().Unmap()
+	if !trustedProxy(ip) {
+		return ip.String()
+	}
+
+	hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+		if err != nil {
+			break
+		}
+		ip = hop.Unmap()
+		if !trustedProxy(ip) {
+			break
+		}
+	}
+	return ip.String()
+}
+
+// trustedProxy reports whether ip belongs to one of the trusted proxies.
+func trustedProxy(ip netip.Addr) bool {
+	for _, prefix := range utils.ConfigInstance.TrustedProxies {
+		if prefix.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// StartHTTPServer starts the public redirect listener on the specified port.
+//
+// Parameters:
+//   - port: The port on which the HTTP server will listen.
+//   - repo: The LinkStore used to resolve slugs.
+//   - linksClient: A gRPC client for links-service-write, used to record clicks.
+//   - access: The signer of the access cookies of password-protected links.
+//   - attempts: The limiter of the password attempts of each visitor and on each link.
+//   - geo: The GeoIP resolver locating visitors for the country conditions of redirect rules.
+//
+// Returns:
+//   - error: An error if the server fails to start or stops unexpectedly.
+func StartHTTPServer(port string, repo repository.LinkStore, linksClient *links.Client, access *linkaccess.Signer, attempts *linkaccess.Limiter, geo *geoip.Resolver) error {
+	server := &http.Server{
+		Addr:              ":" + port,
+		Handler:           NewHTTPServer(repo, linksClient, access, attempts, geo).Routes(),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	logger.Log.Info("HTTP redirect server listening", zap.String("port", port))
+	return server.ListenAndServe()
+}
@@ -0,0 +1,47 @@
+package server
+
+import (
+	"links-service-read/internal/infra/repository"
+	"net/http"
+	"time"
+)
+
+// variantCookieName is the name of the cookie holding the variant a visitor of a link
+// with sticky variants was assigned. Each cookie is scoped to the path of its link's slug.
+const variantCookieName = "link_variant"
+
+// variantCookieTTL is how long visitors of a link with sticky variants keep the variant
+// they were assigned after their last visit.
+const variantCookieTTL = 30 * 24 * time.Hour
+
+// assignedVariant returns the variant the visitor was assigned on a previous visit to
+// the link, from their variant cookie, "" if they have none or the link's variants are
+// not sticky. See repository.Link.AssignVariant.
+//
+// Notes:
+//   - The cookie is not signed: a visitor who edits it only picks their own variant, and
+//     unknown variants are drawn again.
+func assignedVariant(r *http.Request, link *repository.Link) string {
+	if !link.StickyVariants {
+		return ""
+	}
+	cookie, err := r.Cookie(variantCookieName)
+	if err != nil {
+		return ""
+	}
+	return cookie.Value
+}
+
+// setVariantCookie keeps the variant the visitor was sent to for their next visits to the
+// link, for variantCookieTTL.
+func setVariantCookie(w http.ResponseWriter, r *http.Request, variant string) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     variantCookieName,
+		Value:    variant,
+		Path:     r.URL.EscapedPath(),
+		MaxAge:   int(variantCookieTTL / time.Second),
+		HttpOnly: true,
+		Secure:   isHTTPS(r),
+		SameSite: http.SameSiteLaxMode,
+	})
+}
@@ -0,0 +1,52 @@
+package visitors
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReader(t *testing.T) {
+	s, err := miniredis.Run()
+	require.NoError(t, err, "Failed to start miniredis")
+	defer s.Close()
+
+	rdb := redis.NewClient(&redis.Options{Addr: s.Addr()})
+	defer rdb.Close()
+
+	ctx := context.Background()
+	monday := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	rdb.PFAdd(ctx, DayKey("link1", monday), "a", "b")
+	rdb.PFAdd(ctx, DayKey("link1", monday.AddDate(0, 0, 1)), "c")
+	rdb.PFAdd(ctx, DayKey("link1", monday.AddDate(0, 0, 7)), "d")
+	rdb.PFAdd(ctx, TotalKey("link1"), "a", "b", "c", "d")
+
+	reader := NewReader(rdb)
+
+	t.Run("Total counts every link in one call", func(t *testing.T) {
+		counts, err := reader.Total(ctx, "link1", "link2")
+		require.NoError(t, err)
+		require.Equal(t, map[string]int64{"link1": 4, "link2": 0}, counts)
+	})
+
+	t.Run("Between counts each day range", func(t *testing.T) {
+		counts, err := reader.Between(ctx, "link1",
+			Range{Start: monday.Add(13 * time.Hour), End: monday.Add(13 * time.Hour)},
+			Range{Start: monday, End: monday.AddDate(0, 0, 7).Add(-time.Nanosecond)},
+			Range{Start: monday, End: monday.AddDate(0, 0, 14)},
+			Range{Start: monday, End: monday.Add(-time.Hour)},
+		)
+		require.NoError(t, err)
+		require.Equal(t, []int64{2, 3, 4, 0}, counts)
+	})
+
+	t.Run("Disabled reader reports zero visitors", func(t *testing.T) {
+		counts, err := NewReader(nil).Between(ctx, "link1", Range{Start: monday, End: monday})
+		require.NoError(t, err)
+		require.Equal(t, []int64{0}, counts)
+	})
+}
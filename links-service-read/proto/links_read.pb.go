@@ -65,23 +65,91 @@ func (x *GetLinkRequest) GetShortUrl() string {
 	return ""
 }
 
+type GetLinkByIDRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CustomerId    string                 `protobuf:"bytes,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLinkByIDRequest) Reset() {
+	*x = GetLinkByIDRequest{}
+	mi := &file_proto_links_read_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLinkByIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLinkByIDRequest) ProtoMessage() {}
+
+func (x *GetLinkByIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_links_read_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLinkByIDRequest.ProtoReflect.Descriptor instead.
+func (*GetLinkByIDRequest) Descriptor() ([]byte, []int) {
+	return file_proto_links_read_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetLinkByIDRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetLinkByIDRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
 type GetLinkResponse struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	OriginalUrl    string                 `protobuf:"bytes,2,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`
-	ShortUrl       string                 `protobuf:"bytes,3,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
-	CustomSlug     string                 `protobuf:"bytes,4,opt,name=custom_slug,json=customSlug,proto3" json:"custom_slug,omitempty"`
-	Clicks         int32                  `protobuf:"varint,5,opt,name=clicks,proto3" json:"clicks,omitempty"`
-	CreatedAt      string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt      string                 `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	ExpirationDate *string                `protobuf:"bytes,8,opt,name=expiration_date,json=expirationDate,proto3,oneof" json:"expiration_date,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OriginalUrl       string                 `protobuf:"bytes,2,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`
+	ShortUrl          string                 `protobuf:"bytes,3,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	CustomSlug        string                 `protobuf:"bytes,4,opt,name=custom_slug,json=customSlug,proto3" json:"custom_slug,omitempty"`
+	Clicks            int32                  `protobuf:"varint,5,opt,name=clicks,proto3" json:"clicks,omitempty"`
+	CreatedAt         string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt         string                 `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	ExpirationDate    *string                `protobuf:"bytes,8,opt,name=expiration_date,json=expirationDate,proto3,oneof" json:"expiration_date,omitempty"`
+	BotClicks         int32                  `protobuf:"varint,9,opt,name=bot_clicks,json=botClicks,proto3" json:"bot_clicks,omitempty"`
+	UniqueVisitors    int64                  `protobuf:"varint,10,opt,name=unique_visitors,json=uniqueVisitors,proto3" json:"unique_visitors,omitempty"`
+	Status            string                 `protobuf:"bytes,11,opt,name=status,proto3" json:"status,omitempty"`
+	SlugType          string                 `protobuf:"bytes,12,opt,name=slug_type,json=slugType,proto3" json:"slug_type,omitempty"`
+	Title             string                 `protobuf:"bytes,13,opt,name=title,proto3" json:"title,omitempty"`
+	Tags              []string               `protobuf:"bytes,14,rep,name=tags,proto3" json:"tags,omitempty"`
+	Domain            string                 `protobuf:"bytes,15,opt,name=domain,proto3" json:"domain,omitempty"`
+	Utm               *LinkUTM               `protobuf:"bytes,16,opt,name=utm,proto3,oneof" json:"utm,omitempty"`
+	PasswordProtected bool                   `protobuf:"varint,17,opt,name=password_protected,json=passwordProtected,proto3" json:"password_protected,omitempty"`
+	MaxClicks         *int32                 `protobuf:"varint,18,opt,name=max_clicks,json=maxClicks,proto3,oneof" json:"max_clicks,omitempty"`
+	ActivatesAt       *string                `protobuf:"bytes,19,opt,name=activates_at,json=activatesAt,proto3,oneof" json:"activates_at,omitempty"`
+	FallbackUrl       string                 `protobuf:"bytes,20,opt,name=fallback_url,json=fallbackUrl,proto3" json:"fallback_url,omitempty"`
+	FallbackMode      string                 `protobuf:"bytes,21,opt,name=fallback_mode,json=fallbackMode,proto3" json:"fallback_mode,omitempty"`
+	Rules             []*LinkRedirectRule    `protobuf:"bytes,22,rep,name=rules,proto3" json:"rules,omitempty"`
+	Variants          []*LinkVariant         `protobuf:"bytes,23,rep,name=variants,proto3" json:"variants,omitempty"`
+	StickyVariants    bool                   `protobuf:"varint,24,opt,name=sticky_variants,json=stickyVariants,proto3" json:"sticky_variants,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *GetLinkResponse) Reset() {
 	*x = GetLinkResponse{}
-	mi := &file_proto_links_read_proto_msgTypes[1]
+	mi := &file_proto_links_read_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -93,7 +161,7 @@ func (x *GetLinkResponse) String() string {
 func (*GetLinkResponse) ProtoMessage() {}
 
 func (x *GetLinkResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_links_read_proto_msgTypes[1]
+	mi := &file_proto_links_read_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -106,7 +174,7 @@ func (x *GetLinkResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetLinkResponse.ProtoReflect.Descriptor instead.
 func (*GetLinkResponse) Descriptor() ([]byte, []int) {
-	return file_proto_links_read_proto_rawDescGZIP(), []int{1}
+	return file_proto_links_read_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *GetLinkResponse) GetId() string {
@@ -165,35 +233,144 @@ func (x *GetLinkResponse) GetExpirationDate() string {
 	return ""
 }
 
-type GetCustomerLinksRequest struct {
+func (x *GetLinkResponse) GetBotClicks() int32 {
+	if x != nil {
+		return x.BotClicks
+	}
+	return 0
+}
+
+func (x *GetLinkResponse) GetUniqueVisitors() int64 {
+	if x != nil {
+		return x.UniqueVisitors
+	}
+	return 0
+}
+
+func (x *GetLinkResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *GetLinkResponse) GetSlugType() string {
+	if x != nil {
+		return x.SlugType
+	}
+	return ""
+}
+
+func (x *GetLinkResponse) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *GetLinkResponse) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *GetLinkResponse) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *GetLinkResponse) GetUtm() *LinkUTM {
+	if x != nil {
+		return x.Utm
+	}
+	return nil
+}
+
+func (x *GetLinkResponse) GetPasswordProtected() bool {
+	if x != nil {
+		return x.PasswordProtected
+	}
+	return false
+}
+
+func (x *GetLinkResponse) GetMaxClicks() int32 {
+	if x != nil && x.MaxClicks != nil {
+		return *x.MaxClicks
+	}
+	return 0
+}
+
+func (x *GetLinkResponse) GetActivatesAt() string {
+	if x != nil && x.ActivatesAt != nil {
+		return *x.ActivatesAt
+	}
+	return ""
+}
+
+func (x *GetLinkResponse) GetFallbackUrl() string {
+	if x != nil {
+		return x.FallbackUrl
+	}
+	return ""
+}
+
+func (x *GetLinkResponse) GetFallbackMode() string {
+	if x != nil {
+		return x.FallbackMode
+	}
+	return ""
+}
+
+func (x *GetLinkResponse) GetRules() []*LinkRedirectRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *GetLinkResponse) GetVariants() []*LinkVariant {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+func (x *GetLinkResponse) GetStickyVariants() bool {
+	if x != nil {
+		return x.StickyVariants
+	}
+	return false
+}
+
+type LinkUTM struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	CustomerId    string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
-	Limit         *int32                 `protobuf:"varint,2,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
-	Offset        *int32                 `protobuf:"varint,3,opt,name=offset,proto3,oneof" json:"offset,omitempty"`
-	Search        *string                `protobuf:"bytes,4,opt,name=search,proto3,oneof" json:"search,omitempty"`
-	Status        *string                `protobuf:"bytes,5,opt,name=status,proto3,oneof" json:"status,omitempty"`
-	SlugType      *string                `protobuf:"bytes,6,opt,name=slug_type,json=slugType,proto3,oneof" json:"slug_type,omitempty"`
-	SortBy        *string                `protobuf:"bytes,7,opt,name=sort_by,json=sortBy,proto3,oneof" json:"sort_by,omitempty"`
-	SortDirection *string                `protobuf:"bytes,8,opt,name=sort_direction,json=sortDirection,proto3,oneof" json:"sort_direction,omitempty"`
+	Source        string                 `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Medium        string                 `protobuf:"bytes,2,opt,name=medium,proto3" json:"medium,omitempty"`
+	Campaign      string                 `protobuf:"bytes,3,opt,name=campaign,proto3" json:"campaign,omitempty"`
+	Term          string                 `protobuf:"bytes,4,opt,name=term,proto3" json:"term,omitempty"`
+	Content       string                 `protobuf:"bytes,5,opt,name=content,proto3" json:"content,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetCustomerLinksRequest) Reset() {
-	*x = GetCustomerLinksRequest{}
-	mi := &file_proto_links_read_proto_msgTypes[2]
+func (x *LinkUTM) Reset() {
+	*x = LinkUTM{}
+	mi := &file_proto_links_read_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetCustomerLinksRequest) String() string {
+func (x *LinkUTM) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetCustomerLinksRequest) ProtoMessage() {}
+func (*LinkUTM) ProtoMessage() {}
 
-func (x *GetCustomerLinksRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_links_read_proto_msgTypes[2]
+func (x *LinkUTM) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_links_read_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -204,89 +381,170 @@ func (x *GetCustomerLinksRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetCustomerLinksRequest.ProtoReflect.Descriptor instead.
-func (*GetCustomerLinksRequest) Descriptor() ([]byte, []int) {
-	return file_proto_links_read_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use LinkUTM.ProtoReflect.Descriptor instead.
+func (*LinkUTM) Descriptor() ([]byte, []int) {
+	return file_proto_links_read_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *GetCustomerLinksRequest) GetCustomerId() string {
+func (x *LinkUTM) GetSource() string {
 	if x != nil {
-		return x.CustomerId
+		return x.Source
 	}
 	return ""
 }
 
-func (x *GetCustomerLinksRequest) GetLimit() int32 {
-	if x != nil && x.Limit != nil {
-		return *x.Limit
+func (x *LinkUTM) GetMedium() string {
+	if x != nil {
+		return x.Medium
 	}
-	return 0
+	return ""
 }
 
-func (x *GetCustomerLinksRequest) GetOffset() int32 {
-	if x != nil && x.Offset != nil {
-		return *x.Offset
+func (x *LinkUTM) GetCampaign() string {
+	if x != nil {
+		return x.Campaign
 	}
-	return 0
+	return ""
 }
 
-func (x *GetCustomerLinksRequest) GetSearch() string {
-	if x != nil && x.Search != nil {
-		return *x.Search
+func (x *LinkUTM) GetTerm() string {
+	if x != nil {
+		return x.Term
 	}
 	return ""
 }
 
-func (x *GetCustomerLinksRequest) GetStatus() string {
-	if x != nil && x.Status != nil {
-		return *x.Status
+func (x *LinkUTM) GetContent() string {
+	if x != nil {
+		return x.Content
 	}
 	return ""
 }
 
-func (x *GetCustomerLinksRequest) GetSlugType() string {
-	if x != nil && x.SlugType != nil {
-		return *x.SlugType
+type LinkRedirectRule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Destination   string                 `protobuf:"bytes,1,opt,name=destination,proto3" json:"destination,omitempty"`
+	Devices       []string               `protobuf:"bytes,2,rep,name=devices,proto3" json:"devices,omitempty"`
+	Countries     []string               `protobuf:"bytes,3,rep,name=countries,proto3" json:"countries,omitempty"`
+	Languages     []string               `protobuf:"bytes,4,rep,name=languages,proto3" json:"languages,omitempty"`
+	Days          []string               `protobuf:"bytes,5,rep,name=days,proto3" json:"days,omitempty"`
+	StartTime     string                 `protobuf:"bytes,6,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime       string                 `protobuf:"bytes,7,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Timezone      string                 `protobuf:"bytes,8,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkRedirectRule) Reset() {
+	*x = LinkRedirectRule{}
+	mi := &file_proto_links_read_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkRedirectRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkRedirectRule) ProtoMessage() {}
+
+func (x *LinkRedirectRule) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_links_read_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkRedirectRule.ProtoReflect.Descriptor instead.
+func (*LinkRedirectRule) Descriptor() ([]byte, []int) {
+	return file_proto_links_read_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *LinkRedirectRule) GetDestination() string {
+	if x != nil {
+		return x.Destination
 	}
 	return ""
 }
 
-func (x *GetCustomerLinksRequest) GetSortBy() string {
-	if x != nil && x.SortBy != nil {
-		return *x.SortBy
+func (x *LinkRedirectRule) GetDevices() []string {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+func (x *LinkRedirectRule) GetCountries() []string {
+	if x != nil {
+		return x.Countries
+	}
+	return nil
+}
+
+func (x *LinkRedirectRule) GetLanguages() []string {
+	if x != nil {
+		return x.Languages
+	}
+	return nil
+}
+
+func (x *LinkRedirectRule) GetDays() []string {
+	if x != nil {
+		return x.Days
+	}
+	return nil
+}
+
+func (x *LinkRedirectRule) GetStartTime() string {
+	if x != nil {
+		return x.StartTime
 	}
 	return ""
 }
 
-func (x *GetCustomerLinksRequest) GetSortDirection() string {
-	if x != nil && x.SortDirection != nil {
-		return *x.SortDirection
+func (x *LinkRedirectRule) GetEndTime() string {
+	if x != nil {
+		return x.EndTime
 	}
 	return ""
 }
 
-type GetCustomerLinksResponse struct {
+func (x *LinkRedirectRule) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+type LinkVariant struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Links         []*GetLinkResponse     `protobuf:"bytes,1,rep,name=links,proto3" json:"links,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	Weight        int32                  `protobuf:"varint,3,opt,name=weight,proto3" json:"weight,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetCustomerLinksResponse) Reset() {
-	*x = GetCustomerLinksResponse{}
-	mi := &file_proto_links_read_proto_msgTypes[3]
+func (x *LinkVariant) Reset() {
+	*x = LinkVariant{}
+	mi := &file_proto_links_read_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetCustomerLinksResponse) String() string {
+func (x *LinkVariant) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetCustomerLinksResponse) ProtoMessage() {}
+func (*LinkVariant) ProtoMessage() {}
 
-func (x *GetCustomerLinksResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_links_read_proto_msgTypes[3]
+func (x *LinkVariant) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_links_read_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -297,26 +555,796 @@ func (x *GetCustomerLinksResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetCustomerLinksResponse.ProtoReflect.Descriptor instead.
-func (*GetCustomerLinksResponse) Descriptor() ([]byte, []int) {
-	return file_proto_links_read_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use LinkVariant.ProtoReflect.Descriptor instead.
+func (*LinkVariant) Descriptor() ([]byte, []int) {
+	return file_proto_links_read_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *GetCustomerLinksResponse) GetLinks() []*GetLinkResponse {
+func (x *LinkVariant) GetId() string {
 	if x != nil {
-		return x.Links
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-var File_proto_links_read_proto protoreflect.FileDescriptor
+func (x *LinkVariant) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
 
-const file_proto_links_read_proto_rawDesc = "" +
-	"\n" +
-	"\x16proto/links_read.proto\x12\n" +
+func (x *LinkVariant) GetWeight() int32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+type GetCustomerLinksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Limit         *int32                 `protobuf:"varint,2,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	Offset        *int32                 `protobuf:"varint,3,opt,name=offset,proto3,oneof" json:"offset,omitempty"`
+	Search        *string                `protobuf:"bytes,4,opt,name=search,proto3,oneof" json:"search,omitempty"`
+	Status        *string                `protobuf:"bytes,5,opt,name=status,proto3,oneof" json:"status,omitempty"`
+	SlugType      *string                `protobuf:"bytes,6,opt,name=slug_type,json=slugType,proto3,oneof" json:"slug_type,omitempty"`
+	SortBy        *string                `protobuf:"bytes,7,opt,name=sort_by,json=sortBy,proto3,oneof" json:"sort_by,omitempty"`
+	SortDirection *string                `protobuf:"bytes,8,opt,name=sort_direction,json=sortDirection,proto3,oneof" json:"sort_direction,omitempty"`
+	Cursor        *string                `protobuf:"bytes,9,opt,name=cursor,proto3,oneof" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCustomerLinksRequest) Reset() {
+	*x = GetCustomerLinksRequest{}
+	mi := &file_proto_links_read_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCustomerLinksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCustomerLinksRequest) ProtoMessage() {}
+
+func (x *GetCustomerLinksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_links_read_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCustomerLinksRequest.ProtoReflect.Descriptor instead.
+func (*GetCustomerLinksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_links_read_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetCustomerLinksRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *GetCustomerLinksRequest) GetLimit() int32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+func (x *GetCustomerLinksRequest) GetOffset() int32 {
+	if x != nil && x.Offset != nil {
+		return *x.Offset
+	}
+	return 0
+}
+
+func (x *GetCustomerLinksRequest) GetSearch() string {
+	if x != nil && x.Search != nil {
+		return *x.Search
+	}
+	return ""
+}
+
+func (x *GetCustomerLinksRequest) GetStatus() string {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return ""
+}
+
+func (x *GetCustomerLinksRequest) GetSlugType() string {
+	if x != nil && x.SlugType != nil {
+		return *x.SlugType
+	}
+	return ""
+}
+
+func (x *GetCustomerLinksRequest) GetSortBy() string {
+	if x != nil && x.SortBy != nil {
+		return *x.SortBy
+	}
+	return ""
+}
+
+func (x *GetCustomerLinksRequest) GetSortDirection() string {
+	if x != nil && x.SortDirection != nil {
+		return *x.SortDirection
+	}
+	return ""
+}
+
+func (x *GetCustomerLinksRequest) GetCursor() string {
+	if x != nil && x.Cursor != nil {
+		return *x.Cursor
+	}
+	return ""
+}
+
+type GetCustomerLinksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Links         []*GetLinkResponse     `protobuf:"bytes,1,rep,name=links,proto3" json:"links,omitempty"`
+	NextCursor    *string                `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3,oneof" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCustomerLinksResponse) Reset() {
+	*x = GetCustomerLinksResponse{}
+	mi := &file_proto_links_read_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCustomerLinksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCustomerLinksResponse) ProtoMessage() {}
+
+func (x *GetCustomerLinksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_links_read_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCustomerLinksResponse.ProtoReflect.Descriptor instead.
+func (*GetCustomerLinksResponse) Descriptor() ([]byte, []int) {
+	return file_proto_links_read_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetCustomerLinksResponse) GetLinks() []*GetLinkResponse {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+func (x *GetCustomerLinksResponse) GetNextCursor() string {
+	if x != nil && x.NextCursor != nil {
+		return *x.NextCursor
+	}
+	return ""
+}
+
+type GetLinkAnalyticsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	LinkId        string                 `protobuf:"bytes,1,opt,name=link_id,json=linkId,proto3" json:"link_id,omitempty"`
+	CustomerId    string                 `protobuf:"bytes,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Granularity   *string                `protobuf:"bytes,3,opt,name=granularity,proto3,oneof" json:"granularity,omitempty"`
+	Start         *string                `protobuf:"bytes,4,opt,name=start,proto3,oneof" json:"start,omitempty"`
+	End           *string                `protobuf:"bytes,5,opt,name=end,proto3,oneof" json:"end,omitempty"`
+	IncludeBots   *bool                  `protobuf:"varint,6,opt,name=include_bots,json=includeBots,proto3,oneof" json:"include_bots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLinkAnalyticsRequest) Reset() {
+	*x = GetLinkAnalyticsRequest{}
+	mi := &file_proto_links_read_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLinkAnalyticsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLinkAnalyticsRequest) ProtoMessage() {}
+
+func (x *GetLinkAnalyticsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_links_read_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLinkAnalyticsRequest.ProtoReflect.Descriptor instead.
+func (*GetLinkAnalyticsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_links_read_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetLinkAnalyticsRequest) GetLinkId() string {
+	if x != nil {
+		return x.LinkId
+	}
+	return ""
+}
+
+func (x *GetLinkAnalyticsRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *GetLinkAnalyticsRequest) GetGranularity() string {
+	if x != nil && x.Granularity != nil {
+		return *x.Granularity
+	}
+	return ""
+}
+
+func (x *GetLinkAnalyticsRequest) GetStart() string {
+	if x != nil && x.Start != nil {
+		return *x.Start
+	}
+	return ""
+}
+
+func (x *GetLinkAnalyticsRequest) GetEnd() string {
+	if x != nil && x.End != nil {
+		return *x.End
+	}
+	return ""
+}
+
+func (x *GetLinkAnalyticsRequest) GetIncludeBots() bool {
+	if x != nil && x.IncludeBots != nil {
+		return *x.IncludeBots
+	}
+	return false
+}
+
+type AnalyticsBreakdownEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Clicks        int64                  `protobuf:"varint,2,opt,name=clicks,proto3" json:"clicks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnalyticsBreakdownEntry) Reset() {
+	*x = AnalyticsBreakdownEntry{}
+	mi := &file_proto_links_read_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnalyticsBreakdownEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnalyticsBreakdownEntry) ProtoMessage() {}
+
+func (x *AnalyticsBreakdownEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_links_read_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnalyticsBreakdownEntry.ProtoReflect.Descriptor instead.
+func (*AnalyticsBreakdownEntry) Descriptor() ([]byte, []int) {
+	return file_proto_links_read_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *AnalyticsBreakdownEntry) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *AnalyticsBreakdownEntry) GetClicks() int64 {
+	if x != nil {
+		return x.Clicks
+	}
+	return 0
+}
+
+type AnalyticsBucket struct {
+	state          protoimpl.MessageState     `protogen:"open.v1"`
+	Start          string                     `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	Clicks         int64                      `protobuf:"varint,2,opt,name=clicks,proto3" json:"clicks,omitempty"`
+	Referrers      []*AnalyticsBreakdownEntry `protobuf:"bytes,3,rep,name=referrers,proto3" json:"referrers,omitempty"`
+	Devices        []*AnalyticsBreakdownEntry `protobuf:"bytes,4,rep,name=devices,proto3" json:"devices,omitempty"`
+	Countries      []*AnalyticsBreakdownEntry `protobuf:"bytes,5,rep,name=countries,proto3" json:"countries,omitempty"`
+	BotClicks      int64                      `protobuf:"varint,6,opt,name=bot_clicks,json=botClicks,proto3" json:"bot_clicks,omitempty"`
+	Bots           []*AnalyticsBreakdownEntry `protobuf:"bytes,7,rep,name=bots,proto3" json:"bots,omitempty"`
+	UniqueVisitors int64                      `protobuf:"varint,8,opt,name=unique_visitors,json=uniqueVisitors,proto3" json:"unique_visitors,omitempty"`
+	Campaigns      []*AnalyticsBreakdownEntry `protobuf:"bytes,9,rep,name=campaigns,proto3" json:"campaigns,omitempty"`
+	InactiveHits   int64                      `protobuf:"varint,10,opt,name=inactive_hits,json=inactiveHits,proto3" json:"inactive_hits,omitempty"`
+	Variants       []*AnalyticsBreakdownEntry `protobuf:"bytes,11,rep,name=variants,proto3" json:"variants,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AnalyticsBucket) Reset() {
+	*x = AnalyticsBucket{}
+	mi := &file_proto_links_read_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnalyticsBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnalyticsBucket) ProtoMessage() {}
+
+func (x *AnalyticsBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_links_read_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnalyticsBucket.ProtoReflect.Descriptor instead.
+func (*AnalyticsBucket) Descriptor() ([]byte, []int) {
+	return file_proto_links_read_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *AnalyticsBucket) GetStart() string {
+	if x != nil {
+		return x.Start
+	}
+	return ""
+}
+
+func (x *AnalyticsBucket) GetClicks() int64 {
+	if x != nil {
+		return x.Clicks
+	}
+	return 0
+}
+
+func (x *AnalyticsBucket) GetReferrers() []*AnalyticsBreakdownEntry {
+	if x != nil {
+		return x.Referrers
+	}
+	return nil
+}
+
+func (x *AnalyticsBucket) GetDevices() []*AnalyticsBreakdownEntry {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+func (x *AnalyticsBucket) GetCountries() []*AnalyticsBreakdownEntry {
+	if x != nil {
+		return x.Countries
+	}
+	return nil
+}
+
+func (x *AnalyticsBucket) GetBotClicks() int64 {
+	if x != nil {
+		return x.BotClicks
+	}
+	return 0
+}
+
+func (x *AnalyticsBucket) GetBots() []*AnalyticsBreakdownEntry {
+	if x != nil {
+		return x.Bots
+	}
+	return nil
+}
+
+func (x *AnalyticsBucket) GetUniqueVisitors() int64 {
+	if x != nil {
+		return x.UniqueVisitors
+	}
+	return 0
+}
+
+func (x *AnalyticsBucket) GetCampaigns() []*AnalyticsBreakdownEntry {
+	if x != nil {
+		return x.Campaigns
+	}
+	return nil
+}
+
+func (x *AnalyticsBucket) GetInactiveHits() int64 {
+	if x != nil {
+		return x.InactiveHits
+	}
+	return 0
+}
+
+func (x *AnalyticsBucket) GetVariants() []*AnalyticsBreakdownEntry {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+type GetLinkAnalyticsResponse struct {
+	state             protoimpl.MessageState     `protogen:"open.v1"`
+	LinkId            string                     `protobuf:"bytes,1,opt,name=link_id,json=linkId,proto3" json:"link_id,omitempty"`
+	Granularity       string                     `protobuf:"bytes,2,opt,name=granularity,proto3" json:"granularity,omitempty"`
+	Start             string                     `protobuf:"bytes,3,opt,name=start,proto3" json:"start,omitempty"`
+	End               string                     `protobuf:"bytes,4,opt,name=end,proto3" json:"end,omitempty"`
+	TotalClicks       int64                      `protobuf:"varint,5,opt,name=total_clicks,json=totalClicks,proto3" json:"total_clicks,omitempty"`
+	Buckets           []*AnalyticsBucket         `protobuf:"bytes,6,rep,name=buckets,proto3" json:"buckets,omitempty"`
+	Referrers         []*AnalyticsBreakdownEntry `protobuf:"bytes,7,rep,name=referrers,proto3" json:"referrers,omitempty"`
+	Devices           []*AnalyticsBreakdownEntry `protobuf:"bytes,8,rep,name=devices,proto3" json:"devices,omitempty"`
+	Countries         []*AnalyticsBreakdownEntry `protobuf:"bytes,9,rep,name=countries,proto3" json:"countries,omitempty"`
+	TotalBotClicks    int64                      `protobuf:"varint,10,opt,name=total_bot_clicks,json=totalBotClicks,proto3" json:"total_bot_clicks,omitempty"`
+	Bots              []*AnalyticsBreakdownEntry `protobuf:"bytes,11,rep,name=bots,proto3" json:"bots,omitempty"`
+	UniqueVisitors    int64                      `protobuf:"varint,12,opt,name=unique_visitors,json=uniqueVisitors,proto3" json:"unique_visitors,omitempty"`
+	Campaigns         []*AnalyticsBreakdownEntry `protobuf:"bytes,13,rep,name=campaigns,proto3" json:"campaigns,omitempty"`
+	TotalInactiveHits int64                      `protobuf:"varint,14,opt,name=total_inactive_hits,json=totalInactiveHits,proto3" json:"total_inactive_hits,omitempty"`
+	InactiveStatuses  []*AnalyticsBreakdownEntry `protobuf:"bytes,15,rep,name=inactive_statuses,json=inactiveStatuses,proto3" json:"inactive_statuses,omitempty"`
+	Variants          []*AnalyticsBreakdownEntry `protobuf:"bytes,16,rep,name=variants,proto3" json:"variants,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetLinkAnalyticsResponse) Reset() {
+	*x = GetLinkAnalyticsResponse{}
+	mi := &file_proto_links_read_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLinkAnalyticsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLinkAnalyticsResponse) ProtoMessage() {}
+
+func (x *GetLinkAnalyticsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_links_read_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLinkAnalyticsResponse.ProtoReflect.Descriptor instead.
+func (*GetLinkAnalyticsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_links_read_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetLinkAnalyticsResponse) GetLinkId() string {
+	if x != nil {
+		return x.LinkId
+	}
+	return ""
+}
+
+func (x *GetLinkAnalyticsResponse) GetGranularity() string {
+	if x != nil {
+		return x.Granularity
+	}
+	return ""
+}
+
+func (x *GetLinkAnalyticsResponse) GetStart() string {
+	if x != nil {
+		return x.Start
+	}
+	return ""
+}
+
+func (x *GetLinkAnalyticsResponse) GetEnd() string {
+	if x != nil {
+		return x.End
+	}
+	return ""
+}
+
+func (x *GetLinkAnalyticsResponse) GetTotalClicks() int64 {
+	if x != nil {
+		return x.TotalClicks
+	}
+	return 0
+}
+
+func (x *GetLinkAnalyticsResponse) GetBuckets() []*AnalyticsBucket {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+func (x *GetLinkAnalyticsResponse) GetReferrers() []*AnalyticsBreakdownEntry {
+	if x != nil {
+		return x.Referrers
+	}
+	return nil
+}
+
+func (x *GetLinkAnalyticsResponse) GetDevices() []*AnalyticsBreakdownEntry {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+func (x *GetLinkAnalyticsResponse) GetCountries() []*AnalyticsBreakdownEntry {
+	if x != nil {
+		return x.Countries
+	}
+	return nil
+}
+
+func (x *GetLinkAnalyticsResponse) GetTotalBotClicks() int64 {
+	if x != nil {
+		return x.TotalBotClicks
+	}
+	return 0
+}
+
+func (x *GetLinkAnalyticsResponse) GetBots() []*AnalyticsBreakdownEntry {
+	if x != nil {
+		return x.Bots
+	}
+	return nil
+}
+
+func (x *GetLinkAnalyticsResponse) GetUniqueVisitors() int64 {
+	if x != nil {
+		return x.UniqueVisitors
+	}
+	return 0
+}
+
+func (x *GetLinkAnalyticsResponse) GetCampaigns() []*AnalyticsBreakdownEntry {
+	if x != nil {
+		return x.Campaigns
+	}
+	return nil
+}
+
+func (x *GetLinkAnalyticsResponse) GetTotalInactiveHits() int64 {
+	if x != nil {
+		return x.TotalInactiveHits
+	}
+	return 0
+}
+
+func (x *GetLinkAnalyticsResponse) GetInactiveStatuses() []*AnalyticsBreakdownEntry {
+	if x != nil {
+		return x.InactiveStatuses
+	}
+	return nil
+}
+
+func (x *GetLinkAnalyticsResponse) GetVariants() []*AnalyticsBreakdownEntry {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+type WatchClicksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	LinkId        *string                `protobuf:"bytes,2,opt,name=link_id,json=linkId,proto3,oneof" json:"link_id,omitempty"`
+	IncludeBots   *bool                  `protobuf:"varint,3,opt,name=include_bots,json=includeBots,proto3,oneof" json:"include_bots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchClicksRequest) Reset() {
+	*x = WatchClicksRequest{}
+	mi := &file_proto_links_read_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchClicksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchClicksRequest) ProtoMessage() {}
+
+func (x *WatchClicksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_links_read_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchClicksRequest.ProtoReflect.Descriptor instead.
+func (*WatchClicksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_links_read_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *WatchClicksRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *WatchClicksRequest) GetLinkId() string {
+	if x != nil && x.LinkId != nil {
+		return *x.LinkId
+	}
+	return ""
+}
+
+func (x *WatchClicksRequest) GetIncludeBots() bool {
+	if x != nil && x.IncludeBots != nil {
+		return *x.IncludeBots
+	}
+	return false
+}
+
+type ClickNotification struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	EventId        string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	LinkId         string                 `protobuf:"bytes,2,opt,name=link_id,json=linkId,proto3" json:"link_id,omitempty"`
+	ClickedAt      string                 `protobuf:"bytes,3,opt,name=clicked_at,json=clickedAt,proto3" json:"clicked_at,omitempty"`
+	ReferrerDomain string                 `protobuf:"bytes,4,opt,name=referrer_domain,json=referrerDomain,proto3" json:"referrer_domain,omitempty"`
+	DeviceClass    string                 `protobuf:"bytes,5,opt,name=device_class,json=deviceClass,proto3" json:"device_class,omitempty"`
+	Country        string                 `protobuf:"bytes,6,opt,name=country,proto3" json:"country,omitempty"`
+	Region         string                 `protobuf:"bytes,7,opt,name=region,proto3" json:"region,omitempty"`
+	City           string                 `protobuf:"bytes,8,opt,name=city,proto3" json:"city,omitempty"`
+	BotCategory    *string                `protobuf:"bytes,9,opt,name=bot_category,json=botCategory,proto3,oneof" json:"bot_category,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ClickNotification) Reset() {
+	*x = ClickNotification{}
+	mi := &file_proto_links_read_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClickNotification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClickNotification) ProtoMessage() {}
+
+func (x *ClickNotification) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_links_read_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClickNotification.ProtoReflect.Descriptor instead.
+func (*ClickNotification) Descriptor() ([]byte, []int) {
+	return file_proto_links_read_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ClickNotification) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *ClickNotification) GetLinkId() string {
+	if x != nil {
+		return x.LinkId
+	}
+	return ""
+}
+
+func (x *ClickNotification) GetClickedAt() string {
+	if x != nil {
+		return x.ClickedAt
+	}
+	return ""
+}
+
+func (x *ClickNotification) GetReferrerDomain() string {
+	if x != nil {
+		return x.ReferrerDomain
+	}
+	return ""
+}
+
+func (x *ClickNotification) GetDeviceClass() string {
+	if x != nil {
+		return x.DeviceClass
+	}
+	return ""
+}
+
+func (x *ClickNotification) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *ClickNotification) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *ClickNotification) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *ClickNotification) GetBotCategory() string {
+	if x != nil && x.BotCategory != nil {
+		return *x.BotCategory
+	}
+	return ""
+}
+
+var File_proto_links_read_proto protoreflect.FileDescriptor
+
+const file_proto_links_read_proto_rawDesc = "" +
+	"\n" +
+	"\x16proto/links_read.proto\x12\n" +
 	"links_read\"-\n" +
 	"\x0eGetLinkRequest\x12\x1b\n" +
-	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\"\x9a\x02\n" +
+	"\tshort_url\x18\x01 \x01(\tR\bshortUrl\"E\n" +
+	"\x12GetLinkByIDRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vcustomer_id\x18\x02 \x01(\tR\n" +
+	"customerId\"\x82\a\n" +
 	"\x0fGetLinkResponse\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
 	"\foriginal_url\x18\x02 \x01(\tR\voriginalUrl\x12\x1b\n" +
@@ -328,8 +1356,50 @@ const file_proto_links_read_proto_rawDesc = "" +
 	"created_at\x18\x06 \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
 	"updated_at\x18\a \x01(\tR\tupdatedAt\x12,\n" +
-	"\x0fexpiration_date\x18\b \x01(\tH\x00R\x0eexpirationDate\x88\x01\x01B\x12\n" +
-	"\x10_expiration_date\"\xf0\x02\n" +
+	"\x0fexpiration_date\x18\b \x01(\tH\x00R\x0eexpirationDate\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"bot_clicks\x18\t \x01(\x05R\tbotClicks\x12'\n" +
+	"\x0funique_visitors\x18\n" +
+	" \x01(\x03R\x0euniqueVisitors\x12\x16\n" +
+	"\x06status\x18\v \x01(\tR\x06status\x12\x1b\n" +
+	"\tslug_type\x18\f \x01(\tR\bslugType\x12\x14\n" +
+	"\x05title\x18\r \x01(\tR\x05title\x12\x12\n" +
+	"\x04tags\x18\x0e \x03(\tR\x04tags\x12\x16\n" +
+	"\x06domain\x18\x0f \x01(\tR\x06domain\x12*\n" +
+	"\x03utm\x18\x10 \x01(\v2\x13.links_read.LinkUTMH\x01R\x03utm\x88\x01\x01\x12-\n" +
+	"\x12password_protected\x18\x11 \x01(\bR\x11passwordProtected\x12\"\n" +
+	"\n" +
+	"max_clicks\x18\x12 \x01(\x05H\x02R\tmaxClicks\x88\x01\x01\x12&\n" +
+	"\factivates_at\x18\x13 \x01(\tH\x03R\vactivatesAt\x88\x01\x01\x12!\n" +
+	"\ffallback_url\x18\x14 \x01(\tR\vfallbackUrl\x12#\n" +
+	"\rfallback_mode\x18\x15 \x01(\tR\ffallbackMode\x122\n" +
+	"\x05rules\x18\x16 \x03(\v2\x1c.links_read.LinkRedirectRuleR\x05rules\x123\n" +
+	"\bvariants\x18\x17 \x03(\v2\x17.links_read.LinkVariantR\bvariants\x12'\n" +
+	"\x0fsticky_variants\x18\x18 \x01(\bR\x0estickyVariantsB\x12\n" +
+	"\x10_expiration_dateB\x06\n" +
+	"\x04_utmB\r\n" +
+	"\v_max_clicksB\x0f\n" +
+	"\r_activates_at\"\x83\x01\n" +
+	"\aLinkUTM\x12\x16\n" +
+	"\x06source\x18\x01 \x01(\tR\x06source\x12\x16\n" +
+	"\x06medium\x18\x02 \x01(\tR\x06medium\x12\x1a\n" +
+	"\bcampaign\x18\x03 \x01(\tR\bcampaign\x12\x12\n" +
+	"\x04term\x18\x04 \x01(\tR\x04term\x12\x18\n" +
+	"\acontent\x18\x05 \x01(\tR\acontent\"\xf4\x01\n" +
+	"\x10LinkRedirectRule\x12 \n" +
+	"\vdestination\x18\x01 \x01(\tR\vdestination\x12\x18\n" +
+	"\adevices\x18\x02 \x03(\tR\adevices\x12\x1c\n" +
+	"\tcountries\x18\x03 \x03(\tR\tcountries\x12\x1c\n" +
+	"\tlanguages\x18\x04 \x03(\tR\tlanguages\x12\x12\n" +
+	"\x04days\x18\x05 \x03(\tR\x04days\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\x06 \x01(\tR\tstartTime\x12\x19\n" +
+	"\bend_time\x18\a \x01(\tR\aendTime\x12\x1a\n" +
+	"\btimezone\x18\b \x01(\tR\btimezone\"G\n" +
+	"\vLinkVariant\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x16\n" +
+	"\x06weight\x18\x03 \x01(\x05R\x06weight\"\x98\x03\n" +
 	"\x17GetCustomerLinksRequest\x12\x1f\n" +
 	"\vcustomer_id\x18\x01 \x01(\tR\n" +
 	"customerId\x12\x19\n" +
@@ -339,7 +1409,8 @@ const file_proto_links_read_proto_rawDesc = "" +
 	"\x06status\x18\x05 \x01(\tH\x03R\x06status\x88\x01\x01\x12 \n" +
 	"\tslug_type\x18\x06 \x01(\tH\x04R\bslugType\x88\x01\x01\x12\x1c\n" +
 	"\asort_by\x18\a \x01(\tH\x05R\x06sortBy\x88\x01\x01\x12*\n" +
-	"\x0esort_direction\x18\b \x01(\tH\x06R\rsortDirection\x88\x01\x01B\b\n" +
+	"\x0esort_direction\x18\b \x01(\tH\x06R\rsortDirection\x88\x01\x01\x12\x1b\n" +
+	"\x06cursor\x18\t \x01(\tH\aR\x06cursor\x88\x01\x01B\b\n" +
 	"\x06_limitB\t\n" +
 	"\a_offsetB\t\n" +
 	"\a_searchB\t\n" +
@@ -348,12 +1419,87 @@ const file_proto_links_read_proto_rawDesc = "" +
 	"_slug_typeB\n" +
 	"\n" +
 	"\b_sort_byB\x11\n" +
-	"\x0f_sort_direction\"M\n" +
+	"\x0f_sort_directionB\t\n" +
+	"\a_cursor\"\x83\x01\n" +
 	"\x18GetCustomerLinksResponse\x121\n" +
-	"\x05links\x18\x01 \x03(\v2\x1b.links_read.GetLinkResponseR\x05links2\xb9\x01\n" +
+	"\x05links\x18\x01 \x03(\v2\x1b.links_read.GetLinkResponseR\x05links\x12$\n" +
+	"\vnext_cursor\x18\x02 \x01(\tH\x00R\n" +
+	"nextCursor\x88\x01\x01B\x0e\n" +
+	"\f_next_cursor\"\x87\x02\n" +
+	"\x17GetLinkAnalyticsRequest\x12\x17\n" +
+	"\alink_id\x18\x01 \x01(\tR\x06linkId\x12\x1f\n" +
+	"\vcustomer_id\x18\x02 \x01(\tR\n" +
+	"customerId\x12%\n" +
+	"\vgranularity\x18\x03 \x01(\tH\x00R\vgranularity\x88\x01\x01\x12\x19\n" +
+	"\x05start\x18\x04 \x01(\tH\x01R\x05start\x88\x01\x01\x12\x15\n" +
+	"\x03end\x18\x05 \x01(\tH\x02R\x03end\x88\x01\x01\x12&\n" +
+	"\finclude_bots\x18\x06 \x01(\bH\x03R\vincludeBots\x88\x01\x01B\x0e\n" +
+	"\f_granularityB\b\n" +
+	"\x06_startB\x06\n" +
+	"\x04_endB\x0f\n" +
+	"\r_include_bots\"C\n" +
+	"\x17AnalyticsBreakdownEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x16\n" +
+	"\x06clicks\x18\x02 \x01(\x03R\x06clicks\"\xae\x04\n" +
+	"\x0fAnalyticsBucket\x12\x14\n" +
+	"\x05start\x18\x01 \x01(\tR\x05start\x12\x16\n" +
+	"\x06clicks\x18\x02 \x01(\x03R\x06clicks\x12A\n" +
+	"\treferrers\x18\x03 \x03(\v2#.links_read.AnalyticsBreakdownEntryR\treferrers\x12=\n" +
+	"\adevices\x18\x04 \x03(\v2#.links_read.AnalyticsBreakdownEntryR\adevices\x12A\n" +
+	"\tcountries\x18\x05 \x03(\v2#.links_read.AnalyticsBreakdownEntryR\tcountries\x12\x1d\n" +
+	"\n" +
+	"bot_clicks\x18\x06 \x01(\x03R\tbotClicks\x127\n" +
+	"\x04bots\x18\a \x03(\v2#.links_read.AnalyticsBreakdownEntryR\x04bots\x12'\n" +
+	"\x0funique_visitors\x18\b \x01(\x03R\x0euniqueVisitors\x12A\n" +
+	"\tcampaigns\x18\t \x03(\v2#.links_read.AnalyticsBreakdownEntryR\tcampaigns\x12#\n" +
+	"\rinactive_hits\x18\n" +
+	" \x01(\x03R\finactiveHits\x12?\n" +
+	"\bvariants\x18\v \x03(\v2#.links_read.AnalyticsBreakdownEntryR\bvariants\"\xae\x06\n" +
+	"\x18GetLinkAnalyticsResponse\x12\x17\n" +
+	"\alink_id\x18\x01 \x01(\tR\x06linkId\x12 \n" +
+	"\vgranularity\x18\x02 \x01(\tR\vgranularity\x12\x14\n" +
+	"\x05start\x18\x03 \x01(\tR\x05start\x12\x10\n" +
+	"\x03end\x18\x04 \x01(\tR\x03end\x12!\n" +
+	"\ftotal_clicks\x18\x05 \x01(\x03R\vtotalClicks\x125\n" +
+	"\abuckets\x18\x06 \x03(\v2\x1b.links_read.AnalyticsBucketR\abuckets\x12A\n" +
+	"\treferrers\x18\a \x03(\v2#.links_read.AnalyticsBreakdownEntryR\treferrers\x12=\n" +
+	"\adevices\x18\b \x03(\v2#.links_read.AnalyticsBreakdownEntryR\adevices\x12A\n" +
+	"\tcountries\x18\t \x03(\v2#.links_read.AnalyticsBreakdownEntryR\tcountries\x12(\n" +
+	"\x10total_bot_clicks\x18\n" +
+	" \x01(\x03R\x0etotalBotClicks\x127\n" +
+	"\x04bots\x18\v \x03(\v2#.links_read.AnalyticsBreakdownEntryR\x04bots\x12'\n" +
+	"\x0funique_visitors\x18\f \x01(\x03R\x0euniqueVisitors\x12A\n" +
+	"\tcampaigns\x18\r \x03(\v2#.links_read.AnalyticsBreakdownEntryR\tcampaigns\x12.\n" +
+	"\x13total_inactive_hits\x18\x0e \x01(\x03R\x11totalInactiveHits\x12P\n" +
+	"\x11inactive_statuses\x18\x0f \x03(\v2#.links_read.AnalyticsBreakdownEntryR\x10inactiveStatuses\x12?\n" +
+	"\bvariants\x18\x10 \x03(\v2#.links_read.AnalyticsBreakdownEntryR\bvariants\"\x98\x01\n" +
+	"\x12WatchClicksRequest\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\x12\x1c\n" +
+	"\alink_id\x18\x02 \x01(\tH\x00R\x06linkId\x88\x01\x01\x12&\n" +
+	"\finclude_bots\x18\x03 \x01(\bH\x01R\vincludeBots\x88\x01\x01B\n" +
+	"\n" +
+	"\b_link_idB\x0f\n" +
+	"\r_include_bots\"\xb1\x02\n" +
+	"\x11ClickNotification\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12\x17\n" +
+	"\alink_id\x18\x02 \x01(\tR\x06linkId\x12\x1d\n" +
+	"\n" +
+	"clicked_at\x18\x03 \x01(\tR\tclickedAt\x12'\n" +
+	"\x0freferrer_domain\x18\x04 \x01(\tR\x0ereferrerDomain\x12!\n" +
+	"\fdevice_class\x18\x05 \x01(\tR\vdeviceClass\x12\x18\n" +
+	"\acountry\x18\x06 \x01(\tR\acountry\x12\x16\n" +
+	"\x06region\x18\a \x01(\tR\x06region\x12\x12\n" +
+	"\x04city\x18\b \x01(\tR\x04city\x12&\n" +
+	"\fbot_category\x18\t \x01(\tH\x00R\vbotCategory\x88\x01\x01B\x0f\n" +
+	"\r_bot_category2\x97\x04\n" +
 	"\x10LinksServiceRead\x12D\n" +
 	"\aGetLink\x12\x1a.links_read.GetLinkRequest\x1a\x1b.links_read.GetLinkResponse\"\x00\x12_\n" +
-	"\x10GetCustomerLinks\x12#.links_read.GetCustomerLinksRequest\x1a$.links_read.GetCustomerLinksResponse\"\x00B\x15Z\x13links-service/protob\x06proto3"
+	"\x10GetCustomerLinks\x12#.links_read.GetCustomerLinksRequest\x1a$.links_read.GetCustomerLinksResponse\"\x00\x12_\n" +
+	"\x10GetLinkAnalytics\x12#.links_read.GetLinkAnalyticsRequest\x1a$.links_read.GetLinkAnalyticsResponse\"\x00\x12P\n" +
+	"\vWatchClicks\x12\x1e.links_read.WatchClicksRequest\x1a\x1d.links_read.ClickNotification\"\x000\x01\x12[\n" +
+	"\x13ExportCustomerLinks\x12#.links_read.GetCustomerLinksRequest\x1a\x1b.links_read.GetLinkResponse\"\x000\x01\x12L\n" +
+	"\vGetLinkByID\x12\x1e.links_read.GetLinkByIDRequest\x1a\x1b.links_read.GetLinkResponse\"\x00B\x15Z\x13links-service/protob\x06proto3"
 
 var (
 	file_proto_links_read_proto_rawDescOnce sync.Once
@@ -367,24 +1513,59 @@ func file_proto_links_read_proto_rawDescGZIP() []byte {
 	return file_proto_links_read_proto_rawDescData
 }
 
-var file_proto_links_read_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_proto_links_read_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_proto_links_read_proto_goTypes = []any{
 	(*GetLinkRequest)(nil),           // 0: links_read.GetLinkRequest
-	(*GetLinkResponse)(nil),          // 1: links_read.GetLinkResponse
-	(*GetCustomerLinksRequest)(nil),  // 2: links_read.GetCustomerLinksRequest
-	(*GetCustomerLinksResponse)(nil), // 3: links_read.GetCustomerLinksResponse
+	(*GetLinkByIDRequest)(nil),       // 1: links_read.GetLinkByIDRequest
+	(*GetLinkResponse)(nil),          // 2: links_read.GetLinkResponse
+	(*LinkUTM)(nil),                  // 3: links_read.LinkUTM
+	(*LinkRedirectRule)(nil),         // 4: links_read.LinkRedirectRule
+	(*LinkVariant)(nil),              // 5: links_read.LinkVariant
+	(*GetCustomerLinksRequest)(nil),  // 6: links_read.GetCustomerLinksRequest
+	(*GetCustomerLinksResponse)(nil), // 7: links_read.GetCustomerLinksResponse
+	(*GetLinkAnalyticsRequest)(nil),  // 8: links_read.GetLinkAnalyticsRequest
+	(*AnalyticsBreakdownEntry)(nil),  // 9: links_read.AnalyticsBreakdownEntry
+	(*AnalyticsBucket)(nil),          // 10: links_read.AnalyticsBucket
+	(*GetLinkAnalyticsResponse)(nil), // 11: links_read.GetLinkAnalyticsResponse
+	(*WatchClicksRequest)(nil),       // 12: links_read.WatchClicksRequest
+	(*ClickNotification)(nil),        // 13: links_read.ClickNotification
 }
 var file_proto_links_read_proto_depIdxs = []int32{
-	1, // 0: links_read.GetCustomerLinksResponse.links:type_name -> links_read.GetLinkResponse
-	0, // 1: links_read.LinksServiceRead.GetLink:input_type -> links_read.GetLinkRequest
-	2, // 2: links_read.LinksServiceRead.GetCustomerLinks:input_type -> links_read.GetCustomerLinksRequest
-	1, // 3: links_read.LinksServiceRead.GetLink:output_type -> links_read.GetLinkResponse
-	3, // 4: links_read.LinksServiceRead.GetCustomerLinks:output_type -> links_read.GetCustomerLinksResponse
-	3, // [3:5] is the sub-list for method output_type
-	1, // [1:3] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	3,  // 0: links_read.GetLinkResponse.utm:type_name -> links_read.LinkUTM
+	4,  // 1: links_read.GetLinkResponse.rules:type_name -> links_read.LinkRedirectRule
+	5,  // 2: links_read.GetLinkResponse.variants:type_name -> links_read.LinkVariant
+	2,  // 3: links_read.GetCustomerLinksResponse.links:type_name -> links_read.GetLinkResponse
+	9,  // 4: links_read.AnalyticsBucket.referrers:type_name -> links_read.AnalyticsBreakdownEntry
+	9,  // 5: links_read.AnalyticsBucket.devices:type_name -> links_read.AnalyticsBreakdownEntry
+	9,  // 6: links_read.AnalyticsBucket.countries:type_name -> links_read.AnalyticsBreakdownEntry
+	9,  // 7: links_read.AnalyticsBucket.bots:type_name -> links_read.AnalyticsBreakdownEntry
+	9,  // 8: links_read.AnalyticsBucket.campaigns:type_name -> links_read.AnalyticsBreakdownEntry
+	9,  // 9: links_read.AnalyticsBucket.variants:type_name -> links_read.AnalyticsBreakdownEntry
+	10, // 10: links_read.GetLinkAnalyticsResponse.buckets:type_name -> links_read.AnalyticsBucket
+	9,  // 11: links_read.GetLinkAnalyticsResponse.referrers:type_name -> links_read.AnalyticsBreakdownEntry
+	9,  // 12: links_read.GetLinkAnalyticsResponse.devices:type_name -> links_read.AnalyticsBreakdownEntry
+	9,  // 13: links_read.GetLinkAnalyticsResponse.countries:type_name -> links_read.AnalyticsBreakdownEntry
+	9,  // 14: links_read.GetLinkAnalyticsResponse.bots:type_name -> links_read.AnalyticsBreakdownEntry
+	9,  // 15: links_read.GetLinkAnalyticsResponse.campaigns:type_name -> links_read.AnalyticsBreakdownEntry
+	9,  // 16: links_read.GetLinkAnalyticsResponse.inactive_statuses:type_name -> links_read.AnalyticsBreakdownEntry
+	9,  // 17: links_read.GetLinkAnalyticsResponse.variants:type_name -> links_read.AnalyticsBreakdownEntry
+	0,  // 18: links_read.LinksServiceRead.GetLink:input_type -> links_read.GetLinkRequest
+	6,  // 19: links_read.LinksServiceRead.GetCustomerLinks:input_type -> links_read.GetCustomerLinksRequest
+	8,  // 20: links_read.LinksServiceRead.GetLinkAnalytics:input_type -> links_read.GetLinkAnalyticsRequest
+	12, // 21: links_read.LinksServiceRead.WatchClicks:input_type -> links_read.WatchClicksRequest
+	6,  // 22: links_read.LinksServiceRead.ExportCustomerLinks:input_type -> links_read.GetCustomerLinksRequest
+	1,  // 23: links_read.LinksServiceRead.GetLinkByID:input_type -> links_read.GetLinkByIDRequest
+	2,  // 24: links_read.LinksServiceRead.GetLink:output_type -> links_read.GetLinkResponse
+	7,  // 25: links_read.LinksServiceRead.GetCustomerLinks:output_type -> links_read.GetCustomerLinksResponse
+	11, // 26: links_read.LinksServiceRead.GetLinkAnalytics:output_type -> links_read.GetLinkAnalyticsResponse
+	13, // 27: links_read.LinksServiceRead.WatchClicks:output_type -> links_read.ClickNotification
+	2,  // 28: links_read.LinksServiceRead.ExportCustomerLinks:output_type -> links_read.GetLinkResponse
+	2,  // 29: links_read.LinksServiceRead.GetLinkByID:output_type -> links_read.GetLinkResponse
+	24, // [24:30] is the sub-list for method output_type
+	18, // [18:24] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
 }
 
 func init() { file_proto_links_read_proto_init() }
@@ -392,15 +1573,19 @@ func file_proto_links_read_proto_init() {
 	if File_proto_links_read_proto != nil {
 		return
 	}
-	file_proto_links_read_proto_msgTypes[1].OneofWrappers = []any{}
 	file_proto_links_read_proto_msgTypes[2].OneofWrappers = []any{}
+	file_proto_links_read_proto_msgTypes[6].OneofWrappers = []any{}
+	file_proto_links_read_proto_msgTypes[7].OneofWrappers = []any{}
+	file_proto_links_read_proto_msgTypes[8].OneofWrappers = []any{}
+	file_proto_links_read_proto_msgTypes[12].OneofWrappers = []any{}
+	file_proto_links_read_proto_msgTypes[13].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_links_read_proto_rawDesc), len(file_proto_links_read_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
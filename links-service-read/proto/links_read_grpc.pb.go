@@ -19,8 +19,12 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	LinksServiceRead_GetLink_FullMethodName          = "/links_read.LinksServiceRead/GetLink"
-	LinksServiceRead_GetCustomerLinks_FullMethodName = "/links_read.LinksServiceRead/GetCustomerLinks"
+	LinksServiceRead_GetLink_FullMethodName             = "/links_read.LinksServiceRead/GetLink"
+	LinksServiceRead_GetCustomerLinks_FullMethodName    = "/links_read.LinksServiceRead/GetCustomerLinks"
+	LinksServiceRead_GetLinkAnalytics_FullMethodName    = "/links_read.LinksServiceRead/GetLinkAnalytics"
+	LinksServiceRead_WatchClicks_FullMethodName         = "/links_read.LinksServiceRead/WatchClicks"
+	LinksServiceRead_ExportCustomerLinks_FullMethodName = "/links_read.LinksServiceRead/ExportCustomerLinks"
+	LinksServiceRead_GetLinkByID_FullMethodName         = "/links_read.LinksServiceRead/GetLinkByID"
 )
 
 // LinksServiceReadClient is the client API for LinksServiceRead service.
@@ -29,6 +33,10 @@ const (
 type LinksServiceReadClient interface {
 	GetLink(ctx context.Context, in *GetLinkRequest, opts ...grpc.CallOption) (*GetLinkResponse, error)
 	GetCustomerLinks(ctx context.Context, in *GetCustomerLinksRequest, opts ...grpc.CallOption) (*GetCustomerLinksResponse, error)
+	GetLinkAnalytics(ctx context.Context, in *GetLinkAnalyticsRequest, opts ...grpc.CallOption) (*GetLinkAnalyticsResponse, error)
+	WatchClicks(ctx context.Context, in *WatchClicksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ClickNotification], error)
+	ExportCustomerLinks(ctx context.Context, in *GetCustomerLinksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GetLinkResponse], error)
+	GetLinkByID(ctx context.Context, in *GetLinkByIDRequest, opts ...grpc.CallOption) (*GetLinkResponse, error)
 }
 
 type linksServiceReadClient struct {
@@ -59,12 +67,74 @@ func (c *linksServiceReadClient) GetCustomerLinks(ctx context.Context, in *GetCu
 	return out, nil
 }
 
+func (c *linksServiceReadClient) GetLinkAnalytics(ctx context.Context, in *GetLinkAnalyticsRequest, opts ...grpc.CallOption) (*GetLinkAnalyticsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLinkAnalyticsResponse)
+	err := c.cc.Invoke(ctx, LinksServiceRead_GetLinkAnalytics_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *linksServiceReadClient) WatchClicks(ctx context.Context, in *WatchClicksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ClickNotification], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &LinksServiceRead_ServiceDesc.Streams[0], LinksServiceRead_WatchClicks_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchClicksRequest, ClickNotification]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LinksServiceRead_WatchClicksClient = grpc.ServerStreamingClient[ClickNotification]
+
+func (c *linksServiceReadClient) ExportCustomerLinks(ctx context.Context, in *GetCustomerLinksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GetLinkResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &LinksServiceRead_ServiceDesc.Streams[1], LinksServiceRead_ExportCustomerLinks_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetCustomerLinksRequest, GetLinkResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LinksServiceRead_ExportCustomerLinksClient = grpc.ServerStreamingClient[GetLinkResponse]
+
+func (c *linksServiceReadClient) GetLinkByID(ctx context.Context, in *GetLinkByIDRequest, opts ...grpc.CallOption) (*GetLinkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLinkResponse)
+	err := c.cc.Invoke(ctx, LinksServiceRead_GetLinkByID_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // LinksServiceReadServer is the server API for LinksServiceRead service.
 // All implementations must embed UnimplementedLinksServiceReadServer
 // for forward compatibility.
 type LinksServiceReadServer interface {
 	GetLink(context.Context, *GetLinkRequest) (*GetLinkResponse, error)
 	GetCustomerLinks(context.Context, *GetCustomerLinksRequest) (*GetCustomerLinksResponse, error)
+	GetLinkAnalytics(context.Context, *GetLinkAnalyticsRequest) (*GetLinkAnalyticsResponse, error)
+	WatchClicks(*WatchClicksRequest, grpc.ServerStreamingServer[ClickNotification]) error
+	ExportCustomerLinks(*GetCustomerLinksRequest, grpc.ServerStreamingServer[GetLinkResponse]) error
+	GetLinkByID(context.Context, *GetLinkByIDRequest) (*GetLinkResponse, error)
 	mustEmbedUnimplementedLinksServiceReadServer()
 }
 
@@ -81,6 +151,18 @@ func (UnimplementedLinksServiceReadServer) GetLink(context.Context, *GetLinkRequ
 func (UnimplementedLinksServiceReadServer) GetCustomerLinks(context.Context, *GetCustomerLinksRequest) (*GetCustomerLinksResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetCustomerLinks not implemented")
 }
+func (UnimplementedLinksServiceReadServer) GetLinkAnalytics(context.Context, *GetLinkAnalyticsRequest) (*GetLinkAnalyticsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLinkAnalytics not implemented")
+}
+func (UnimplementedLinksServiceReadServer) WatchClicks(*WatchClicksRequest, grpc.ServerStreamingServer[ClickNotification]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchClicks not implemented")
+}
+func (UnimplementedLinksServiceReadServer) ExportCustomerLinks(*GetCustomerLinksRequest, grpc.ServerStreamingServer[GetLinkResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportCustomerLinks not implemented")
+}
+func (UnimplementedLinksServiceReadServer) GetLinkByID(context.Context, *GetLinkByIDRequest) (*GetLinkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLinkByID not implemented")
+}
 func (UnimplementedLinksServiceReadServer) mustEmbedUnimplementedLinksServiceReadServer() {}
 func (UnimplementedLinksServiceReadServer) testEmbeddedByValue()                          {}
 
@@ -138,6 +220,64 @@ func _LinksServiceRead_GetCustomerLinks_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LinksServiceRead_GetLinkAnalytics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLinkAnalyticsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LinksServiceReadServer).GetLinkAnalytics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LinksServiceRead_GetLinkAnalytics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LinksServiceReadServer).GetLinkAnalytics(ctx, req.(*GetLinkAnalyticsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LinksServiceRead_GetLinkByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLinkByIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LinksServiceReadServer).GetLinkByID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LinksServiceRead_GetLinkByID_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LinksServiceReadServer).GetLinkByID(ctx, req.(*GetLinkByIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LinksServiceRead_WatchClicks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchClicksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LinksServiceReadServer).WatchClicks(m, &grpc.GenericServerStream[WatchClicksRequest, ClickNotification]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LinksServiceRead_WatchClicksServer = grpc.ServerStreamingServer[ClickNotification]
+
+func _LinksServiceRead_ExportCustomerLinks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetCustomerLinksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LinksServiceReadServer).ExportCustomerLinks(m, &grpc.GenericServerStream[GetCustomerLinksRequest, GetLinkResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LinksServiceRead_ExportCustomerLinksServer = grpc.ServerStreamingServer[GetLinkResponse]
+
 // LinksServiceRead_ServiceDesc is the grpc.ServiceDesc for LinksServiceRead service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -153,7 +293,26 @@ var LinksServiceRead_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetCustomerLinks",
 			Handler:    _LinksServiceRead_GetCustomerLinks_Handler,
 		},
+		{
+			MethodName: "GetLinkAnalytics",
+			Handler:    _LinksServiceRead_GetLinkAnalytics_Handler,
+		},
+		{
+			MethodName: "GetLinkByID",
+			Handler:    _LinksServiceRead_GetLinkByID_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchClicks",
+			Handler:       _LinksServiceRead_WatchClicks_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportCustomerLinks",
+			Handler:       _LinksServiceRead_ExportCustomerLinks_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/links_read.proto",
 }
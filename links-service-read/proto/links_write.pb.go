@@ -0,0 +1,3075 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.28.3
+// source: proto/links_write.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type UTMParams struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Source        string                 `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Medium        string                 `protobuf:"bytes,2,opt,name=medium,proto3" json:"medium,omitempty"`
+	Campaign      string                 `protobuf:"bytes,3,opt,name=campaign,proto3" json:"campaign,omitempty"`
+	Term          string                 `protobuf:"bytes,4,opt,name=term,proto3" json:"term,omitempty"`
+	Content       string                 `protobuf:"bytes,5,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UTMParams) Reset() {
+	*x = UTMParams{}
+	mi := &file_proto_links_write_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UTMParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UTMParams) ProtoMessage() {}
+
+func (x *UTMParams) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_links_write_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UTMParams.ProtoReflect.Descriptor instead.
+func (*UTMParams) Descriptor() ([]byte, []int) {
+	return file_proto_links_write_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *UTMParams) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *UTMParams) GetMedium() string {
+	if x != nil {
+		return x.Medium
+	}
+	return ""
+}
+
+func (x *UTMParams) GetCampaign() string {
+	if x != nil {
+		return x.Campaign
+	}
+	return ""
+}
+
+func (x *UTMParams) GetTerm() string {
+	if x != nil {
+		return x.Term
+	}
+	return ""
+}
+
+func (x *UTMParams) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+type RedirectRule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Destination   string                 `protobuf:"bytes,1,opt,name=destination,proto3" json:"destination,omitempty"`
+	Devices       []string               `protobuf:"bytes,2,rep,name=devices,proto3" json:"devices,omitempty"`
+	Countries     []string               `protobuf:"bytes,3,rep,name=countries,proto3" json:"countries,omitempty"`
+	Languages     []string               `protobuf:"bytes,4,rep,name=languages,proto3" json:"languages,omitempty"`
+	Days          []string               `protobuf:"bytes,5,rep,name=days,proto3" json:"days,omitempty"`
+	StartTime     string                 `protobuf:"bytes,6,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime       string                 `protobuf:"bytes,7,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Timezone      string                 `protobuf:"bytes,8,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RedirectRule) Reset() {
+	*x = RedirectRule{}
+	mi := &file_proto_links_write_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedirectRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedirectRule) ProtoMessage() {}
+
+func (x *RedirectRule) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_links_write_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedirectRule.ProtoReflect.Descriptor instead.
+func (*RedirectRule) Descriptor() ([]byte, []int) {
+	return file_proto_links_write_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RedirectRule) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+func (x *RedirectRule) GetDevices() []string {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+func (x *RedirectRule) GetCountries() []string {
+	if x != nil {
+		return x.Countries
+	}
+	return nil
+}
+
+func (x *RedirectRule) GetLanguages() []string {
+	if x != nil {
+		return x.Languages
+	}
+	return nil
+}
+
+func (x *RedirectRule) GetDays() []string {
+	if x != nil {
+		return x.Days
+	}
+	return nil
+}
+
+func (x *RedirectRule) GetStartTime() string {
+	if x != nil {
+		return x.StartTime
+	}
+	return ""
+}
+
+func (x *RedirectRule) GetEndTime() string {
+	if x != nil {
+		return x.EndTime
+	}
+	return ""
+}
+
+func (x *RedirectRule) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+type RedirectRules struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rules         []*RedirectRule        `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RedirectRules) Reset() {
+	*x = RedirectRules{}
+	mi := &file_proto_links_write_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedirectRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedirectRules) ProtoMessage() {}
+
+func (x *RedirectRules) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_links_write_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedirectRules.ProtoReflect.Descriptor instead.
+func (*RedirectRules) Descriptor() ([]byte, []int) {
+	return file_proto_links_write_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RedirectRules) GetRules() []*RedirectRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+type DestinationVariant struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	Weight        int32                  `protobuf:"varint,3,opt,name=weight,proto3" json:"weight,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DestinationVariant) Reset() {
+	*x = DestinationVariant{}
+	mi := &file_proto_links_write_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DestinationVariant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DestinationVariant) ProtoMessage() {}
+
+func (x *DestinationVariant) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_links_write_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DestinationVariant.ProtoReflect.Descriptor instead.
+func (*DestinationVariant) Descriptor() ([]byte, []int) {
+	return file_proto_links_write_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DestinationVariant) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DestinationVariant) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *DestinationVariant) GetWeight() int32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+type DestinationVariants struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Variants      []*DestinationVariant  `protobuf:"bytes,1,rep,name=variants,proto3" json:"variants,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DestinationVariants) Reset() {
+	*x = DestinationVariants{}
+	mi := &file_proto_links_write_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DestinationVariants) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DestinationVariants) ProtoMessage() {}
+
+func (x *DestinationVariants) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_links_write_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DestinationVariants.ProtoReflect.Descriptor instead.
+func (*DestinationVariants) Descriptor() ([]byte, []int) {
+	return file_proto_links_write_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DestinationVariants) GetVariants() []*DestinationVariant {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+type CreateLinkRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OriginalUrl    string                 `protobuf:"bytes,1,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`
+	CustomSlug     string                 `protobuf:"bytes,2,opt,name=custom_slug,json=customSlug,proto3" json:"custom_slug,omitempty"`
+	CustomerId     string                 `protobuf:"bytes,3,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	ExpirationDate *string                `protobuf:"bytes,4,opt,name=expiration_date,json=expirationDate,proto3,oneof" json:"expiration_date,omitempty"`
+	Title          string                 `protobuf:"bytes,5,opt,name=title,proto3" json:"title,omitempty"`
+	Tags           []string               `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
+	Domain         string                 `protobuf:"bytes,7,opt,name=domain,proto3" json:"domain,omitempty"`
+	Utm            *UTMParams             `protobuf:"bytes,8,opt,name=utm,proto3,oneof" json:"utm,omitempty"`
+	UtmTemplateId  string                 `protobuf:"bytes,9,opt,name=utm_template_id,json=utmTemplateId,proto3" json:"utm_template_id,omitempty"`
+	Password       string                 `protobuf:"bytes,10,opt,name=password,proto3" json:"password,omitempty"`
+	MaxClicks      *int32                 `protobuf:"varint,11,opt,name=max_clicks,json=maxClicks,proto3,oneof" json:"max_clicks,omitempty"`
+	OneTime        bool                   `protobuf:"varint,12,opt,name=one_time,json=oneTime,proto3" json:"one_time,omitempty"`
+	ActivatesAt    *string                `protobuf:"bytes,13,opt,name=activates_at,json=activatesAt,proto3,oneof" json:"activates_at,omitempty"`
+	FallbackUrl    string                 `protobuf:"bytes,14,opt,name=fallback_url,json=fallbackUrl,proto3" json:"fallback_url,omitempty"`
+	Rules          []*RedirectRule        `protobuf:"bytes,15,rep,name=rules,proto3" json:"rules,omitempty"`
+	Variants       []*DestinationVariant  `protobuf:"bytes,16,rep,name=variants,proto3" json:"variants,omitempty"`
+	StickyVariants bool                   `protobuf:"varint,17,opt,name=sticky_variants,json=stickyVariants,proto3" json:"sticky_variants,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateLinkRequest) Reset() {
+	*x = CreateLinkRequest{}
+	mi := &file_proto_links_write_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateLinkRequest) ProtoMessage() {}
+
+func (x *CreateLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_links_write_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateLinkRequest.ProtoReflect.Descriptor instead.
+func (*CreateLinkRequest) Descriptor() ([]byte, []int) {
+	return file_proto_links_write_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateLinkRequest) GetOriginalUrl() string {
+	if x != nil {
+		return x.OriginalUrl
+	}
+	return ""
+}
+
+func (x *CreateLinkRequest) GetCustomSlug() string {
+	if x != nil {
+		return x.CustomSlug
+	}
+	return ""
+}
+
+func (x *CreateLinkRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *CreateLinkRequest) GetExpirationDate() string {
+	if x != nil && x.ExpirationDate != nil {
+		return *x.ExpirationDate
+	}
+	return ""
+}
+
+func (x *CreateLinkRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateLinkRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *CreateLinkRequest) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *CreateLinkRequest) GetUtm() *UTMParams {
+	if x != nil {
+		return x.Utm
+	}
+	return nil
+}
+
+func (x *CreateLinkRequest) GetUtmTemplateId() string {
+	if x != nil {
+		return x.UtmTemplateId
+	}
+	return ""
+}
+
+func (x *CreateLinkRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *CreateLinkRequest) GetMaxClicks() int32 {
+	if x != nil && x.MaxClicks != nil {
+		return *x.MaxClicks
+	}
+	return 0
+}
+
+func (x *CreateLinkRequest) GetOneTime() bool {
+	if x != nil {
+		return x.OneTime
+	}
+	return false
+}
+
+func (x *CreateLinkRequest) GetActivatesAt() string {
+	if x != nil && x.ActivatesAt != nil {
+		return *x.ActivatesAt
+	}
+	return ""
+}
+
+func (x *CreateLinkRequest) GetFallbackUrl() string {
+	if x != nil {
+		return x.FallbackUrl
+	}
+	return ""
+}
+
+func (x *CreateLinkRequest) GetRules() []*RedirectRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *CreateLinkRequest) GetVariants() []*DestinationVariant {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+func (x *CreateLinkRequest) GetStickyVariants() bool {
+	if x != nil {
+		return x.StickyVariants
+	}
+	return false
+}
+
+type CreateLinkResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ShortUrl          string                 `protobuf:"bytes,2,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	CustomSlug        string                 `protobuf:"bytes,3,opt,name=custom_slug,json=customSlug,proto3" json:"custom_slug,omitempty"`
+	Clicks            int32                  `protobuf:"varint,4,opt,name=clicks,proto3" json:"clicks,omitempty"`
+	CreatedAt         string                 `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt         string                 `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	CustomerId        string                 `protobuf:"bytes,7,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	ExpirationDate    *string                `protobuf:"bytes,8,opt,name=expiration_date,json=expirationDate,proto3,oneof" json:"expiration_date,omitempty"`
+	SlugType          string                 `protobuf:"bytes,9,opt,name=slug_type,json=slugType,proto3" json:"slug_type,omitempty"`
+	Title             string                 `protobuf:"bytes,10,opt,name=title,proto3" json:"title,omitempty"`
+	Tags              []string               `protobuf:"bytes,11,rep,name=tags,proto3" json:"tags,omitempty"`
+	Domain            string                 `protobuf:"bytes,12,opt,name=domain,proto3" json:"domain,omitempty"`
+	Utm               *UTMParams             `protobuf:"bytes,13,opt,name=utm,proto3,oneof" json:"utm,omitempty"`
+	PasswordProtected bool                   `protobuf:"varint,14,opt,name=password_protected,json=passwordProtected,proto3" json:"password_protected,omitempty"`
+	MaxClicks         *int32                 `protobuf:"varint,15,opt,name=max_clicks,json=maxClicks,proto3,oneof" json:"max_clicks,omitempty"`
+	ActivatesAt       *string                `protobuf:"bytes,16,opt,name=activates_at,json=activatesAt,proto3,oneof" json:"activates_at,omitempty"`
+	FallbackUrl       string                 `protobuf:"bytes,17,opt,name=fallback_url,json=fallbackUrl,proto3" json:"fallback_url,omitempty"`
+	Rules             []*RedirectRule        `protobuf:"bytes,18,rep,name=rules,proto3" json:"rules,omitempty"`
+	Variants          []*DestinationVariant  `protobuf:"bytes,19,rep,name=variants,proto3" json:"variants,omitempty"`
+	StickyVariants    bool                   `protobuf:"varint,20,opt,name=sticky_variants,json=stickyVariants,proto3" json:"sticky_variants,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CreateLinkResponse) Reset() {
+	*x = CreateLinkResponse{}
+	mi := &file_proto_links_write_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateLinkResponse) ProtoMessage() {}
+
+func (x *CreateLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_links_write_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateLinkResponse.ProtoReflect.Descriptor instead.
+func (*CreateLinkResponse) Descriptor() ([]byte, []int) {
+	return file_proto_links_write_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CreateLinkResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CreateLinkResponse) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+func (x *CreateLinkResponse) GetCustomSlug() string {
+	if x != nil {
+		return x.CustomSlug
+	}
+	return ""
+}
+
+func (x *CreateLinkResponse) GetClicks() int32 {
+	if x != nil {
+		return x.Clicks
+	}
+	return 0
+}
+
+func (x *CreateLinkResponse) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *CreateLinkResponse) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *CreateLinkResponse) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *CreateLinkResponse) GetExpirationDate() string {
+	if x != nil && x.ExpirationDate != nil {
+		return *x.ExpirationDate
+	}
+	return ""
+}
+
+func (x *CreateLinkResponse) GetSlugType() string {
+	if x != nil {
+		return x.SlugType
+	}
+	return ""
+}
+
+func (x *CreateLinkResponse) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateLinkResponse) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *CreateLinkResponse) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *CreateLinkResponse) GetUtm() *UTMParams {
+	if x != nil {
+		return x.Utm
+	}
+	return nil
+}
+
+func (x *CreateLinkResponse) GetPasswordProtected() bool {
+	if x != nil {
+		return x.PasswordProtected
+	}
+	return false
+}
+
+func (x *CreateLinkResponse) GetMaxClicks() int32 {
+	if x != nil && x.MaxClicks != nil {
+		return *x.MaxClicks
+	}
+	return 0
+}
+
+func (x *CreateLinkResponse) GetActivatesAt() string {
+	if x != nil && x.ActivatesAt != nil {
+		return *x.ActivatesAt
+	}
+	return ""
+}
+
+func (x *CreateLinkResponse) GetFallbackUrl() string {
+	if x != nil {
+		return x.FallbackUrl
+	}
+	return ""
+}
+
+func (x *CreateLinkResponse) GetRules() []*RedirectRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *CreateLinkResponse) GetVariants() []*DestinationVariant {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+func (x *CreateLinkResponse) GetStickyVariants() bool {
+	if x != nil {
+		return x.StickyVariants
+	}
+	return false
+}
+
+type DeleteLinkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CustomerId    string                 `protobuf:"bytes,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteLinkRequest) Reset() {
+	*x = DeleteLinkRequest{}
+	mi := &file_proto_links_write_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteLinkRequest) ProtoMessage() {}
+
+func (x *DeleteLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_links_write_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteLinkRequest.ProtoReflect.Descriptor instead.
+func (*DeleteLinkRequest) Descriptor() ([]byte, []int) {
+	return file_proto_links_write_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteLinkRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeleteLinkRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+type DeleteLinkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteLinkResponse) Reset() {
+	*x = DeleteLinkResponse{}
+	mi := &file_proto_links_write_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteLinkResponse) ProtoMessage() {}
+
+func (x *DeleteLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_links_write_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteLinkResponse.ProtoReflect.Descriptor instead.
+func (*DeleteLinkResponse) Descriptor() ([]byte, []int) {
+	return file_proto_links_write_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeleteLinkResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type UpdateLinkRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CustomerId     string                 `protobuf:"bytes,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	OriginalUrl    string                 `protobuf:"bytes,3,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`
+	CustomSlug     string                 `protobuf:"bytes,4,opt,name=custom_slug,json=customSlug,proto3" json:"custom_slug,omitempty"`
+	ExpirationDate *string                `protobuf:"bytes,5,opt,name=expiration_date,json=expirationDate,proto3,oneof" json:"expiration_date,omitempty"`
+	Disabled       *bool                  `protobuf:"varint,6,opt,name=disabled,proto3,oneof" json:"disabled,omitempty"`
+	Title          string                 `protobuf:"bytes,7,opt,name=title,proto3" json:"title,omitempty"`
+	Tags           []string               `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty"`
+	Utm            *UTMParams             `protobuf:"bytes,9,opt,name=utm,proto3,oneof" json:"utm,omitempty"`
+	UtmTemplateId  string                 `protobuf:"bytes,10,opt,name=utm_template_id,json=utmTemplateId,proto3" json:"utm_template_id,omitempty"`
+	Password       *string                `protobuf:"bytes,11,opt,name=password,proto3,oneof" json:"password,omitempty"`
+	MaxClicks      *int32                 `protobuf:"varint,12,opt,name=max_clicks,json=maxClicks,proto3,oneof" json:"max_clicks,omitempty"`
+	OneTime        *bool                  `protobuf:"varint,13,opt,name=one_time,json=oneTime,proto3,oneof" json:"one_time,omitempty"`
+	ActivatesAt    *string                `protobuf:"bytes,14,opt,name=activates_at,json=activatesAt,proto3,oneof" json:"activates_at,omitempty"`
+	FallbackUrl    *string                `protobuf:"bytes,15,opt,name=fallback_url,json=fallbackUrl,proto3,oneof" json:"fallback_url,omitempty"`
+	Rules          *RedirectRules         `protobuf:"bytes,16,opt,name=rules,proto3,oneof" json:"rules,omitempty"`
+	Variants       *DestinationVariants   `protobuf:"bytes,17,opt,name=variants,proto3,oneof" json:"variants,omitempty"`
+	StickyVariants *bool                  `protobuf:"varint,18,opt,name=sticky_variants,json=stickyVariants,proto3,oneof" json:"sticky_variants,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UpdateLinkRequest) Reset() {
+	*x = UpdateLinkRequest{}
+	mi := &file_proto_links_write_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateLinkRequest) ProtoMessage() {}
+
+func (x *UpdateLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_links_write_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateLinkRequest.ProtoReflect.Descriptor instead.
+func (*UpdateLinkRequest) Descriptor() ([]byte, []int) {
+	return file_proto_links_write_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateLinkRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateLinkRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *UpdateLinkRequest) GetOriginalUrl() string {
+	if x != nil {
+		return x.OriginalUrl
+	}
+	return ""
+}
+
+func (x *UpdateLinkRequest) GetCustomSlug() string {
+	if x != nil {
+		return x.CustomSlug
+	}
+	return ""
+}
+
+func (x *UpdateLinkRequest) GetExpirationDate() string {
+	if x != nil && x.ExpirationDate != nil {
+		return *x.ExpirationDate
+	}
+	return ""
+}
+
+func (x *UpdateLinkRequest) GetDisabled() bool {
+	if x != nil && x.Disabled != nil {
+		return *x.Disabled
+	}
+	return false
+}
+
+func (x *UpdateLinkRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *UpdateLinkRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *UpdateLinkRequest) GetUtm() *UTMParams {
+	if x != nil {
+		return x.Utm
+	}
+	return nil
+}
+
+func (x *UpdateLinkRequest) GetUtmTemplateId() string {
+	if x != nil {
+		return x.UtmTemplateId
+	}
+	return ""
+}
+
+func (x *UpdateLinkRequest) GetPassword() string {
+	if x != nil && x.Password != nil {
+		return *x.Password
+	}
+	return ""
+}
+
+func (x *UpdateLinkRequest) GetMaxClicks() int32 {
+	if x != nil && x.MaxClicks != nil {
+		return *x.MaxClicks
+	}
+	return 0
+}
+
+func (x *UpdateLinkRequest) GetOneTime() bool {
+	if x != nil && x.OneTime != nil {
+		return *x.OneTime
+	}
+	return false
+}
+
+func (x *UpdateLinkRequest) GetActivatesAt() string {
+	if x != nil && x.ActivatesAt != nil {
+		return *x.ActivatesAt
+	}
+	return ""
+}
+
+func (x *UpdateLinkRequest) GetFallbackUrl() string {
+	if x != nil && x.FallbackUrl != nil {
+		return *x.FallbackUrl
+	}
+	return ""
+}
+
+func (x *UpdateLinkRequest) GetRules() *RedirectRules {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *UpdateLinkRequest) GetVariants() *DestinationVariants {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+func (x *UpdateLinkRequest) GetStickyVariants() bool {
+	if x != nil && x.StickyVariants != nil {
+		return *x.StickyVariants
+	}
+	return false
+}
+
+type UpdateLinkResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OriginalUrl       string                 `protobuf:"bytes,2,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`
+	ShortUrl          string                 `protobuf:"bytes,3,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	CustomSlug        string                 `protobuf:"bytes,4,opt,name=custom_slug,json=customSlug,proto3" json:"custom_slug,omitempty"`
+	Clicks            int32                  `protobuf:"varint,5,opt,name=clicks,proto3" json:"clicks,omitempty"`
+	CreatedAt         string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt         string                 `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	CustomerId        string                 `protobuf:"bytes,8,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	ExpirationDate    *string                `protobuf:"bytes,9,opt,name=expiration_date,json=expirationDate,proto3,oneof" json:"expiration_date,omitempty"`
+	Disabled          bool                   `protobuf:"varint,10,opt,name=disabled,proto3" json:"disabled,omitempty"`
+	SlugType          string                 `protobuf:"bytes,11,opt,name=slug_type,json=slugType,proto3" json:"slug_type,omitempty"`
+	Title             string                 `protobuf:"bytes,12,opt,name=title,proto3" json:"title,omitempty"`
+	Tags              []string               `protobuf:"bytes,13,rep,name=tags,proto3" json:"tags,omitempty"`
+	Domain            string                 `protobuf:"bytes,14,opt,name=domain,proto3" json:"domain,omitempty"`
+	Utm               *UTMParams             `protobuf:"bytes,15,opt,name=utm,proto3,oneof" json:"utm,omitempty"`
+	PasswordProtected bool                   `protobuf:"varint,16,opt,name=password_protected,json=passwordProtected,proto3" json:"password_protected,omitempty"`
+	MaxClicks         *int32                 `protobuf:"varint,17,opt,name=max_clicks,json=maxClicks,proto3,oneof" json:"max_clicks,omitempty"`
+	ActivatesAt       *string                `protobuf:"bytes,18,opt,name=activates_at,json=activatesAt,proto3,oneof" json:"activates_at,omitempty"`
+	FallbackUrl       string                 `protobuf:"bytes,19,opt,name=fallback_url,json=fallbackUrl,proto3" json:"fallback_url,omitempty"`
+	Rules             []*RedirectRule        `protobuf:"bytes,20,rep,name=rules,proto3" json:"rules,omitempty"`
+	Variants          []*DestinationVariant  `protobuf:"bytes,21,rep,name=variants,proto3" json:"variants,omitempty"`
+	StickyVariants    bool                   `protobuf:"varint,22,opt,name=sticky_variants,json=stickyVariants,proto3" json:"sticky_variants,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *UpdateLinkResponse) Reset() {
+	*x = UpdateLinkResponse{}
+	mi := &file_proto_links_write_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateLinkResponse) ProtoMessage() {}
+
+func (x *UpdateLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_links_write_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateLinkResponse.ProtoReflect.Descriptor instead.
+func (*UpdateLinkResponse) Descriptor() ([]byte, []int) {
+	return file_proto_links_write_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdateLinkResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateLinkResponse) GetOriginalUrl() string {
+	if x != nil {
+		return x.OriginalUrl
+	}
+	return ""
+}
+
+func (x *UpdateLinkResponse) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+func (x *UpdateLinkResponse) GetCustomSlug() string {
+	if x != nil {
+		return x.CustomSlug
+	}
+	return ""
+}
+
+func (x *UpdateLinkResponse) GetClicks() int32 {
+	if x != nil {
+		return x.Clicks
+	}
+	return 0
+}
+
+func (x *UpdateLinkResponse) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *UpdateLinkResponse) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *UpdateLinkResponse) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *UpdateLinkResponse) GetExpirationDate() string {
+	if x != nil && x.ExpirationDate != nil {
+		return *x.ExpirationDate
+	}
+	return ""
+}
+
+func (x *UpdateLinkResponse) GetDisabled() bool {
+	if x != nil {
+		return x.Disabled
+	}
+	return false
+}
+
+func (x *UpdateLinkResponse) GetSlugType() string {
+	if x != nil {
+		return x.SlugType
+	}
+	return ""
+}
+
+func (x *UpdateLinkResponse) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *UpdateLinkResponse) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *UpdateLinkResponse) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *UpdateLinkResponse) GetUtm() *UTMParams {
+	if x != nil {
+		return x.Utm
+	}
+	return nil
+}
+
+func (x *UpdateLinkResponse) GetPasswordProtected() bool {
+	if x != nil {
+		return x.PasswordProtected
+	}
+	return false
+}
+
+func (x *UpdateLinkResponse) GetMaxClicks() int32 {
+	if x != nil && x.MaxClicks != nil {
+		return *x.MaxClicks
+	}
+	return 0
+}
+
+func (x *UpdateLinkResponse) GetActivatesAt() string {
+	if x != nil && x.ActivatesAt != nil {
+		return *x.ActivatesAt
+	}
+	return ""
+}
+
+func (x *UpdateLinkResponse) GetFallbackUrl() string {
+	if x != nil {
+		return x.FallbackUrl
+	}
+	return ""
+}
+
+func (x *UpdateLinkResponse) GetRules() []*RedirectRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *UpdateLinkResponse) GetVariants() []*DestinationVariant {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+func (x *UpdateLinkResponse) GetStickyVariants() bool {
+	if x != nil {
+		return x.StickyVariants
+	}
+	return false
+}
+
+type UpdateLinkClicksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserAgent     string                 `protobuf:"bytes,2,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	IpAddress     string                 `protobuf:"bytes,3,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateLinkClicksRequest) Reset() {
+	*x = UpdateLinkClicksRequest{}
+	mi := &file_proto_links_write_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateLinkClicksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateLinkClicksRequest) ProtoMessage() {}
+
+func (x *UpdateLinkClicksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_links_write_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateLinkClicksRequest.ProtoReflect.Descriptor instead.
+func (*UpdateLinkClicksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_links_write_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UpdateLinkClicksRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateLinkClicksRequest) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *UpdateLinkClicksRequest) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
+type UpdateLinkClicksResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OriginalUrl    string                 `protobuf:"bytes,2,opt,name=original_url,json=originalUrl,proto3" json:"original_url,omitempty"`
+	ShortUrl       string                 `protobuf:"bytes,3,opt,name=short_url,json=shortUrl,proto3" json:"short_url,omitempty"`
+	CustomSlug     string                 `protobuf:"bytes,4,opt,name=custom_slug,json=customSlug,proto3" json:"custom_slug,omitempty"`
+	Clicks         int32                  `protobuf:"varint,5,opt,name=clicks,proto3" json:"clicks,omitempty"`
+	CreatedAt      string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      string                 `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	CustomerId     string                 `protobuf:"bytes,8,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	ExpirationDate *string                `protobuf:"bytes,9,opt,name=expiration_date,json=expirationDate,proto3,oneof" json:"expiration_date,omitempty"`
+	MaxClicks      *int32                 `protobuf:"varint,10,opt,name=max_clicks,json=maxClicks,proto3,oneof" json:"max_clicks,omitempty"`
+	BotCategory    *string                `protobuf:"bytes,11,opt,name=bot_category,json=botCategory,proto3,oneof" json:"bot_category,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UpdateLinkClicksResponse) Reset() {
+	*x = UpdateLinkClicksResponse{}
+	mi := &file_proto_links_write_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateLinkClicksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateLinkClicksResponse) ProtoMessage() {}
+
+func (x *UpdateLinkClicksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_links_write_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateLinkClicksResponse.ProtoReflect.Descriptor instead.
+func (*UpdateLinkClicksResponse) Descriptor() ([]byte, []int) {
+	return file_proto_links_write_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UpdateLinkClicksResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateLinkClicksResponse) GetOriginalUrl() string {
+	if x != nil {
+		return x.OriginalUrl
+	}
+	return ""
+}
+
+func (x *UpdateLinkClicksResponse) GetShortUrl() string {
+	if x != nil {
+		return x.ShortUrl
+	}
+	return ""
+}
+
+func (x *UpdateLinkClicksResponse) GetCustomSlug() string {
+	if x != nil {
+		return x.CustomSlug
+	}
+	return ""
+}
+
+func (x *UpdateLinkClicksResponse) GetClicks() int32 {
+	if x != nil {
+		return x.Clicks
+	}
+	return 0
+}
+
+func (x *UpdateLinkClicksResponse) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *UpdateLinkClicksResponse) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *UpdateLinkClicksResponse) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *UpdateLinkClicksResponse) GetExpirationDate() string {
+	if x != nil && x.ExpirationDate != nil {
+		return *x.ExpirationDate
+	}
+	return ""
+}
+
+func (x *UpdateLinkClicksResponse) GetMaxClicks() int32 {
+	if x != nil && x.MaxClicks != nil {
+		return *x.MaxClicks
+	}
+	return 0
+}
+
+func (x *UpdateLinkClicksResponse) GetBotCategory() string {
+	if x != nil && x.BotCategory != nil {
+		return *x.BotCategory
+	}
+	return ""
+}
+
+type RecordClickRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	LinkId         string                 `protobuf:"bytes,1,opt,name=link_id,json=linkId,proto3" json:"link_id,omitempty"`
+	Referrer       string                 `protobuf:"bytes,2,opt,name=referrer,proto3" json:"referrer,omitempty"`
+	UserAgent      string                 `protobuf:"bytes,3,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	IpAddress      string                 `protobuf:"bytes,4,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	AcceptLanguage string                 `protobuf:"bytes,5,opt,name=accept_language,json=acceptLanguage,proto3" json:"accept_language,omitempty"`
+	Counted        bool                   `protobuf:"varint,6,opt,name=counted,proto3" json:"counted,omitempty"`
+	LinkStatus     string                 `protobuf:"bytes,7,opt,name=link_status,json=linkStatus,proto3" json:"link_status,omitempty"`
+	Variant        string                 `protobuf:"bytes,8,opt,name=variant,proto3" json:"variant,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RecordClickRequest) Reset() {
+	*x = RecordClickRequest{}
+	mi := &file_proto_links_write_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordClickRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordClickRequest) ProtoMessage() {}
+
+func (x *RecordClickRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_links_write_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordClickRequest.ProtoReflect.Descriptor instead.
+func (*RecordClickRequest) Descriptor() ([]byte, []int) {
+	return file_proto_links_write_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RecordClickRequest) GetLinkId() string {
+	if x != nil {
+		return x.LinkId
+	}
+	return ""
+}
+
+func (x *RecordClickRequest) GetReferrer() string {
+	if x != nil {
+		return x.Referrer
+	}
+	return ""
+}
+
+func (x *RecordClickRequest) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *RecordClickRequest) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
+func (x *RecordClickRequest) GetAcceptLanguage() string {
+	if x != nil {
+		return x.AcceptLanguage
+	}
+	return ""
+}
+
+func (x *RecordClickRequest) GetCounted() bool {
+	if x != nil {
+		return x.Counted
+	}
+	return false
+}
+
+func (x *RecordClickRequest) GetLinkStatus() string {
+	if x != nil {
+		return x.LinkStatus
+	}
+	return ""
+}
+
+func (x *RecordClickRequest) GetVariant() string {
+	if x != nil {
+		return x.Variant
+	}
+	return ""
+}
+
+type RecordClickResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	LinkId        string                 `protobuf:"bytes,2,opt,name=link_id,json=linkId,proto3" json:"link_id,omitempty"`
+	ClickedAt     string                 `protobuf:"bytes,3,opt,name=clicked_at,json=clickedAt,proto3" json:"clicked_at,omitempty"`
+	BotCategory   *string                `protobuf:"bytes,4,opt,name=bot_category,json=botCategory,proto3,oneof" json:"bot_category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordClickResponse) Reset() {
+	*x = RecordClickResponse{}
+	mi := &file_proto_links_write_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordClickResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordClickResponse) ProtoMessage() {}
+
+func (x *RecordClickResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_links_write_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordClickResponse.ProtoReflect.Descriptor instead.
+func (*RecordClickResponse) Descriptor() ([]byte, []int) {
+	return file_proto_links_write_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RecordClickResponse) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *RecordClickResponse) GetLinkId() string {
+	if x != nil {
+		return x.LinkId
+	}
+	return ""
+}
+
+func (x *RecordClickResponse) GetClickedAt() string {
+	if x != nil {
+		return x.ClickedAt
+	}
+	return ""
+}
+
+func (x *RecordClickResponse) GetBotCategory() string {
+	if x != nil && x.BotCategory != nil {
+		return *x.BotCategory
+	}
+	return ""
+}
+
+type RegisterDomainRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Domain        string                 `protobuf:"bytes,2,opt,name=domain,proto3" json:"domain,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterDomainRequest) Reset() {
+	*x = RegisterDomainRequest{}
+	mi := &file_proto_links_write_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterDomainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterDomainRequest) ProtoMessage() {}
+
+func (x *RegisterDomainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_links_write_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterDomainRequest.ProtoReflect.Descriptor instead.
+func (*RegisterDomainRequest) Descriptor() ([]byte, []int) {
+	return file_proto_links_write_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RegisterDomainRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *RegisterDomainRequest) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+type VerifyDomainRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Domain        string                 `protobuf:"bytes,2,opt,name=domain,proto3" json:"domain,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyDomainRequest) Reset() {
+	*x = VerifyDomainRequest{}
+	mi := &file_proto_links_write_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyDomainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyDomainRequest) ProtoMessage() {}
+
+func (x *VerifyDomainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_links_write_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyDomainRequest.ProtoReflect.Descriptor instead.
+func (*VerifyDomainRequest) Descriptor() ([]byte, []int) {
+	return file_proto_links_write_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *VerifyDomainRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *VerifyDomainRequest) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+type DomainResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Domain        string                 `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	CustomerId    string                 `protobuf:"bytes,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Verified      bool                   `protobuf:"varint,3,opt,name=verified,proto3" json:"verified,omitempty"`
+	RecordName    string                 `protobuf:"bytes,4,opt,name=record_name,json=recordName,proto3" json:"record_name,omitempty"`
+	RecordValue   string                 `protobuf:"bytes,5,opt,name=record_value,json=recordValue,proto3" json:"record_value,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	VerifiedAt    *string                `protobuf:"bytes,7,opt,name=verified_at,json=verifiedAt,proto3,oneof" json:"verified_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DomainResponse) Reset() {
+	*x = DomainResponse{}
+	mi := &file_proto_links_write_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DomainResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DomainResponse) ProtoMessage() {}
+
+func (x *DomainResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_links_write_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DomainResponse.ProtoReflect.Descriptor instead.
+func (*DomainResponse) Descriptor() ([]byte, []int) {
+	return file_proto_links_write_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DomainResponse) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *DomainResponse) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *DomainResponse) GetVerified() bool {
+	if x != nil {
+		return x.Verified
+	}
+	return false
+}
+
+func (x *DomainResponse) GetRecordName() string {
+	if x != nil {
+		return x.RecordName
+	}
+	return ""
+}
+
+func (x *DomainResponse) GetRecordValue() string {
+	if x != nil {
+		return x.RecordValue
+	}
+	return ""
+}
+
+func (x *DomainResponse) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *DomainResponse) GetVerifiedAt() string {
+	if x != nil && x.VerifiedAt != nil {
+		return *x.VerifiedAt
+	}
+	return ""
+}
+
+type GetCustomerDomainsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCustomerDomainsRequest) Reset() {
+	*x = GetCustomerDomainsRequest{}
+	mi := &file_proto_links_write_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCustomerDomainsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCustomerDomainsRequest) ProtoMessage() {}
+
+func (x *GetCustomerDomainsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_links_write_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCustomerDomainsRequest.ProtoReflect.Descriptor instead.
+func (*GetCustomerDomainsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_links_write_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetCustomerDomainsRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+type GetCustomerDomainsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Domains       []*DomainResponse      `protobuf:"bytes,1,rep,name=domains,proto3" json:"domains,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCustomerDomainsResponse) Reset() {
+	*x = GetCustomerDomainsResponse{}
+	mi := &file_proto_links_write_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCustomerDomainsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCustomerDomainsResponse) ProtoMessage() {}
+
+func (x *GetCustomerDomainsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_links_write_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCustomerDomainsResponse.ProtoReflect.Descriptor instead.
+func (*GetCustomerDomainsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_links_write_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetCustomerDomainsResponse) GetDomains() []*DomainResponse {
+	if x != nil {
+		return x.Domains
+	}
+	return nil
+}
+
+type DeleteDomainRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Domain        string                 `protobuf:"bytes,2,opt,name=domain,proto3" json:"domain,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteDomainRequest) Reset() {
+	*x = DeleteDomainRequest{}
+	mi := &file_proto_links_write_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteDomainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteDomainRequest) ProtoMessage() {}
+
+func (x *DeleteDomainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_links_write_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteDomainRequest.ProtoReflect.Descriptor instead.
+func (*DeleteDomainRequest) Descriptor() ([]byte, []int) {
+	return file_proto_links_write_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *DeleteDomainRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *DeleteDomainRequest) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+type DeleteDomainResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteDomainResponse) Reset() {
+	*x = DeleteDomainResponse{}
+	mi := &file_proto_links_write_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteDomainResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteDomainResponse) ProtoMessage() {}
+
+func (x *DeleteDomainResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_links_write_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteDomainResponse.ProtoReflect.Descriptor instead.
+func (*DeleteDomainResponse) Descriptor() ([]byte, []int) {
+	return file_proto_links_write_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DeleteDomainResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type BulkCreateLinksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Links         []*CreateLinkRequest   `protobuf:"bytes,2,rep,name=links,proto3" json:"links,omitempty"`
+	DryRun        bool                   `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkCreateLinksRequest) Reset() {
+	*x = BulkCreateLinksRequest{}
+	mi := &file_proto_links_write_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkCreateLinksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkCreateLinksRequest) ProtoMessage() {}
+
+func (x *BulkCreateLinksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_links_write_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkCreateLinksRequest.ProtoReflect.Descriptor instead.
+func (*BulkCreateLinksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_links_write_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *BulkCreateLinksRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *BulkCreateLinksRequest) GetLinks() []*CreateLinkRequest {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+func (x *BulkCreateLinksRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type BulkCreateLinkResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Link          *CreateLinkResponse    `protobuf:"bytes,2,opt,name=link,proto3,oneof" json:"link,omitempty"`
+	Code          string                 `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"`
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkCreateLinkResult) Reset() {
+	*x = BulkCreateLinkResult{}
+	mi := &file_proto_links_write_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkCreateLinkResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkCreateLinkResult) ProtoMessage() {}
+
+func (x *BulkCreateLinkResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_links_write_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkCreateLinkResult.ProtoReflect.Descriptor instead.
+func (*BulkCreateLinkResult) Descriptor() ([]byte, []int) {
+	return file_proto_links_write_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *BulkCreateLinkResult) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *BulkCreateLinkResult) GetLink() *CreateLinkResponse {
+	if x != nil {
+		return x.Link
+	}
+	return nil
+}
+
+func (x *BulkCreateLinkResult) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *BulkCreateLinkResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type BulkCreateLinksResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Results       []*BulkCreateLinkResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Created       int32                   `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"`
+	Failed        int32                   `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	DryRun        bool                    `protobuf:"varint,4,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkCreateLinksResponse) Reset() {
+	*x = BulkCreateLinksResponse{}
+	mi := &file_proto_links_write_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkCreateLinksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkCreateLinksResponse) ProtoMessage() {}
+
+func (x *BulkCreateLinksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_links_write_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkCreateLinksResponse.ProtoReflect.Descriptor instead.
+func (*BulkCreateLinksResponse) Descriptor() ([]byte, []int) {
+	return file_proto_links_write_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *BulkCreateLinksResponse) GetResults() []*BulkCreateLinkResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *BulkCreateLinksResponse) GetCreated() int32 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *BulkCreateLinksResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *BulkCreateLinksResponse) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type CreateUTMTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Utm           *UTMParams             `protobuf:"bytes,3,opt,name=utm,proto3" json:"utm,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateUTMTemplateRequest) Reset() {
+	*x = CreateUTMTemplateRequest{}
+	mi := &file_proto_links_write_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateUTMTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateUTMTemplateRequest) ProtoMessage() {}
+
+func (x *CreateUTMTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_links_write_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateUTMTemplateRequest.ProtoReflect.Descriptor instead.
+func (*CreateUTMTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_proto_links_write_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *CreateUTMTemplateRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *CreateUTMTemplateRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateUTMTemplateRequest) GetUtm() *UTMParams {
+	if x != nil {
+		return x.Utm
+	}
+	return nil
+}
+
+type UTMTemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CustomerId    string                 `protobuf:"bytes,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Utm           *UTMParams             `protobuf:"bytes,4,opt,name=utm,proto3" json:"utm,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UTMTemplateResponse) Reset() {
+	*x = UTMTemplateResponse{}
+	mi := &file_proto_links_write_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UTMTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UTMTemplateResponse) ProtoMessage() {}
+
+func (x *UTMTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_links_write_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UTMTemplateResponse.ProtoReflect.Descriptor instead.
+func (*UTMTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_proto_links_write_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *UTMTemplateResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UTMTemplateResponse) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *UTMTemplateResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UTMTemplateResponse) GetUtm() *UTMParams {
+	if x != nil {
+		return x.Utm
+	}
+	return nil
+}
+
+func (x *UTMTemplateResponse) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type GetCustomerUTMTemplatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCustomerUTMTemplatesRequest) Reset() {
+	*x = GetCustomerUTMTemplatesRequest{}
+	mi := &file_proto_links_write_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCustomerUTMTemplatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCustomerUTMTemplatesRequest) ProtoMessage() {}
+
+func (x *GetCustomerUTMTemplatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_links_write_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCustomerUTMTemplatesRequest.ProtoReflect.Descriptor instead.
+func (*GetCustomerUTMTemplatesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_links_write_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetCustomerUTMTemplatesRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+type GetCustomerUTMTemplatesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Templates     []*UTMTemplateResponse `protobuf:"bytes,1,rep,name=templates,proto3" json:"templates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCustomerUTMTemplatesResponse) Reset() {
+	*x = GetCustomerUTMTemplatesResponse{}
+	mi := &file_proto_links_write_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCustomerUTMTemplatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCustomerUTMTemplatesResponse) ProtoMessage() {}
+
+func (x *GetCustomerUTMTemplatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_links_write_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCustomerUTMTemplatesResponse.ProtoReflect.Descriptor instead.
+func (*GetCustomerUTMTemplatesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_links_write_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetCustomerUTMTemplatesResponse) GetTemplates() []*UTMTemplateResponse {
+	if x != nil {
+		return x.Templates
+	}
+	return nil
+}
+
+type DeleteUTMTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CustomerId    string                 `protobuf:"bytes,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUTMTemplateRequest) Reset() {
+	*x = DeleteUTMTemplateRequest{}
+	mi := &file_proto_links_write_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUTMTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUTMTemplateRequest) ProtoMessage() {}
+
+func (x *DeleteUTMTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_links_write_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUTMTemplateRequest.ProtoReflect.Descriptor instead.
+func (*DeleteUTMTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_proto_links_write_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *DeleteUTMTemplateRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeleteUTMTemplateRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+type DeleteUTMTemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUTMTemplateResponse) Reset() {
+	*x = DeleteUTMTemplateResponse{}
+	mi := &file_proto_links_write_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUTMTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUTMTemplateResponse) ProtoMessage() {}
+
+func (x *DeleteUTMTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_links_write_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUTMTemplateResponse.ProtoReflect.Descriptor instead.
+func (*DeleteUTMTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_proto_links_write_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *DeleteUTMTemplateResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type GetCustomerLinkSettingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCustomerLinkSettingsRequest) Reset() {
+	*x = GetCustomerLinkSettingsRequest{}
+	mi := &file_proto_links_write_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCustomerLinkSettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCustomerLinkSettingsRequest) ProtoMessage() {}
+
+func (x *GetCustomerLinkSettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_links_write_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCustomerLinkSettingsRequest.ProtoReflect.Descriptor instead.
+func (*GetCustomerLinkSettingsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_links_write_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetCustomerLinkSettingsRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+type UpdateCustomerLinkSettingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	FallbackUrl   string                 `protobuf:"bytes,2,opt,name=fallback_url,json=fallbackUrl,proto3" json:"fallback_url,omitempty"`
+	FallbackMode  string                 `protobuf:"bytes,3,opt,name=fallback_mode,json=fallbackMode,proto3" json:"fallback_mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCustomerLinkSettingsRequest) Reset() {
+	*x = UpdateCustomerLinkSettingsRequest{}
+	mi := &file_proto_links_write_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCustomerLinkSettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCustomerLinkSettingsRequest) ProtoMessage() {}
+
+func (x *UpdateCustomerLinkSettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_links_write_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCustomerLinkSettingsRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCustomerLinkSettingsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_links_write_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *UpdateCustomerLinkSettingsRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *UpdateCustomerLinkSettingsRequest) GetFallbackUrl() string {
+	if x != nil {
+		return x.FallbackUrl
+	}
+	return ""
+}
+
+func (x *UpdateCustomerLinkSettingsRequest) GetFallbackMode() string {
+	if x != nil {
+		return x.FallbackMode
+	}
+	return ""
+}
+
+type CustomerLinkSettingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId    string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	FallbackUrl   string                 `protobuf:"bytes,2,opt,name=fallback_url,json=fallbackUrl,proto3" json:"fallback_url,omitempty"`
+	FallbackMode  string                 `protobuf:"bytes,3,opt,name=fallback_mode,json=fallbackMode,proto3" json:"fallback_mode,omitempty"`
+	UpdatedAt     string                 `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CustomerLinkSettingsResponse) Reset() {
+	*x = CustomerLinkSettingsResponse{}
+	mi := &file_proto_links_write_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CustomerLinkSettingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CustomerLinkSettingsResponse) ProtoMessage() {}
+
+func (x *CustomerLinkSettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_links_write_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CustomerLinkSettingsResponse.ProtoReflect.Descriptor instead.
+func (*CustomerLinkSettingsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_links_write_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *CustomerLinkSettingsResponse) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *CustomerLinkSettingsResponse) GetFallbackUrl() string {
+	if x != nil {
+		return x.FallbackUrl
+	}
+	return ""
+}
+
+func (x *CustomerLinkSettingsResponse) GetFallbackMode() string {
+	if x != nil {
+		return x.FallbackMode
+	}
+	return ""
+}
+
+func (x *CustomerLinkSettingsResponse) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+var File_proto_links_write_proto protoreflect.FileDescriptor
+
+const file_proto_links_write_proto_rawDesc = "" +
+	"\n" +
+	"\x17proto/links_write.proto\x12\vlinks_write\"\x85\x01\n" +
+	"\tUTMParams\x12\x16\n" +
+	"\x06source\x18\x01 \x01(\tR\x06source\x12\x16\n" +
+	"\x06medium\x18\x02 \x01(\tR\x06medium\x12\x1a\n" +
+	"\bcampaign\x18\x03 \x01(\tR\bcampaign\x12\x12\n" +
+	"\x04term\x18\x04 \x01(\tR\x04term\x12\x18\n" +
+	"\acontent\x18\x05 \x01(\tR\acontent\"\xf0\x01\n" +
+	"\fRedirectRule\x12 \n" +
+	"\vdestination\x18\x01 \x01(\tR\vdestination\x12\x18\n" +
+	"\adevices\x18\x02 \x03(\tR\adevices\x12\x1c\n" +
+	"\tcountries\x18\x03 \x03(\tR\tcountries\x12\x1c\n" +
+	"\tlanguages\x18\x04 \x03(\tR\tlanguages\x12\x12\n" +
+	"\x04days\x18\x05 \x03(\tR\x04days\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\x06 \x01(\tR\tstartTime\x12\x19\n" +
+	"\bend_time\x18\a \x01(\tR\aendTime\x12\x1a\n" +
+	"\btimezone\x18\b \x01(\tR\btimezone\"@\n" +
+	"\rRedirectRules\x12/\n" +
+	"\x05rules\x18\x01 \x03(\v2\x19.links_write.RedirectRuleR\x05rules\"N\n" +
+	"\x12DestinationVariant\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x16\n" +
+	"\x06weight\x18\x03 \x01(\x05R\x06weight\"R\n" +
+	"\x13DestinationVariants\x12;\n" +
+	"\bvariants\x18\x01 \x03(\v2\x1f.links_write.DestinationVariantR\bvariants\"\xb8\x05\n" +
+	"\x11CreateLinkRequest\x12!\n" +
+	"\foriginal_url\x18\x01 \x01(\tR\voriginalUrl\x12\x1f\n" +
+	"\vcustom_slug\x18\x02 \x01(\tR\n" +
+	"customSlug\x12\x1f\n" +
+	"\vcustomer_id\x18\x03 \x01(\tR\n" +
+	"customerId\x12,\n" +
+	"\x0fexpiration_date\x18\x04 \x01(\tH\x00R\x0eexpirationDate\x88\x01\x01\x12\x14\n" +
+	"\x05title\x18\x05 \x01(\tR\x05title\x12\x12\n" +
+	"\x04tags\x18\x06 \x03(\tR\x04tags\x12\x16\n" +
+	"\x06domain\x18\a \x01(\tR\x06domain\x12-\n" +
+	"\x03utm\x18\b \x01(\v2\x16.links_write.UTMParamsH\x01R\x03utm\x88\x01\x01\x12&\n" +
+	"\x0futm_template_id\x18\t \x01(\tR\rutmTemplateId\x12\x1a\n" +
+	"\bpassword\x18\n" +
+	" \x01(\tR\bpassword\x12\"\n" +
+	"\n" +
+	"max_clicks\x18\v \x01(\x05H\x02R\tmaxClicks\x88\x01\x01\x12\x19\n" +
+	"\bone_time\x18\f \x01(\bR\aoneTime\x12&\n" +
+	"\factivates_at\x18\r \x01(\tH\x03R\vactivatesAt\x88\x01\x01\x12!\n" +
+	"\ffallback_url\x18\x0e \x01(\tR\vfallbackUrl\x12/\n" +
+	"\x05rules\x18\x0f \x03(\v2\x19.links_write.RedirectRuleR\x05rules\x12;\n" +
+	"\bvariants\x18\x10 \x03(\v2\x1f.links_write.DestinationVariantR\bvariants\x12'\n" +
+	"\x0fsticky_variants\x18\x11 \x01(\bR\x0estickyVariantsB\x12\n" +
+	"\x10_expiration_dateB\x06\n" +
+	"\x04_utmB\r\n" +
+	"\v_max_clicksB\x0f\n" +
+	"\r_activates_at\"\x86\x06\n" +
+	"\x12CreateLinkResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tshort_url\x18\x02 \x01(\tR\bshortUrl\x12\x1f\n" +
+	"\vcustom_slug\x18\x03 \x01(\tR\n" +
+	"customSlug\x12\x16\n" +
+	"\x06clicks\x18\x04 \x01(\x05R\x06clicks\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\tR\tupdatedAt\x12\x1f\n" +
+	"\vcustomer_id\x18\a \x01(\tR\n" +
+	"customerId\x12,\n" +
+	"\x0fexpiration_date\x18\b \x01(\tH\x00R\x0eexpirationDate\x88\x01\x01\x12\x1b\n" +
+	"\tslug_type\x18\t \x01(\tR\bslugType\x12\x14\n" +
+	"\x05title\x18\n" +
+	" \x01(\tR\x05title\x12\x12\n" +
+	"\x04tags\x18\v \x03(\tR\x04tags\x12\x16\n" +
+	"\x06domain\x18\f \x01(\tR\x06domain\x12-\n" +
+	"\x03utm\x18\r \x01(\v2\x16.links_write.UTMParamsH\x01R\x03utm\x88\x01\x01\x12-\n" +
+	"\x12password_protected\x18\x0e \x01(\bR\x11passwordProtected\x12\"\n" +
+	"\n" +
+	"max_clicks\x18\x0f \x01(\x05H\x02R\tmaxClicks\x88\x01\x01\x12&\n" +
+	"\factivates_at\x18\x10 \x01(\tH\x03R\vactivatesAt\x88\x01\x01\x12!\n" +
+	"\ffallback_url\x18\x11 \x01(\tR\vfallbackUrl\x12/\n" +
+	"\x05rules\x18\x12 \x03(\v2\x19.links_write.RedirectRuleR\x05rules\x12;\n" +
+	"\bvariants\x18\x13 \x03(\v2\x1f.links_write.DestinationVariantR\bvariants\x12'\n" +
+	"\x0fsticky_variants\x18\x14 \x01(\bR\x0estickyVariantsB\x12\n" +
+	"\x10_expiration_dateB\x06\n" +
+	"\x04_utmB\r\n" +
+	"\v_max_clicksB\x0f\n" +
+	"\r_activates_at\"D\n" +
+	"\x11DeleteLinkRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vcustomer_id\x18\x02 \x01(\tR\n" +
+	"customerId\".\n" +
+	"\x12DeleteLinkResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xd4\x06\n" +
+	"\x11UpdateLinkRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vcustomer_id\x18\x02 \x01(\tR\n" +
+	"customerId\x12!\n" +
+	"\foriginal_url\x18\x03 \x01(\tR\voriginalUrl\x12\x1f\n" +
+	"\vcustom_slug\x18\x04 \x01(\tR\n" +
+	"customSlug\x12,\n" +
+	"\x0fexpiration_date\x18\x05 \x01(\tH\x00R\x0eexpirationDate\x88\x01\x01\x12\x1f\n" +
+	"\bdisabled\x18\x06 \x01(\bH\x01R\bdisabled\x88\x01\x01\x12\x14\n" +
+	"\x05title\x18\a \x01(\tR\x05title\x12\x12\n" +
+	"\x04tags\x18\b \x03(\tR\x04tags\x12-\n" +
+	"\x03utm\x18\t \x01(\v2\x16.links_write.UTMParamsH\x02R\x03utm\x88\x01\x01\x12&\n" +
+	"\x0futm_template_id\x18\n" +
+	" \x01(\tR\rutmTemplateId\x12\x1f\n" +
+	"\bpassword\x18\v \x01(\tH\x03R\bpassword\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"max_clicks\x18\f \x01(\x05H\x04R\tmaxClicks\x88\x01\x01\x12\x1e\n" +
+	"\bone_time\x18\r \x01(\bH\x05R\aoneTime\x88\x01\x01\x12&\n" +
+	"\factivates_at\x18\x0e \x01(\tH\x06R\vactivatesAt\x88\x01\x01\x12&\n" +
+	"\ffallback_url\x18\x0f \x01(\tH\aR\vfallbackUrl\x88\x01\x01\x125\n" +
+	"\x05rules\x18\x10 \x01(\v2\x1a.links_write.RedirectRulesH\bR\x05rules\x88\x01\x01\x12A\n" +
+	"\bvariants\x18\x11 \x01(\v2 .links_write.DestinationVariantsH\tR\bvariants\x88\x01\x01\x12,\n" +
+	"\x0fsticky_variants\x18\x12 \x01(\bH\n" +
+	"R\x0estickyVariants\x88\x01\x01B\x12\n" +
+	"\x10_expiration_dateB\v\n" +
+	"\t_disabledB\x06\n" +
+	"\x04_utmB\v\n" +
+	"\t_passwordB\r\n" +
+	"\v_max_clicksB\v\n" +
+	"\t_one_timeB\x0f\n" +
+	"\r_activates_atB\x0f\n" +
+	"\r_fallback_urlB\b\n" +
+	"\x06_rulesB\v\n" +
+	"\t_variantsB\x12\n" +
+	"\x10_sticky_variants\"\xc5\x06\n" +
+	"\x12UpdateLinkResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
+	"\foriginal_url\x18\x02 \x01(\tR\voriginalUrl\x12\x1b\n" +
+	"\tshort_url\x18\x03 \x01(\tR\bshortUrl\x12\x1f\n" +
+	"\vcustom_slug\x18\x04 \x01(\tR\n" +
+	"customSlug\x12\x16\n" +
+	"\x06clicks\x18\x05 \x01(\x05R\x06clicks\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\tR\tupdatedAt\x12\x1f\n" +
+	"\vcustomer_id\x18\b \x01(\tR\n" +
+	"customerId\x12,\n" +
+	"\x0fexpiration_date\x18\t \x01(\tH\x00R\x0eexpirationDate\x88\x01\x01\x12\x1a\n" +
+	"\bdisabled\x18\n" +
+	" \x01(\bR\bdisabled\x12\x1b\n" +
+	"\tslug_type\x18\v \x01(\tR\bslugType\x12\x14\n" +
+	"\x05title\x18\f \x01(\tR\x05title\x12\x12\n" +
+	"\x04tags\x18\r \x03(\tR\x04tags\x12\x16\n" +
+	"\x06domain\x18\x0e \x01(\tR\x06domain\x12-\n" +
+	"\x03utm\x18\x0f \x01(\v2\x16.links_write.UTMParamsH\x01R\x03utm\x88\x01\x01\x12-\n" +
+	"\x12password_protected\x18\x10 \x01(\bR\x11passwordProtected\x12\"\n" +
+	"\n" +
+	"max_clicks\x18\x11 \x01(\x05H\x02R\tmaxClicks\x88\x01\x01\x12&\n" +
+	"\factivates_at\x18\x12 \x01(\tH\x03R\vactivatesAt\x88\x01\x01\x12!\n" +
+	"\ffallback_url\x18\x13 \x01(\tR\vfallbackUrl\x12/\n" +
+	"\x05rules\x18\x14 \x03(\v2\x19.links_write.RedirectRuleR\x05rules\x12;\n" +
+	"\bvariants\x18\x15 \x03(\v2\x1f.links_write.DestinationVariantR\bvariants\x12'\n" +
+	"\x0fsticky_variants\x18\x16 \x01(\bR\x0estickyVariantsB\x12\n" +
+	"\x10_expiration_dateB\x06\n" +
+	"\x04_utmB\r\n" +
+	"\v_max_clicksB\x0f\n" +
+	"\r_activates_at\"g\n" +
+	"\x17UpdateLinkClicksRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\x02 \x01(\tR\tuserAgent\x12\x1d\n" +
+	"\n" +
+	"ip_address\x18\x03 \x01(\tR\tipAddress\"\xb0\x03\n" +
+	"\x18UpdateLinkClicksResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
+	"\foriginal_url\x18\x02 \x01(\tR\voriginalUrl\x12\x1b\n" +
+	"\tshort_url\x18\x03 \x01(\tR\bshortUrl\x12\x1f\n" +
+	"\vcustom_slug\x18\x04 \x01(\tR\n" +
+	"customSlug\x12\x16\n" +
+	"\x06clicks\x18\x05 \x01(\x05R\x06clicks\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\tR\tupdatedAt\x12\x1f\n" +
+	"\vcustomer_id\x18\b \x01(\tR\n" +
+	"customerId\x12,\n" +
+	"\x0fexpiration_date\x18\t \x01(\tH\x00R\x0eexpirationDate\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"max_clicks\x18\n" +
+	" \x01(\x05H\x01R\tmaxClicks\x88\x01\x01\x12&\n" +
+	"\fbot_category\x18\v \x01(\tH\x02R\vbotCategory\x88\x01\x01B\x12\n" +
+	"\x10_expiration_dateB\r\n" +
+	"\v_max_clicksB\x0f\n" +
+	"\r_bot_category\"\x85\x02\n" +
+	"\x12RecordClickRequest\x12\x17\n" +
+	"\alink_id\x18\x01 \x01(\tR\x06linkId\x12\x1a\n" +
+	"\breferrer\x18\x02 \x01(\tR\breferrer\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\x03 \x01(\tR\tuserAgent\x12\x1d\n" +
+	"\n" +
+	"ip_address\x18\x04 \x01(\tR\tipAddress\x12'\n" +
+	"\x0faccept_language\x18\x05 \x01(\tR\x0eacceptLanguage\x12\x18\n" +
+	"\acounted\x18\x06 \x01(\bR\acounted\x12\x1f\n" +
+	"\vlink_status\x18\a \x01(\tR\n" +
+	"linkStatus\x12\x18\n" +
+	"\avariant\x18\b \x01(\tR\avariant\"\xa1\x01\n" +
+	"\x13RecordClickResponse\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12\x17\n" +
+	"\alink_id\x18\x02 \x01(\tR\x06linkId\x12\x1d\n" +
+	"\n" +
+	"clicked_at\x18\x03 \x01(\tR\tclickedAt\x12&\n" +
+	"\fbot_category\x18\x04 \x01(\tH\x00R\vbotCategory\x88\x01\x01B\x0f\n" +
+	"\r_bot_category\"P\n" +
+	"\x15RegisterDomainRequest\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\x12\x16\n" +
+	"\x06domain\x18\x02 \x01(\tR\x06domain\"N\n" +
+	"\x13VerifyDomainRequest\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\x12\x16\n" +
+	"\x06domain\x18\x02 \x01(\tR\x06domain\"\xfe\x01\n" +
+	"\x0eDomainResponse\x12\x16\n" +
+	"\x06domain\x18\x01 \x01(\tR\x06domain\x12\x1f\n" +
+	"\vcustomer_id\x18\x02 \x01(\tR\n" +
+	"customerId\x12\x1a\n" +
+	"\bverified\x18\x03 \x01(\bR\bverified\x12\x1f\n" +
+	"\vrecord_name\x18\x04 \x01(\tR\n" +
+	"recordName\x12!\n" +
+	"\frecord_value\x18\x05 \x01(\tR\vrecordValue\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\tR\tcreatedAt\x12$\n" +
+	"\vverified_at\x18\a \x01(\tH\x00R\n" +
+	"verifiedAt\x88\x01\x01B\x0e\n" +
+	"\f_verified_at\"<\n" +
+	"\x19GetCustomerDomainsRequest\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\"S\n" +
+	"\x1aGetCustomerDomainsResponse\x125\n" +
+	"\adomains\x18\x01 \x03(\v2\x1b.links_write.DomainResponseR\adomains\"N\n" +
+	"\x13DeleteDomainRequest\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\x12\x16\n" +
+	"\x06domain\x18\x02 \x01(\tR\x06domain\"0\n" +
+	"\x14DeleteDomainResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x88\x01\n" +
+	"\x16BulkCreateLinksRequest\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\x124\n" +
+	"\x05links\x18\x02 \x03(\v2\x1e.links_write.CreateLinkRequestR\x05links\x12\x17\n" +
+	"\adry_run\x18\x03 \x01(\bR\x06dryRun\"\x99\x01\n" +
+	"\x14BulkCreateLinkResult\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x128\n" +
+	"\x04link\x18\x02 \x01(\v2\x1f.links_write.CreateLinkResponseH\x00R\x04link\x88\x01\x01\x12\x12\n" +
+	"\x04code\x18\x03 \x01(\tR\x04code\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05errorB\a\n" +
+	"\x05_link\"\xa1\x01\n" +
+	"\x17BulkCreateLinksResponse\x12;\n" +
+	"\aresults\x18\x01 \x03(\v2!.links_write.BulkCreateLinkResultR\aresults\x12\x18\n" +
+	"\acreated\x18\x02 \x01(\x05R\acreated\x12\x16\n" +
+	"\x06failed\x18\x03 \x01(\x05R\x06failed\x12\x17\n" +
+	"\adry_run\x18\x04 \x01(\bR\x06dryRun\"y\n" +
+	"\x18CreateUTMTemplateRequest\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12(\n" +
+	"\x03utm\x18\x03 \x01(\v2\x16.links_write.UTMParamsR\x03utm\"\xa3\x01\n" +
+	"\x13UTMTemplateResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vcustomer_id\x18\x02 \x01(\tR\n" +
+	"customerId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12(\n" +
+	"\x03utm\x18\x04 \x01(\v2\x16.links_write.UTMParamsR\x03utm\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\tR\tcreatedAt\"A\n" +
+	"\x1eGetCustomerUTMTemplatesRequest\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\"a\n" +
+	"\x1fGetCustomerUTMTemplatesResponse\x12>\n" +
+	"\ttemplates\x18\x01 \x03(\v2 .links_write.UTMTemplateResponseR\ttemplates\"K\n" +
+	"\x18DeleteUTMTemplateRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vcustomer_id\x18\x02 \x01(\tR\n" +
+	"customerId\"5\n" +
+	"\x19DeleteUTMTemplateResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"A\n" +
+	"\x1eGetCustomerLinkSettingsRequest\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\"\x8c\x01\n" +
+	"!UpdateCustomerLinkSettingsRequest\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\x12!\n" +
+	"\ffallback_url\x18\x02 \x01(\tR\vfallbackUrl\x12#\n" +
+	"\rfallback_mode\x18\x03 \x01(\tR\ffallbackMode\"\xa6\x01\n" +
+	"\x1cCustomerLinkSettingsResponse\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\x12!\n" +
+	"\ffallback_url\x18\x02 \x01(\tR\vfallbackUrl\x12#\n" +
+	"\rfallback_mode\x18\x03 \x01(\tR\ffallbackMode\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x04 \x01(\tR\tupdatedAt2\xb1\v\n" +
+	"\x11LinksServiceWrite\x12O\n" +
+	"\n" +
+	"CreateLink\x12\x1e.links_write.CreateLinkRequest\x1a\x1f.links_write.CreateLinkResponse\"\x00\x12O\n" +
+	"\n" +
+	"DeleteLink\x12\x1e.links_write.DeleteLinkRequest\x1a\x1f.links_write.DeleteLinkResponse\"\x00\x12O\n" +
+	"\n" +
+	"UpdateLink\x12\x1e.links_write.UpdateLinkRequest\x1a\x1f.links_write.UpdateLinkResponse\"\x00\x12a\n" +
+	"\x10UpdateLinkClicks\x12$.links_write.UpdateLinkClicksRequest\x1a%.links_write.UpdateLinkClicksResponse\"\x00\x12R\n" +
+	"\vRecordClick\x12\x1f.links_write.RecordClickRequest\x1a .links_write.RecordClickResponse\"\x00\x12S\n" +
+	"\x0eRegisterDomain\x12\".links_write.RegisterDomainRequest\x1a\x1b.links_write.DomainResponse\"\x00\x12O\n" +
+	"\fVerifyDomain\x12 .links_write.VerifyDomainRequest\x1a\x1b.links_write.DomainResponse\"\x00\x12g\n" +
+	"\x12GetCustomerDomains\x12&.links_write.GetCustomerDomainsRequest\x1a'.links_write.GetCustomerDomainsResponse\"\x00\x12^\n" +
+	"\x0fBulkCreateLinks\x12#.links_write.BulkCreateLinksRequest\x1a$.links_write.BulkCreateLinksResponse\"\x00\x12^\n" +
+	"\x11CreateUTMTemplate\x12%.links_write.CreateUTMTemplateRequest\x1a .links_write.UTMTemplateResponse\"\x00\x12v\n" +
+	"\x17GetCustomerUTMTemplates\x12+.links_write.GetCustomerUTMTemplatesRequest\x1a,.links_write.GetCustomerUTMTemplatesResponse\"\x00\x12d\n" +
+	"\x11DeleteUTMTemplate\x12%.links_write.DeleteUTMTemplateRequest\x1a&.links_write.DeleteUTMTemplateResponse\"\x00\x12s\n" +
+	"\x17GetCustomerLinkSettings\x12+.links_write.GetCustomerLinkSettingsRequest\x1a).links_write.CustomerLinkSettingsResponse\"\x00\x12y\n" +
+	"\x1aUpdateCustomerLinkSettings\x12..links_write.UpdateCustomerLinkSettingsRequest\x1a).links_write.CustomerLinkSettingsResponse\"\x00\x12U\n" +
+	"\fDeleteDomain\x12 .links_write.DeleteDomainRequest\x1a!.links_write.DeleteDomainResponse\"\x00B\x15Z\x13links-service/protob\x06proto3"
+
+var (
+	file_proto_links_write_proto_rawDescOnce sync.Once
+	file_proto_links_write_proto_rawDescData []byte
+)
+
+func file_proto_links_write_proto_rawDescGZIP() []byte {
+	file_proto_links_write_proto_rawDescOnce.Do(func() {
+		file_proto_links_write_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_links_write_proto_rawDesc), len(file_proto_links_write_proto_rawDesc)))
+	})
+	return file_proto_links_write_proto_rawDescData
+}
+
+var file_proto_links_write_proto_msgTypes = make([]protoimpl.MessageInfo, 34)
+var file_proto_links_write_proto_goTypes = []any{
+	(*UTMParams)(nil),                         // 0: links_write.UTMParams
+	(*RedirectRule)(nil),                      // 1: links_write.RedirectRule
+	(*RedirectRules)(nil),                     // 2: links_write.RedirectRules
+	(*DestinationVariant)(nil),                // 3: links_write.DestinationVariant
+	(*DestinationVariants)(nil),               // 4: links_write.DestinationVariants
+	(*CreateLinkRequest)(nil),                 // 5: links_write.CreateLinkRequest
+	(*CreateLinkResponse)(nil),                // 6: links_write.CreateLinkResponse
+	(*DeleteLinkRequest)(nil),                 // 7: links_write.DeleteLinkRequest
+	(*DeleteLinkResponse)(nil),                // 8: links_write.DeleteLinkResponse
+	(*UpdateLinkRequest)(nil),                 // 9: links_write.UpdateLinkRequest
+	(*UpdateLinkResponse)(nil),                // 10: links_write.UpdateLinkResponse
+	(*UpdateLinkClicksRequest)(nil),           // 11: links_write.UpdateLinkClicksRequest
+	(*UpdateLinkClicksResponse)(nil),          // 12: links_write.UpdateLinkClicksResponse
+	(*RecordClickRequest)(nil),                // 13: links_write.RecordClickRequest
+	(*RecordClickResponse)(nil),               // 14: links_write.RecordClickResponse
+	(*RegisterDomainRequest)(nil),             // 15: links_write.RegisterDomainRequest
+	(*VerifyDomainRequest)(nil),               // 16: links_write.VerifyDomainRequest
+	(*DomainResponse)(nil),                    // 17: links_write.DomainResponse
+	(*GetCustomerDomainsRequest)(nil),         // 18: links_write.GetCustomerDomainsRequest
+	(*GetCustomerDomainsResponse)(nil),        // 19: links_write.GetCustomerDomainsResponse
+	(*DeleteDomainRequest)(nil),               // 20: links_write.DeleteDomainRequest
+	(*DeleteDomainResponse)(nil),              // 21: links_write.DeleteDomainResponse
+	(*BulkCreateLinksRequest)(nil),            // 22: links_write.BulkCreateLinksRequest
+	(*BulkCreateLinkResult)(nil),              // 23: links_write.BulkCreateLinkResult
+	(*BulkCreateLinksResponse)(nil),           // 24: links_write.BulkCreateLinksResponse
+	(*CreateUTMTemplateRequest)(nil),          // 25: links_write.CreateUTMTemplateRequest
+	(*UTMTemplateResponse)(nil),               // 26: links_write.UTMTemplateResponse
+	(*GetCustomerUTMTemplatesRequest)(nil),    // 27: links_write.GetCustomerUTMTemplatesRequest
+	(*GetCustomerUTMTemplatesResponse)(nil),   // 28: links_write.GetCustomerUTMTemplatesResponse
+	(*DeleteUTMTemplateRequest)(nil),          // 29: links_write.DeleteUTMTemplateRequest
+	(*DeleteUTMTemplateResponse)(nil),         // 30: links_write.DeleteUTMTemplateResponse
+	(*GetCustomerLinkSettingsRequest)(nil),    // 31: links_write.GetCustomerLinkSettingsRequest
+	(*UpdateCustomerLinkSettingsRequest)(nil), // 32: links_write.UpdateCustomerLinkSettingsRequest
+	(*CustomerLinkSettingsResponse)(nil),      // 33: links_write.CustomerLinkSettingsResponse
+}
+var file_proto_links_write_proto_depIdxs = []int32{
+	1,  // 0: links_write.RedirectRules.rules:type_name -> links_write.RedirectRule
+	3,  // 1: links_write.DestinationVariants.variants:type_name -> links_write.DestinationVariant
+	0,  // 2: links_write.CreateLinkRequest.utm:type_name -> links_write.UTMParams
+	1,  // 3: links_write.CreateLinkRequest.rules:type_name -> links_write.RedirectRule
+	3,  // 4: links_write.CreateLinkRequest.variants:type_name -> links_write.DestinationVariant
+	0,  // 5: links_write.CreateLinkResponse.utm:type_name -> links_write.UTMParams
+	1,  // 6: links_write.CreateLinkResponse.rules:type_name -> links_write.RedirectRule
+	3,  // 7: links_write.CreateLinkResponse.variants:type_name -> links_write.DestinationVariant
+	0,  // 8: links_write.UpdateLinkRequest.utm:type_name -> links_write.UTMParams
+	2,  // 9: links_write.UpdateLinkRequest.rules:type_name -> links_write.RedirectRules
+	4,  // 10: links_write.UpdateLinkRequest.variants:type_name -> links_write.DestinationVariants
+	0,  // 11: links_write.UpdateLinkResponse.utm:type_name -> links_write.UTMParams
+	1,  // 12: links_write.UpdateLinkResponse.rules:type_name -> links_write.RedirectRule
+	3,  // 13: links_write.UpdateLinkResponse.variants:type_name -> links_write.DestinationVariant
+	17, // 14: links_write.GetCustomerDomainsResponse.domains:type_name -> links_write.DomainResponse
+	5,  // 15: links_write.BulkCreateLinksRequest.links:type_name -> links_write.CreateLinkRequest
+	6,  // 16: links_write.BulkCreateLinkResult.link:type_name -> links_write.CreateLinkResponse
+	23, // 17: links_write.BulkCreateLinksResponse.results:type_name -> links_write.BulkCreateLinkResult
+	0,  // 18: links_write.CreateUTMTemplateRequest.utm:type_name -> links_write.UTMParams
+	0,  // 19: links_write.UTMTemplateResponse.utm:type_name -> links_write.UTMParams
+	26, // 20: links_write.GetCustomerUTMTemplatesResponse.templates:type_name -> links_write.UTMTemplateResponse
+	5,  // 21: links_write.LinksServiceWrite.CreateLink:input_type -> links_write.CreateLinkRequest
+	7,  // 22: links_write.LinksServiceWrite.DeleteLink:input_type -> links_write.DeleteLinkRequest
+	9,  // 23: links_write.LinksServiceWrite.UpdateLink:input_type -> links_write.UpdateLinkRequest
+	11, // 24: links_write.LinksServiceWrite.UpdateLinkClicks:input_type -> links_write.UpdateLinkClicksRequest
+	13, // 25: links_write.LinksServiceWrite.RecordClick:input_type -> links_write.RecordClickRequest
+	15, // 26: links_write.LinksServiceWrite.RegisterDomain:input_type -> links_write.RegisterDomainRequest
+	16, // 27: links_write.LinksServiceWrite.VerifyDomain:input_type -> links_write.VerifyDomainRequest
+	18, // 28: links_write.LinksServiceWrite.GetCustomerDomains:input_type -> links_write.GetCustomerDomainsRequest
+	22, // 29: links_write.LinksServiceWrite.BulkCreateLinks:input_type -> links_write.BulkCreateLinksRequest
+	25, // 30: links_write.LinksServiceWrite.CreateUTMTemplate:input_type -> links_write.CreateUTMTemplateRequest
+	27, // 31: links_write.LinksServiceWrite.GetCustomerUTMTemplates:input_type -> links_write.GetCustomerUTMTemplatesRequest
+	29, // 32: links_write.LinksServiceWrite.DeleteUTMTemplate:input_type -> links_write.DeleteUTMTemplateRequest
+	31, // 33: links_write.LinksServiceWrite.GetCustomerLinkSettings:input_type -> links_write.GetCustomerLinkSettingsRequest
+	32, // 34: links_write.LinksServiceWrite.UpdateCustomerLinkSettings:input_type -> links_write.UpdateCustomerLinkSettingsRequest
+	20, // 35: links_write.LinksServiceWrite.DeleteDomain:input_type -> links_write.DeleteDomainRequest
+	6,  // 36: links_write.LinksServiceWrite.CreateLink:output_type -> links_write.CreateLinkResponse
+	8,  // 37: links_write.LinksServiceWrite.DeleteLink:output_type -> links_write.DeleteLinkResponse
+	10, // 38: links_write.LinksServiceWrite.UpdateLink:output_type -> links_write.UpdateLinkResponse
+	12, // 39: links_write.LinksServiceWrite.UpdateLinkClicks:output_type -> links_write.UpdateLinkClicksResponse
+	14, // 40: links_write.LinksServiceWrite.RecordClick:output_type -> links_write.RecordClickResponse
+	17, // 41: links_write.LinksServiceWrite.RegisterDomain:output_type -> links_write.DomainResponse
+	17, // 42: links_write.LinksServiceWrite.VerifyDomain:output_type -> links_write.DomainResponse
+	19, // 43: links_write.LinksServiceWrite.GetCustomerDomains:output_type -> links_write.GetCustomerDomainsResponse
+	24, // 44: links_write.LinksServiceWrite.BulkCreateLinks:output_type -> links_write.BulkCreateLinksResponse
+	26, // 45: links_write.LinksServiceWrite.CreateUTMTemplate:output_type -> links_write.UTMTemplateResponse
+	28, // 46: links_write.LinksServiceWrite.GetCustomerUTMTemplates:output_type -> links_write.GetCustomerUTMTemplatesResponse
+	30, // 47: links_write.LinksServiceWrite.DeleteUTMTemplate:output_type -> links_write.DeleteUTMTemplateResponse
+	33, // 48: links_write.LinksServiceWrite.GetCustomerLinkSettings:output_type -> links_write.CustomerLinkSettingsResponse
+	33, // 49: links_write.LinksServiceWrite.UpdateCustomerLinkSettings:output_type -> links_write.CustomerLinkSettingsResponse
+	21, // 50: links_write.LinksServiceWrite.DeleteDomain:output_type -> links_write.DeleteDomainResponse
+	36, // [36:51] is the sub-list for method output_type
+	21, // [21:36] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
+}
+
+func init() { file_proto_links_write_proto_init() }
+func file_proto_links_write_proto_init() {
+	if File_proto_links_write_proto != nil {
+		return
+	}
+	file_proto_links_write_proto_msgTypes[5].OneofWrappers = []any{}
+	file_proto_links_write_proto_msgTypes[6].OneofWrappers = []any{}
+	file_proto_links_write_proto_msgTypes[9].OneofWrappers = []any{}
+	file_proto_links_write_proto_msgTypes[10].OneofWrappers = []any{}
+	file_proto_links_write_proto_msgTypes[12].OneofWrappers = []any{}
+	file_proto_links_write_proto_msgTypes[14].OneofWrappers = []any{}
+	file_proto_links_write_proto_msgTypes[17].OneofWrappers = []any{}
+	file_proto_links_write_proto_msgTypes[23].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_links_write_proto_rawDesc), len(file_proto_links_write_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   34,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_links_write_proto_goTypes,
+		DependencyIndexes: file_proto_links_write_proto_depIdxs,
+		MessageInfos:      file_proto_links_write_proto_msgTypes,
+	}.Build()
+	File_proto_links_write_proto = out.File
+	file_proto_links_write_proto_goTypes = nil
+	file_proto_links_write_proto_depIdxs = nil
+}
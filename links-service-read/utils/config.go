@@ -1,12 +1,28 @@
 package utils
 
 import (
+	"net/netip"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type Config struct {
-	FrontendSource string
-	DynamoEndpoint string
+	FrontendSource       string
+	DynamoEndpoint       string
+	LinksServiceWriteUrl string
+	RedirectPermanent    bool
+	RedirectHosts        []string
+	RedisHost            string
+	RedisPort            string
+	LinksStore           string
+	DBSource             string
+	LinkAccessSecret     string
+	GeoIPPath            string
+	GeoIPReload          time.Duration
+	TrustedProxies       []netip.Prefix
+	LinkPasswordAttempts int
 }
 
 var (
@@ -17,10 +33,78 @@ var (
 // It retrieves the following environment variables:
 // - FRONTEND_SOURCE: The source URL for the frontend.
 // - DYNAMODB_ENDPOINT: The endpoint URL for DynamoDB.
+// - LINKS_SERVICE_WRITE_URL: The gRPC address of links-service-write, used to record clicks.
+// - REDIRECT_PERMANENT: When "true", redirects are answered with 301 instead of 302.
+// - REDIRECT_HOSTS: Comma-separated hosts serving default-domain links besides FRONTEND_SOURCE's; other hosts are branded domains.
+// - REDIS_HOST / REDIS_PORT: The Redis server holding unique visitor counters and live clicks (optional).
+// - LINKS_STORE: The storage backend of links and clicks: dynamodb (default), postgres or memory.
+// - DB_SOURCE: The PostgreSQL connection string, used when LINKS_STORE is postgres.
+// - LINK_ACCESS_SECRET: The key signing the access cookies of password-protected links; a random one is used when unset.
+// - GEOIP_DATABASE_PATH: The local GeoLite2/GeoIP2 City (.mmdb) file locating visitors for the country conditions of redirect rules.
+// - GEOIP_RELOAD_INTERVAL: How often the GeoIP file is checked for changes (defaults to 1m).
+// - TRUSTED_PROXIES: Comma-separated IP addresses or CIDR ranges of the proxies in front of the service, whose X-Forwarded-For hops are trusted by the password attempt limits; invalid entries are ignored.
+// - LINK_PASSWORD_ATTEMPTS: The failed password attempts allowed on a link every 15 minutes from all IP addresses together, after which only the addresses that unlocked it in the last day can try (defaults to 100). Lower values resist distributed guessing better but let fewer attackers lock new visitors out.
 // These values are used to populate the Config struct.
 func LoadEnvInstance() {
 	ConfigInstance = Config{
-		FrontendSource: os.Getenv("FRONTEND_SOURCE"),
-		DynamoEndpoint: os.Getenv("DYNAMODB_ENDPOINT"),
+		FrontendSource:       os.Getenv("FRONTEND_SOURCE"),
+		DynamoEndpoint:       os.Getenv("DYNAMODB_ENDPOINT"),
+		LinksServiceWriteUrl: os.Getenv("LINKS_SERVICE_WRITE_URL"),
+		RedirectPermanent:    os.Getenv("REDIRECT_PERMANENT") == "true",
+		RedirectHosts:        listEnv("REDIRECT_HOSTS"),
+		RedisHost:            os.Getenv("REDIS_HOST"),
+		RedisPort:            os.Getenv("REDIS_PORT"),
+		LinksStore:           stringEnv("LINKS_STORE", "dynamodb"),
+		DBSource:             os.Getenv("DB_SOURCE"),
+		LinkAccessSecret:     os.Getenv("LINK_ACCESS_SECRET"),
+		GeoIPPath:            os.Getenv("GEOIP_DATABASE_PATH"),
+		GeoIPReload:          durationEnv("GEOIP_RELOAD_INTERVAL", time.Minute),
+		TrustedProxies:       prefixListEnv("TRUSTED_PROXIES"),
+		LinkPasswordAttempts: intEnv("LINK_PASSWORD_ATTEMPTS", 0),
 	}
 }
+
+func durationEnv(key string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+func intEnv(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+func stringEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+func listEnv(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.ToLower(strings.TrimSpace(value)); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func prefixListEnv(key string) []netip.Prefix {
+	var prefixes []netip.Prefix
+	for _, value := range listEnv(key) {
+		if prefix, err := netip.ParsePrefix(value); err == nil {
+			prefixes = append(prefixes, prefix.Masked())
+		} else if addr, err := netip.ParseAddr(value); err == nil {
+			prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+		}
+	}
+	return prefixes
+}
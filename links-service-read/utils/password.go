@@ -0,0 +1,13 @@
+package utils
+
+import "golang.org/x/crypto/bcrypt"
+
+// CheckPassword reports whether password matches the bcrypt hash of a protected link's
+// password, as stored by links-service-write.
+//
+// Returns:
+//   - nil if the password matches, bcrypt.ErrMismatchedHashAndPassword if it does not,
+//     or another error if the hash is invalid.
+func CheckPassword(password string, hashedPassword string) error {
+	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
+}
@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// ShortURL returns the public URL of a slug: on the branded domain when there is one,
+// otherwise on the frontend.
+//
+// Parameters:
+//   - domain: The branded domain of the link, or "" for the default one.
+//   - slug: The slug of the link.
+//
+// Returns:
+//   - The short URL, such as "https://go.example.com/sale" or "<FRONTEND_SOURCE>/sale".
+func ShortURL(domain, slug string) string {
+	if domain == "" {
+		return ConfigInstance.FrontendSource + "/" + slug
+	}
+	return "https://" + domain + "/" + slug
+}
+
+// LinkDomain returns the branded domain a request host stands for, or "" when the host
+// serves default-domain links: the FRONTEND_SOURCE host, the REDIRECT_HOSTS, localhost
+// and IP addresses.
+//
+// Parameters:
+//   - host: The host of the request, with or without port.
+//
+// Returns:
+//   - The lowercased host name without port, or "" for the default domain.
+func LinkDomain(host string) string {
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		host = hostname
+	}
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+
+	if host == "" || host == "localhost" || net.ParseIP(strings.Trim(host, "[]")) != nil {
+		return ""
+	}
+	if frontend, err := url.Parse(ConfigInstance.FrontendSource); err == nil && strings.EqualFold(frontend.Hostname(), host) {
+		return ""
+	}
+	if slices.Contains(ConfigInstance.RedirectHosts, host) {
+		return ""
+	}
+	return host
+}
@@ -3,10 +3,18 @@ package main
 import (
 	"context"
 	"fmt"
+	"links-service-write/internal/botfilter"
+	"links-service-write/internal/clickstream"
+	"links-service-write/internal/domains"
+	"links-service-write/internal/geoip"
+	"links-service-write/internal/infra/cache"
 	"links-service-write/internal/infra/database"
 	"links-service-write/internal/infra/repository"
 	"links-service-write/internal/logger"
 	"links-service-write/internal/server"
+	"links-service-write/internal/shortcode"
+	"links-service-write/internal/slugpolicy"
+	"links-service-write/internal/visitors"
 	"links-service-write/utils"
 	"os"
 	"os/signal"
@@ -14,6 +22,7 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/go-redis/redis/v8"
 	"github.com/joho/godotenv"
 	"go.uber.org/zap"
 )
@@ -48,6 +57,71 @@ func initDynamo() (*dynamodb.Client, error) {
 	return client, nil
 }
 
+// initLinkStore creates the storage backend selected by LINKS_STORE. Only the DynamoDB
+// backend needs the read model and slug reservation backfills, which run in the
+// background until ctx ends.
+// The returned function releases the backend's connections.
+func initLinkStore(ctx context.Context) (repository.LinkStore, func(), error) {
+	switch utils.ConfigInstance.LinksStore {
+	case repository.StoreDynamoDB:
+		db, err := initDynamo()
+		if err != nil {
+			return nil, nil, err
+		}
+		store := repository.NewDynamoLinkStore(db)
+
+		go func() {
+			if _, err := store.BackfillReadModel(ctx); err != nil {
+				logger.Log.Error("Failed to backfill links read model",
+					zap.Error(err),
+					zap.String("component", "repository"),
+				)
+			}
+			if _, err := store.BackfillSlugReservations(ctx); err != nil {
+				logger.Log.Error("Failed to backfill slug reservations",
+					zap.Error(err),
+					zap.String("component", "repository"),
+				)
+			}
+		}()
+		return store, func() {}, nil
+
+	case repository.StorePostgres:
+		pool, err := database.NewPostgresConnection()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to connect to PostgreSQL: %w", err)
+		}
+		return repository.NewPostgresLinkStore(pool), pool.Close, nil
+
+	case repository.StoreMemory:
+		logger.Log.Warn("Using the in-memory links store, links are lost on restart and not shared with links-service-read",
+			zap.String("component", "repository"),
+		)
+		return repository.NewMemoryLinkStore(), func() {}, nil
+
+	default:
+		return nil, nil, fmt.Errorf("unknown LINKS_STORE %q", utils.ConfigInstance.LinksStore)
+	}
+}
+
+// initRedis connects to the Redis server holding unique visitor counters and carrying
+// live click notifications. Redis is optional: without REDIS_HOST, unique visitors are
+// not counted and clicks are not announced.
+func initRedis() (*redis.Client, error) {
+	if utils.ConfigInstance.RedisHost == "" {
+		logger.Log.Warn("Redis not configured, unique visitor counting and live clicks disabled",
+			zap.String("component", "cache"),
+		)
+		return nil, nil
+	}
+
+	rdb, err := cache.NewRedisClient(utils.ConfigInstance.RedisHost, utils.ConfigInstance.RedisPort)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Redis client: %w", err)
+	}
+	return rdb, nil
+}
+
 func main() {
 	defer logger.Log.Sync()
 
@@ -57,29 +131,75 @@ func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	db, err := initDynamo()
+	linksRepo, closeStore, err := initLinkStore(ctx)
 	if err != nil {
-		logger.Log.Fatal("Failed to connect to DynamoDB",
+		logger.Log.Fatal("Failed to initialize links store",
 			zap.Error(err),
 			zap.String("component", "database"),
 		)
 	}
-	logger.Log.Info("Successfully connected to DynamoDB",
-		zap.String("component", "database"),
+	defer closeStore()
+	logger.Log.Info("Links store initialized",
+		zap.String("store", utils.ConfigInstance.LinksStore),
+		zap.String("component", "repository"),
 	)
 
-	linksRepo := repository.NewLinksRepository(db)
-	logger.Log.Info("LinksRepository initialized",
-		zap.String("component", "repository"),
+	geo := geoip.NewResolver(utils.ConfigInstance.GeoIPPath, utils.ConfigInstance.GeoIPReload)
+	defer geo.Close()
+	go geo.Watch(ctx)
+	asn := geoip.NewResolver(utils.ConfigInstance.GeoIPASNPath, utils.ConfigInstance.GeoIPReload)
+	defer asn.Close()
+	go asn.Watch(ctx)
+	logger.Log.Info("GeoIP resolvers initialized",
+		zap.String("component", "geoip"),
 	)
 
+	bots, err := botfilter.LoadClassifier(utils.ConfigInstance.BotRulesPath)
+	if err != nil {
+		logger.Log.Fatal("Failed to load bot filtering rules",
+			zap.Error(err),
+			zap.String("component", "botfilter"),
+		)
+	}
+
+	slugs, err := shortcode.LoadGenerator(utils.ConfigInstance.SlugSettings, linksRepo)
+	if err != nil {
+		logger.Log.Fatal("Failed to load short code settings",
+			zap.Error(err),
+			zap.String("component", "shortcode"),
+		)
+	}
+
+	policy, err := slugpolicy.LoadPolicy(utils.ConfigInstance.SlugPolicyPath)
+	if err != nil {
+		logger.Log.Fatal("Failed to load slug policy",
+			zap.Error(err),
+			zap.String("component", "slugpolicy"),
+		)
+	}
+
+	owners := domains.NewVerifier(domains.NewResolver(utils.ConfigInstance.DNSResolver))
+
+	rdb, err := initRedis()
+	if err != nil {
+		logger.Log.Fatal("Failed to connect to Redis",
+			zap.Error(err),
+			zap.String("component", "cache"),
+		)
+	}
+	if rdb != nil {
+		defer rdb.Close()
+	}
+	visitorCounter := visitors.NewCounter(rdb)
+	clickPublisher := clickstream.NewPublisher(rdb)
+
 	go func() {
 		logger.Log.Info("Starting gRPC server",
 			zap.String("port", "50052"),
 			zap.String("component", "server"),
 		)
 
-		if err := server.StartGRPCServer("50052", linksRepo); err != nil {
+		if err := server.StartGRPCServer("50052", linksRepo, slugs, policy, geo, asn, bots, owners, visitorCounter, clickPublisher); err != nil {
 			logger.Log.Error("Failed to start gRPC server",
 				zap.Error(err),
 				zap.String("component", "server"),
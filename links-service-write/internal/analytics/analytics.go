@@ -0,0 +1,81 @@
+package analytics
+
+import (
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	GranularityHour = "hour"
+	GranularityDay  = "day"
+	GranularityWeek = "week"
+)
+
+// Granularities lists every bucket size maintained for click rollups.
+var Granularities = []string{GranularityHour, GranularityDay, GranularityWeek}
+
+// BucketKey returns the rollup sort key of the bucket containing t for the given granularity.
+// Keys are prefixed with the granularity and sort lexicographically in time order:
+//   - hour: "hour#2006-01-02T15"
+//   - day:  "day#2006-01-02"
+//   - week: "week#2006-01-02", where the date is the Monday starting the ISO week.
+//
+// All buckets are computed in UTC.
+func BucketKey(granularity string, t time.Time) string {
+	t = t.UTC()
+	switch granularity {
+	case GranularityHour:
+		return GranularityHour + "#" + t.Format("2006-01-02T15")
+	case GranularityWeek:
+		offset := (int(t.Weekday()) + 6) % 7
+		monday := time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, time.UTC)
+		return GranularityWeek + "#" + monday.Format("2006-01-02")
+	default:
+		return GranularityDay + "#" + t.Format("2006-01-02")
+	}
+}
+
+// ReferrerDomain reduces a Referer header to the host it came from, lowercased and without
+// a leading "www.". Visits without a referrer are reported as "direct" and unparseable
+// values as "unknown".
+func ReferrerDomain(referrer string) string {
+	referrer = strings.TrimSpace(referrer)
+	if referrer == "" {
+		return "direct"
+	}
+
+	u, err := url.Parse(referrer)
+	if err != nil || u.Hostname() == "" {
+		return "unknown"
+	}
+
+	host := strings.ToLower(u.Hostname())
+	return strings.TrimPrefix(host, "www.")
+}
+
+// DeviceClass classifies a User-Agent header into "mobile", "tablet", "desktop" or "unknown".
+// The classification is a coarse heuristic meant for reporting, not for feature detection.
+func DeviceClass(userAgent string) string {
+	ua := strings.ToLower(userAgent)
+	switch {
+	case ua == "":
+		return "unknown"
+	case strings.Contains(ua, "ipad"),
+		strings.Contains(ua, "tablet"),
+		strings.Contains(ua, "android") && !strings.Contains(ua, "mobile"):
+		return "tablet"
+	case strings.Contains(ua, "mobi"),
+		strings.Contains(ua, "iphone"),
+		strings.Contains(ua, "ipod"),
+		strings.Contains(ua, "windows phone"):
+		return "mobile"
+	case strings.Contains(ua, "windows"),
+		strings.Contains(ua, "macintosh"),
+		strings.Contains(ua, "x11"),
+		strings.Contains(ua, "cros"):
+		return "desktop"
+	default:
+		return "unknown"
+	}
+}
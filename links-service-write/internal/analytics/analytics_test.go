@@ -0,0 +1,56 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBucketKey(t *testing.T) {
+	clickedAt := time.Date(2025, time.January, 2, 15, 4, 5, 0, time.UTC)
+
+	t.Run("Hour bucket truncates to the hour", func(t *testing.T) {
+		require.Equal(t, "hour#2025-01-02T15", BucketKey(GranularityHour, clickedAt))
+	})
+
+	t.Run("Day bucket truncates to the day", func(t *testing.T) {
+		require.Equal(t, "day#2025-01-02", BucketKey(GranularityDay, clickedAt))
+	})
+
+	t.Run("Week bucket starts on Monday", func(t *testing.T) {
+		require.Equal(t, "week#2024-12-30", BucketKey(GranularityWeek, clickedAt))
+		sunday := time.Date(2025, time.January, 5, 23, 0, 0, 0, time.UTC)
+		require.Equal(t, "week#2024-12-30", BucketKey(GranularityWeek, sunday))
+	})
+
+	t.Run("Buckets are computed in UTC", func(t *testing.T) {
+		saoPaulo := time.FixedZone("BRT", -3*60*60)
+		local := time.Date(2025, time.January, 2, 22, 0, 0, 0, saoPaulo)
+		require.Equal(t, "day#2025-01-03", BucketKey(GranularityDay, local))
+	})
+}
+
+func TestReferrerDomain(t *testing.T) {
+	require.Equal(t, "direct", ReferrerDomain(""))
+	require.Equal(t, "google.com", ReferrerDomain("https://www.Google.com/search?q=gobizz"))
+	require.Equal(t, "t.co", ReferrerDomain("https://t.co/abc"))
+	require.Equal(t, "unknown", ReferrerDomain("not a url"))
+}
+
+func TestDeviceClass(t *testing.T) {
+	cases := map[string]string{
+		"": "unknown",
+		"Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) Mobile/15E148": "mobile",
+		"Mozilla/5.0 (Linux; Android 14; Pixel 8) Mobile Safari/537.36":        "mobile",
+		"Mozilla/5.0 (iPad; CPU OS 17_0 like Mac OS X)":                        "tablet",
+		"Mozilla/5.0 (Linux; Android 13; SM-X700) Safari/537.36":               "tablet",
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) Chrome/124.0":               "desktop",
+		"Mozilla/5.0 (Macintosh; Intel Mac OS X 14_4) Safari/605.1.15":         "desktop",
+		"curl/8.5.0": "unknown",
+	}
+
+	for userAgent, expected := range cases {
+		require.Equal(t, expected, DeviceClass(userAgent), "user agent %q", userAgent)
+	}
+}
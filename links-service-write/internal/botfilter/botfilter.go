@@ -0,0 +1,157 @@
+package botfilter
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// CategoryBot covers crawlers, HTTP libraries, uptime monitors and traffic from
+	// hosting networks.
+	CategoryBot = "bot"
+	// CategoryPreviewer covers link unfurlers of chat and social apps, which fetch a
+	// link as soon as it is pasted.
+	CategoryPreviewer = "previewer"
+	// CategoryHeadless covers automated browsers.
+	CategoryHeadless = "headless"
+)
+
+//go:embed rules.json
+var defaultRules []byte
+
+type UserAgentRule struct {
+	Pattern  string `json:"pattern"`
+	Category string `json:"category"`
+}
+
+type ASNRule struct {
+	ASN      uint   `json:"asn"`
+	Name     string `json:"name"`
+	Category string `json:"category"`
+}
+
+// Ruleset is the serialized form of the rules used to tell automated clicks apart from
+// human ones. User agent patterns are case-insensitive regular expressions evaluated in
+// order; the first match wins. ASN rules are only consulted when no pattern matches.
+type Ruleset struct {
+	UserAgents []UserAgentRule `json:"user_agents"`
+	ASNs       []ASNRule       `json:"asns"`
+}
+
+type Classification struct {
+	Category string
+	Reason   string
+}
+
+// IsBot reports whether the click was classified as automated traffic.
+func (c Classification) IsBot() bool {
+	return c.Category != ""
+}
+
+type userAgentMatcher struct {
+	source   string
+	pattern  *regexp.Regexp
+	category string
+}
+
+// Classifier decides whether a click comes from a bot, a link previewer or a headless
+// browser, based on its user agent and the autonomous system announcing its IP address.
+// A Classifier is immutable and safe for concurrent use.
+type Classifier struct {
+	userAgents []userAgentMatcher
+	asns       map[uint]ASNRule
+}
+
+// NewClassifier compiles a Ruleset into a Classifier.
+//
+// Parameters:
+//   - rules: The user agent and ASN rules to apply.
+//
+// Returns:
+//   - A pointer to the compiled Classifier.
+//   - An error if a pattern does not compile or a rule has no category.
+func NewClassifier(rules Ruleset) (*Classifier, error) {
+	classifier := &Classifier{
+		userAgents: make([]userAgentMatcher, 0, len(rules.UserAgents)),
+		asns:       make(map[uint]ASNRule, len(rules.ASNs)),
+	}
+
+	for _, rule := range rules.UserAgents {
+		if rule.Category == "" {
+			return nil, fmt.Errorf("user agent rule %q has no category", rule.Pattern)
+		}
+		pattern, err := regexp.Compile("(?i)" + rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid user agent pattern %q: %v", rule.Pattern, err)
+		}
+		classifier.userAgents = append(classifier.userAgents, userAgentMatcher{source: rule.Pattern, pattern: pattern, category: rule.Category})
+	}
+
+	for _, rule := range rules.ASNs {
+		if rule.Category == "" {
+			return nil, fmt.Errorf("ASN rule %d has no category", rule.ASN)
+		}
+		classifier.asns[rule.ASN] = rule
+	}
+
+	return classifier, nil
+}
+
+// LoadClassifier builds a Classifier from the JSON ruleset at path. An empty path loads
+// the ruleset embedded in the binary, which can be used as a template for custom files.
+//
+// Parameters:
+//   - path: Location of a JSON ruleset on disk, or "" for the default ruleset.
+//
+// Returns:
+//   - A pointer to the compiled Classifier.
+//   - An error if the file cannot be read or parsed.
+func LoadClassifier(path string) (*Classifier, error) {
+	data := defaultRules
+	if path != "" {
+		var err error
+		data, err = os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read bot rules: %v", err)
+		}
+	}
+
+	var rules Ruleset
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse bot rules: %v", err)
+	}
+	return NewClassifier(rules)
+}
+
+// Classify returns the category of a click. Requests without a user agent are treated as
+// bots, since every browser and previewer sends one.
+//
+// Parameters:
+//   - userAgent: The User-Agent header of the request.
+//   - asn: The autonomous system number of the client IP address, or 0 if unknown.
+//
+// Returns:
+//   - A Classification whose Category is empty for human traffic.
+func (c *Classifier) Classify(userAgent string, asn uint) Classification {
+	userAgent = strings.TrimSpace(userAgent)
+	if userAgent == "" {
+		return Classification{Category: CategoryBot, Reason: "empty user agent"}
+	}
+
+	for _, matcher := range c.userAgents {
+		if matcher.pattern.MatchString(userAgent) {
+			return Classification{Category: matcher.category, Reason: "user agent matches " + matcher.source}
+		}
+	}
+
+	if rule, ok := c.asns[asn]; ok && asn != 0 {
+		return Classification{Category: rule.Category, Reason: "AS" + strconv.FormatUint(uint64(asn), 10) + " " + rule.Name}
+	}
+
+	return Classification{}
+}
@@ -0,0 +1,62 @@
+package botfilter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	chromeUA   = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36"
+	cubotUA    = "Mozilla/5.0 (Linux; Android 12; CUBOT X30) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Mobile Safari/537.36"
+	googleUA   = "Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)"
+	slackUA    = "Slackbot-LinkExpanding 1.0 (+https://api.slack.com/robots)"
+	facebookUA = "facebookexternalhit/1.1 (+http://www.facebook.com/externalhit_uatext.php)"
+	headlessUA = "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) HeadlessChrome/124.0.0.0 Safari/537.36"
+)
+
+func TestDefaultClassifier(t *testing.T) {
+	classifier, err := LoadClassifier("")
+	require.NoError(t, err)
+
+	t.Run("Browsers are human", func(t *testing.T) {
+		require.False(t, classifier.Classify(chromeUA, 0).IsBot())
+		require.False(t, classifier.Classify(cubotUA, 0).IsBot(), "Device names containing 'bot' must not match")
+	})
+
+	t.Run("Link previewers are detected before generic bots", func(t *testing.T) {
+		require.Equal(t, CategoryPreviewer, classifier.Classify(slackUA, 0).Category)
+		require.Equal(t, CategoryPreviewer, classifier.Classify(facebookUA, 0).Category)
+	})
+
+	t.Run("Crawlers, libraries and headless browsers are detected", func(t *testing.T) {
+		require.Equal(t, CategoryBot, classifier.Classify(googleUA, 0).Category)
+		require.Equal(t, CategoryBot, classifier.Classify("curl/8.5.0", 0).Category)
+		require.Equal(t, CategoryHeadless, classifier.Classify(headlessUA, 0).Category)
+	})
+
+	t.Run("Empty user agents are bots", func(t *testing.T) {
+		require.Equal(t, CategoryBot, classifier.Classify("  ", 0).Category)
+	})
+
+	t.Run("Hosting networks are bots", func(t *testing.T) {
+		classification := classifier.Classify(chromeUA, 16509)
+		require.Equal(t, CategoryBot, classification.Category)
+		require.Contains(t, classification.Reason, "AS16509")
+	})
+}
+
+func TestLoadClassifierFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"user_agents":[{"pattern":"^internal-probe","category":"bot"}]}`), 0o644))
+
+	classifier, err := LoadClassifier(path)
+	require.NoError(t, err)
+	require.True(t, classifier.Classify("Internal-Probe/1.0", 0).IsBot())
+	require.False(t, classifier.Classify(googleUA, 0).IsBot(), "Custom rules replace the defaults")
+
+	_, err = NewClassifier(Ruleset{UserAgents: []UserAgentRule{{Pattern: "(", Category: CategoryBot}}})
+	require.Error(t, err)
+}
@@ -0,0 +1,73 @@
+package clickstream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Notification is the message published for every recorded click. It must match the
+// messages decoded by links-service-read.
+type Notification struct {
+	EventID        string `json:"event_id"`
+	LinkID         string `json:"link_id"`
+	CustomerID     string `json:"customer_id"`
+	ClickedAt      string `json:"clicked_at"`
+	ReferrerDomain string `json:"referrer_domain,omitempty"`
+	DeviceClass    string `json:"device_class,omitempty"`
+	Country        string `json:"country,omitempty"`
+	Region         string `json:"region,omitempty"`
+	City           string `json:"city,omitempty"`
+	BotCategory    string `json:"bot_category,omitempty"`
+}
+
+// Channel returns the Redis Pub/Sub channel carrying the clicks on a customer's links.
+func Channel(customerID string) string {
+	return "clicks:" + customerID
+}
+
+// Publisher announces recorded clicks over Redis Pub/Sub, so that links-service-read can
+// stream them to dashboards as they happen. Pub/Sub is fire-and-forget: clicks published
+// while nobody is watching are simply dropped, the stored events being the source of truth.
+//
+// A Publisher without a Redis client is disabled and silently publishes nothing.
+type Publisher struct {
+	rdb *redis.Client
+}
+
+// NewPublisher creates a Publisher backed by the given Redis client.
+//
+// Parameters:
+//   - rdb: The Redis client to publish on, or nil to disable publishing.
+//
+// Returns:
+//   - A pointer to the initialized Publisher.
+func NewPublisher(rdb *redis.Client) *Publisher {
+	return &Publisher{rdb: rdb}
+}
+
+// Publish announces a click on the channel of the customer owning the link.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - notification: The click to announce. CustomerID selects the channel.
+//
+// Returns:
+//   - An error if the notification cannot be encoded or Redis cannot be reached.
+func (p *Publisher) Publish(ctx context.Context, notification Notification) error {
+	if p.rdb == nil {
+		return nil
+	}
+
+	payload, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("failed to encode click notification: %v", err)
+	}
+
+	if err := p.rdb.Publish(ctx, Channel(notification.CustomerID), payload).Err(); err != nil {
+		return fmt.Errorf("failed to publish click notification: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package domains
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"golang.org/x/net/idna"
+)
+
+// Ownership of a branded domain is proven by a TXT record named RecordName(domain)
+// holding RecordValue(token), the token being issued when the domain is registered.
+const (
+	recordPrefix = "_gobizz-verification."
+	valuePrefix  = "gobizz-verification="
+)
+
+// lookupTimeout bounds the DNS lookups of a verification.
+const lookupTimeout = 5 * time.Second
+
+// TXTResolver looks up the TXT records of a name. *net.Resolver implements it.
+type TXTResolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// NewResolver returns the resolver checking verification records.
+//
+// Parameters:
+//   - addr: The host:port of the DNS server to query, or "" for the system resolver.
+//
+// Returns:
+//   - A TXTResolver querying addr over UDP, or the system resolver.
+func NewResolver(addr string) TXTResolver {
+	if addr == "" {
+		return net.DefaultResolver
+	}
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, network, addr)
+		},
+	}
+}
+
+// Normalize returns the canonical form of a domain name: lowercased, without trailing
+// dot and with internationalized labels in their ASCII (punycode) form.
+//
+// Parameters:
+//   - domain: The domain name entered by the customer, such as "Links.Example.com.".
+//
+// Returns:
+//   - The canonical domain name, such as "links.example.com".
+//   - An error if domain is not a valid host name with at least two labels, or is an
+//     IP address.
+func Normalize(domain string) (string, error) {
+	domain = strings.TrimSuffix(strings.TrimSpace(domain), ".")
+	if domain == "" {
+		return "", fmt.Errorf("domain is required")
+	}
+	if net.ParseIP(domain) != nil {
+		return "", fmt.Errorf("domain '%s' is an IP address", domain)
+	}
+
+	ascii, err := idna.Lookup.ToASCII(domain)
+	if err != nil {
+		return "", fmt.Errorf("invalid domain '%s': %v", domain, err)
+	}
+	if !strings.Contains(ascii, ".") {
+		return "", fmt.Errorf("invalid domain '%s': a top-level domain cannot be used", domain)
+	}
+	return ascii, nil
+}
+
+// RecordName returns the name of the TXT record proving the ownership of domain.
+func RecordName(domain string) string {
+	return recordPrefix + domain
+}
+
+// RecordValue returns the value the TXT record of a domain registered with token must
+// hold.
+func RecordValue(token string) string {
+	return valuePrefix + token
+}
+
+// Verifier checks the verification records of branded domains. A Verifier is safe for
+// concurrent use.
+type Verifier struct {
+	resolver TXTResolver
+}
+
+// NewVerifier creates a Verifier looking records up with resolver.
+//
+// Parameters:
+//   - resolver: The resolver to query, see NewResolver.
+//
+// Returns:
+//   - A pointer to the Verifier.
+func NewVerifier(resolver TXTResolver) *Verifier {
+	return &Verifier{resolver: resolver}
+}
+
+// Verify reports whether the verification record of domain holds token. A missing
+// record or name is not an error: the customer may not have published it yet.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - domain: The normalized domain name.
+//   - token: The token issued when the domain was registered.
+//
+// Returns:
+//   - true if one of the TXT records of RecordName(domain) equals RecordValue(token).
+//   - An error if the DNS lookup fails for another reason than a missing record.
+func (v *Verifier) Verify(ctx context.Context, domain, token string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, lookupTimeout)
+	defer cancel()
+
+	records, err := v.resolver.LookupTXT(ctx, RecordName(domain))
+	if err != nil {
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to look up verification record: %v", err)
+	}
+
+	want := RecordValue(token)
+	for _, record := range records {
+		if strings.TrimSpace(record) == want {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,106 @@
+package domains
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// serveTXT answers DNS queries on a local UDP port with the TXT records of records,
+// and with NXDOMAIN for other names. It returns the address of the server.
+func serveTXT(t *testing.T, records map[string][]string) string {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+
+			var query dnsmessage.Message
+			if err := query.Unpack(buf[:n]); err != nil || len(query.Questions) != 1 {
+				continue
+			}
+			question := query.Questions[0]
+
+			reply := dnsmessage.Message{
+				Header:    dnsmessage.Header{ID: query.ID, Response: true, Authoritative: true},
+				Questions: query.Questions,
+			}
+			values, ok := records[question.Name.String()]
+			if !ok {
+				reply.RCode = dnsmessage.RCodeNameError
+			} else if question.Type == dnsmessage.TypeTXT {
+				for _, value := range values {
+					reply.Answers = append(reply.Answers, dnsmessage.Resource{
+						Header: dnsmessage.ResourceHeader{Name: question.Name, Type: dnsmessage.TypeTXT, Class: dnsmessage.ClassINET, TTL: 60},
+						Body:   &dnsmessage.TXTResource{TXT: []string{value}},
+					})
+				}
+			}
+
+			packed, err := reply.Pack()
+			if err != nil {
+				continue
+			}
+			conn.WriteTo(packed, addr)
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+func TestNormalize(t *testing.T) {
+	t.Run("Valid domains are canonicalized", func(t *testing.T) {
+		for input, want := range map[string]string{
+			"Links.Example.com.": "links.example.com",
+			" go.acme.io ":       "go.acme.io",
+			"bücher.example":     "xn--bcher-kva.example",
+		} {
+			got, err := Normalize(input)
+			require.NoError(t, err, input)
+			require.Equal(t, want, got)
+		}
+	})
+
+	t.Run("Invalid domains are rejected", func(t *testing.T) {
+		for _, input := range []string{"", "localhost", "127.0.0.1", "::1", "exa mple.com", "https://example.com"} {
+			_, err := Normalize(input)
+			require.Error(t, err, input)
+		}
+	})
+}
+
+func TestVerifier(t *testing.T) {
+	addr := serveTXT(t, map[string][]string{
+		"_gobizz-verification.links.example.com.": {"v=spf1 -all", RecordValue("token123")},
+		"_gobizz-verification.other.example.com.": {RecordValue("stale")},
+	})
+	verifier := NewVerifier(NewResolver(addr))
+	ctx := context.Background()
+
+	t.Run("Matching record", func(t *testing.T) {
+		verified, err := verifier.Verify(ctx, "links.example.com", "token123")
+		require.NoError(t, err)
+		require.True(t, verified)
+	})
+
+	t.Run("Record with another token", func(t *testing.T) {
+		verified, err := verifier.Verify(ctx, "other.example.com", "token123")
+		require.NoError(t, err)
+		require.False(t, verified)
+	})
+
+	t.Run("Missing record", func(t *testing.T) {
+		verified, err := verifier.Verify(ctx, "missing.example.com", "token123")
+		require.NoError(t, err)
+		require.False(t, verified)
+	})
+}
@@ -0,0 +1,187 @@
+package geoip
+
+import (
+	"context"
+	"fmt"
+	"links-service-write/internal/logger"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/oschwald/geoip2-golang"
+	"go.uber.org/zap"
+)
+
+type Location struct {
+	Country string
+	Region  string
+	City    string
+}
+
+// Resolver resolves IP addresses using a MaxMind-format database read from local disk.
+// A resolver opened on a City database serves Lookup, one opened on an ASN database
+// serves LookupASN. No network calls are made.
+//
+// The database file is watched for changes and reloaded in place, so a new monthly
+// GeoLite2 release can be dropped next to the running service. Lookups never block on a
+// reload for longer than it takes to swap the reader.
+type Resolver struct {
+	path     string
+	interval time.Duration
+
+	mu      sync.RWMutex
+	reader  *geoip2.Reader
+	modTime time.Time
+	size    int64
+}
+
+// NewResolver creates a Resolver for the database at path and loads it immediately.
+//
+// A missing or unreadable file is not fatal: the resolver starts empty, every lookup
+// returns an empty Location, and the file is picked up by the watcher once it appears.
+// An empty path disables geolocation altogether.
+//
+// Parameters:
+//   - path: Location of the .mmdb file on disk.
+//   - interval: How often Watch checks the file for changes.
+//
+// Returns:
+//   - A pointer to the initialized Resolver.
+func NewResolver(path string, interval time.Duration) *Resolver {
+	r := &Resolver{path: path, interval: interval}
+	if path == "" {
+		logger.Log.Warn("GeoIP database path not configured, geolocation disabled")
+		return r
+	}
+
+	if err := r.reloadIfChanged(); err != nil {
+		logger.Log.Error("Failed to load GeoIP database", zap.String("path", path), zap.Error(err))
+	}
+	return r
+}
+
+// Watch polls the database file every interval and reloads it when its size or
+// modification time changes. It returns when ctx is cancelled.
+func (r *Resolver) Watch(ctx context.Context) {
+	if r.path == "" || r.interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.reloadIfChanged(); err != nil {
+				logger.Log.Error("Failed to reload GeoIP database", zap.String("path", r.path), zap.Error(err))
+			}
+		}
+	}
+}
+
+// Lookup returns the location of the given IP address. Unknown, private and malformed
+// addresses, as well as lookups made while no database is loaded, yield an empty Location.
+func (r *Resolver) Lookup(ip string) Location {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return Location{}
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.reader == nil {
+		return Location{}
+	}
+
+	record, err := r.reader.City(parsed)
+	if err != nil {
+		logger.Log.Warn("GeoIP lookup failed", zap.Error(err))
+		return Location{}
+	}
+
+	location := Location{
+		Country: record.Country.IsoCode,
+		City:    record.City.Names["en"],
+	}
+	if len(record.Subdivisions) > 0 {
+		location.Region = record.Subdivisions[0].Names["en"]
+	}
+	return location
+}
+
+// LookupASN returns the autonomous system number announcing the given IP address, or 0
+// when the address is unknown, malformed, or no database is loaded.
+func (r *Resolver) LookupASN(ip string) uint {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return 0
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.reader == nil {
+		return 0
+	}
+
+	record, err := r.reader.ASN(parsed)
+	if err != nil {
+		logger.Log.Warn("GeoIP ASN lookup failed", zap.Error(err))
+		return 0
+	}
+	return record.AutonomousSystemNumber
+}
+
+// Close releases the currently loaded database.
+func (r *Resolver) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.reader == nil {
+		return nil
+	}
+	err := r.reader.Close()
+	r.reader = nil
+	return err
+}
+
+func (r *Resolver) reloadIfChanged() error {
+	info, err := os.Stat(r.path)
+	if err != nil {
+		return fmt.Errorf("failed to stat GeoIP database: %v", err)
+	}
+
+	r.mu.RLock()
+	unchanged := r.reader != nil && info.ModTime().Equal(r.modTime) && info.Size() == r.size
+	r.mu.RUnlock()
+	if unchanged {
+		return nil
+	}
+
+	reader, err := geoip2.Open(r.path)
+	if err != nil {
+		return fmt.Errorf("failed to open GeoIP database: %v", err)
+	}
+
+	r.mu.Lock()
+	previous := r.reader
+	r.reader = reader
+	r.modTime = info.ModTime()
+	r.size = info.Size()
+	r.mu.Unlock()
+
+	if previous != nil {
+		previous.Close()
+	}
+
+	logger.Log.Info("GeoIP database loaded",
+		zap.String("path", r.path),
+		zap.String("build", time.Unix(int64(reader.Metadata().BuildEpoch), 0).UTC().Format(time.RFC3339)),
+	)
+	return nil
+}
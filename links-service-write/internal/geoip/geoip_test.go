@@ -0,0 +1,92 @@
+package geoip
+
+import (
+	"links-service-write/internal/logger"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testdata/GeoIP2-City-Test.mmdb is a small GeoLite2-City formatted fixture containing:
+//   - 200.160.0.0/20  -> BR, São Paulo, São Paulo
+//   - 177.71.128.0/17 -> BR, Rio de Janeiro, Rio de Janeiro
+//   - 81.2.69.0/24    -> GB, England, London
+//   - 2001:db8:1::/48 -> PT, Lisbon, Lisbon
+//   - 8.8.8.0/24      -> US (no region or city)
+const fixturePath = "testdata/GeoIP2-City-Test.mmdb"
+
+// testdata/GeoLite2-ASN-Test.mmdb is a GeoLite2-ASN formatted fixture containing:
+//   - 200.160.0.0/20  -> AS22548
+//   - 177.71.128.0/17 -> AS16509
+//   - 31.13.64.0/18   -> AS32934
+//   - 81.2.69.0/24    -> AS20712
+const asnFixturePath = "testdata/GeoLite2-ASN-Test.mmdb"
+
+func TestMain(m *testing.M) {
+	logger.Initialize("development")
+	code := m.Run()
+	logger.Sync()
+	os.Exit(code)
+}
+
+func TestResolverLookup(t *testing.T) {
+	resolver := NewResolver(fixturePath, 0)
+	defer resolver.Close()
+
+	t.Run("Resolves country, region and city", func(t *testing.T) {
+		require.Equal(t, Location{Country: "BR", Region: "São Paulo", City: "São Paulo"}, resolver.Lookup("200.160.2.3"))
+		require.Equal(t, Location{Country: "GB", Region: "England", City: "London"}, resolver.Lookup("81.2.69.142"))
+	})
+
+	t.Run("Resolves IPv6 addresses", func(t *testing.T) {
+		require.Equal(t, "PT", resolver.Lookup("2001:db8:1::1").Country)
+	})
+
+	t.Run("Country-only records leave region and city empty", func(t *testing.T) {
+		require.Equal(t, Location{Country: "US"}, resolver.Lookup("8.8.8.8"))
+	})
+
+	t.Run("Unknown and malformed addresses yield an empty location", func(t *testing.T) {
+		require.Equal(t, Location{}, resolver.Lookup("10.0.0.1"))
+		require.Equal(t, Location{}, resolver.Lookup("not-an-ip"))
+		require.Equal(t, Location{}, resolver.Lookup(""))
+	})
+}
+
+func TestResolverLookupASN(t *testing.T) {
+	resolver := NewResolver(asnFixturePath, 0)
+	defer resolver.Close()
+
+	require.Equal(t, uint(16509), resolver.LookupASN("177.71.130.1"))
+	require.Equal(t, uint(32934), resolver.LookupASN("31.13.65.7"))
+	require.Equal(t, uint(0), resolver.LookupASN("10.0.0.1"))
+	require.Equal(t, uint(0), resolver.LookupASN("not-an-ip"))
+}
+
+func TestResolverReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "GeoLite2-City.mmdb")
+
+	resolver := NewResolver(path, time.Hour)
+	defer resolver.Close()
+
+	require.Equal(t, Location{}, resolver.Lookup("200.160.2.3"), "Missing database should resolve nothing")
+
+	fixture, err := os.ReadFile(fixturePath)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, fixture, 0o644))
+
+	require.NoError(t, resolver.reloadIfChanged())
+	require.Equal(t, "BR", resolver.Lookup("200.160.2.3").Country, "Database should be picked up once it appears")
+
+	require.NoError(t, resolver.reloadIfChanged(), "Unchanged database should be kept")
+	require.Equal(t, "BR", resolver.Lookup("200.160.2.3").Country)
+}
+
+func TestResolverDisabled(t *testing.T) {
+	resolver := NewResolver("", 0)
+	require.Equal(t, Location{}, resolver.Lookup("200.160.2.3"))
+	require.NoError(t, resolver.Close())
+}
@@ -21,7 +21,9 @@ import (
 // In production, it uses the default AWS configuration with the "2" region.
 // In development/local, it uses a custom endpoint specified by the DYNAMODB_ENDPOINT environment variable.
 //
-// The function also ensures the existence of the "Links" table in DynamoDB.
+// The function also ensures the existence of the "Links", "SlugReservations", "Domains",
+// "UTMTemplates", "CustomerLinkSettings", "ClickEvents", "BotClickEvents" and "ClickRollups"
+// tables in DynamoDB.
 //
 // Returns:
 // - *dynamodb.Client: A pointer to the initialized DynamoDB client.
@@ -61,16 +63,44 @@ func NewDynamoClient() (*dynamodb.Client, error) {
 		return nil, err
 	}
 
+	if err := ensureSlugReservationsTable(ctx, client); err != nil {
+		return nil, err
+	}
+
+	if err := ensureDomainsTable(ctx, client); err != nil {
+		return nil, err
+	}
+
+	if err := ensureUTMTemplatesTable(ctx, client); err != nil {
+		return nil, err
+	}
+
+	if err := ensureLinkSettingsTable(ctx, client); err != nil {
+		return nil, err
+	}
+
+	if err := ensureClickEventsTable(ctx, client, "ClickEvents"); err != nil {
+		return nil, err
+	}
+
+	if err := ensureClickEventsTable(ctx, client, "BotClickEvents"); err != nil {
+		return nil, err
+	}
+
+	if err := ensureClickRollupsTable(ctx, client); err != nil {
+		return nil, err
+	}
+
 	return client, nil
 }
 
 func ensureLinksTable(ctx context.Context, db *dynamodb.Client) error {
-	_, err := db.DescribeTable(ctx, &dynamodb.DescribeTableInput{
+	described, err := db.DescribeTable(ctx, &dynamodb.DescribeTableInput{
 		TableName: aws.String("Links"),
 	})
 	if err == nil {
 		logger.Log.Info("DynamoDB table 'Links' already exists")
-		return nil
+		return ensureCustomerSortIndexes(ctx, db, described.Table)
 	}
 
 	var rnfe *types.ResourceNotFoundException
@@ -86,6 +116,9 @@ func ensureLinksTable(ctx context.Context, db *dynamodb.Client) error {
 		{AttributeName: aws.String("created_at"), AttributeType: types.ScalarAttributeTypeS},
 		{AttributeName: aws.String("id"), AttributeType: types.ScalarAttributeTypeS},
 	}
+	for _, index := range customerSortIndexes() {
+		attributeDefinitions = append(attributeDefinitions, index.rangeKey)
+	}
 
 	createInput := &dynamodb.CreateTableInput{
 		TableName:            aws.String("Links"),
@@ -97,7 +130,7 @@ func ensureLinksTable(ctx context.Context, db *dynamodb.Client) error {
 			ReadCapacityUnits:  aws.Int64(5),
 			WriteCapacityUnits: aws.Int64(5),
 		},
-		GlobalSecondaryIndexes: []types.GlobalSecondaryIndex{
+		GlobalSecondaryIndexes: append([]types.GlobalSecondaryIndex{
 			{
 				IndexName: aws.String("ByCustomSlug"),
 				KeySchema: []types.KeySchemaElement{
@@ -132,7 +165,7 @@ func ensureLinksTable(ctx context.Context, db *dynamodb.Client) error {
 					WriteCapacityUnits: aws.Int64(2),
 				},
 			},
-		},
+		}, customerSortGSIs()...),
 	}
 
 	_, err = db.CreateTable(ctx, createInput)
@@ -167,3 +200,324 @@ func ensureLinksTable(ctx context.Context, db *dynamodb.Client) error {
 	logger.Log.Info("DynamoDB table 'Links' created successfully with TTL enabled")
 	return nil
 }
+
+// customerSortIndex is a GSI of the "Links" table that lists the links of a customer
+// in a given order, for GetCustomerLinks' sort_by (ByCustomer covers created_at).
+type customerSortIndex struct {
+	name     string
+	rangeKey types.AttributeDefinition
+}
+
+// customerSortIndexes returns the sort indexes of the "Links" table. clicks is kept up
+// to date by RecordClick; expiration_sort and original_url_sort are derived by the
+// write repository whenever a link is written.
+func customerSortIndexes() []customerSortIndex {
+	return []customerSortIndex{
+		{name: "ByCustomerClicks", rangeKey: types.AttributeDefinition{AttributeName: aws.String("clicks"), AttributeType: types.ScalarAttributeTypeN}},
+		{name: "ByCustomerExpiration", rangeKey: types.AttributeDefinition{AttributeName: aws.String("expiration_sort"), AttributeType: types.ScalarAttributeTypeS}},
+		{name: "ByCustomerOriginalURL", rangeKey: types.AttributeDefinition{AttributeName: aws.String("original_url_sort"), AttributeType: types.ScalarAttributeTypeS}},
+	}
+}
+
+func (i customerSortIndex) keySchema() []types.KeySchemaElement {
+	return []types.KeySchemaElement{
+		{AttributeName: aws.String("customer_id"), KeyType: types.KeyTypeHash},
+		{AttributeName: i.rangeKey.AttributeName, KeyType: types.KeyTypeRange},
+	}
+}
+
+func customerSortGSIs() []types.GlobalSecondaryIndex {
+	var gsis []types.GlobalSecondaryIndex
+	for _, index := range customerSortIndexes() {
+		gsis = append(gsis, types.GlobalSecondaryIndex{
+			IndexName:  aws.String(index.name),
+			KeySchema:  index.keySchema(),
+			Projection: &types.Projection{ProjectionType: types.ProjectionTypeAll},
+			ProvisionedThroughput: &types.ProvisionedThroughput{
+				ReadCapacityUnits:  aws.Int64(2),
+				WriteCapacityUnits: aws.Int64(2),
+			},
+		})
+	}
+	return gsis
+}
+
+// ensureCustomerSortIndexes adds the customer sort indexes missing from an existing
+// "Links" table. DynamoDB builds one index at a time, so each creation is awaited
+// before the next one starts; links written before the read model attributes existed
+// only show up in these indexes once DynamoLinkStore.BackfillReadModel has run.
+func ensureCustomerSortIndexes(ctx context.Context, db *dynamodb.Client, table *types.TableDescription) error {
+	existing := make(map[string]bool, len(table.GlobalSecondaryIndexes))
+	for _, gsi := range table.GlobalSecondaryIndexes {
+		existing[aws.ToString(gsi.IndexName)] = true
+	}
+
+	for _, index := range customerSortIndexes() {
+		if existing[index.name] {
+			continue
+		}
+
+		_, err := db.UpdateTable(ctx, &dynamodb.UpdateTableInput{
+			TableName: aws.String("Links"),
+			AttributeDefinitions: []types.AttributeDefinition{
+				{AttributeName: aws.String("customer_id"), AttributeType: types.ScalarAttributeTypeS},
+				index.rangeKey,
+			},
+			GlobalSecondaryIndexUpdates: []types.GlobalSecondaryIndexUpdate{
+				{
+					Create: &types.CreateGlobalSecondaryIndexAction{
+						IndexName:  aws.String(index.name),
+						KeySchema:  index.keySchema(),
+						Projection: &types.Projection{ProjectionType: types.ProjectionTypeAll},
+						ProvisionedThroughput: &types.ProvisionedThroughput{
+							ReadCapacityUnits:  aws.Int64(2),
+							WriteCapacityUnits: aws.Int64(2),
+						},
+					},
+				},
+			},
+		})
+		if err != nil {
+			logger.Log.Error("Failed to create DynamoDB index", zap.String("index", index.name), zap.Error(err))
+			return fmt.Errorf("failed to create DynamoDB index %s: %v", index.name, err)
+		}
+
+		if err := waitForIndex(ctx, db, index.name, 10*time.Minute); err != nil {
+			return err
+		}
+		logger.Log.Info("DynamoDB index created successfully", zap.String("index", index.name))
+	}
+
+	return nil
+}
+
+// waitForIndex polls the "Links" table until the given index is active.
+func waitForIndex(ctx context.Context, db *dynamodb.Client, indexName string, maxWait time.Duration) error {
+	deadline := time.Now().Add(maxWait)
+	for {
+		described, err := db.DescribeTable(ctx, &dynamodb.DescribeTableInput{
+			TableName: aws.String("Links"),
+		})
+		if err != nil {
+			logger.Log.Error("Error describing DynamoDB table", zap.Error(err))
+			return fmt.Errorf("error describing DynamoDB table: %v", err)
+		}
+
+		for _, gsi := range described.Table.GlobalSecondaryIndexes {
+			if aws.ToString(gsi.IndexName) == indexName && gsi.IndexStatus == types.IndexStatusActive {
+				return nil
+			}
+		}
+
+		if time.Now().After(deadline) {
+			logger.Log.Error("Timed out waiting for DynamoDB index", zap.String("index", indexName))
+			return fmt.Errorf("timed out waiting for DynamoDB index %s", indexName)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(5 * time.Second):
+		}
+	}
+}
+
+// ensureSlugReservationsTable creates the "SlugReservations" table if it does not exist
+// yet, with TTL enabled. Each item reserves one slug, a short URL or custom slug, for
+// the link in its link_id attribute; reservations are written in the same transaction
+// as the link, which makes slugs unique across the "Links" table and its indexes.
+func ensureSlugReservationsTable(ctx context.Context, db *dynamodb.Client) error {
+	err := ensureTable(ctx, db, &dynamodb.CreateTableInput{
+		TableName: aws.String("SlugReservations"),
+		AttributeDefinitions: []types.AttributeDefinition{
+			{AttributeName: aws.String("slug"), AttributeType: types.ScalarAttributeTypeS},
+		},
+		KeySchema: []types.KeySchemaElement{
+			{AttributeName: aws.String("slug"), KeyType: types.KeyTypeHash},
+		},
+		ProvisionedThroughput: &types.ProvisionedThroughput{
+			ReadCapacityUnits:  aws.Int64(5),
+			WriteCapacityUnits: aws.Int64(5),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	described, err := db.DescribeTimeToLive(ctx, &dynamodb.DescribeTimeToLiveInput{
+		TableName: aws.String("SlugReservations"),
+	})
+	if err != nil {
+		logger.Log.Error("Failed to describe TTL of DynamoDB table", zap.Error(err))
+		return fmt.Errorf("failed to describe TTL: %v", err)
+	}
+	if described.TimeToLiveDescription != nil && described.TimeToLiveDescription.TimeToLiveStatus != types.TimeToLiveStatusDisabled {
+		return nil
+	}
+
+	_, err = db.UpdateTimeToLive(ctx, &dynamodb.UpdateTimeToLiveInput{
+		TableName: aws.String("SlugReservations"),
+		TimeToLiveSpecification: &types.TimeToLiveSpecification{
+			Enabled:       aws.Bool(true),
+			AttributeName: aws.String("ttl"),
+		},
+	})
+	if err != nil {
+		logger.Log.Error("Failed to enable TTL on DynamoDB table", zap.Error(err))
+		return fmt.Errorf("failed to enable TTL: %v", err)
+	}
+	return nil
+}
+
+// ensureDomainsTable creates the "Domains" table if it does not exist yet. Each item is
+// a branded domain registered by a customer, keyed by its host name, with the token its
+// ownership is verified with; the "ByCustomer" index lists the domains of a customer.
+func ensureDomainsTable(ctx context.Context, db *dynamodb.Client) error {
+	return ensureTable(ctx, db, &dynamodb.CreateTableInput{
+		TableName: aws.String("Domains"),
+		AttributeDefinitions: []types.AttributeDefinition{
+			{AttributeName: aws.String("domain"), AttributeType: types.ScalarAttributeTypeS},
+			{AttributeName: aws.String("customer_id"), AttributeType: types.ScalarAttributeTypeS},
+		},
+		KeySchema: []types.KeySchemaElement{
+			{AttributeName: aws.String("domain"), KeyType: types.KeyTypeHash},
+		},
+		GlobalSecondaryIndexes: []types.GlobalSecondaryIndex{
+			{
+				IndexName: aws.String("ByCustomer"),
+				KeySchema: []types.KeySchemaElement{
+					{AttributeName: aws.String("customer_id"), KeyType: types.KeyTypeHash},
+					{AttributeName: aws.String("domain"), KeyType: types.KeyTypeRange},
+				},
+				Projection: &types.Projection{ProjectionType: types.ProjectionTypeAll},
+				ProvisionedThroughput: &types.ProvisionedThroughput{
+					ReadCapacityUnits:  aws.Int64(2),
+					WriteCapacityUnits: aws.Int64(2),
+				},
+			},
+		},
+		ProvisionedThroughput: &types.ProvisionedThroughput{
+			ReadCapacityUnits:  aws.Int64(5),
+			WriteCapacityUnits: aws.Int64(5),
+		},
+	})
+}
+
+// ensureUTMTemplatesTable creates the "UTMTemplates" table if it does not exist yet.
+// Each item is a named set of UTM parameters of a customer, keyed by the customer ID and
+// the template ID, so that a customer's templates are listed with a single query.
+func ensureUTMTemplatesTable(ctx context.Context, db *dynamodb.Client) error {
+	return ensureTable(ctx, db, &dynamodb.CreateTableInput{
+		TableName: aws.String("UTMTemplates"),
+		AttributeDefinitions: []types.AttributeDefinition{
+			{AttributeName: aws.String("customer_id"), AttributeType: types.ScalarAttributeTypeS},
+			{AttributeName: aws.String("id"), AttributeType: types.ScalarAttributeTypeS},
+		},
+		KeySchema: []types.KeySchemaElement{
+			{AttributeName: aws.String("customer_id"), KeyType: types.KeyTypeHash},
+			{AttributeName: aws.String("id"), KeyType: types.KeyTypeRange},
+		},
+		ProvisionedThroughput: &types.ProvisionedThroughput{
+			ReadCapacityUnits:  aws.Int64(2),
+			WriteCapacityUnits: aws.Int64(2),
+		},
+	})
+}
+
+// ensureLinkSettingsTable creates the "CustomerLinkSettings" table if it does not exist
+// yet. Each item holds the settings a customer applies to all of their links, keyed by
+// the customer ID.
+func ensureLinkSettingsTable(ctx context.Context, db *dynamodb.Client) error {
+	return ensureTable(ctx, db, &dynamodb.CreateTableInput{
+		TableName: aws.String("CustomerLinkSettings"),
+		AttributeDefinitions: []types.AttributeDefinition{
+			{AttributeName: aws.String("customer_id"), AttributeType: types.ScalarAttributeTypeS},
+		},
+		KeySchema: []types.KeySchemaElement{
+			{AttributeName: aws.String("customer_id"), KeyType: types.KeyTypeHash},
+		},
+		ProvisionedThroughput: &types.ProvisionedThroughput{
+			ReadCapacityUnits:  aws.Int64(2),
+			WriteCapacityUnits: aws.Int64(2),
+		},
+	})
+}
+
+// ensureClickEventsTable creates a click events table if it does not exist yet. Human
+// clicks go to "ClickEvents" and automated ones to "BotClickEvents", which share the
+// same layout: each item is a single click, keyed by the link ID and a time-ordered
+// event ID so that the events of a link can be read back chronologically.
+func ensureClickEventsTable(ctx context.Context, db *dynamodb.Client, tableName string) error {
+	return ensureTable(ctx, db, &dynamodb.CreateTableInput{
+		TableName: aws.String(tableName),
+		AttributeDefinitions: []types.AttributeDefinition{
+			{AttributeName: aws.String("link_id"), AttributeType: types.ScalarAttributeTypeS},
+			{AttributeName: aws.String("event_id"), AttributeType: types.ScalarAttributeTypeS},
+		},
+		KeySchema: []types.KeySchemaElement{
+			{AttributeName: aws.String("link_id"), KeyType: types.KeyTypeHash},
+			{AttributeName: aws.String("event_id"), KeyType: types.KeyTypeRange},
+		},
+		ProvisionedThroughput: &types.ProvisionedThroughput{
+			ReadCapacityUnits:  aws.Int64(5),
+			WriteCapacityUnits: aws.Int64(5),
+		},
+	})
+}
+
+// ensureClickRollupsTable creates the "ClickRollups" table if it does not exist yet.
+// Each item holds the pre-aggregated clicks of one link in one time bucket, keyed by the
+// link ID and a bucket key such as "day#2025-01-02" (see analytics.BucketKey).
+func ensureClickRollupsTable(ctx context.Context, db *dynamodb.Client) error {
+	return ensureTable(ctx, db, &dynamodb.CreateTableInput{
+		TableName: aws.String("ClickRollups"),
+		AttributeDefinitions: []types.AttributeDefinition{
+			{AttributeName: aws.String("link_id"), AttributeType: types.ScalarAttributeTypeS},
+			{AttributeName: aws.String("bucket"), AttributeType: types.ScalarAttributeTypeS},
+		},
+		KeySchema: []types.KeySchemaElement{
+			{AttributeName: aws.String("link_id"), KeyType: types.KeyTypeHash},
+			{AttributeName: aws.String("bucket"), KeyType: types.KeyTypeRange},
+		},
+		ProvisionedThroughput: &types.ProvisionedThroughput{
+			ReadCapacityUnits:  aws.Int64(5),
+			WriteCapacityUnits: aws.Int64(5),
+		},
+	})
+}
+
+// ensureTable creates the table described by input if it does not exist yet and waits
+// until it becomes active.
+func ensureTable(ctx context.Context, db *dynamodb.Client, input *dynamodb.CreateTableInput) error {
+	tableName := aws.ToString(input.TableName)
+
+	_, err := db.DescribeTable(ctx, &dynamodb.DescribeTableInput{
+		TableName: input.TableName,
+	})
+	if err == nil {
+		logger.Log.Info("DynamoDB table already exists", zap.String("table", tableName))
+		return nil
+	}
+
+	var rnfe *types.ResourceNotFoundException
+	if !errors.As(err, &rnfe) {
+		logger.Log.Error("Error describing DynamoDB table", zap.Error(err))
+		return fmt.Errorf("error describing DynamoDB table: %v", err)
+	}
+
+	if _, err := db.CreateTable(ctx, input); err != nil {
+		logger.Log.Error("Failed to create DynamoDB table", zap.Error(err))
+		return fmt.Errorf("failed to create DynamoDB table: %v", err)
+	}
+
+	waiter := dynamodb.NewTableExistsWaiter(db)
+	if err := waiter.Wait(ctx, &dynamodb.DescribeTableInput{
+		TableName: input.TableName,
+	}, 5*time.Minute); err != nil {
+		logger.Log.Error("Failed to wait for table creation", zap.Error(err))
+		return fmt.Errorf("failed to wait for table creation: %v", err)
+	}
+
+	logger.Log.Info("DynamoDB table created successfully", zap.String("table", tableName))
+	return nil
+}
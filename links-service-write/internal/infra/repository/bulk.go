@@ -0,0 +1,277 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"links-service-write/internal/logger"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"go.uber.org/zap"
+)
+
+const (
+	// batchWriteSize is the maximum number of items of a DynamoDB BatchWriteItem call,
+	// well below the 100 keys of a BatchGetItem call.
+	batchWriteSize = 25
+	// batchWriteRetries is how many times the unprocessed items of a batch are resent
+	// before the links they hold are reported as failed.
+	batchWriteRetries = 5
+	// batchWriteBackoff is the delay before the first resend, doubled on each retry.
+	batchWriteBackoff = 50 * time.Millisecond
+)
+
+// CreateLinks stores new links in bulk in the DynamoDB table "Links". It runs in two
+// phases:
+//  1. The slugs of each link are reserved with a transaction of their own, so that a
+//     slug held by another link, or by an earlier link of the batch, fails that link
+//     alone with an error containing "already exists".
+//  2. The links holding their reservations are written with BatchWriteItem, in chunks
+//     of 25 items. Items DynamoDB leaves unprocessed are resent with exponential
+//     backoff; links that still cannot be written get their reservations released.
+//
+// BatchWriteItem takes no condition, so before each chunk is written the short URLs of
+// its links are looked up with BatchGetItem: links written before slug reservations
+// existed hold no reservation until BackfillSlugReservations has reserved their slugs,
+// and a link whose short URL is taken by one of them fails with an error containing
+// "already exists" rather than overwriting it.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - links: The links to create.
+//
+// Returns:
+//   - The created links and the errors of the links that failed, both indexed like links:
+//     exactly one of created[i] and errs[i] is set.
+func (r *DynamoLinkStore) CreateLinks(ctx context.Context, links []Link) ([]*Link, []error) {
+	created := make([]*Link, len(links))
+	errs := make([]error, len(links))
+
+	var pending []int
+	requests := make(map[string]types.WriteRequest)
+	for i := range links {
+		link := &links[i]
+		if err := prepareNewLink(link); err != nil {
+			errs[i] = err
+			continue
+		}
+
+		item, err := attributevalue.MarshalMap(*link)
+		if err != nil {
+			errs[i] = fmt.Errorf("failed to marshal link: %v", err)
+			continue
+		}
+
+		if err := r.reserveLinkSlugs(ctx, link); err != nil {
+			errs[i] = err
+			continue
+		}
+
+		pending = append(pending, i)
+		requests[link.ShortURL] = types.WriteRequest{PutRequest: &types.PutRequest{Item: item}}
+	}
+
+	for start := 0; start < len(pending); start += batchWriteSize {
+		chunk := pending[start:min(start+batchWriteSize, len(pending))]
+
+		shortURLs := make([]string, len(chunk))
+		for j, i := range chunk {
+			shortURLs[j] = links[i].ShortURL
+		}
+		existing, err := r.existingShortURLs(ctx, shortURLs)
+		if err != nil {
+			for _, i := range chunk {
+				errs[i] = fmt.Errorf("failed to create link: %v", err)
+				r.releaseLinkSlugs(ctx, &links[i])
+			}
+			continue
+		}
+
+		writes := make([]types.WriteRequest, 0, len(chunk))
+		var writable []int
+		for _, i := range chunk {
+			if existing[links[i].ShortURL] {
+				logger.Log.Error("slug already exists", zap.String("slug", links[i].ShortURL))
+				errs[i] = slugExistsError(&links[i], links[i].ShortURL)
+				r.releaseLinkSlugs(ctx, &links[i])
+				continue
+			}
+			writable = append(writable, i)
+			writes = append(writes, requests[links[i].ShortURL])
+		}
+		if len(writes) == 0 {
+			continue
+		}
+		chunk = writable
+
+		unwritten, err := r.batchWriteLinks(ctx, writes)
+		for _, i := range chunk {
+			if !unwritten[links[i].ShortURL] {
+				created[i] = &links[i]
+				continue
+			}
+
+			errs[i] = fmt.Errorf("failed to create link: %v", err)
+			r.releaseLinkSlugs(ctx, &links[i])
+		}
+	}
+
+	logger.Log.Info("links created in bulk",
+		zap.Int("requested", len(links)),
+		zap.Int("written", len(pending)),
+	)
+	return created, errs
+}
+
+// reserveLinkSlugs reserves the slugs of a link (see linkSlugs) in a single transaction.
+func (r *DynamoLinkStore) reserveLinkSlugs(ctx context.Context, link *Link) error {
+	slugs := linkSlugs(link)
+	transactItems := make([]types.TransactWriteItem, 0, len(slugs))
+	for _, slug := range slugs {
+		transactItems = append(transactItems, reserveSlugItem(slug, link))
+	}
+
+	_, err := r.db.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: transactItems,
+	})
+	if err != nil {
+		if slug := conflictingSlug(err, slugs); slug != "" {
+			logger.Log.Error("slug already exists", zap.String("slug", slug))
+			return slugExistsError(link, slug)
+		}
+		logger.Log.Error("failed to reserve slugs", zap.Error(err))
+		return fmt.Errorf("failed to reserve slugs: %v", err)
+	}
+	return nil
+}
+
+// releaseLinkSlugs releases the reservations of a link whose item could not be written.
+// A failure is logged: the reservations are then left behind, holding slugs no link uses.
+func (r *DynamoLinkStore) releaseLinkSlugs(ctx context.Context, link *Link) {
+	slugs := linkSlugs(link)
+	transactItems := make([]types.TransactWriteItem, 0, len(slugs))
+	for _, slug := range slugs {
+		transactItems = append(transactItems, releaseSlugItem(slug, link.ID))
+	}
+
+	if _, err := r.db.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: transactItems,
+	}); err != nil {
+		logger.Log.Error("failed to release slugs of unwritten link",
+			zap.String("link_id", link.ID),
+			zap.Strings("slugs", slugs),
+			zap.Error(err),
+		)
+	}
+}
+
+// existingShortURLs looks up up to 100 short URLs in the "Links" table with
+// BatchGetItem, fetching the keys DynamoDB leaves unprocessed again with exponential
+// backoff. It returns the short URLs that are taken.
+func (r *DynamoLinkStore) existingShortURLs(ctx context.Context, shortURLs []string) (map[string]bool, error) {
+	keys := make([]map[string]types.AttributeValue, len(shortURLs))
+	for i, shortURL := range shortURLs {
+		keys[i] = map[string]types.AttributeValue{
+			"short_url": &types.AttributeValueMemberS{Value: shortURL},
+		}
+	}
+
+	existing := make(map[string]bool)
+	backoff := batchWriteBackoff
+	for attempt := 0; ; attempt++ {
+		output, err := r.db.BatchGetItem(ctx, &dynamodb.BatchGetItemInput{
+			RequestItems: map[string]types.KeysAndAttributes{
+				"Links": {Keys: keys, ProjectionExpression: aws.String("short_url")},
+			},
+		})
+		if err != nil {
+			logger.Log.Error("failed to batch get links", zap.Error(err))
+			return nil, err
+		}
+
+		for _, item := range output.Responses["Links"] {
+			if shortURL, ok := item["short_url"].(*types.AttributeValueMemberS); ok {
+				existing[shortURL.Value] = true
+			}
+		}
+
+		keys = output.UnprocessedKeys["Links"].Keys
+		if len(keys) == 0 {
+			return existing, nil
+		}
+		if attempt+1 >= batchWriteRetries {
+			logger.Log.Error("links left unread", zap.Int("count", len(keys)))
+			return nil, fmt.Errorf("%d links left unread after %d attempts", len(keys), attempt+1)
+		}
+
+		logger.Log.Warn("reading unprocessed links again", zap.Int("count", len(keys)), zap.Int("attempt", attempt))
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// batchWriteLinks writes up to 25 link items with BatchWriteItem, resending the items
+// left unprocessed. It returns the short URLs of the items that could not be written,
+// with the error that stopped the writes, if any.
+func (r *DynamoLinkStore) batchWriteLinks(ctx context.Context, writes []types.WriteRequest) (map[string]bool, error) {
+	backoff := batchWriteBackoff
+	for attempt := 0; ; attempt++ {
+		output, err := r.db.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{
+			RequestItems: map[string][]types.WriteRequest{"Links": writes},
+		})
+		if err != nil {
+			logger.Log.Error("failed to batch write links", zap.Error(err))
+			return unwrittenShortURLs(writes), err
+		}
+
+		writes = output.UnprocessedItems["Links"]
+		if len(writes) == 0 {
+			return nil, nil
+		}
+		if attempt+1 >= batchWriteRetries {
+			logger.Log.Error("links left unprocessed", zap.Int("count", len(writes)))
+			return unwrittenShortURLs(writes), fmt.Errorf("%d links left unprocessed after %d attempts", len(writes), attempt+1)
+		}
+
+		logger.Log.Warn("resending unprocessed links", zap.Int("count", len(writes)), zap.Int("attempt", attempt))
+		select {
+		case <-ctx.Done():
+			return unwrittenShortURLs(writes), ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// unwrittenShortURLs returns the short URLs of the link items of writes.
+func unwrittenShortURLs(writes []types.WriteRequest) map[string]bool {
+	shortURLs := make(map[string]bool, len(writes))
+	for _, write := range writes {
+		if write.PutRequest == nil {
+			continue
+		}
+		if shortURL, ok := write.PutRequest.Item["short_url"].(*types.AttributeValueMemberS); ok {
+			shortURLs[shortURL.Value] = true
+		}
+	}
+	return shortURLs
+}
+
+// createLinksOneByOne implements CreateLinks for backends whose CreateLink is cheap
+// enough to call per link: each link is created on its own, and a failure affects it
+// alone.
+func createLinksOneByOne(ctx context.Context, store LinkStore, links []Link) ([]*Link, []error) {
+	created := make([]*Link, len(links))
+	errs := make([]error, len(links))
+	for i, link := range links {
+		created[i], errs[i] = store.CreateLink(ctx, link)
+	}
+	return created, errs
+}
@@ -0,0 +1,263 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"links-service-write/internal/analytics"
+	"links-service-write/internal/logger"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"go.uber.org/zap"
+)
+
+type ClickEvent struct {
+	LinkID         string `dynamodbav:"link_id"`
+	EventID        string `dynamodbav:"event_id"`
+	CustomerID     string `dynamodbav:"customer_id"`
+	ClickedAt      string `dynamodbav:"clicked_at"`
+	Referrer       string `dynamodbav:"referrer,omitempty"`
+	ReferrerDomain string `dynamodbav:"referrer_domain,omitempty"`
+	UserAgent      string `dynamodbav:"user_agent,omitempty"`
+	DeviceClass    string `dynamodbav:"device_class,omitempty"`
+	IPHash         string `dynamodbav:"ip_hash,omitempty"`
+	AcceptLanguage string `dynamodbav:"accept_language,omitempty"`
+	Country        string `dynamodbav:"country,omitempty"`
+	Region         string `dynamodbav:"region,omitempty"`
+	City           string `dynamodbav:"city,omitempty"`
+	ASN            uint   `dynamodbav:"asn,omitempty"`
+	BotCategory    string `dynamodbav:"bot_category,omitempty"`
+
+	// UTMCampaign is the UTM campaign of the link when it was clicked, filled in by
+	// RecordClick so that clicks can be grouped by campaign.
+	UTMCampaign string `dynamodbav:"utm_campaign,omitempty"`
+
+	// Counted reports that the click was already counted on the link by UpdateLinkClicks,
+	// as done for links with a click limit: RecordClick then leaves the link's counters
+	// alone. It is not stored.
+	Counted bool `dynamodbav:"-"`
+
+	// LinkStatus is the status of the link when it was hit, "" if it was active. Hits on
+	// links that are expired, at their click limit, disabled or not active yet (which
+	// links-service-read answers with the link's fallback instead of redirecting) are
+	// recorded apart from clicks: they leave the link's counters alone and only count
+	// towards the "inactive" rollup counters.
+	LinkStatus string `dynamodbav:"link_status,omitempty"`
+
+	// Variant is the ID of the variant of the link the visitor was sent to, "" if the
+	// link has no variants or a redirect rule sent them elsewhere. See Variant.
+	Variant string `dynamodbav:"variant,omitempty"`
+}
+
+// countsOnLink reports whether the click increments the counters of its link.
+func (e ClickEvent) countsOnLink() bool {
+	return !e.Counted && e.LinkStatus == ""
+}
+
+// RecordClick stores a single click event in the "ClickEvents" table, increments the
+// click counter of the corresponding link in the "Links" table and adds the click to the
+// hourly, daily and weekly buckets of the "ClickRollups" table.
+//
+// All writes are issued in one DynamoDB transaction, so neither the counter on the link
+// nor the rollups ever drift from the stored events. The link update is conditioned on
+// the link still existing, which keeps a click racing a delete from recreating a partial item.
+//
+// Clicks with a BotCategory are kept out of the human figures: the event goes to the
+// "BotClickEvents" table, the link's "bot_clicks" counter is incremented instead of
+// "clicks", and the rollups only receive the "bots" and "bot:<category>" counters.
+//
+// The event is stamped with the customer and UTM campaign of the link, read before the
+// transaction, so that clicks can be grouped by campaign.
+//
+// Counted clicks (see ClickEvent.Counted) and hits on inactive links (see
+// ClickEvent.LinkStatus) only check that the link still exists, without incrementing its
+// counters.
+//
+// Each rollup bucket counts at most MaxRollupReferrers referrer domains apart: when a
+// bucket is full, the transaction is retried with the click counted towards
+// OtherReferrer in that bucket.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - event: The click event to store. LinkID and EventID must be set; ClickedAt defaults to now.
+//
+// Returns:
+//   - A pointer to the stored ClickEvent.
+//   - An error if the link cannot be found or the transaction fails.
+func (r *DynamoLinkStore) RecordClick(ctx context.Context, event ClickEvent) (*ClickEvent, error) {
+	link, err := r.GetLinkByID(ctx, event.LinkID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if event.ClickedAt == "" {
+		event.ClickedAt = now.Format(time.RFC3339)
+	}
+	event.CustomerID = link.CustomerID
+	event.UTMCampaign = link.UTMCampaign()
+
+	item, err := attributevalue.MarshalMap(event)
+	if err != nil {
+		logger.Log.Error("failed to marshal click event", zap.Error(err))
+		return nil, fmt.Errorf("failed to marshal click event: %v", err)
+	}
+
+	clickedAt, err := time.Parse(time.RFC3339, event.ClickedAt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid clicked_at format: %v", err)
+	}
+
+	eventsTable := "ClickEvents"
+	linkUpdate := "SET clicks = clicks + :one, updated_at = :now"
+	linkValues := map[string]types.AttributeValue{
+		":one": &types.AttributeValueMemberN{Value: "1"},
+		":now": &types.AttributeValueMemberS{Value: now.Format(time.RFC3339)},
+	}
+	if event.BotCategory != "" {
+		eventsTable = "BotClickEvents"
+		linkUpdate = "SET bot_clicks = if_not_exists(bot_clicks, :zero) + :one, updated_at = :now"
+		linkValues[":zero"] = &types.AttributeValueMemberN{Value: "0"}
+	}
+
+	linkKey := map[string]types.AttributeValue{
+		"short_url": &types.AttributeValueMemberS{Value: link.ShortURL},
+	}
+	linkItem := types.TransactWriteItem{
+		Update: &types.Update{
+			TableName:                 aws.String("Links"),
+			Key:                       linkKey,
+			UpdateExpression:          aws.String(linkUpdate),
+			ConditionExpression:       aws.String("attribute_exists(short_url)"),
+			ExpressionAttributeValues: linkValues,
+		},
+	}
+	if !event.countsOnLink() {
+		linkItem = types.TransactWriteItem{
+			ConditionCheck: &types.ConditionCheck{
+				TableName:           aws.String("Links"),
+				Key:                 linkKey,
+				ConditionExpression: aws.String("attribute_exists(short_url)"),
+			},
+		}
+	}
+
+	// otherReferrer[i] reports that the bucket of analytics.Granularities[i] is full of
+	// referrer domains, see rollupUpdate.
+	otherReferrer := make([]bool, len(analytics.Granularities))
+	for {
+		transactItems := []types.TransactWriteItem{
+			{
+				Put: &types.Put{
+					TableName:           aws.String(eventsTable),
+					Item:                item,
+					ConditionExpression: aws.String("attribute_not_exists(event_id)"),
+				},
+			},
+			linkItem,
+		}
+		for i, granularity := range analytics.Granularities {
+			transactItems = append(transactItems, rollupUpdate(event, analytics.BucketKey(granularity, clickedAt), otherReferrer[i]))
+		}
+
+		_, err = r.db.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+			TransactItems: transactItems,
+		})
+		if err == nil {
+			break
+		}
+
+		var tce *types.TransactionCanceledException
+		if errors.As(err, &tce) && len(tce.CancellationReasons) > 1 &&
+			aws.ToString(tce.CancellationReasons[1].Code) == "ConditionalCheckFailed" {
+			logger.Log.Error("link not found", zap.String("link_id", event.LinkID))
+			return nil, fmt.Errorf("link not found")
+		}
+		if tce != nil && fullRollups(tce.CancellationReasons[min(2, len(tce.CancellationReasons)):], otherReferrer) {
+			continue
+		}
+		logger.Log.Error("failed to record click", zap.Error(err))
+		return nil, fmt.Errorf("failed to record click: %v", err)
+	}
+
+	logger.Log.Info("click recorded successfully",
+		zap.String("link_id", event.LinkID),
+		zap.String("event_id", event.EventID),
+		zap.String("bot_category", event.BotCategory),
+	)
+	return &event, nil
+}
+
+// rollupUpdate builds the transactional update that adds one click to a rollup bucket.
+// Counters (see rollupCounters) are stored as top-level attributes so that ADD can
+// create them on first use without a pre-existing map.
+//
+// The referrer domains counted in the bucket are kept in its "referrers" string set, and
+// the update is conditioned on the click's domain being one of them or the set holding
+// fewer than MaxRollupReferrers, so that visitors cannot grow the item past DynamoDB's
+// item size limit with made-up Referer headers. When otherReferrer is set, the click is
+// counted towards OtherReferrer instead, unconditionally.
+func rollupUpdate(event ClickEvent, bucket string, otherReferrer bool) types.TransactWriteItem {
+	counters := rollupCounters(event)
+	names := make(map[string]string, len(counters)+1)
+	adds := make([]string, 0, len(counters)+1)
+	values := map[string]types.AttributeValue{
+		":one": &types.AttributeValueMemberN{Value: "1"},
+	}
+	var condition *string
+	for i, counter := range counters {
+		if referrer, ok := strings.CutPrefix(counter, "ref:"); ok {
+			if otherReferrer {
+				counter = "ref:" + OtherReferrer
+			} else {
+				names["#referrers"] = "referrers"
+				adds = append(adds, "#referrers :referrers")
+				values[":referrer"] = &types.AttributeValueMemberS{Value: referrer}
+				values[":referrers"] = &types.AttributeValueMemberSS{Value: []string{referrer}}
+				values[":max_referrers"] = &types.AttributeValueMemberN{Value: strconv.Itoa(MaxRollupReferrers)}
+				condition = aws.String("attribute_not_exists(#referrers) OR contains(#referrers, :referrer) OR size(#referrers) < :max_referrers")
+			}
+		}
+		placeholder := fmt.Sprintf("#c%d", i)
+		names[placeholder] = counter
+		adds = append(adds, placeholder+" :one")
+	}
+
+	return types.TransactWriteItem{
+		Update: &types.Update{
+			TableName: aws.String("ClickRollups"),
+			Key: map[string]types.AttributeValue{
+				"link_id": &types.AttributeValueMemberS{Value: event.LinkID},
+				"bucket":  &types.AttributeValueMemberS{Value: bucket},
+			},
+			UpdateExpression:          aws.String("ADD " + strings.Join(adds, ", ")),
+			ConditionExpression:       condition,
+			ExpressionAttributeNames:  names,
+			ExpressionAttributeValues: values,
+		},
+	}
+}
+
+// fullRollups marks the rollup buckets whose update failed its condition, because they
+// hold MaxRollupReferrers referrer domains, in otherReferrer, given the cancellation
+// reasons of their updates in the order of analytics.Granularities.
+//
+// Returns:
+//   - Whether a bucket was newly found full, in which case the click can be retried.
+func fullRollups(reasons []types.CancellationReason, otherReferrer []bool) bool {
+	full := false
+	for i, reason := range reasons {
+		if i < len(otherReferrer) && !otherReferrer[i] &&
+			aws.ToString(reason.Code) == "ConditionalCheckFailed" {
+			otherReferrer[i] = true
+			full = true
+		}
+	}
+	return full
+}
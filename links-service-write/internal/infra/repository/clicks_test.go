@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/require"
+)
+
+// counterNames returns the rollup counters written by a rollup update.
+func counterNames(update *types.Update) []string {
+	var names []string
+	for placeholder, name := range update.ExpressionAttributeNames {
+		if placeholder != "#referrers" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func TestRollupUpdate(t *testing.T) {
+	event := ClickEvent{LinkID: "id-1", ReferrerDomain: "news.example.com", DeviceClass: "mobile"}
+
+	t.Run("Referrer domains are capped per bucket", func(t *testing.T) {
+		update := rollupUpdate(event, "day#2025-01-02", false).Update
+		require.Contains(t, counterNames(update), "ref:news.example.com")
+		require.Contains(t, aws.ToString(update.UpdateExpression), "#referrers :referrers")
+		require.Equal(t, "attribute_not_exists(#referrers) OR contains(#referrers, :referrer) OR size(#referrers) < :max_referrers",
+			aws.ToString(update.ConditionExpression))
+		require.Equal(t, &types.AttributeValueMemberSS{Value: []string{"news.example.com"}}, update.ExpressionAttributeValues[":referrers"])
+	})
+
+	t.Run("Full buckets count the click towards other referrers", func(t *testing.T) {
+		update := rollupUpdate(event, "day#2025-01-02", true).Update
+		require.Contains(t, counterNames(update), "ref:"+OtherReferrer)
+		require.NotContains(t, counterNames(update), "ref:news.example.com")
+		require.Nil(t, update.ConditionExpression)
+		require.NotContains(t, update.ExpressionAttributeNames, "#referrers")
+	})
+
+	t.Run("Bot clicks are not conditioned on referrers", func(t *testing.T) {
+		update := rollupUpdate(ClickEvent{LinkID: "id-1", BotCategory: "crawler"}, "day#2025-01-02", false).Update
+		require.ElementsMatch(t, []string{"bots", "bot:crawler"}, counterNames(update))
+		require.Nil(t, update.ConditionExpression)
+	})
+}
+
+func TestFullRollups(t *testing.T) {
+	failed := types.CancellationReason{Code: aws.String("ConditionalCheckFailed")}
+	none := types.CancellationReason{Code: aws.String("None")}
+
+	otherReferrer := make([]bool, 3)
+	require.True(t, fullRollups([]types.CancellationReason{none, failed, none}, otherReferrer))
+	require.Equal(t, []bool{false, true, false}, otherReferrer)
+
+	require.False(t, fullRollups([]types.CancellationReason{none, failed, none}, otherReferrer),
+		"A bucket already counted towards other referrers is not retried again")
+	require.False(t, fullRollups(nil, otherReferrer))
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"links-service-write/internal/logger"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"go.uber.org/zap"
+)
+
+// ReserveCounter atomically adds n to a named counter of the DynamoDB table "Counters",
+// keyed by "name", creating it at 0 if it does not exist.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - name: The name of the counter.
+//   - n: How many values to reserve.
+//
+// Returns:
+//   - The new value of the counter: the caller owns the n values up to it, that value
+//     included.
+//   - An error if the update fails.
+func (r *DynamoLinkStore) ReserveCounter(ctx context.Context, name string, n uint64) (uint64, error) {
+	result, err := r.db.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String("Counters"),
+		Key: map[string]types.AttributeValue{
+			"name": &types.AttributeValueMemberS{Value: name},
+		},
+		UpdateExpression:         aws.String("ADD #value :n"),
+		ExpressionAttributeNames: map[string]string{"#value": "value"},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":n": &types.AttributeValueMemberN{Value: strconv.FormatUint(n, 10)},
+		},
+		ReturnValues: types.ReturnValueUpdatedNew,
+	})
+	if err != nil {
+		logger.Log.Error("failed to reserve counter values", zap.String("counter", name), zap.Error(err))
+		return 0, fmt.Errorf("failed to reserve counter values: %v", err)
+	}
+
+	value, ok := result.Attributes["value"].(*types.AttributeValueMemberN)
+	if !ok {
+		return 0, fmt.Errorf("failed to reserve counter values: counter %s has no value", name)
+	}
+	end, err := strconv.ParseUint(value.Value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse counter %s: %v", name, err)
+	}
+	return end, nil
+}
@@ -0,0 +1,245 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"links-service-write/internal/logger"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"go.uber.org/zap"
+)
+
+// UnverifiedDomainTTL is how long an unverified registration holds its domain. Past it,
+// any customer can register the domain again, so that a registration that is never
+// verified cannot keep the domain from its owner.
+const UnverifiedDomainTTL = 72 * time.Hour
+
+// Domain is a branded domain registered by a customer to serve its links on. Links can
+// only be bound to it once its ownership has been verified, through a DNS TXT record
+// holding Token.
+type Domain struct {
+	Domain     string  `dynamodbav:"domain"`
+	CustomerID string  `dynamodbav:"customer_id"`
+	Token      string  `dynamodbav:"token"`
+	CreatedAt  string  `dynamodbav:"created_at"`
+	VerifiedAt *string `dynamodbav:"verified_at,omitempty"`
+}
+
+// Verified reports whether the ownership of the domain has been verified.
+func (d *Domain) Verified() bool {
+	return d.VerifiedAt != nil && *d.VerifiedAt != ""
+}
+
+// unverifiedDomainCutoff returns the creation time before which unverified registrations
+// have expired, for a registration created at createdAt.
+func unverifiedDomainCutoff(createdAt string) (time.Time, error) {
+	created, err := time.Parse(time.RFC3339, createdAt)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid created_at format: %v", err)
+	}
+	return created.UTC().Add(-UnverifiedDomainTTL), nil
+}
+
+// CreateDomain registers a domain in the DynamoDB table "Domains", replacing an unverified
+// registration created more than UnverifiedDomainTTL before it.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - domain: The domain to register, unverified.
+//
+// Returns:
+//   - A pointer to the registered Domain.
+//   - An error containing "already exists" if the domain is verified or its registration
+//     has not expired, whichever customer holds it, or an error if the write fails.
+func (r *DynamoLinkStore) CreateDomain(ctx context.Context, domain Domain) (*Domain, error) {
+	cutoff, err := unverifiedDomainCutoff(domain.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := attributevalue.MarshalMap(domain)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal domain: %v", err)
+	}
+
+	_, err = r.db.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:           aws.String("Domains"),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(#domain) OR (attribute_not_exists(verified_at) AND created_at < :cutoff)"),
+		ExpressionAttributeNames: map[string]string{
+			"#domain": "domain",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":cutoff": &types.AttributeValueMemberS{Value: cutoff.Format(time.RFC3339)},
+		},
+	})
+	if err != nil {
+		var ccfe *types.ConditionalCheckFailedException
+		if errors.As(err, &ccfe) {
+			logger.Log.Error("domain already exists", zap.String("domain", domain.Domain))
+			return nil, fmt.Errorf("domain '%s' already exists", domain.Domain)
+		}
+		logger.Log.Error("failed to create domain", zap.Error(err))
+		return nil, fmt.Errorf("failed to create domain: %v", err)
+	}
+
+	logger.Log.Info("domain created successfully", zap.String("domain", domain.Domain))
+	return &domain, nil
+}
+
+// GetDomain retrieves a registered domain from the DynamoDB table "Domains".
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - name: The host name of the domain.
+//
+// Returns:
+//   - A pointer to the Domain if it is registered.
+//   - An error if the domain is not found or the read fails.
+func (r *DynamoLinkStore) GetDomain(ctx context.Context, name string) (*Domain, error) {
+	result, err := r.db.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String("Domains"),
+		Key: map[string]types.AttributeValue{
+			"domain": &types.AttributeValueMemberS{Value: name},
+		},
+	})
+	if err != nil {
+		logger.Log.Error("failed to get domain", zap.Error(err))
+		return nil, fmt.Errorf("failed to get domain: %v", err)
+	}
+	if len(result.Item) == 0 {
+		logger.Log.Warn("domain not found", zap.String("domain", name))
+		return nil, fmt.Errorf("domain not found")
+	}
+
+	var domain Domain
+	if err := attributevalue.UnmarshalMap(result.Item, &domain); err != nil {
+		logger.Log.Error("failed to unmarshal domain", zap.Error(err))
+		return nil, fmt.Errorf("failed to unmarshal domain: %v", err)
+	}
+	return &domain, nil
+}
+
+// GetCustomerDomains retrieves the domains registered by a customer through the
+// "ByCustomer" index of the "Domains" table, ordered by name.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - customerID: The ID of the customer.
+//
+// Returns:
+//   - The customer's domains, possibly none.
+//   - An error if the query fails.
+func (r *DynamoLinkStore) GetCustomerDomains(ctx context.Context, customerID string) ([]*Domain, error) {
+	paginator := dynamodb.NewQueryPaginator(r.db, &dynamodb.QueryInput{
+		TableName:              aws.String("Domains"),
+		IndexName:              aws.String("ByCustomer"),
+		KeyConditionExpression: aws.String("customer_id = :customer_id"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":customer_id": &types.AttributeValueMemberS{Value: customerID},
+		},
+	})
+
+	var domains []*Domain
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			logger.Log.Error("failed to query customer domains", zap.Error(err))
+			return nil, fmt.Errorf("failed to query customer domains: %v", err)
+		}
+
+		var pageDomains []*Domain
+		if err := attributevalue.UnmarshalListOfMaps(page.Items, &pageDomains); err != nil {
+			logger.Log.Error("failed to unmarshal domains", zap.Error(err))
+			return nil, fmt.Errorf("failed to unmarshal domains: %v", err)
+		}
+		domains = append(domains, pageDomains...)
+	}
+	return domains, nil
+}
+
+// MarkDomainVerified sets the verification time of a registered domain in the
+// DynamoDB table "Domains".
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - name: The host name of the domain.
+//   - verifiedAt: The RFC3339 time the ownership was verified at.
+//
+// Returns:
+//   - A pointer to the updated Domain.
+//   - An error if the domain is not found or the update fails.
+func (r *DynamoLinkStore) MarkDomainVerified(ctx context.Context, name, verifiedAt string) (*Domain, error) {
+	result, err := r.db.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String("Domains"),
+		Key: map[string]types.AttributeValue{
+			"domain": &types.AttributeValueMemberS{Value: name},
+		},
+		UpdateExpression:    aws.String("SET verified_at = :verified_at"),
+		ConditionExpression: aws.String("attribute_exists(#domain)"),
+		ExpressionAttributeNames: map[string]string{
+			"#domain": "domain",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":verified_at": &types.AttributeValueMemberS{Value: verifiedAt},
+		},
+		ReturnValues: types.ReturnValueAllNew,
+	})
+	if err != nil {
+		var ccfe *types.ConditionalCheckFailedException
+		if errors.As(err, &ccfe) {
+			logger.Log.Warn("domain not found", zap.String("domain", name))
+			return nil, fmt.Errorf("domain not found")
+		}
+		logger.Log.Error("failed to verify domain", zap.Error(err))
+		return nil, fmt.Errorf("failed to verify domain: %v", err)
+	}
+
+	var domain Domain
+	if err := attributevalue.UnmarshalMap(result.Attributes, &domain); err != nil {
+		logger.Log.Error("failed to unmarshal domain", zap.Error(err))
+		return nil, fmt.Errorf("failed to unmarshal domain: %v", err)
+	}
+
+	logger.Log.Info("domain verified successfully", zap.String("domain", name))
+	return &domain, nil
+}
+
+// DeleteDomain deletes one of a customer's domains from the DynamoDB table "Domains".
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - customerID: The ID of the customer owning the domain.
+//   - name: The host name of the domain.
+//
+// Returns:
+//   - An error if the customer has no domain with this name or the delete fails.
+func (r *DynamoLinkStore) DeleteDomain(ctx context.Context, customerID, name string) error {
+	_, err := r.db.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String("Domains"),
+		Key: map[string]types.AttributeValue{
+			"domain": &types.AttributeValueMemberS{Value: name},
+		},
+		ConditionExpression: aws.String("customer_id = :customer_id"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":customer_id": &types.AttributeValueMemberS{Value: customerID},
+		},
+	})
+	if err != nil {
+		var ccfe *types.ConditionalCheckFailedException
+		if errors.As(err, &ccfe) {
+			logger.Log.Warn("domain not found", zap.String("domain", name))
+			return fmt.Errorf("domain not found")
+		}
+		logger.Log.Error("failed to delete domain", zap.Error(err))
+		return fmt.Errorf("failed to delete domain: %v", err)
+	}
+
+	logger.Log.Info("domain deleted successfully", zap.String("domain", name))
+	return nil
+}
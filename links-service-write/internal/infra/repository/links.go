@@ -5,6 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"links-service-write/internal/logger"
+	"reflect"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -22,30 +25,75 @@ type Link struct {
 	CustomSlug     string  `dynamodbav:"custom_slug"`
 	CustomerID     string  `dynamodbav:"customer_id"`
 	Clicks         int32   `dynamodbav:"clicks"`
+	BotClicks      int32   `dynamodbav:"bot_clicks"`
 	CreatedAt      string  `dynamodbav:"created_at"`
 	UpdatedAt      string  `dynamodbav:"updated_at"`
 	ExpirationDate *string `dynamodbav:"expiration_date,omitempty"`
 	TTL            *int64  `dynamodbav:"ttl,omitempty"`
+
+	// Domain is the branded domain the link is served on, "" for the default one. The
+	// short URL of a branded link is prefixed with it, see LinkKey.
+	Domain string `dynamodbav:"domain,omitempty"`
+
+	SlugType    string  `dynamodbav:"slug_type"`
+	Disabled    bool    `dynamodbav:"disabled"`
+	ActivatesAt *string `dynamodbav:"activates_at,omitempty"`
+
+	// FallbackURL is where visitors are sent while the link is not active, that is when
+	// it is expired, at its click limit, disabled or not active yet, "" to fall back to
+	// the customer's fallback URL (see LinkSettings).
+	FallbackURL string `dynamodbav:"fallback_url,omitempty"`
+
+	// Rules send visitors meeting their conditions to other destinations than
+	// OriginalURL, in order, nil if none. See RedirectRule.
+	Rules []RedirectRule `dynamodbav:"rules,omitempty"`
+
+	// Variants split the visitors of the link between several destinations, nil if it
+	// has a single one. OriginalURL is then the URL of the first variant. Visitors keep
+	// the variant they were assigned on their next visits if StickyVariants is set.
+	Variants       []Variant `dynamodbav:"variants,omitempty"`
+	StickyVariants bool      `dynamodbav:"sticky_variants,omitempty"`
+
+	Title string   `dynamodbav:"title,omitempty"`
+	Tags  []string `dynamodbav:"tags,omitempty"`
+
+	// UTM holds the UTM parameters added to OriginalURL on redirect, nil if none.
+	UTM *UTM `dynamodbav:"utm,omitempty"`
+
+	// PasswordHash is the bcrypt hash of the password visitors must enter before being
+	// redirected, "" if the link is not protected.
+	PasswordHash string `dynamodbav:"password_hash,omitempty"`
+
+	// MaxClicks is the number of clicks after which the link behaves as expired, 0 if
+	// unlimited. It is enforced by UpdateLinkClicks; a one-time link has a limit of 1.
+	MaxClicks int32 `dynamodbav:"max_clicks,omitempty"`
+
+	// Sort keys of the ByCustomerExpiration and ByCustomerOriginalURL indexes, and
+	// the search index of the link, derived by applyReadModel.
+	ExpirationSort  string `dynamodbav:"expiration_sort"`
+	OriginalURLSort string `dynamodbav:"original_url_sort"`
+	SearchText      string `dynamodbav:"search_text"`
 }
 
-type LinksRepository struct {
+type DynamoLinkStore struct {
 	db *dynamodb.Client
 }
 
-// NewLinksRepository creates a new instance of LinksRepository with the provided DynamoDB client.
+// NewDynamoLinkStore creates a new instance of DynamoLinkStore with the provided DynamoDB client.
 // It initializes the repository to interact with the DynamoDB database.
 //
 // Parameters:
 //   - db: A pointer to a dynamodb.Client instance used to perform database operations.
 //
 // Returns:
-//   - A pointer to a LinksRepository instance.
-func NewLinksRepository(db *dynamodb.Client) *LinksRepository {
-	return &LinksRepository{db: db}
+//   - A pointer to a DynamoLinkStore instance.
+func NewDynamoLinkStore(db *dynamodb.Client) *DynamoLinkStore {
+	return &DynamoLinkStore{db: db}
 }
 
 // CreateLink inserts a new link into the DynamoDB table "Links".
-// It ensures that the custom slug is unique and sets default timestamps if not provided.
+// It reserves the slugs of the link and writes it in a single transaction, and sets default
+// timestamps if not provided.
 //
 // Parameters:
 //   - ctx: The context for managing request deadlines and cancellations.
@@ -54,35 +102,24 @@ func NewLinksRepository(db *dynamodb.Client) *LinksRepository {
 // Returns:
 //   - A pointer to the created Link object.
 //   - An error if the operation fails, such as invalid expiration date format,
-//     failure to marshal the link, or if the custom slug already exists.
+//     failure to marshal the link, or if one of its slugs is already taken.
 //
 // Behavior:
 //   - If the CreatedAt or UpdatedAt fields in the Link object are empty,
 //     they are set to the current UTC time in RFC3339 format.
-//   - If the ExpirationDate field is provided, it is parsed and used to set the TTL (Time-To-Live) value.
-//   - The function ensures that the custom slug is unique by using a conditional expression
-//     in the DynamoDB PutItem operation.
+//   - If the ExpirationDate field is provided, it is parsed and used to set the TTL (Time-To-Live) value,
+//     ExpiredLinkRetention after it.
+//   - The short URL and custom slug are reserved in the "SlugReservations" table (see linkSlugs)
+//     within a TransactWriteItems call, so that concurrent creates of the same slug cannot both
+//     succeed. A GSI attribute such as custom_slug cannot be protected by a condition on the item itself.
 //
 // Errors:
 //   - Returns an error if the ExpirationDate is in an invalid format.
-//   - Returns an error if the custom slug already exists in the table.
+//   - Returns an error containing "already exists" if the short URL or custom slug is taken.
 //   - Returns an error if there is a failure in marshaling the Link object or inserting it into DynamoDB.
-func (r *LinksRepository) CreateLink(ctx context.Context, link Link) (*Link, error) {
-	now := time.Now().UTC().Format(time.RFC3339)
-	if link.CreatedAt == "" {
-		link.CreatedAt = now
-	}
-	if link.UpdatedAt == "" {
-		link.UpdatedAt = now
-	}
-
-	if link.ExpirationDate != nil && *link.ExpirationDate != "" {
-		expTime, err := time.Parse(time.RFC3339, *link.ExpirationDate)
-		if err != nil {
-			return nil, fmt.Errorf("invalid expiration date format: %v", err)
-		}
-		ttl := expTime.Unix()
-		link.TTL = &ttl
+func (r *DynamoLinkStore) CreateLink(ctx context.Context, link Link) (*Link, error) {
+	if err := prepareNewLink(&link); err != nil {
+		return nil, err
 	}
 
 	item, err := attributevalue.MarshalMap(link)
@@ -90,20 +127,26 @@ func (r *LinksRepository) CreateLink(ctx context.Context, link Link) (*Link, err
 		return nil, fmt.Errorf("failed to marshal link: %v", err)
 	}
 
-	_, err = r.db.PutItem(ctx, &dynamodb.PutItemInput{
-		TableName:           aws.String("Links"),
-		Item:                item,
-		ConditionExpression: aws.String("attribute_not_exists(custom_slug) OR custom_slug = :empty"),
-		ExpressionAttributeValues: map[string]types.AttributeValue{
-			":empty": &types.AttributeValueMemberS{Value: ""},
+	slugs := linkSlugs(&link)
+	transactItems := make([]types.TransactWriteItem, 0, len(slugs)+1)
+	for _, slug := range slugs {
+		transactItems = append(transactItems, reserveSlugItem(slug, &link))
+	}
+	transactItems = append(transactItems, types.TransactWriteItem{
+		Put: &types.Put{
+			TableName:           aws.String("Links"),
+			Item:                item,
+			ConditionExpression: aws.String("attribute_not_exists(short_url)"),
 		},
 	})
 
+	_, err = r.db.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: transactItems,
+	})
 	if err != nil {
-		var ccfe *types.ConditionalCheckFailedException
-		if errors.As(err, &ccfe) {
-			logger.Log.Error("custom slug already exists", zap.String("custom_slug", link.CustomSlug))
-			return nil, fmt.Errorf("custom slug '%s' already exists", link.CustomSlug)
+		if slug := conflictingSlug(err, append(slugs, link.ShortURL)); slug != "" {
+			logger.Log.Error("slug already exists", zap.String("slug", slug))
+			return nil, slugExistsError(&link, slug)
 		}
 		logger.Log.Error("failed to create link", zap.Error(err))
 		return nil, fmt.Errorf("failed to create link: %v", err)
@@ -123,7 +166,7 @@ func (r *LinksRepository) CreateLink(ctx context.Context, link Link) (*Link, err
 // Returns:
 //   - A pointer to the Link struct if the link is found.
 //   - An error if the link is not found, the query fails, or unmarshaling fails.
-func (r *LinksRepository) GetLinkByShortURL(ctx context.Context, shortURL string) (*Link, error) {
+func (r *DynamoLinkStore) GetLinkByShortURL(ctx context.Context, shortURL string) (*Link, error) {
 	result, err := r.db.GetItem(ctx, &dynamodb.GetItemInput{
 		TableName: aws.String("Links"),
 		Key: map[string]types.AttributeValue{
@@ -160,7 +203,7 @@ func (r *LinksRepository) GetLinkByShortURL(ctx context.Context, shortURL string
 //   - A pointer to the Link struct if the link is found.
 //   - An error if the link is not found, if there is an issue building the query expression,
 //     querying the database, or unmarshaling the result.
-func (r *LinksRepository) GetLinkByID(ctx context.Context, id string) (*Link, error) {
+func (r *DynamoLinkStore) GetLinkByID(ctx context.Context, id string) (*Link, error) {
 	expr, err := expression.NewBuilder().
 		WithKeyCondition(
 			expression.Key("id").Equal(expression.Value(id)),
@@ -208,7 +251,7 @@ func (r *LinksRepository) GetLinkByID(ctx context.Context, id string) (*Link, er
 // Returns:
 //   - A pointer to the Link struct if a matching link is found.
 //   - An error if the query fails, the link is not found, or unmarshalling the result fails.
-func (r *LinksRepository) GetLinkByCustomSlug(ctx context.Context, customSlug string) (*Link, error) {
+func (r *DynamoLinkStore) GetLinkByCustomSlug(ctx context.Context, customSlug string) (*Link, error) {
 	result, err := r.db.Query(ctx, &dynamodb.QueryInput{
 		TableName:              aws.String("Links"),
 		IndexName:              aws.String("ByCustomSlug"),
@@ -249,7 +292,7 @@ func (r *LinksRepository) GetLinkByCustomSlug(ctx context.Context, customSlug st
 // Returns:
 //   - A slice of pointers to Link objects representing the customer's links.
 //   - An error if the query fails or if unmarshalling the data encounters an issue.
-func (r *LinksRepository) GetCustomerLinks(ctx context.Context, customerID string) ([]*Link, error) {
+func (r *DynamoLinkStore) GetCustomerLinks(ctx context.Context, customerID string) ([]*Link, error) {
 	result, err := r.db.Query(ctx, &dynamodb.QueryInput{
 		TableName:              aws.String("Links"),
 		IndexName:              aws.String("ByCustomer"),
@@ -280,7 +323,8 @@ func (r *LinksRepository) GetCustomerLinks(ctx context.Context, customerID strin
 
 // DeleteLink deletes a link from the database based on its ID and associated customer ID.
 // It first retrieves the link using the provided ID to obtain the primary key (short_url),
-// and then deletes the link using this key.
+// and then deletes the link using this key, releasing its slug reservations in the same
+// transaction.
 //
 // Parameters:
 //   - ctx: The context for managing request deadlines and cancellations.
@@ -288,18 +332,34 @@ func (r *LinksRepository) GetCustomerLinks(ctx context.Context, customerID strin
 //   - customerID: The ID of the customer associated with the link.
 //
 // Returns:
-//   - error: An error if the operation fails, or nil if the deletion is successful.
-func (r *LinksRepository) DeleteLink(ctx context.Context, id, customerID string) error {
+//   - error: An error if the link does not exist or belongs to another customer, if the
+//     operation fails, or nil if the deletion is successful.
+func (r *DynamoLinkStore) DeleteLink(ctx context.Context, id, customerID string) error {
 	link, err := r.GetLinkByID(ctx, id)
 	if err != nil {
 		return err
 	}
+	if link.CustomerID != customerID {
+		logger.Log.Error("link does not belong to this customer", zap.String("customer_id", customerID))
+		return fmt.Errorf("link does not belong to this customer")
+	}
 
-	_, err = r.db.DeleteItem(ctx, &dynamodb.DeleteItemInput{
-		TableName: aws.String("Links"),
-		Key: map[string]types.AttributeValue{
-			"short_url": &types.AttributeValueMemberS{Value: link.ShortURL},
+	transactItems := []types.TransactWriteItem{
+		{
+			Delete: &types.Delete{
+				TableName: aws.String("Links"),
+				Key: map[string]types.AttributeValue{
+					"short_url": &types.AttributeValueMemberS{Value: link.ShortURL},
+				},
+			},
 		},
+	}
+	for _, slug := range linkSlugs(link) {
+		transactItems = append(transactItems, releaseSlugItem(slug, link.ID))
+	}
+
+	_, err = r.db.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: transactItems,
 	})
 	if err != nil {
 		logger.Log.Error("failed to delete link", zap.Error(err))
@@ -312,63 +372,71 @@ func (r *LinksRepository) DeleteLink(ctx context.Context, id, customerID string)
 
 // UpdateLink updates an existing link in the repository.
 // It retrieves the original link to ensure it belongs to the client and uses its primary key.
-// The function preserves certain fields from the original link, such as ShortURL, CreatedAt, and Clicks,
+// The function preserves certain fields from the original link, such as ShortURL, CreatedAt, Clicks and BotClicks,
 // while updating the UpdatedAt field to the current time.
 //
 // If the CustomerID field is empty, an error is returned.
 //
-// If the ExpirationDate field is provided, it validates the format and calculates the TTL (time-to-live).
+// If the ExpirationDate field is provided, it validates the format and calculates the TTL (time-to-live),
+// ExpiredLinkRetention after it.
 // If the ExpirationDate is invalid, an error is returned. If no ExpirationDate is provided, the TTL is set to nil.
 //
-// The updated link is marshaled into a DynamoDB-compatible attribute map and stored in the "Links" table.
-// If the update operation fails, an error is returned.
+// Only the editable attributes of the link are written (see editableLinkAttributes), with an UpdateItem
+// conditioned on the item still holding the same link, in the same transaction that reserves a new custom
+// slug and releases the previous one. The click counters, which the original link is read from an eventually
+// consistent index for, are never written back, so that clicks counted in the meantime are kept and a link
+// at its click limit is not reopened; a link deleted in the meantime is not brought back. If the new custom
+// slug is held by another link, an error containing "already exists" is returned.
 //
 // Parameters:
 //   - ctx: The context for managing request deadlines and cancellations.
 //   - link: The Link object containing the updated data.
 //
 // Returns:
-//   - A pointer to the updated Link object.
-//   - An error if the update operation fails or if validation errors occur.
-func (r *LinksRepository) UpdateLink(ctx context.Context, link Link) (*Link, error) {
+//   - A pointer to the updated Link object. Its click counters are those of the original link, and may
+//     lag behind the stored ones.
+//   - An error if the link is not found, the update operation fails or if validation errors occur.
+func (r *DynamoLinkStore) UpdateLink(ctx context.Context, link Link) (*Link, error) {
 	existingLink, err := r.GetLinkByID(ctx, link.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	link.ShortURL = existingLink.ShortURL
-	link.CreatedAt = existingLink.CreatedAt
-	link.Clicks = existingLink.Clicks
-	link.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
-
-	if link.CustomerID == "" {
-		logger.Log.Error("customer_id cannot be empty")
-		return nil, fmt.Errorf("customer_id cannot be empty")
-	}
-
-	if link.ExpirationDate != nil && *link.ExpirationDate != "" {
-		expTime, err := time.Parse(time.RFC3339, *link.ExpirationDate)
-		if err != nil {
-			logger.Log.Error("invalid expiration date format", zap.Error(err))
-			return nil, fmt.Errorf("invalid expiration date format: %v", err)
-		}
-		ttl := expTime.Unix()
-		link.TTL = &ttl
-	} else {
-		link.TTL = nil
+	if err := prepareUpdatedLink(&link, existingLink); err != nil {
+		logger.Log.Error("invalid updated link", zap.Error(err))
+		return nil, err
 	}
 
-	item, err := attributevalue.MarshalMap(link)
+	update, err := linkUpdate(&link)
 	if err != nil {
 		logger.Log.Error("failed to marshal updated link", zap.Error(err))
 		return nil, fmt.Errorf("failed to marshal updated link: %v", err)
 	}
 
-	_, err = r.db.PutItem(ctx, &dynamodb.PutItemInput{
-		TableName: aws.String("Links"),
-		Item:      item,
+	slugs := linkSlugs(&link)
+	transactItems := make([]types.TransactWriteItem, 0, len(slugs)+2)
+	for _, slug := range slugs {
+		transactItems = append(transactItems, reserveSlugItem(slug, &link))
+	}
+	for _, slug := range releasedSlugs(existingLink, &link) {
+		transactItems = append(transactItems, releaseSlugItem(slug, link.ID))
+	}
+	transactItems = append(transactItems, types.TransactWriteItem{Update: update})
+
+	_, err = r.db.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: transactItems,
 	})
 	if err != nil {
+		if slug := conflictingSlug(err, slugs); slug != "" {
+			logger.Log.Error("slug already exists", zap.String("slug", slug))
+			return nil, slugExistsError(&link, slug)
+		}
+		var tce *types.TransactionCanceledException
+		if errors.As(err, &tce) && len(tce.CancellationReasons) == len(transactItems) &&
+			aws.ToString(tce.CancellationReasons[len(transactItems)-1].Code) == "ConditionalCheckFailed" {
+			logger.Log.Warn("link not found", zap.String("link_id", link.ID))
+			return nil, fmt.Errorf("link not found")
+		}
 		logger.Log.Error("failed to update link", zap.Error(err))
 		return nil, fmt.Errorf("failed to update link: %v", err)
 	}
@@ -377,20 +445,84 @@ func (r *LinksRepository) UpdateLink(ctx context.Context, link Link) (*Link, err
 	return &link, nil
 }
 
+// editableLinkAttributes lists the attributes of a link item that UpdateLink writes:
+// every attribute of Link but its key, ID, domain, creation date and click counters.
+var editableLinkAttributes = func() []string {
+	kept := map[string]bool{
+		"short_url": true, "id": true, "domain": true, "created_at": true, "clicks": true, "bot_clicks": true,
+	}
+
+	var names []string
+	linkType := reflect.TypeOf(Link{})
+	for i := 0; i < linkType.NumField(); i++ {
+		name, _, _ := strings.Cut(linkType.Field(i).Tag.Get("dynamodbav"), ",")
+		if name != "" && name != "-" && !kept[name] {
+			names = append(names, name)
+		}
+	}
+	return names
+}()
+
+// linkUpdate builds the transactional update writing the editable attributes of a link
+// (see editableLinkAttributes): attributes the link sets are set, and the others,
+// left out by omitempty, are removed. The update is conditioned on the item holding the
+// same link, so that it fails rather than recreating a deleted link.
+func linkUpdate(link *Link) (*types.Update, error) {
+	item, err := attributevalue.MarshalMap(*link)
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[string]string{"#id": "id"}
+	values := map[string]types.AttributeValue{":id": &types.AttributeValueMemberS{Value: link.ID}}
+	var set, remove []string
+	for i, name := range editableLinkAttributes {
+		placeholder := "#a" + strconv.Itoa(i)
+		names[placeholder] = name
+		if value, ok := item[name]; ok {
+			values[":a"+strconv.Itoa(i)] = value
+			set = append(set, placeholder+" = :a"+strconv.Itoa(i))
+		} else {
+			remove = append(remove, placeholder)
+		}
+	}
+
+	updateExpression := "SET " + strings.Join(set, ", ")
+	if len(remove) > 0 {
+		updateExpression += " REMOVE " + strings.Join(remove, ", ")
+	}
+
+	return &types.Update{
+		TableName: aws.String("Links"),
+		Key: map[string]types.AttributeValue{
+			"short_url": &types.AttributeValueMemberS{Value: link.ShortURL},
+		},
+		UpdateExpression:          aws.String(updateExpression),
+		ConditionExpression:       aws.String("#id = :id"),
+		ExpressionAttributeNames:  names,
+		ExpressionAttributeValues: values,
+	}, nil
+}
+
 // UpdateLinkClicks increments the click count of a link and updates its
 // "updated_at" timestamp in the database. It retrieves the link by its ID,
 // constructs an update expression to modify the "clicks" and "updated_at" fields,
 // and applies the update to the DynamoDB table.
 //
+// The update is conditioned on the link being below its click limit (see
+// Link.MaxClicks), so that concurrent clicks on a link with one click left cannot both
+// be counted: DynamoDB evaluates the condition and the increment atomically.
+//
 // Parameters:
 //   - ctx: The context for managing request deadlines and cancellations.
 //   - id: The unique identifier of the link to be updated.
 //
 // Returns:
 //   - A pointer to the updated Link object if the operation is successful.
-//   - An error if the link cannot be retrieved, the update expression cannot be
-//     built, the update operation fails, or the updated link cannot be unmarshaled.
-func (r *LinksRepository) UpdateLinkClicks(ctx context.Context, id string) (*Link, error) {
+//   - An error if the link cannot be retrieved, has reached its click limit (the message
+//     then contains "click limit reached"), the update expression cannot be built, the
+//     update operation fails, or the updated link cannot be unmarshaled.
+func (r *DynamoLinkStore) UpdateLinkClicks(ctx context.Context, id string) (*Link, error) {
 	link, err := r.GetLinkByID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -405,6 +537,14 @@ func (r *LinksRepository) UpdateLinkClicks(ctx context.Context, id string) (*Lin
 				expression.Name("updated_at"),
 				expression.Value(time.Now().UTC().Format(time.RFC3339)),
 			),
+		).
+		WithCondition(
+			expression.AttributeExists(expression.Name("short_url")).And(
+				expression.Or(
+					expression.AttributeNotExists(expression.Name("max_clicks")),
+					expression.Name("clicks").LessThan(expression.Name("max_clicks")),
+				),
+			),
 		).Build()
 	if err != nil {
 		logger.Log.Error("failed to build update expression", zap.Error(err))
@@ -416,12 +556,23 @@ func (r *LinksRepository) UpdateLinkClicks(ctx context.Context, id string) (*Lin
 		Key: map[string]types.AttributeValue{
 			"short_url": &types.AttributeValueMemberS{Value: link.ShortURL},
 		},
-		UpdateExpression:          expr.Update(),
-		ExpressionAttributeNames:  expr.Names(),
-		ExpressionAttributeValues: expr.Values(),
-		ReturnValues:              types.ReturnValueAllNew,
+		UpdateExpression:                    expr.Update(),
+		ConditionExpression:                 expr.Condition(),
+		ExpressionAttributeNames:            expr.Names(),
+		ExpressionAttributeValues:           expr.Values(),
+		ReturnValues:                        types.ReturnValueAllNew,
+		ReturnValuesOnConditionCheckFailure: types.ReturnValuesOnConditionCheckFailureAllOld,
 	})
 	if err != nil {
+		var ccfe *types.ConditionalCheckFailedException
+		if errors.As(err, &ccfe) {
+			if len(ccfe.Item) == 0 {
+				logger.Log.Error("link not found", zap.String("id", id))
+				return nil, fmt.Errorf("link not found")
+			}
+			logger.Log.Info("link click limit reached", zap.String("id", id))
+			return nil, fmt.Errorf("link click limit reached")
+		}
 		logger.Log.Error("failed to update link clicks", zap.Error(err))
 		return nil, fmt.Errorf("failed to update link clicks: %v", err)
 	}
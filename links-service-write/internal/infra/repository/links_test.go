@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinkUpdate(t *testing.T) {
+	link := Link{ID: "id-1", ShortURL: "promo", CustomerID: "customer-1", OriginalURL: "https://example.com",
+		Clicks: 3, BotClicks: 1, CreatedAt: "2025-01-02T10:00:00Z", Title: "Promo"}
+
+	update, err := linkUpdate(&link)
+	require.NoError(t, err)
+	require.Equal(t, "#id = :id", aws.ToString(update.ConditionExpression), "Deleted links should not be recreated")
+	require.Equal(t, &types.AttributeValueMemberS{Value: "promo"}, update.Key["short_url"])
+
+	written := make(map[string]bool)
+	for placeholder, name := range update.ExpressionAttributeNames {
+		if placeholder != "#id" {
+			written[name] = true
+		}
+	}
+	for _, name := range []string{"clicks", "bot_clicks", "created_at", "short_url", "domain"} {
+		require.False(t, written[name], "%s should never be written by an update", name)
+	}
+	require.True(t, written["title"])
+	require.True(t, written["password_hash"], "Attributes left empty should be removed")
+	require.Contains(t, aws.ToString(update.UpdateExpression), " REMOVE ")
+}
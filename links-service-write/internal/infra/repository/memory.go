@@ -0,0 +1,434 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"links-service-write/internal/analytics"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryLinkStore is a LinkStore keeping links, domains, UTM templates, link settings,
+// click events and rollups in memory. It is meant for tests and local development: nothing is persisted,
+// and the data is not shared with links-service-read.
+type MemoryLinkStore struct {
+	mu       sync.RWMutex
+	links    map[string]*Link            // by short URL
+	slugs    map[string]string           // link ID by reserved slug
+	domains  map[string]*Domain          // by name
+	utm      map[string]*UTMTemplate     // by customer ID and template ID
+	settings map[string]LinkSettings     // by customer ID
+	events   map[string][]ClickEvent     // by link ID
+	rollups  map[string]map[string]int64 // by link ID and bucket, then by counter
+	counters map[string]uint64           // by name
+}
+
+// NewMemoryLinkStore creates an empty MemoryLinkStore.
+//
+// Returns:
+//   - A pointer to a MemoryLinkStore instance.
+func NewMemoryLinkStore() *MemoryLinkStore {
+	return &MemoryLinkStore{
+		links:    make(map[string]*Link),
+		slugs:    make(map[string]string),
+		domains:  make(map[string]*Domain),
+		utm:      make(map[string]*UTMTemplate),
+		settings: make(map[string]LinkSettings),
+		events:   make(map[string][]ClickEvent),
+		rollups:  make(map[string]map[string]int64),
+		counters: make(map[string]uint64),
+	}
+}
+
+// CreateLink stores a new link. Like the other backends, it rejects a short URL or
+// custom slug that another link holds.
+func (s *MemoryLinkStore) CreateLink(ctx context.Context, link Link) (*Link, error) {
+	if err := prepareNewLink(&link); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.reserveSlugsLocked(&link); err != nil {
+		return nil, err
+	}
+
+	s.links[link.ShortURL] = copyLink(&link)
+	return &link, nil
+}
+
+// CreateLinks stores new links one by one, see LinkStore.CreateLinks.
+func (s *MemoryLinkStore) CreateLinks(ctx context.Context, links []Link) ([]*Link, []error) {
+	return createLinksOneByOne(ctx, s, links)
+}
+
+// GetLinkByShortURL returns the link served at the given short URL.
+func (s *MemoryLinkStore) GetLinkByShortURL(ctx context.Context, shortURL string) (*Link, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	link, ok := s.links[shortURL]
+	if !ok {
+		return nil, fmt.Errorf("link not found")
+	}
+	return copyLink(link), nil
+}
+
+// GetLinkByID returns the link with the given ID.
+func (s *MemoryLinkStore) GetLinkByID(ctx context.Context, id string) (*Link, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	link := s.findLocked(func(l *Link) bool { return l.ID == id })
+	if link == nil {
+		return nil, fmt.Errorf("link not found")
+	}
+	return copyLink(link), nil
+}
+
+// GetLinkByCustomSlug returns the link using the given custom slug.
+func (s *MemoryLinkStore) GetLinkByCustomSlug(ctx context.Context, customSlug string) (*Link, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	link := s.findLocked(func(l *Link) bool { return customSlug != "" && l.CustomSlug == customSlug })
+	if link == nil {
+		return nil, fmt.Errorf("link not found")
+	}
+	return copyLink(link), nil
+}
+
+// DeleteLink deletes a link of the given customer.
+func (s *MemoryLinkStore) DeleteLink(ctx context.Context, id, customerID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	link := s.findLocked(func(l *Link) bool { return l.ID == id })
+	if link == nil {
+		return fmt.Errorf("link not found")
+	}
+	if link.CustomerID != customerID {
+		return fmt.Errorf("link does not belong to this customer")
+	}
+
+	delete(s.links, link.ShortURL)
+	for _, slug := range linkSlugs(link) {
+		delete(s.slugs, slug)
+	}
+	return nil
+}
+
+// UpdateLink replaces the editable fields of a link.
+func (s *MemoryLinkStore) UpdateLink(ctx context.Context, link Link) (*Link, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existingLink := s.findLocked(func(l *Link) bool { return l.ID == link.ID })
+	if existingLink == nil {
+		return nil, fmt.Errorf("link not found")
+	}
+	if err := prepareUpdatedLink(&link, existingLink); err != nil {
+		return nil, err
+	}
+	if err := s.reserveSlugsLocked(&link); err != nil {
+		return nil, err
+	}
+	for _, slug := range releasedSlugs(existingLink, &link) {
+		delete(s.slugs, slug)
+	}
+
+	s.links[link.ShortURL] = copyLink(&link)
+	return &link, nil
+}
+
+// UpdateLinkClicks increments the click counter of a link, unless it has reached its
+// click limit.
+func (s *MemoryLinkStore) UpdateLinkClicks(ctx context.Context, id string) (*Link, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	link := s.findLocked(func(l *Link) bool { return l.ID == id })
+	if link == nil {
+		return nil, fmt.Errorf("link not found")
+	}
+	if link.MaxClicks > 0 && link.Clicks >= link.MaxClicks {
+		return nil, fmt.Errorf("link click limit reached")
+	}
+
+	link.Clicks++
+	link.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+	return copyLink(link), nil
+}
+
+// RecordClick stores a click event and adds it to the link's counters and rollups.
+func (s *MemoryLinkStore) RecordClick(ctx context.Context, event ClickEvent) (*ClickEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	link := s.findLocked(func(l *Link) bool { return l.ID == event.LinkID })
+	if link == nil {
+		return nil, fmt.Errorf("link not found")
+	}
+
+	now := time.Now().UTC()
+	if event.ClickedAt == "" {
+		event.ClickedAt = now.Format(time.RFC3339)
+	}
+	clickedAt, err := time.Parse(time.RFC3339, event.ClickedAt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid clicked_at format: %v", err)
+	}
+	event.CustomerID = link.CustomerID
+	event.UTMCampaign = link.UTMCampaign()
+
+	for _, existing := range s.events[event.LinkID] {
+		if existing.EventID == event.EventID {
+			return nil, fmt.Errorf("failed to record click: event %s already recorded", event.EventID)
+		}
+	}
+	s.events[event.LinkID] = append(s.events[event.LinkID], event)
+
+	if event.countsOnLink() {
+		if event.BotCategory != "" {
+			link.BotClicks++
+		} else {
+			link.Clicks++
+		}
+		link.UpdatedAt = now.Format(time.RFC3339)
+	}
+
+	for _, granularity := range analytics.Granularities {
+		key := event.LinkID + "#" + analytics.BucketKey(granularity, clickedAt)
+		if s.rollups[key] == nil {
+			s.rollups[key] = make(map[string]int64)
+		}
+		for _, counter := range rollupCounters(event) {
+			s.rollups[key][counter]++
+		}
+	}
+
+	return &event, nil
+}
+
+// ClickEvents returns the click events recorded on a link, in recording order.
+func (s *MemoryLinkStore) ClickEvents(linkID string) []ClickEvent {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append([]ClickEvent(nil), s.events[linkID]...)
+}
+
+// ClickRollup returns the counters of a link's rollup bucket, such as "day#2025-01-02".
+func (s *MemoryLinkStore) ClickRollup(linkID, bucket string) map[string]int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	counters := make(map[string]int64, len(s.rollups[linkID+"#"+bucket]))
+	for counter, count := range s.rollups[linkID+"#"+bucket] {
+		counters[counter] = count
+	}
+	return counters
+}
+
+// CreateDomain registers a branded domain, unless it is verified already or its
+// registration has not expired, see UnverifiedDomainTTL.
+func (s *MemoryLinkStore) CreateDomain(ctx context.Context, domain Domain) (*Domain, error) {
+	cutoff, err := unverifiedDomainCutoff(domain.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, ok := s.domains[domain.Domain]; ok {
+		if existing.Verified() || existing.CreatedAt >= cutoff.Format(time.RFC3339) {
+			return nil, fmt.Errorf("domain '%s' already exists", domain.Domain)
+		}
+	}
+	s.domains[domain.Domain] = copyDomain(&domain)
+	return &domain, nil
+}
+
+// GetDomain returns the registered domain with the given name.
+func (s *MemoryLinkStore) GetDomain(ctx context.Context, name string) (*Domain, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	domain, ok := s.domains[name]
+	if !ok {
+		return nil, fmt.Errorf("domain not found")
+	}
+	return copyDomain(domain), nil
+}
+
+// GetCustomerDomains returns the domains registered by a customer, ordered by name.
+func (s *MemoryLinkStore) GetCustomerDomains(ctx context.Context, customerID string) ([]*Domain, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var domains []*Domain
+	for _, domain := range s.domains {
+		if domain.CustomerID == customerID {
+			domains = append(domains, copyDomain(domain))
+		}
+	}
+	sort.Slice(domains, func(i, j int) bool { return domains[i].Domain < domains[j].Domain })
+	return domains, nil
+}
+
+// MarkDomainVerified records the verification time of a registered domain.
+func (s *MemoryLinkStore) MarkDomainVerified(ctx context.Context, name, verifiedAt string) (*Domain, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	domain, ok := s.domains[name]
+	if !ok {
+		return nil, fmt.Errorf("domain not found")
+	}
+	domain.VerifiedAt = &verifiedAt
+	return copyDomain(domain), nil
+}
+
+// DeleteDomain deletes a branded domain of the given customer.
+func (s *MemoryLinkStore) DeleteDomain(ctx context.Context, customerID, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if domain, ok := s.domains[name]; !ok || domain.CustomerID != customerID {
+		return fmt.Errorf("domain not found")
+	}
+	delete(s.domains, name)
+	return nil
+}
+
+// CreateUTMTemplate stores a UTM template of a customer.
+func (s *MemoryLinkStore) CreateUTMTemplate(ctx context.Context, template UTMTemplate) (*UTMTemplate, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := template
+	s.utm[template.CustomerID+"#"+template.ID] = &c
+	return &template, nil
+}
+
+// GetUTMTemplate returns the UTM template of a customer with the given ID.
+func (s *MemoryLinkStore) GetUTMTemplate(ctx context.Context, customerID, id string) (*UTMTemplate, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	template, ok := s.utm[customerID+"#"+id]
+	if !ok {
+		return nil, fmt.Errorf("utm template not found")
+	}
+	c := *template
+	return &c, nil
+}
+
+// GetCustomerUTMTemplates returns the UTM templates of a customer, ordered by name.
+func (s *MemoryLinkStore) GetCustomerUTMTemplates(ctx context.Context, customerID string) ([]*UTMTemplate, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var templates []*UTMTemplate
+	for _, template := range s.utm {
+		if template.CustomerID == customerID {
+			c := *template
+			templates = append(templates, &c)
+		}
+	}
+	sortUTMTemplates(templates)
+	return templates, nil
+}
+
+// DeleteUTMTemplate deletes a UTM template of a customer.
+func (s *MemoryLinkStore) DeleteUTMTemplate(ctx context.Context, customerID, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.utm[customerID+"#"+id]; !ok {
+		return fmt.Errorf("utm template not found")
+	}
+	delete(s.utm, customerID+"#"+id)
+	return nil
+}
+
+// PutLinkSettings stores the link settings of a customer, replacing the previous ones.
+func (s *MemoryLinkStore) PutLinkSettings(ctx context.Context, settings LinkSettings) (*LinkSettings, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.settings[settings.CustomerID] = settings
+	return &settings, nil
+}
+
+// GetLinkSettings returns the link settings of a customer, empty if they never saved any.
+func (s *MemoryLinkStore) GetLinkSettings(ctx context.Context, customerID string) (*LinkSettings, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	settings, ok := s.settings[customerID]
+	if !ok {
+		settings = LinkSettings{CustomerID: customerID}
+	}
+	return &settings, nil
+}
+
+// ReserveCounter adds n to a named counter, which starts at 0, and returns its new value.
+func (s *MemoryLinkStore) ReserveCounter(ctx context.Context, name string, n uint64) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.counters[name] += n
+	return s.counters[name], nil
+}
+
+// reserveSlugsLocked reserves the slugs of a link, or fails without reserving any if
+// another link holds one of them. s.mu must be held.
+func (s *MemoryLinkStore) reserveSlugsLocked(link *Link) error {
+	slugs := linkSlugs(link)
+	for _, slug := range slugs {
+		if holder, ok := s.slugs[slug]; ok && holder != link.ID {
+			return slugExistsError(link, slug)
+		}
+	}
+	for _, slug := range slugs {
+		s.slugs[slug] = link.ID
+	}
+	return nil
+}
+
+// findLocked returns the first stored link matching the predicate. s.mu must be held.
+func (s *MemoryLinkStore) findLocked(match func(*Link) bool) *Link {
+	for _, link := range s.links {
+		if match(link) {
+			return link
+		}
+	}
+	return nil
+}
+
+// copyLink returns a copy of link that shares no mutable state with it.
+func copyLink(link *Link) *Link {
+	c := *link
+	c.Tags = append([]string(nil), link.Tags...)
+	c.Rules = copyRules(link.Rules)
+	c.Variants = append([]Variant(nil), link.Variants...)
+	if link.UTM != nil {
+		utm := *link.UTM
+		c.UTM = &utm
+	}
+	return &c
+}
+
+// copyDomain returns a copy of domain that shares no mutable state with it.
+func copyDomain(domain *Domain) *Domain {
+	c := *domain
+	if domain.VerifiedAt != nil {
+		verifiedAt := *domain.VerifiedAt
+		c.VerifiedAt = &verifiedAt
+	}
+	return &c
+}
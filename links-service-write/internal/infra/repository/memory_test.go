@@ -0,0 +1,242 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryLinkStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryLinkStore()
+
+	link, err := store.CreateLink(ctx, Link{ID: "id-1", ShortURL: "promo", CustomSlug: "promo", CustomerID: "customer-1", OriginalURL: "https://example.com"})
+	require.NoError(t, err)
+	require.Equal(t, SlugTypeCustom, link.SlugType, "Read model attributes should be derived")
+	require.NotEmpty(t, link.CreatedAt)
+
+	t.Run("Rejects a taken custom slug", func(t *testing.T) {
+		_, err := store.CreateLink(ctx, Link{ID: "id-2", ShortURL: "other", CustomSlug: "promo", CustomerID: "customer-2"})
+		require.EqualError(t, err, "custom slug 'promo' already exists")
+	})
+
+	t.Run("Counts human and bot clicks apart", func(t *testing.T) {
+		_, err := store.RecordClick(ctx, ClickEvent{LinkID: "id-1", EventID: "e1", ClickedAt: "2025-01-02T10:00:00Z", Country: "BR"})
+		require.NoError(t, err)
+		_, err = store.RecordClick(ctx, ClickEvent{LinkID: "id-1", EventID: "e2", ClickedAt: "2025-01-02T11:00:00Z", BotCategory: "crawler"})
+		require.NoError(t, err)
+
+		_, err = store.RecordClick(ctx, ClickEvent{LinkID: "id-1", EventID: "e1", ClickedAt: "2025-01-02T10:00:00Z"})
+		require.Error(t, err, "Duplicate events should be rejected")
+
+		link, err := store.GetLinkByID(ctx, "id-1")
+		require.NoError(t, err)
+		require.Equal(t, int32(1), link.Clicks)
+		require.Equal(t, int32(1), link.BotClicks)
+
+		require.Equal(t, map[string]int64{
+			"total": 1, "ref:unknown": 1, "dev:unknown": 1, "cty:BR": 1, "cmp:unknown": 1, "bots": 1, "bot:crawler": 1,
+		}, store.ClickRollup("id-1", "day#2025-01-02"))
+		require.Len(t, store.ClickEvents("id-1"), 2)
+	})
+
+	t.Run("Only deletes links of the customer", func(t *testing.T) {
+		require.EqualError(t, store.DeleteLink(ctx, "id-1", "customer-2"), "link does not belong to this customer")
+		require.NoError(t, store.DeleteLink(ctx, "id-1", "customer-1"))
+
+		_, err := store.GetLinkByShortURL(ctx, "promo")
+		require.EqualError(t, err, "link not found")
+	})
+
+	t.Run("Registers and verifies domains", func(t *testing.T) {
+		_, err := store.CreateDomain(ctx, Domain{Domain: "go.example.com", CustomerID: "customer-1", Token: "t1", CreatedAt: "2025-01-02T10:00:00Z"})
+		require.NoError(t, err)
+		_, err = store.CreateDomain(ctx, Domain{Domain: "go.example.com", CustomerID: "customer-2", Token: "t2", CreatedAt: "2025-01-04T10:00:00Z"})
+		require.EqualError(t, err, "domain 'go.example.com' already exists")
+		_, err = store.CreateDomain(ctx, Domain{Domain: "a.example.com", CustomerID: "customer-1", Token: "t3", CreatedAt: "2025-01-02T10:00:00Z"})
+		require.NoError(t, err)
+
+		domain, err := store.GetDomain(ctx, "go.example.com")
+		require.NoError(t, err)
+		require.False(t, domain.Verified())
+
+		domain, err = store.MarkDomainVerified(ctx, "go.example.com", "2025-01-02T10:00:00Z")
+		require.NoError(t, err)
+		require.True(t, domain.Verified())
+
+		domains, err := store.GetCustomerDomains(ctx, "customer-1")
+		require.NoError(t, err)
+		require.Len(t, domains, 2)
+		require.Equal(t, "a.example.com", domains[0].Domain)
+
+		_, err = store.GetDomain(ctx, "missing.example.com")
+		require.EqualError(t, err, "domain not found")
+
+		_, err = store.CreateDomain(ctx, Domain{Domain: "go.example.com", CustomerID: "customer-2", Token: "t2", CreatedAt: "2025-02-01T10:00:00Z"})
+		require.EqualError(t, err, "domain 'go.example.com' already exists", "Verified domains should never expire")
+
+		require.EqualError(t, store.DeleteDomain(ctx, "customer-2", "a.example.com"), "domain not found")
+		require.NoError(t, store.DeleteDomain(ctx, "customer-1", "a.example.com"))
+		_, err = store.GetDomain(ctx, "a.example.com")
+		require.EqualError(t, err, "domain not found")
+	})
+
+	t.Run("Lets unverified domain registrations expire", func(t *testing.T) {
+		_, err := store.CreateDomain(ctx, Domain{Domain: "squat.example.com", CustomerID: "customer-1", Token: "t1", CreatedAt: "2025-01-02T10:00:00Z"})
+		require.NoError(t, err)
+		_, err = store.CreateDomain(ctx, Domain{Domain: "squat.example.com", CustomerID: "customer-2", Token: "t2", CreatedAt: "2025-01-05T09:59:59Z"})
+		require.EqualError(t, err, "domain 'squat.example.com' already exists")
+
+		_, err = store.CreateDomain(ctx, Domain{Domain: "squat.example.com", CustomerID: "customer-2", Token: "t2", CreatedAt: "2025-01-05T10:00:01Z"})
+		require.NoError(t, err)
+		domain, err := store.GetDomain(ctx, "squat.example.com")
+		require.NoError(t, err)
+		require.Equal(t, "customer-2", domain.CustomerID)
+		require.Equal(t, "t2", domain.Token)
+	})
+
+	t.Run("Groups clicks by the UTM campaign of the link", func(t *testing.T) {
+		_, err := store.CreateLink(ctx, Link{ID: "id-3", ShortURL: "summer", CustomerID: "customer-1",
+			UTM: &UTM{Source: "newsletter", Campaign: "summer_sale"}})
+		require.NoError(t, err)
+
+		event, err := store.RecordClick(ctx, ClickEvent{LinkID: "id-3", EventID: "e1", ClickedAt: "2025-01-02T10:00:00Z"})
+		require.NoError(t, err)
+		require.Equal(t, "summer_sale", event.UTMCampaign)
+		require.Equal(t, int64(1), store.ClickRollup("id-3", "day#2025-01-02")["cmp:summer_sale"])
+	})
+
+	t.Run("Groups clicks by the variant the visitor was sent to", func(t *testing.T) {
+		_, err := store.CreateLink(ctx, Link{ID: "id-ab", ShortURL: "ab", CustomerID: "customer-1",
+			OriginalURL: "https://example.com/a", Variants: []Variant{
+				{ID: "a", URL: "https://example.com/a", Weight: 50},
+				{ID: "b", URL: "https://example.com/b", Weight: 50},
+			}})
+		require.NoError(t, err)
+
+		_, err = store.RecordClick(ctx, ClickEvent{LinkID: "id-ab", EventID: "e1", ClickedAt: "2025-01-02T10:00:00Z", Variant: "b"})
+		require.NoError(t, err)
+		_, err = store.RecordClick(ctx, ClickEvent{LinkID: "id-ab", EventID: "e2", ClickedAt: "2025-01-02T10:00:00Z"})
+		require.NoError(t, err)
+
+		rollup := store.ClickRollup("id-ab", "day#2025-01-02")
+		require.Equal(t, int64(2), rollup["total"])
+		require.Equal(t, int64(1), rollup["var:b"])
+		require.NotContains(t, rollup, "var:unknown", "Clicks sent elsewhere by a rule should not count towards a variant")
+	})
+
+	t.Run("Keeps UTM templates per customer", func(t *testing.T) {
+		_, err := store.CreateUTMTemplate(ctx, UTMTemplate{ID: "t1", CustomerID: "customer-1", Name: "Newsletter", UTM: UTM{Source: "newsletter"}})
+		require.NoError(t, err)
+		_, err = store.CreateUTMTemplate(ctx, UTMTemplate{ID: "t2", CustomerID: "customer-1", Name: "Ads", UTM: UTM{Medium: "cpc"}})
+		require.NoError(t, err)
+
+		_, err = store.GetUTMTemplate(ctx, "customer-2", "t1")
+		require.EqualError(t, err, "utm template not found")
+
+		templates, err := store.GetCustomerUTMTemplates(ctx, "customer-1")
+		require.NoError(t, err)
+		require.Len(t, templates, 2)
+		require.Equal(t, "Ads", templates[0].Name)
+
+		require.EqualError(t, store.DeleteUTMTemplate(ctx, "customer-2", "t1"), "utm template not found")
+		require.NoError(t, store.DeleteUTMTemplate(ctx, "customer-1", "t1"))
+		_, err = store.GetUTMTemplate(ctx, "customer-1", "t1")
+		require.EqualError(t, err, "utm template not found")
+	})
+
+	t.Run("Stops counting clicks at the click limit", func(t *testing.T) {
+		_, err := store.CreateLink(ctx, Link{ID: "id-once", ShortURL: "once", CustomerID: "customer-1", MaxClicks: 1})
+		require.NoError(t, err)
+
+		link, err := store.UpdateLinkClicks(ctx, "id-once")
+		require.NoError(t, err)
+		require.Equal(t, int32(1), link.Clicks)
+
+		_, err = store.UpdateLinkClicks(ctx, "id-once")
+		require.EqualError(t, err, "link click limit reached")
+		_, err = store.UpdateLinkClicks(ctx, "missing")
+		require.EqualError(t, err, "link not found")
+
+		_, err = store.RecordClick(ctx, ClickEvent{LinkID: "id-once", EventID: "e1", ClickedAt: "2025-01-02T10:00:00Z", Counted: true})
+		require.NoError(t, err)
+		link, err = store.GetLinkByID(ctx, "id-once")
+		require.NoError(t, err)
+		require.Equal(t, int32(1), link.Clicks, "Counted clicks should not be counted twice")
+		require.Equal(t, int64(1), store.ClickRollup("id-once", "day#2025-01-02")["total"])
+	})
+
+	t.Run("Records hits on inactive links apart from clicks", func(t *testing.T) {
+		_, err := store.CreateLink(ctx, Link{ID: "id-gone", ShortURL: "gone", CustomerID: "customer-1"})
+		require.NoError(t, err)
+
+		_, err = store.RecordClick(ctx, ClickEvent{LinkID: "id-gone", EventID: "e1", ClickedAt: "2025-01-02T10:00:00Z", LinkStatus: "expired"})
+		require.NoError(t, err)
+		link, err := store.GetLinkByID(ctx, "id-gone")
+		require.NoError(t, err)
+		require.Zero(t, link.Clicks)
+
+		rollup := store.ClickRollup("id-gone", "day#2025-01-02")
+		require.Zero(t, rollup["total"])
+		require.Equal(t, int64(1), rollup["inactive"])
+		require.Equal(t, int64(1), rollup["inactive:expired"])
+		require.Equal(t, "expired", store.ClickEvents("id-gone")[0].LinkStatus)
+	})
+
+	t.Run("Stores link settings per customer", func(t *testing.T) {
+		settings, err := store.GetLinkSettings(ctx, "customer-2")
+		require.NoError(t, err)
+		require.Equal(t, LinkSettings{CustomerID: "customer-2"}, *settings)
+
+		_, err = store.PutLinkSettings(ctx, LinkSettings{CustomerID: "customer-2", FallbackURL: "https://example.com", FallbackMode: FallbackPage})
+		require.NoError(t, err)
+		settings, err = store.GetLinkSettings(ctx, "customer-2")
+		require.NoError(t, err)
+		require.Equal(t, "https://example.com", settings.FallbackURL)
+		require.Equal(t, FallbackPage, settings.FallbackMode)
+	})
+
+	t.Run("Keeps redirect rules apart from the caller's", func(t *testing.T) {
+		rules := []RedirectRule{{Destination: "https://example.com/app", Devices: []string{DeviceIOS}}}
+		_, err := store.CreateLink(ctx, Link{ID: "id-rules", ShortURL: "rules", CustomerID: "customer-1", Rules: rules})
+		require.NoError(t, err)
+		rules[0].Devices[0] = DeviceAndroid
+
+		link, err := store.GetLinkByID(ctx, "id-rules")
+		require.NoError(t, err)
+		require.Equal(t, []string{DeviceIOS}, link.Rules[0].Devices)
+	})
+
+	t.Run("Creates links in bulk without stopping at failures", func(t *testing.T) {
+		created, errs := store.CreateLinks(ctx, []Link{
+			{ID: "id-10", ShortURL: "bulk-1", CustomerID: "customer-1"},
+			{ID: "id-11", ShortURL: "bulk-1", CustomerID: "customer-1"},
+			{ID: "id-12", ShortURL: "bulk-2", CustomerID: "customer-1"},
+		})
+		require.NoError(t, errs[0])
+		require.EqualError(t, errs[1], "slug 'bulk-1' already exists")
+		require.Nil(t, created[1])
+		require.NoError(t, errs[2])
+		require.Equal(t, "id-12", created[2].ID)
+	})
+
+	t.Run("Reserves counter values", func(t *testing.T) {
+		end, err := store.ReserveCounter(ctx, "hashids", 100)
+		require.NoError(t, err)
+		require.Equal(t, uint64(100), end)
+		end, err = store.ReserveCounter(ctx, "hashids", 100)
+		require.NoError(t, err)
+		require.Equal(t, uint64(200), end)
+		end, err = store.ReserveCounter(ctx, "other", 1)
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), end)
+	})
+}
+
+func TestUTMMerge(t *testing.T) {
+	utm := UTM{Source: "newsletter", Campaign: "summer_sale"}.Merge(UTM{Source: "ads", Medium: "email"})
+	require.Equal(t, UTM{Source: "newsletter", Medium: "email", Campaign: "summer_sale"}, utm)
+	require.True(t, UTM{}.IsZero())
+	require.False(t, utm.IsZero())
+}
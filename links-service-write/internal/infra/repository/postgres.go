@@ -0,0 +1,971 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"links-service-write/internal/analytics"
+	"links-service-write/internal/logger"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+)
+
+// uniqueViolation is the PostgreSQL error code of a unique constraint violation.
+const uniqueViolation = "23505"
+
+// linkColumns lists the columns of the "links" table read by scanLink, in scan order.
+const linkColumns = `id, short_url, original_url, COALESCE(custom_slug, ''), customer_id::text,
+	clicks, bot_clicks, created_at, updated_at, expires_at, slug_type, disabled, activates_at,
+	title, tags, expiration_sort, original_url_sort, search_text, domain,
+	utm_source, utm_medium, utm_campaign, utm_term, utm_content, password_hash, max_clicks,
+	fallback_url, redirect_rules, variants, sticky_variants`
+
+// PostgresLinkStore is a LinkStore backed by PostgreSQL, for deployments that do without
+// DynamoDB. Links live in the "links" table created by auth-service's migrations (see
+// 002_create_links, 004_links_service_store, 005_link_slugs, 006_custom_domains,
+// 007_utm_parameters, 008_link_passwords, 009_link_click_limits, 010_link_fallback_url,
+// 011_link_fallbacks, 012_link_redirect_rules, 013_link_variants and 014_counters) and
+// their slugs in "link_slugs", branded domains in "customer_domains", UTM templates in
+// "utm_templates", link settings in "customer_link_settings", clicks in "click_events",
+// rollups in "click_rollups", one row per link, bucket and counter, and the counter of
+// hashids short codes in "counters".
+type PostgresLinkStore struct {
+	db *pgxpool.Pool
+}
+
+// NewPostgresLinkStore creates a new instance of PostgresLinkStore with the provided
+// connection pool.
+//
+// Parameters:
+//   - db: A pointer to a pgxpool.Pool connected to the database holding the "links" table.
+//
+// Returns:
+//   - A pointer to a PostgresLinkStore instance.
+func NewPostgresLinkStore(db *pgxpool.Pool) *PostgresLinkStore {
+	return &PostgresLinkStore{db: db}
+}
+
+// CreateLink inserts a new link into the "links" table and reserves its slugs in the
+// "link_slugs" table, in a single transaction, so that no other link can hold its short
+// URL or custom slug.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - link: The Link object containing the details of the link to be created.
+//
+// Returns:
+//   - A pointer to the created Link object.
+//   - An error if the expiration date is invalid, a slug is already taken (the message then
+//     contains "already exists"), or the insert fails.
+func (r *PostgresLinkStore) CreateLink(ctx context.Context, link Link) (*Link, error) {
+	if err := prepareNewLink(&link); err != nil {
+		return nil, err
+	}
+
+	params, err := linkParams(&link)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		logger.Log.Error("failed to begin transaction", zap.Error(err))
+		return nil, fmt.Errorf("failed to create link: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO links (id, short_url, original_url, custom_slug, customer_id, clicks, bot_clicks,
+			created_at, updated_at, expires_at, slug_type, disabled, activates_at,
+			title, tags, expiration_sort, original_url_sort, search_text, domain,
+			utm_source, utm_medium, utm_campaign, utm_term, utm_content, password_hash, max_clicks, fallback_url,
+			redirect_rules, variants, sticky_variants)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19,
+			$20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30)`,
+		link.ID, link.ShortURL, link.OriginalURL, link.CustomSlug, link.CustomerID, link.Clicks, link.BotClicks,
+		params.createdAt, params.updatedAt, params.expiresAt, link.SlugType, link.Disabled, params.activatesAt,
+		link.Title, params.tags, link.ExpirationSort, link.OriginalURLSort, link.SearchText, link.Domain,
+		params.utm.Source, params.utm.Medium, params.utm.Campaign, params.utm.Term, params.utm.Content,
+		link.PasswordHash, link.MaxClicks, link.FallbackURL, params.rules, params.variants, link.StickyVariants,
+	)
+	if err != nil {
+		if slug := violatedSlug(err, &link); slug != "" {
+			logger.Log.Error("slug already exists", zap.String("slug", slug))
+			return nil, slugExistsError(&link, slug)
+		}
+		logger.Log.Error("failed to create link", zap.Error(err))
+		return nil, fmt.Errorf("failed to create link: %v", err)
+	}
+
+	if err := reserveSlugs(ctx, tx, &link); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		logger.Log.Error("failed to create link", zap.Error(err))
+		return nil, fmt.Errorf("failed to create link: %v", err)
+	}
+
+	logger.Log.Info("link created successfully", zap.String("short_url", link.ShortURL))
+	return &link, nil
+}
+
+// CreateLinks inserts new links one by one, each in its own transaction like CreateLink,
+// so that a link whose slug is taken fails alone. See LinkStore.CreateLinks.
+func (r *PostgresLinkStore) CreateLinks(ctx context.Context, links []Link) ([]*Link, []error) {
+	return createLinksOneByOne(ctx, r, links)
+}
+
+// GetLinkByShortURL retrieves a link from the "links" table by its short URL.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - shortURL: The short URL of the link to retrieve.
+//
+// Returns:
+//   - A pointer to the Link struct if the link is found.
+//   - An error if the link is not found or the query fails.
+func (r *PostgresLinkStore) GetLinkByShortURL(ctx context.Context, shortURL string) (*Link, error) {
+	return r.getLink(ctx, "short_url", shortURL)
+}
+
+// GetLinkByID retrieves a link from the "links" table by its ID.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - id: The unique identifier of the link to retrieve.
+//
+// Returns:
+//   - A pointer to the Link struct if the link is found.
+//   - An error if the link is not found or the query fails.
+func (r *PostgresLinkStore) GetLinkByID(ctx context.Context, id string) (*Link, error) {
+	return r.getLink(ctx, "id", id)
+}
+
+// GetLinkByCustomSlug retrieves a link from the "links" table by its custom slug.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - customSlug: The custom slug used to identify the link.
+//
+// Returns:
+//   - A pointer to the Link struct if a matching link is found.
+//   - An error if the link is not found or the query fails.
+func (r *PostgresLinkStore) GetLinkByCustomSlug(ctx context.Context, customSlug string) (*Link, error) {
+	return r.getLink(ctx, "custom_slug", customSlug)
+}
+
+// getLink retrieves the link whose column equals value. column is one of the unique
+// columns of the "links" table and never comes from user input.
+func (r *PostgresLinkStore) getLink(ctx context.Context, column, value string) (*Link, error) {
+	link, err := scanLink(r.db.QueryRow(ctx, "SELECT "+linkColumns+" FROM links WHERE "+column+" = $1", value))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logger.Log.Warn("link not found", zap.String(column, value))
+			return nil, fmt.Errorf("link not found")
+		}
+		logger.Log.Error("failed to get link", zap.Error(err))
+		return nil, fmt.Errorf("failed to get link: %v", err)
+	}
+
+	logger.Log.Info("link retrieved successfully", zap.String(column, value))
+	return link, nil
+}
+
+// DeleteLink deletes a link from the "links" table after checking that it belongs to
+// the customer; its slug reservations are deleted along with it by the foreign key of
+// "link_slugs". Its click events and rollups are kept, as with DynamoDB.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - id: The unique identifier of the link to be deleted.
+//   - customerID: The ID of the customer associated with the link.
+//
+// Returns:
+//   - error: An error if the link does not exist or belongs to another customer, if the
+//     operation fails, or nil if the deletion is successful.
+func (r *PostgresLinkStore) DeleteLink(ctx context.Context, id, customerID string) error {
+	link, err := r.GetLinkByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if link.CustomerID != customerID {
+		logger.Log.Error("link does not belong to this customer", zap.String("customer_id", customerID))
+		return fmt.Errorf("link does not belong to this customer")
+	}
+
+	if _, err := r.db.Exec(ctx, "DELETE FROM links WHERE id = $1", id); err != nil {
+		logger.Log.Error("failed to delete link", zap.Error(err))
+		return fmt.Errorf("failed to delete link: %v", err)
+	}
+
+	logger.Log.Info("link deleted successfully", zap.String("short_url", link.ShortURL))
+	return nil
+}
+
+// UpdateLink replaces the editable fields of a link, keeping its short URL, creation
+// date and click counters. A new custom slug is reserved and the previous one released
+// in the same transaction as the update. The counters returned are read by the update
+// itself, so that they include the clicks counted since the link was read.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - link: The Link object containing the updated data.
+//
+// Returns:
+//   - A pointer to the updated Link object.
+//   - An error if the link does not exist, the update is invalid, the custom slug is held
+//     by another link (the message then contains "already exists"), or the query fails.
+func (r *PostgresLinkStore) UpdateLink(ctx context.Context, link Link) (*Link, error) {
+	existingLink, err := r.GetLinkByID(ctx, link.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := prepareUpdatedLink(&link, existingLink); err != nil {
+		logger.Log.Error("invalid updated link", zap.Error(err))
+		return nil, err
+	}
+
+	params, err := linkParams(&link)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		logger.Log.Error("failed to begin transaction", zap.Error(err))
+		return nil, fmt.Errorf("failed to update link: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if released := releasedSlugs(existingLink, &link); len(released) > 0 {
+		if _, err := tx.Exec(ctx, "DELETE FROM link_slugs WHERE link_id = $1 AND slug = ANY($2)", link.ID, released); err != nil {
+			logger.Log.Error("failed to release slugs", zap.Error(err))
+			return nil, fmt.Errorf("failed to update link: %v", err)
+		}
+	}
+	if err := reserveSlugs(ctx, tx, &link); err != nil {
+		return nil, err
+	}
+
+	err = tx.QueryRow(ctx, `
+		UPDATE links SET original_url = $2, custom_slug = NULLIF($3, ''), customer_id = $4, updated_at = $5,
+			expires_at = $6, slug_type = $7, disabled = $8, activates_at = $9, title = $10, tags = $11,
+			expiration_sort = $12, original_url_sort = $13, search_text = $14,
+			utm_source = $15, utm_medium = $16, utm_campaign = $17, utm_term = $18, utm_content = $19,
+			password_hash = $20, max_clicks = $21, fallback_url = $22, redirect_rules = $23,
+			variants = $24, sticky_variants = $25
+		WHERE id = $1
+		RETURNING clicks, bot_clicks`,
+		link.ID, link.OriginalURL, link.CustomSlug, link.CustomerID, params.updatedAt,
+		params.expiresAt, link.SlugType, link.Disabled, params.activatesAt, link.Title, params.tags,
+		link.ExpirationSort, link.OriginalURLSort, link.SearchText,
+		params.utm.Source, params.utm.Medium, params.utm.Campaign, params.utm.Term, params.utm.Content,
+		link.PasswordHash, link.MaxClicks, link.FallbackURL, params.rules, params.variants, link.StickyVariants,
+	).Scan(&link.Clicks, &link.BotClicks)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logger.Log.Warn("link not found", zap.String("link_id", link.ID))
+			return nil, fmt.Errorf("link not found")
+		}
+		if slug := violatedSlug(err, &link); slug != "" {
+			logger.Log.Error("slug already exists", zap.String("slug", slug))
+			return nil, slugExistsError(&link, slug)
+		}
+		logger.Log.Error("failed to update link", zap.Error(err))
+		return nil, fmt.Errorf("failed to update link: %v", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		logger.Log.Error("failed to update link", zap.Error(err))
+		return nil, fmt.Errorf("failed to update link: %v", err)
+	}
+
+	logger.Log.Info("link updated successfully", zap.String("short_url", link.ShortURL))
+	return &link, nil
+}
+
+// UpdateLinkClicks increments the click count of a link, unless it has reached its
+// click limit (see Link.MaxClicks). The limit is checked by the UPDATE itself, so that
+// concurrent clicks on a link with one click left cannot both be counted.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - id: The unique identifier of the link to be updated.
+//
+// Returns:
+//   - A pointer to the updated Link object if the operation is successful.
+//   - An error if the link does not exist, has reached its click limit (the message then
+//     contains "click limit reached") or the update fails.
+func (r *PostgresLinkStore) UpdateLinkClicks(ctx context.Context, id string) (*Link, error) {
+	link, err := scanLink(r.db.QueryRow(ctx, `
+		UPDATE links SET clicks = clicks + 1, updated_at = now()
+		WHERE id = $1 AND (max_clicks = 0 OR clicks < max_clicks)
+		RETURNING `+linkColumns, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			var exists bool
+			if err := r.db.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM links WHERE id = $1)", id).Scan(&exists); err != nil {
+				logger.Log.Error("failed to update link clicks", zap.Error(err))
+				return nil, fmt.Errorf("failed to update link clicks: %v", err)
+			}
+			if exists {
+				logger.Log.Info("link click limit reached", zap.String("id", id))
+				return nil, fmt.Errorf("link click limit reached")
+			}
+			logger.Log.Error("link not found", zap.String("id", id))
+			return nil, fmt.Errorf("link not found")
+		}
+		logger.Log.Error("failed to update link clicks", zap.Error(err))
+		return nil, fmt.Errorf("failed to update link clicks: %v", err)
+	}
+
+	logger.Log.Info("link clicks updated successfully", zap.String("short_url", link.ShortURL))
+	return link, nil
+}
+
+// RecordClick stores a click event in the "click_events" table, increments the click
+// counter of the link and adds the click to its hourly, daily and weekly rollups, in a
+// single transaction. Automated clicks are stored with their bot_category and counted
+// in bot_clicks and the bot rollup counters instead (see rollupCounters). Counted clicks
+// (see ClickEvent.Counted) and hits on inactive links (see ClickEvent.LinkStatus) leave
+// the counters of the link alone.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - event: The click event to store. LinkID and EventID must be set; ClickedAt defaults to now.
+//
+// Returns:
+//   - A pointer to the stored ClickEvent.
+//   - An error if the link cannot be found or the transaction fails.
+func (r *PostgresLinkStore) RecordClick(ctx context.Context, event ClickEvent) (*ClickEvent, error) {
+	if event.ClickedAt == "" {
+		event.ClickedAt = time.Now().UTC().Format(time.RFC3339)
+	}
+	clickedAt, err := time.Parse(time.RFC3339, event.ClickedAt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid clicked_at format: %v", err)
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		logger.Log.Error("failed to begin transaction", zap.Error(err))
+		return nil, fmt.Errorf("failed to record click: %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	counter := "clicks"
+	if event.BotCategory != "" {
+		counter = "bot_clicks"
+	}
+	query := "UPDATE links SET " + counter + " = " + counter + " + 1, updated_at = now() WHERE id = $1 RETURNING customer_id::text, utm_campaign"
+	if !event.countsOnLink() {
+		query = "SELECT customer_id::text, utm_campaign FROM links WHERE id = $1"
+	}
+	err = tx.QueryRow(ctx, query, event.LinkID).Scan(&event.CustomerID, &event.UTMCampaign)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logger.Log.Error("link not found", zap.String("link_id", event.LinkID))
+			return nil, fmt.Errorf("link not found")
+		}
+		logger.Log.Error("failed to record click", zap.Error(err))
+		return nil, fmt.Errorf("failed to record click: %v", err)
+	}
+
+	batch := &pgx.Batch{}
+	batch.Queue(`
+		INSERT INTO click_events (link_id, event_id, customer_id, clicked_at, referrer, referrer_domain,
+			user_agent, device_class, ip_hash, accept_language, country, region, city, asn, bot_category, utm_campaign,
+			link_status, variant)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)`,
+		event.LinkID, event.EventID, event.CustomerID, clickedAt, event.Referrer, event.ReferrerDomain,
+		event.UserAgent, event.DeviceClass, event.IPHash, event.AcceptLanguage, event.Country, event.Region,
+		event.City, int64(event.ASN), event.BotCategory, event.UTMCampaign, event.LinkStatus, event.Variant,
+	)
+	for _, granularity := range analytics.Granularities {
+		bucket := analytics.BucketKey(granularity, clickedAt)
+		for _, name := range rollupCounters(event) {
+			batch.Queue(`
+				INSERT INTO click_rollups (link_id, bucket, counter, count) VALUES ($1, $2, $3, 1)
+				ON CONFLICT (link_id, bucket, counter) DO UPDATE SET count = click_rollups.count + 1`,
+				event.LinkID, bucket, name,
+			)
+		}
+	}
+	if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+		logger.Log.Error("failed to record click", zap.Error(err))
+		return nil, fmt.Errorf("failed to record click: %v", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		logger.Log.Error("failed to record click", zap.Error(err))
+		return nil, fmt.Errorf("failed to record click: %v", err)
+	}
+
+	logger.Log.Info("click recorded successfully",
+		zap.String("link_id", event.LinkID),
+		zap.String("event_id", event.EventID),
+		zap.String("bot_category", event.BotCategory),
+	)
+	return &event, nil
+}
+
+// domainColumns lists the columns of the "customer_domains" table read by scanDomain,
+// in scan order.
+const domainColumns = `domain, customer_id::text, token, created_at, verified_at`
+
+// CreateDomain inserts a branded domain into the "customer_domains" table, replacing an
+// unverified registration created more than UnverifiedDomainTTL before it.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - domain: The domain to register, unverified.
+//
+// Returns:
+//   - A pointer to the registered Domain.
+//   - An error containing "already exists" if the domain is verified or its registration
+//     has not expired, or an error if the insert fails.
+func (r *PostgresLinkStore) CreateDomain(ctx context.Context, domain Domain) (*Domain, error) {
+	createdAt, err := time.Parse(time.RFC3339, domain.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid created_at format: %v", err)
+	}
+	cutoff, err := unverifiedDomainCutoff(domain.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	tag, err := r.db.Exec(ctx, `
+		INSERT INTO customer_domains (domain, customer_id, token, created_at) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (domain) DO UPDATE
+			SET customer_id = EXCLUDED.customer_id, token = EXCLUDED.token, created_at = EXCLUDED.created_at
+			WHERE customer_domains.verified_at IS NULL AND customer_domains.created_at < $5`,
+		domain.Domain, domain.CustomerID, domain.Token, createdAt, cutoff,
+	)
+	if err != nil {
+		logger.Log.Error("failed to create domain", zap.Error(err))
+		return nil, fmt.Errorf("failed to create domain: %v", err)
+	}
+	if tag.RowsAffected() == 0 {
+		logger.Log.Error("domain already exists", zap.String("domain", domain.Domain))
+		return nil, fmt.Errorf("domain '%s' already exists", domain.Domain)
+	}
+
+	logger.Log.Info("domain created successfully", zap.String("domain", domain.Domain))
+	return &domain, nil
+}
+
+// GetDomain retrieves a branded domain from the "customer_domains" table.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - name: The host name of the domain.
+//
+// Returns:
+//   - A pointer to the Domain if it is registered.
+//   - An error if the domain is not found or the query fails.
+func (r *PostgresLinkStore) GetDomain(ctx context.Context, name string) (*Domain, error) {
+	domain, err := scanDomain(r.db.QueryRow(ctx,
+		"SELECT "+domainColumns+" FROM customer_domains WHERE domain = $1", name))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logger.Log.Warn("domain not found", zap.String("domain", name))
+			return nil, fmt.Errorf("domain not found")
+		}
+		logger.Log.Error("failed to get domain", zap.Error(err))
+		return nil, fmt.Errorf("failed to get domain: %v", err)
+	}
+	return domain, nil
+}
+
+// GetCustomerDomains retrieves the branded domains of a customer from the
+// "customer_domains" table, ordered by name.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - customerID: The ID of the customer.
+//
+// Returns:
+//   - The customer's domains, possibly none.
+//   - An error if the query fails.
+func (r *PostgresLinkStore) GetCustomerDomains(ctx context.Context, customerID string) ([]*Domain, error) {
+	rows, err := r.db.Query(ctx,
+		"SELECT "+domainColumns+" FROM customer_domains WHERE customer_id = $1 ORDER BY domain", customerID)
+	if err != nil {
+		logger.Log.Error("failed to query customer domains", zap.Error(err))
+		return nil, fmt.Errorf("failed to query customer domains: %v", err)
+	}
+	defer rows.Close()
+
+	var domains []*Domain
+	for rows.Next() {
+		domain, err := scanDomain(rows)
+		if err != nil {
+			logger.Log.Error("failed to scan domain", zap.Error(err))
+			return nil, fmt.Errorf("failed to query customer domains: %v", err)
+		}
+		domains = append(domains, domain)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Log.Error("failed to query customer domains", zap.Error(err))
+		return nil, fmt.Errorf("failed to query customer domains: %v", err)
+	}
+	return domains, nil
+}
+
+// MarkDomainVerified sets the verification time of a branded domain in the
+// "customer_domains" table.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - name: The host name of the domain.
+//   - verifiedAt: The RFC3339 time the ownership was verified at.
+//
+// Returns:
+//   - A pointer to the updated Domain.
+//   - An error if the domain is not found or the update fails.
+func (r *PostgresLinkStore) MarkDomainVerified(ctx context.Context, name, verifiedAt string) (*Domain, error) {
+	verifiedTime, err := time.Parse(time.RFC3339, verifiedAt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid verified_at format: %v", err)
+	}
+
+	domain, err := scanDomain(r.db.QueryRow(ctx,
+		"UPDATE customer_domains SET verified_at = $2 WHERE domain = $1 RETURNING "+domainColumns,
+		name, verifiedTime,
+	))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logger.Log.Warn("domain not found", zap.String("domain", name))
+			return nil, fmt.Errorf("domain not found")
+		}
+		logger.Log.Error("failed to verify domain", zap.Error(err))
+		return nil, fmt.Errorf("failed to verify domain: %v", err)
+	}
+
+	logger.Log.Info("domain verified successfully", zap.String("domain", name))
+	return domain, nil
+}
+
+// DeleteDomain deletes one of a customer's domains from the "customer_domains" table.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - customerID: The ID of the customer owning the domain.
+//   - name: The host name of the domain.
+//
+// Returns:
+//   - An error if the customer has no domain with this name or the delete fails.
+func (r *PostgresLinkStore) DeleteDomain(ctx context.Context, customerID, name string) error {
+	tag, err := r.db.Exec(ctx, "DELETE FROM customer_domains WHERE domain = $1 AND customer_id = $2", name, customerID)
+	if err != nil {
+		logger.Log.Error("failed to delete domain", zap.Error(err))
+		return fmt.Errorf("failed to delete domain: %v", err)
+	}
+	if tag.RowsAffected() == 0 {
+		logger.Log.Warn("domain not found", zap.String("domain", name))
+		return fmt.Errorf("domain not found")
+	}
+
+	logger.Log.Info("domain deleted successfully", zap.String("domain", name))
+	return nil
+}
+
+// scanDomain reads a row of domainColumns into a Domain, formatting dates as RFC3339 in
+// UTC.
+func scanDomain(row pgx.Row) (*Domain, error) {
+	var domain Domain
+	var createdAt time.Time
+	var verifiedAt *time.Time
+
+	if err := row.Scan(&domain.Domain, &domain.CustomerID, &domain.Token, &createdAt, &verifiedAt); err != nil {
+		return nil, err
+	}
+
+	domain.CreatedAt = createdAt.UTC().Format(time.RFC3339)
+	domain.VerifiedAt = formatOptionalTime(verifiedAt)
+	return &domain, nil
+}
+
+// utmTemplateColumns lists the columns of the "utm_templates" table read by
+// scanUTMTemplate, in scan order.
+const utmTemplateColumns = `id, customer_id::text, name, utm_source, utm_medium, utm_campaign, utm_term,
+	utm_content, created_at`
+
+// CreateUTMTemplate inserts a UTM template into the "utm_templates" table.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - template: The template to store, with its ID set.
+//
+// Returns:
+//   - A pointer to the stored UTMTemplate.
+//   - An error if the insert fails.
+func (r *PostgresLinkStore) CreateUTMTemplate(ctx context.Context, template UTMTemplate) (*UTMTemplate, error) {
+	createdAt, err := time.Parse(time.RFC3339, template.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid created_at format: %v", err)
+	}
+
+	_, err = r.db.Exec(ctx, `
+		INSERT INTO utm_templates (id, customer_id, name, utm_source, utm_medium, utm_campaign, utm_term,
+			utm_content, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		template.ID, template.CustomerID, template.Name, template.UTM.Source, template.UTM.Medium,
+		template.UTM.Campaign, template.UTM.Term, template.UTM.Content, createdAt,
+	)
+	if err != nil {
+		logger.Log.Error("failed to create utm template", zap.Error(err))
+		return nil, fmt.Errorf("failed to create utm template: %v", err)
+	}
+
+	logger.Log.Info("utm template created successfully", zap.String("id", template.ID))
+	return &template, nil
+}
+
+// GetUTMTemplate retrieves one of a customer's UTM templates from the "utm_templates"
+// table.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - customerID: The ID of the customer owning the template.
+//   - id: The ID of the template.
+//
+// Returns:
+//   - A pointer to the UTMTemplate.
+//   - An error if the template is not found or the query fails.
+func (r *PostgresLinkStore) GetUTMTemplate(ctx context.Context, customerID, id string) (*UTMTemplate, error) {
+	template, err := scanUTMTemplate(r.db.QueryRow(ctx,
+		"SELECT "+utmTemplateColumns+" FROM utm_templates WHERE customer_id = $1 AND id = $2", customerID, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logger.Log.Warn("utm template not found", zap.String("id", id))
+			return nil, fmt.Errorf("utm template not found")
+		}
+		logger.Log.Error("failed to get utm template", zap.Error(err))
+		return nil, fmt.Errorf("failed to get utm template: %v", err)
+	}
+	return template, nil
+}
+
+// GetCustomerUTMTemplates retrieves the UTM templates of a customer from the
+// "utm_templates" table, ordered by name.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - customerID: The ID of the customer.
+//
+// Returns:
+//   - The customer's templates, possibly none.
+//   - An error if the query fails.
+func (r *PostgresLinkStore) GetCustomerUTMTemplates(ctx context.Context, customerID string) ([]*UTMTemplate, error) {
+	rows, err := r.db.Query(ctx,
+		"SELECT "+utmTemplateColumns+" FROM utm_templates WHERE customer_id = $1 ORDER BY name, created_at", customerID)
+	if err != nil {
+		logger.Log.Error("failed to query customer utm templates", zap.Error(err))
+		return nil, fmt.Errorf("failed to query customer utm templates: %v", err)
+	}
+	defer rows.Close()
+
+	var templates []*UTMTemplate
+	for rows.Next() {
+		template, err := scanUTMTemplate(rows)
+		if err != nil {
+			logger.Log.Error("failed to scan utm template", zap.Error(err))
+			return nil, fmt.Errorf("failed to query customer utm templates: %v", err)
+		}
+		templates = append(templates, template)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Log.Error("failed to query customer utm templates", zap.Error(err))
+		return nil, fmt.Errorf("failed to query customer utm templates: %v", err)
+	}
+	return templates, nil
+}
+
+// DeleteUTMTemplate deletes one of a customer's UTM templates from the "utm_templates"
+// table. Links created with it keep their UTM parameters.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - customerID: The ID of the customer owning the template.
+//   - id: The ID of the template.
+//
+// Returns:
+//   - An error if the template is not found or the delete fails.
+func (r *PostgresLinkStore) DeleteUTMTemplate(ctx context.Context, customerID, id string) error {
+	tag, err := r.db.Exec(ctx, "DELETE FROM utm_templates WHERE customer_id = $1 AND id = $2", customerID, id)
+	if err != nil {
+		logger.Log.Error("failed to delete utm template", zap.Error(err))
+		return fmt.Errorf("failed to delete utm template: %v", err)
+	}
+	if tag.RowsAffected() == 0 {
+		logger.Log.Warn("utm template not found", zap.String("id", id))
+		return fmt.Errorf("utm template not found")
+	}
+
+	logger.Log.Info("utm template deleted successfully", zap.String("id", id))
+	return nil
+}
+
+// scanUTMTemplate reads a row of utmTemplateColumns into a UTMTemplate, formatting its
+// creation date as RFC3339 in UTC.
+func scanUTMTemplate(row pgx.Row) (*UTMTemplate, error) {
+	var template UTMTemplate
+	var createdAt time.Time
+
+	err := row.Scan(
+		&template.ID, &template.CustomerID, &template.Name, &template.UTM.Source, &template.UTM.Medium,
+		&template.UTM.Campaign, &template.UTM.Term, &template.UTM.Content, &createdAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	template.CreatedAt = createdAt.UTC().Format(time.RFC3339)
+	return &template, nil
+}
+
+// PutLinkSettings inserts or replaces the link settings of a customer in the
+// "customer_link_settings" table.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - settings: The settings to store, with their customer ID set.
+//
+// Returns:
+//   - A pointer to the stored LinkSettings.
+//   - An error if the write fails.
+func (r *PostgresLinkStore) PutLinkSettings(ctx context.Context, settings LinkSettings) (*LinkSettings, error) {
+	updatedAt, err := time.Parse(time.RFC3339, settings.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid updated_at format: %v", err)
+	}
+
+	_, err = r.db.Exec(ctx, `
+		INSERT INTO customer_link_settings (customer_id, fallback_url, fallback_mode, updated_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (customer_id) DO UPDATE
+		SET fallback_url = EXCLUDED.fallback_url, fallback_mode = EXCLUDED.fallback_mode,
+			updated_at = EXCLUDED.updated_at`,
+		settings.CustomerID, settings.FallbackURL, settings.FallbackMode, updatedAt,
+	)
+	if err != nil {
+		logger.Log.Error("failed to put link settings", zap.Error(err))
+		return nil, fmt.Errorf("failed to put link settings: %v", err)
+	}
+
+	logger.Log.Info("link settings saved successfully", zap.String("customer_id", settings.CustomerID))
+	return &settings, nil
+}
+
+// GetLinkSettings retrieves the link settings of a customer from the
+// "customer_link_settings" table.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - customerID: The ID of the customer.
+//
+// Returns:
+//   - A pointer to the customer's LinkSettings, empty if they never saved any.
+//   - An error if the query fails.
+func (r *PostgresLinkStore) GetLinkSettings(ctx context.Context, customerID string) (*LinkSettings, error) {
+	settings := LinkSettings{CustomerID: customerID}
+	var updatedAt time.Time
+
+	err := r.db.QueryRow(ctx, `
+		SELECT fallback_url, fallback_mode, updated_at FROM customer_link_settings WHERE customer_id = $1`,
+		customerID,
+	).Scan(&settings.FallbackURL, &settings.FallbackMode, &updatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &settings, nil
+		}
+		logger.Log.Error("failed to get link settings", zap.Error(err))
+		return nil, fmt.Errorf("failed to get link settings: %v", err)
+	}
+
+	settings.UpdatedAt = updatedAt.UTC().Format(time.RFC3339)
+	return &settings, nil
+}
+
+// ReserveCounter atomically adds n to a named counter of the "counters" table, creating
+// it at 0 if it does not exist.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - name: The name of the counter.
+//   - n: How many values to reserve.
+//
+// Returns:
+//   - The new value of the counter: the caller owns the n values up to it, that value
+//     included.
+//   - An error if the query fails.
+func (r *PostgresLinkStore) ReserveCounter(ctx context.Context, name string, n uint64) (uint64, error) {
+	var end int64
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO counters (name, value) VALUES ($1, $2)
+		ON CONFLICT (name) DO UPDATE SET value = counters.value + EXCLUDED.value
+		RETURNING value`,
+		name, int64(n),
+	).Scan(&end)
+	if err != nil {
+		logger.Log.Error("failed to reserve counter values", zap.String("counter", name), zap.Error(err))
+		return 0, fmt.Errorf("failed to reserve counter values: %v", err)
+	}
+	return uint64(end), nil
+}
+
+// postgresLinkParams holds the values of a link converted to their column types.
+type postgresLinkParams struct {
+	createdAt   time.Time
+	updatedAt   time.Time
+	expiresAt   *time.Time
+	activatesAt *time.Time
+	tags        []string
+	utm         UTM
+	rules       []RedirectRule
+	variants    []Variant
+}
+
+// linkParams converts the RFC3339 dates, the tags, the UTM parameters, the redirect rules
+// and the variants of a link to the types of their columns. Tags, rules and variants are
+// never NULL, and missing UTM parameters are empty strings.
+func linkParams(link *Link) (postgresLinkParams, error) {
+	var params postgresLinkParams
+	var err error
+
+	if params.createdAt, err = time.Parse(time.RFC3339, link.CreatedAt); err != nil {
+		return params, fmt.Errorf("invalid created_at format: %v", err)
+	}
+	if params.updatedAt, err = time.Parse(time.RFC3339, link.UpdatedAt); err != nil {
+		return params, fmt.Errorf("invalid updated_at format: %v", err)
+	}
+	if params.expiresAt, err = parseOptionalTime(link.ExpirationDate); err != nil {
+		return params, fmt.Errorf("invalid expiration date format: %v", err)
+	}
+	if params.activatesAt, err = parseOptionalTime(link.ActivatesAt); err != nil {
+		return params, fmt.Errorf("invalid activation date format: %v", err)
+	}
+
+	params.tags = link.Tags
+	if params.tags == nil {
+		params.tags = []string{}
+	}
+	if link.UTM != nil {
+		params.utm = *link.UTM
+	}
+	params.rules = link.Rules
+	if params.rules == nil {
+		params.rules = []RedirectRule{}
+	}
+	params.variants = link.Variants
+	if params.variants == nil {
+		params.variants = []Variant{}
+	}
+	return params, nil
+}
+
+// scanLink reads a row of linkColumns into a Link, formatting dates as RFC3339 in UTC
+// like the DynamoDB backend stores them.
+func scanLink(row pgx.Row) (*Link, error) {
+	var link Link
+	var createdAt, updatedAt time.Time
+	var expiresAt, activatesAt *time.Time
+	var utm UTM
+
+	err := row.Scan(
+		&link.ID, &link.ShortURL, &link.OriginalURL, &link.CustomSlug, &link.CustomerID,
+		&link.Clicks, &link.BotClicks, &createdAt, &updatedAt, &expiresAt, &link.SlugType, &link.Disabled, &activatesAt,
+		&link.Title, &link.Tags, &link.ExpirationSort, &link.OriginalURLSort, &link.SearchText, &link.Domain,
+		&utm.Source, &utm.Medium, &utm.Campaign, &utm.Term, &utm.Content, &link.PasswordHash,
+		&link.MaxClicks, &link.FallbackURL, &link.Rules, &link.Variants, &link.StickyVariants,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	link.CreatedAt = createdAt.UTC().Format(time.RFC3339)
+	link.UpdatedAt = updatedAt.UTC().Format(time.RFC3339)
+	link.ExpirationDate = formatOptionalTime(expiresAt)
+	link.ActivatesAt = formatOptionalTime(activatesAt)
+	if len(link.Tags) == 0 {
+		link.Tags = nil
+	}
+	if len(link.Rules) == 0 {
+		link.Rules = nil
+	}
+	if len(link.Variants) == 0 {
+		link.Variants = nil
+	}
+	if !utm.IsZero() {
+		link.UTM = &utm
+	}
+	return &link, nil
+}
+
+func parseOptionalTime(value *string) (*time.Time, error) {
+	if value == nil || *value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, *value)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func formatOptionalTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.UTC().Format(time.RFC3339)
+	return &formatted
+}
+
+// reserveSlugs reserves the slugs of a link (see linkSlugs) in the "link_slugs" table.
+// Reservations the link already holds are kept; a slug held by another link fails with
+// an error containing "already exists".
+func reserveSlugs(ctx context.Context, tx pgx.Tx, link *Link) error {
+	for _, slug := range linkSlugs(link) {
+		var holder string
+		err := tx.QueryRow(ctx, `
+			INSERT INTO link_slugs (slug, link_id) VALUES ($1, $2)
+			ON CONFLICT (slug) DO UPDATE SET link_id = link_slugs.link_id
+			RETURNING link_id`,
+			slug, link.ID,
+		).Scan(&holder)
+		if err != nil {
+			logger.Log.Error("failed to reserve slug", zap.String("slug", slug), zap.Error(err))
+			return fmt.Errorf("failed to reserve slug: %v", err)
+		}
+		if holder != link.ID {
+			logger.Log.Error("slug already exists", zap.String("slug", slug))
+			return slugExistsError(link, slug)
+		}
+	}
+	return nil
+}
+
+// violatedSlug returns the slug of the link whose unique constraint err violates, or ""
+// if err is not a violation of the short_url or (domain, custom_slug) constraints of
+// "links".
+func violatedSlug(err error, link *Link) string {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != uniqueViolation {
+		return ""
+	}
+	switch pgErr.ConstraintName {
+	case "links_short_url_key":
+		return link.ShortURL
+	case "links_domain_custom_slug_key":
+		return customSlugKey(link)
+	default:
+		return ""
+	}
+}
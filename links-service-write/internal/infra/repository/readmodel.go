@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"links-service-write/internal/logger"
+	"links-service-write/internal/search"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"go.uber.org/zap"
+)
+
+const (
+	SlugTypeCustom    = "custom"
+	SlugTypeGenerated = "generated"
+
+	// noExpirationSortKey sorts links that never expire after every dated link.
+	noExpirationSortKey = "9999-12-31T23:59:59Z"
+	// maxOriginalURLSortKey keeps the original_url_sort key well below the 1024 byte
+	// limit DynamoDB puts on sort keys; URLs sharing such a long prefix tie.
+	maxOriginalURLSortKey = 512
+)
+
+// applyReadModel derives the attributes links-service-read filters, sorts and searches
+// customer links on: the slug type, the sort keys of the ByCustomerExpiration and
+// ByCustomerOriginalURL indexes, and the search text (see search.Document). It must be
+// called before every write of a full link.
+func applyReadModel(link *Link) {
+	link.SlugType = SlugTypeGenerated
+	if link.CustomSlug != "" {
+		link.SlugType = SlugTypeCustom
+	}
+
+	link.ExpirationSort = noExpirationSortKey
+	if link.ExpirationDate != nil && *link.ExpirationDate != "" {
+		if expTime, err := time.Parse(time.RFC3339, *link.ExpirationDate); err == nil {
+			link.ExpirationSort = expTime.UTC().Format(time.RFC3339)
+		}
+	}
+
+	link.OriginalURLSort = originalURLSortKey(link.OriginalURL)
+
+	fields := append([]string{link.OriginalURL, link.ShortURL, link.CustomSlug, link.Title}, link.Tags...)
+	link.SearchText = search.Document(fields...)
+}
+
+// originalURLSortKey lowercases the URL so that sorting is case-insensitive, and
+// truncates it on a rune boundary.
+func originalURLSortKey(originalURL string) string {
+	key := strings.ToLower(originalURL)
+	if len(key) <= maxOriginalURLSortKey {
+		return key
+	}
+
+	key = key[:maxOriginalURLSortKey]
+	for len(key) > 0 && !utf8.ValidString(key) {
+		key = key[:len(key)-1]
+	}
+	return key
+}
+
+// BackfillReadModel adds the read model attributes (see applyReadModel) to links written
+// before they existed, so that they show up in every sort order and filter of
+// links-service-read. Links are updated in place, one at a time; the operation is
+// idempotent and safe to run while links are being written.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//
+// Returns:
+//   - The number of links updated.
+//   - An error if the scan or an update fails.
+func (r *DynamoLinkStore) BackfillReadModel(ctx context.Context) (int, error) {
+	paginator := dynamodb.NewScanPaginator(r.db, &dynamodb.ScanInput{
+		TableName: aws.String("Links"),
+		FilterExpression: aws.String("attribute_not_exists(slug_type) OR attribute_not_exists(expiration_sort) OR " +
+			"attribute_not_exists(original_url_sort) OR attribute_not_exists(search_text)"),
+	})
+
+	updated := 0
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			logger.Log.Error("failed to scan links for backfill", zap.Error(err))
+			return updated, fmt.Errorf("failed to scan links for backfill: %v", err)
+		}
+
+		for _, item := range page.Items {
+			var link Link
+			if err := attributevalue.UnmarshalMap(item, &link); err != nil {
+				logger.Log.Error("failed to unmarshal link", zap.Error(err))
+				return updated, fmt.Errorf("failed to unmarshal link: %v", err)
+			}
+			applyReadModel(&link)
+
+			_, err := r.db.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+				TableName: aws.String("Links"),
+				Key: map[string]types.AttributeValue{
+					"short_url": &types.AttributeValueMemberS{Value: link.ShortURL},
+				},
+				UpdateExpression: aws.String("SET slug_type = :slugType, expiration_sort = :expirationSort, " +
+					"original_url_sort = :originalURLSort, search_text = :searchText"),
+				ConditionExpression: aws.String("attribute_exists(short_url)"),
+				ExpressionAttributeValues: map[string]types.AttributeValue{
+					":slugType":        &types.AttributeValueMemberS{Value: link.SlugType},
+					":expirationSort":  &types.AttributeValueMemberS{Value: link.ExpirationSort},
+					":originalURLSort": &types.AttributeValueMemberS{Value: link.OriginalURLSort},
+					":searchText":      &types.AttributeValueMemberS{Value: link.SearchText},
+				},
+			})
+			if err != nil {
+				var ccfe *types.ConditionalCheckFailedException
+				if errors.As(err, &ccfe) {
+					continue
+				}
+				logger.Log.Error("failed to backfill link", zap.String("short_url", link.ShortURL), zap.Error(err))
+				return updated, fmt.Errorf("failed to backfill link: %v", err)
+			}
+			updated++
+		}
+	}
+
+	logger.Log.Info("links read model backfilled", zap.Int("updated", updated))
+	return updated, nil
+}
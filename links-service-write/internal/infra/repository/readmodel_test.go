@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyReadModel(t *testing.T) {
+	t.Run("Derives slug type and sort keys", func(t *testing.T) {
+		expiration := "2030-06-01T12:00:00-03:00"
+		link := Link{CustomSlug: "promo", OriginalURL: "https://Example.com/Path", ExpirationDate: &expiration}
+
+		applyReadModel(&link)
+		require.Equal(t, SlugTypeCustom, link.SlugType)
+		require.Equal(t, "2030-06-01T15:00:00Z", link.ExpirationSort, "Expiration should be normalized to UTC")
+		require.Equal(t, "https://example.com/path", link.OriginalURLSort)
+	})
+
+	t.Run("Indexes the searchable fields", func(t *testing.T) {
+		link := Link{ShortURL: "promo", CustomSlug: "promo", OriginalURL: "https://loja.com.br", Title: "Promoção", Tags: []string{"Verão"}}
+
+		applyReadModel(&link)
+		require.Equal(t, " https loja com br promo promocao verao", link.SearchText)
+	})
+
+	t.Run("Links without custom slug or expiration", func(t *testing.T) {
+		link := Link{OriginalURL: "https://example.com"}
+
+		applyReadModel(&link)
+		require.Equal(t, SlugTypeGenerated, link.SlugType)
+		require.Equal(t, noExpirationSortKey, link.ExpirationSort, "Links that never expire should sort last")
+	})
+
+	t.Run("Long URLs are truncated on a rune boundary", func(t *testing.T) {
+		link := Link{OriginalURL: "https://example.com/" + strings.Repeat("é", 600)}
+
+		applyReadModel(&link)
+		require.LessOrEqual(t, len(link.OriginalURLSort), maxOriginalURLSortKey)
+		require.True(t, utf8.ValidString(link.OriginalURLSort))
+	})
+}
@@ -0,0 +1,60 @@
+package repository
+
+// Devices matched by RedirectRule.Devices, as classified by links-service-read from the
+// visitor's User-Agent.
+const (
+	DeviceIOS     = "ios"
+	DeviceAndroid = "android"
+	DeviceDesktop = "desktop"
+)
+
+// RedirectRule sends the visitors of a link who meet all of its conditions to another
+// destination than its original URL. links-service-read evaluates the rules of a link in
+// order on every redirect and applies the first one that matches; visitors matching none
+// are sent to the original URL. A condition left empty matches every visitor.
+//
+// Rules are stored as a list attribute of the link on DynamoDB, and as a JSON array in
+// the "redirect_rules" column on PostgreSQL.
+type RedirectRule struct {
+	Destination string `dynamodbav:"destination" json:"destination"`
+
+	// Devices lists the devices matched: DeviceIOS, DeviceAndroid or DeviceDesktop.
+	Devices []string `dynamodbav:"devices,omitempty" json:"devices,omitempty"`
+
+	// Countries lists the ISO 3166-1 alpha-2 codes of the countries matched, in upper
+	// case, as resolved by GeoIP from the visitor's IP address.
+	Countries []string `dynamodbav:"countries,omitempty" json:"countries,omitempty"`
+
+	// Languages lists the languages matched against the visitor's preferred language in
+	// Accept-Language, in lower case: "pt" matches every variant of Portuguese, "pt-br"
+	// only Brazilian Portuguese.
+	Languages []string `dynamodbav:"languages,omitempty" json:"languages,omitempty"`
+
+	// Days lists the days of the week matched in Timezone, "mon" to "sun".
+	Days []string `dynamodbav:"days,omitempty" json:"days,omitempty"`
+
+	// StartTime and EndTime bound the time of day matched in Timezone, "HH:MM", from
+	// StartTime included to EndTime excluded. An EndTime before StartTime spans midnight.
+	// Both are set, or neither.
+	StartTime string `dynamodbav:"start_time,omitempty" json:"start_time,omitempty"`
+	EndTime   string `dynamodbav:"end_time,omitempty" json:"end_time,omitempty"`
+
+	// Timezone is the IANA time zone of Days, StartTime and EndTime, "" for UTC.
+	Timezone string `dynamodbav:"timezone,omitempty" json:"timezone,omitempty"`
+}
+
+// copyRules returns a copy of rules that shares no mutable state with it.
+func copyRules(rules []RedirectRule) []RedirectRule {
+	if rules == nil {
+		return nil
+	}
+	c := make([]RedirectRule, len(rules))
+	for i, rule := range rules {
+		rule.Devices = append([]string(nil), rule.Devices...)
+		rule.Countries = append([]string(nil), rule.Countries...)
+		rule.Languages = append([]string(nil), rule.Languages...)
+		rule.Days = append([]string(nil), rule.Days...)
+		c[i] = rule
+	}
+	return c
+}
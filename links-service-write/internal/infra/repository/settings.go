@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"links-service-write/internal/logger"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"go.uber.org/zap"
+)
+
+// Fallback modes of LinkSettings: how links-service-read answers visitors of a link that
+// is expired, at its click limit, disabled or not active yet, when it has a fallback URL.
+const (
+	// FallbackRedirect redirects visitors to the fallback URL (the default).
+	FallbackRedirect = "redirect"
+	// FallbackPage answers with a page saying the link is not available, which links to
+	// the fallback URL.
+	FallbackPage = "page"
+)
+
+// LinkSettings are the settings a customer applies to all of their links. Customers who
+// never saved any have empty settings.
+type LinkSettings struct {
+	CustomerID string `dynamodbav:"customer_id"`
+
+	// FallbackURL is where visitors of the customer's inactive links are sent when the
+	// link has no fallback URL of its own, "" for none.
+	FallbackURL string `dynamodbav:"fallback_url,omitempty"`
+
+	// FallbackMode is FallbackRedirect or FallbackPage, "" for FallbackRedirect.
+	FallbackMode string `dynamodbav:"fallback_mode,omitempty"`
+
+	UpdatedAt string `dynamodbav:"updated_at,omitempty"`
+}
+
+// PutLinkSettings stores the link settings of a customer in the DynamoDB table
+// "CustomerLinkSettings", replacing the previous ones.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - settings: The settings to store, with their customer ID set.
+//
+// Returns:
+//   - A pointer to the stored LinkSettings.
+//   - An error if the write fails.
+func (r *DynamoLinkStore) PutLinkSettings(ctx context.Context, settings LinkSettings) (*LinkSettings, error) {
+	item, err := attributevalue.MarshalMap(settings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal link settings: %v", err)
+	}
+
+	_, err = r.db.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String("CustomerLinkSettings"),
+		Item:      item,
+	})
+	if err != nil {
+		logger.Log.Error("failed to put link settings", zap.Error(err))
+		return nil, fmt.Errorf("failed to put link settings: %v", err)
+	}
+
+	logger.Log.Info("link settings saved successfully", zap.String("customer_id", settings.CustomerID))
+	return &settings, nil
+}
+
+// GetLinkSettings retrieves the link settings of a customer from the DynamoDB table
+// "CustomerLinkSettings".
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - customerID: The ID of the customer.
+//
+// Returns:
+//   - A pointer to the customer's LinkSettings, empty if they never saved any.
+//   - An error if the read fails.
+func (r *DynamoLinkStore) GetLinkSettings(ctx context.Context, customerID string) (*LinkSettings, error) {
+	result, err := r.db.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String("CustomerLinkSettings"),
+		Key: map[string]types.AttributeValue{
+			"customer_id": &types.AttributeValueMemberS{Value: customerID},
+		},
+	})
+	if err != nil {
+		logger.Log.Error("failed to get link settings", zap.Error(err))
+		return nil, fmt.Errorf("failed to get link settings: %v", err)
+	}
+
+	settings := LinkSettings{CustomerID: customerID}
+	if len(result.Item) == 0 {
+		return &settings, nil
+	}
+	if err := attributevalue.UnmarshalMap(result.Item, &settings); err != nil {
+		logger.Log.Error("failed to unmarshal link settings", zap.Error(err))
+		return nil, fmt.Errorf("failed to unmarshal link settings: %v", err)
+	}
+	return &settings, nil
+}
@@ -0,0 +1,194 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"links-service-write/internal/logger"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"go.uber.org/zap"
+)
+
+// LinkKey returns the key a slug is stored and reserved under on a domain: the slug
+// itself on the default domain, and "<domain>/<slug>" on a branded one, so that slugs
+// are unique per domain. The short URL of a link is the key of its slug.
+func LinkKey(domain, slug string) string {
+	if domain == "" {
+		return slug
+	}
+	return domain + "/" + slug
+}
+
+// Slug returns the slug of a link's short URL, without its domain.
+func (l *Link) Slug() string {
+	if l.Domain == "" {
+		return l.ShortURL
+	}
+	return strings.TrimPrefix(l.ShortURL, l.Domain+"/")
+}
+
+// linkSlugs returns the keys of the slugs a link holds (see LinkKey): its short URL and,
+// when it differs, its custom slug. Every backend reserves these keys in the same
+// transaction that writes the link, so that no two links of a domain can ever hold the
+// same slug, generated or custom.
+func linkSlugs(link *Link) []string {
+	slugs := []string{link.ShortURL}
+	if custom := customSlugKey(link); custom != "" && custom != link.ShortURL {
+		slugs = append(slugs, custom)
+	}
+	return slugs
+}
+
+// customSlugKey returns the key of a link's custom slug, or "" if it has none.
+func customSlugKey(link *Link) string {
+	if link.CustomSlug == "" {
+		return ""
+	}
+	return LinkKey(link.Domain, link.CustomSlug)
+}
+
+// releasedSlugs returns the slugs held by the previous version of a link that its new
+// version no longer holds.
+func releasedSlugs(previous, link *Link) []string {
+	var released []string
+	for _, slug := range linkSlugs(previous) {
+		if slug != link.ShortURL && slug != customSlugKey(link) {
+			released = append(released, slug)
+		}
+	}
+	return released
+}
+
+// slugExistsError reports that the slug with the given key is held by another link.
+// The message names the custom slug when that is the one taken; callers match on
+// "already exists".
+func slugExistsError(link *Link, slug string) error {
+	if slug == customSlugKey(link) {
+		return fmt.Errorf("custom slug '%s' already exists", link.CustomSlug)
+	}
+	return fmt.Errorf("slug '%s' already exists", slug)
+}
+
+// reserveSlugItem builds the transactional put of the "SlugReservations" item holding
+// slug for the link. It fails if another link holds the slug; a reservation of the
+// same link is refreshed, with the link's TTL so that both expire together.
+func reserveSlugItem(slug string, link *Link) types.TransactWriteItem {
+	item := map[string]types.AttributeValue{
+		"slug":    &types.AttributeValueMemberS{Value: slug},
+		"link_id": &types.AttributeValueMemberS{Value: link.ID},
+	}
+	if link.TTL != nil {
+		item["ttl"] = &types.AttributeValueMemberN{Value: strconv.FormatInt(*link.TTL, 10)}
+	}
+
+	return types.TransactWriteItem{
+		Put: &types.Put{
+			TableName:           aws.String("SlugReservations"),
+			Item:                item,
+			ConditionExpression: aws.String("attribute_not_exists(slug) OR link_id = :id"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":id": &types.AttributeValueMemberS{Value: link.ID},
+			},
+		},
+	}
+}
+
+// releaseSlugItem builds the transactional delete of the reservation of slug by the
+// link with the given ID. Reservations of other links are left alone, and slugs of
+// links written before reservations existed have none to delete.
+func releaseSlugItem(slug, linkID string) types.TransactWriteItem {
+	return types.TransactWriteItem{
+		Delete: &types.Delete{
+			TableName: aws.String("SlugReservations"),
+			Key: map[string]types.AttributeValue{
+				"slug": &types.AttributeValueMemberS{Value: slug},
+			},
+			ConditionExpression: aws.String("attribute_not_exists(slug) OR link_id = :id"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":id": &types.AttributeValueMemberS{Value: linkID},
+			},
+		},
+	}
+}
+
+// conflictingSlug returns the slug guarded by the first item of a cancelled
+// transaction whose condition failed, given the slug guarded by each item of the
+// transaction ("" for items guarding none). It returns "" if err is not such a
+// cancellation.
+func conflictingSlug(err error, guarded []string) string {
+	var tce *types.TransactionCanceledException
+	if !errors.As(err, &tce) {
+		return ""
+	}
+	for i, reason := range tce.CancellationReasons {
+		if aws.ToString(reason.Code) == "ConditionalCheckFailed" && i < len(guarded) && guarded[i] != "" {
+			return guarded[i]
+		}
+	}
+	return ""
+}
+
+// BackfillSlugReservations reserves the slugs of links written before slug
+// reservations existed, so that new links cannot take them. Slugs already reserved are
+// skipped, and logged when another link holds them. The operation is idempotent and
+// safe to run while links are being written.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//
+// Returns:
+//   - The number of slugs reserved.
+//   - An error if the scan or a reservation fails.
+func (r *DynamoLinkStore) BackfillSlugReservations(ctx context.Context) (int, error) {
+	paginator := dynamodb.NewScanPaginator(r.db, &dynamodb.ScanInput{
+		TableName: aws.String("Links"),
+	})
+
+	reserved := 0
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			logger.Log.Error("failed to scan links for slug backfill", zap.Error(err))
+			return reserved, fmt.Errorf("failed to scan links for slug backfill: %v", err)
+		}
+
+		for _, item := range page.Items {
+			var link Link
+			if err := attributevalue.UnmarshalMap(item, &link); err != nil {
+				logger.Log.Error("failed to unmarshal link", zap.Error(err))
+				return reserved, fmt.Errorf("failed to unmarshal link: %v", err)
+			}
+
+			for _, slug := range linkSlugs(&link) {
+				put := reserveSlugItem(slug, &link).Put
+				_, err := r.db.PutItem(ctx, &dynamodb.PutItemInput{
+					TableName:                           put.TableName,
+					Item:                                put.Item,
+					ConditionExpression:                 aws.String("attribute_not_exists(slug)"),
+					ReturnValuesOnConditionCheckFailure: types.ReturnValuesOnConditionCheckFailureAllOld,
+				})
+				if err != nil {
+					var ccfe *types.ConditionalCheckFailedException
+					if errors.As(err, &ccfe) {
+						if holder, ok := ccfe.Item["link_id"].(*types.AttributeValueMemberS); ok && holder.Value != link.ID {
+							logger.Log.Warn("slug held by another link", zap.String("slug", slug), zap.String("link_id", link.ID))
+						}
+						continue
+					}
+					logger.Log.Error("failed to reserve slug", zap.String("slug", slug), zap.Error(err))
+					return reserved, fmt.Errorf("failed to reserve slug: %v", err)
+				}
+				reserved++
+			}
+		}
+	}
+
+	logger.Log.Info("slug reservations backfilled", zap.Int("reserved", reserved))
+	return reserved, nil
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinkSlugs(t *testing.T) {
+	require.Equal(t, []string{"abc123"}, linkSlugs(&Link{ShortURL: "abc123"}))
+	require.Equal(t, []string{"promo"}, linkSlugs(&Link{ShortURL: "promo", CustomSlug: "promo"}))
+	require.Equal(t, []string{"abc123", "promo"}, linkSlugs(&Link{ShortURL: "abc123", CustomSlug: "promo"}))
+
+	previous := &Link{ShortURL: "abc123", CustomSlug: "old"}
+	require.Equal(t, []string{"old"}, releasedSlugs(previous, &Link{ShortURL: "abc123", CustomSlug: "new"}))
+	require.Empty(t, releasedSlugs(previous, &Link{ShortURL: "abc123", CustomSlug: "old"}))
+}
+
+func TestConflictingSlug(t *testing.T) {
+	err := &types.TransactionCanceledException{
+		CancellationReasons: []types.CancellationReason{
+			{Code: aws.String("None")},
+			{Code: aws.String("ConditionalCheckFailed")},
+			{Code: aws.String("None")},
+		},
+	}
+
+	require.Equal(t, "promo", conflictingSlug(fmt.Errorf("wrapped: %w", err), []string{"abc123", "promo", ""}))
+	require.Empty(t, conflictingSlug(err, []string{"abc123", "", ""}), "Items guarding no slug should be ignored")
+	require.Empty(t, conflictingSlug(fmt.Errorf("timeout"), []string{"abc123"}))
+}
+
+func TestSlugReservation(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Concurrent creates of a slug let exactly one through", func(t *testing.T) {
+		store := NewMemoryLinkStore()
+
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		created := 0
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				_, err := store.CreateLink(ctx, Link{ID: fmt.Sprintf("id-%d", i), ShortURL: "promo", CustomSlug: "promo", CustomerID: "customer-1"})
+				if err == nil {
+					mu.Lock()
+					created++
+					mu.Unlock()
+				}
+			}(i)
+		}
+		wg.Wait()
+		require.Equal(t, 1, created)
+	})
+
+	t.Run("Updates take and release custom slugs", func(t *testing.T) {
+		store := NewMemoryLinkStore()
+		_, err := store.CreateLink(ctx, Link{ID: "id-1", ShortURL: "abc123", CustomerID: "customer-1"})
+		require.NoError(t, err)
+		_, err = store.CreateLink(ctx, Link{ID: "id-2", ShortURL: "xyz789", CustomerID: "customer-1"})
+		require.NoError(t, err)
+
+		_, err = store.UpdateLink(ctx, Link{ID: "id-1", CustomSlug: "xyz789", CustomerID: "customer-1"})
+		require.EqualError(t, err, "custom slug 'xyz789' already exists", "A generated slug should not be taken as a custom one")
+
+		_, err = store.UpdateLink(ctx, Link{ID: "id-1", CustomSlug: "promo", CustomerID: "customer-1"})
+		require.NoError(t, err)
+		_, err = store.CreateLink(ctx, Link{ID: "id-3", ShortURL: "promo", CustomSlug: "promo", CustomerID: "customer-1"})
+		require.Error(t, err)
+
+		_, err = store.UpdateLink(ctx, Link{ID: "id-1", CustomSlug: "summer", CustomerID: "customer-1"})
+		require.NoError(t, err)
+		_, err = store.CreateLink(ctx, Link{ID: "id-3", ShortURL: "promo", CustomSlug: "promo", CustomerID: "customer-1"})
+		require.NoError(t, err, "The previous custom slug should have been released")
+	})
+}
+
+func TestBrandedDomainSlugs(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryLinkStore()
+
+	require.Equal(t, "promo", LinkKey("", "promo"))
+	require.Equal(t, "go.example.com/promo", LinkKey("go.example.com", "promo"))
+
+	_, err := store.CreateLink(ctx, Link{ID: "id-1", ShortURL: "promo", CustomSlug: "promo", CustomerID: "customer-1"})
+	require.NoError(t, err)
+
+	link, err := store.CreateLink(ctx, Link{ID: "id-2", ShortURL: LinkKey("go.example.com", "promo"), CustomSlug: "promo", CustomerID: "customer-1", Domain: "go.example.com"})
+	require.NoError(t, err, "A slug should be free on every domain")
+	require.Equal(t, "promo", link.Slug())
+	require.Equal(t, []string{"go.example.com/promo"}, linkSlugs(link))
+
+	_, err = store.CreateLink(ctx, Link{ID: "id-3", ShortURL: LinkKey("go.example.com", "abc123"), CustomSlug: "promo", CustomerID: "customer-1", Domain: "go.example.com"})
+	require.EqualError(t, err, "custom slug 'promo' already exists")
+}
@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Storage backends selectable with the LINKS_STORE setting.
+const (
+	StoreDynamoDB = "dynamodb"
+	StorePostgres = "postgres"
+	StoreMemory   = "memory"
+)
+
+// LinkStore is the storage backend of links and of the clicks recorded on them. It is
+// implemented by DynamoLinkStore (the default), PostgresLinkStore and MemoryLinkStore.
+//
+// Implementations report failures with the same messages, which callers match on:
+//   - "link not found" when no link has the requested ID, short URL or custom slug.
+//   - "custom slug '<slug>' already exists" or "slug '<slug>' already exists" when a link is
+//     created or updated with a slug another link holds. Slugs are reserved atomically with
+//     the write of the link, see linkSlugs.
+//   - "link does not belong to this customer" when a delete targets another customer's link.
+//   - "domain not found" when no branded domain has the requested name, and
+//     "domain '<domain>' already exists" when a registered domain is registered again.
+//   - "utm template not found" when the customer has no UTM template with the requested ID.
+//   - "link click limit reached" when UpdateLinkClicks targets a link that has reached its
+//     click limit.
+type LinkStore interface {
+	// CreateLink stores a new link, filling in its timestamps and read model attributes.
+	CreateLink(ctx context.Context, link Link) (*Link, error)
+	// CreateLinks stores new links in bulk, like CreateLink but without stopping at the
+	// links that fail: created[i] is the created links[i], or errs[i] its error.
+	CreateLinks(ctx context.Context, links []Link) (created []*Link, errs []error)
+	// GetLinkByShortURL returns the link served at the given short URL.
+	GetLinkByShortURL(ctx context.Context, shortURL string) (*Link, error)
+	// GetLinkByID returns the link with the given ID.
+	GetLinkByID(ctx context.Context, id string) (*Link, error)
+	// GetLinkByCustomSlug returns the link using the given custom slug.
+	GetLinkByCustomSlug(ctx context.Context, customSlug string) (*Link, error)
+	// DeleteLink deletes a link of the given customer.
+	DeleteLink(ctx context.Context, id, customerID string) error
+	// UpdateLink replaces the editable fields of a link, keeping its short URL, creation
+	// date and click counters.
+	UpdateLink(ctx context.Context, link Link) (*Link, error)
+	// UpdateLinkClicks increments the click counter of a link, atomically with checking
+	// that it is below its click limit.
+	UpdateLinkClicks(ctx context.Context, id string) (*Link, error)
+	// RecordClick stores a click event and adds it to the link's counters and rollups,
+	// atomically. Counted clicks and hits on inactive links are only added to the rollups.
+	RecordClick(ctx context.Context, event ClickEvent) (*ClickEvent, error)
+
+	// CreateDomain registers a branded domain for a customer, replacing an unverified
+	// registration older than UnverifiedDomainTTL.
+	CreateDomain(ctx context.Context, domain Domain) (*Domain, error)
+	// GetDomain returns the registered domain with the given name.
+	GetDomain(ctx context.Context, name string) (*Domain, error)
+	// GetCustomerDomains returns the domains registered by a customer, ordered by name.
+	GetCustomerDomains(ctx context.Context, customerID string) ([]*Domain, error)
+	// MarkDomainVerified records that the ownership of a domain was verified at the given
+	// RFC3339 time.
+	MarkDomainVerified(ctx context.Context, name, verifiedAt string) (*Domain, error)
+	// DeleteDomain deletes a branded domain of the given customer.
+	DeleteDomain(ctx context.Context, customerID, name string) error
+
+	// CreateUTMTemplate stores a UTM template of a customer.
+	CreateUTMTemplate(ctx context.Context, template UTMTemplate) (*UTMTemplate, error)
+	// GetUTMTemplate returns the UTM template of a customer with the given ID.
+	GetUTMTemplate(ctx context.Context, customerID, id string) (*UTMTemplate, error)
+	// GetCustomerUTMTemplates returns the UTM templates of a customer, ordered by name.
+	GetCustomerUTMTemplates(ctx context.Context, customerID string) ([]*UTMTemplate, error)
+	// DeleteUTMTemplate deletes a UTM template of a customer.
+	DeleteUTMTemplate(ctx context.Context, customerID, id string) error
+
+	// PutLinkSettings stores the link settings of a customer, replacing the previous ones.
+	PutLinkSettings(ctx context.Context, settings LinkSettings) (*LinkSettings, error)
+	// GetLinkSettings returns the link settings of a customer, empty if they never saved any.
+	GetLinkSettings(ctx context.Context, customerID string) (*LinkSettings, error)
+
+	// ReserveCounter atomically adds n to a named counter, which starts at 0, and returns
+	// its new value. It persists the counter of the hashids short codes, see
+	// shortcode.Counter.
+	ReserveCounter(ctx context.Context, name string, n uint64) (uint64, error)
+}
+
+var (
+	_ LinkStore = (*DynamoLinkStore)(nil)
+	_ LinkStore = (*PostgresLinkStore)(nil)
+	_ LinkStore = (*MemoryLinkStore)(nil)
+)
+
+// prepareNewLink fills in the timestamps and read model attributes of a link about to
+// be created, and derives the TTL of the DynamoDB item from its expiration date.
+func prepareNewLink(link *Link) error {
+	now := time.Now().UTC().Format(time.RFC3339)
+	if link.CreatedAt == "" {
+		link.CreatedAt = now
+	}
+	if link.UpdatedAt == "" {
+		link.UpdatedAt = now
+	}
+
+	if err := setLinkTTL(link); err != nil {
+		return err
+	}
+
+	applyReadModel(link)
+	return nil
+}
+
+// prepareUpdatedLink carries the fields an update must keep over from the existing
+// link, including the domain it is served on, and refreshes the timestamps, TTL and read model attributes of the new version.
+func prepareUpdatedLink(link *Link, existingLink *Link) error {
+	link.ShortURL = existingLink.ShortURL
+	link.Domain = existingLink.Domain
+	link.CreatedAt = existingLink.CreatedAt
+	link.Clicks = existingLink.Clicks
+	link.BotClicks = existingLink.BotClicks
+	link.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+
+	if link.CustomerID == "" {
+		return fmt.Errorf("customer_id cannot be empty")
+	}
+
+	if err := setLinkTTL(link); err != nil {
+		return err
+	}
+
+	applyReadModel(link)
+	return nil
+}
+
+// ExpiredLinkRetention is how long links are kept after they expire before DynamoDB
+// deletes them, so that their visitors keep being sent to their fallback and are still
+// counted in their analytics in the meantime.
+const ExpiredLinkRetention = 90 * 24 * time.Hour
+
+// setLinkTTL derives the TTL of the DynamoDB item of a link from its expiration date,
+// ExpiredLinkRetention later, or clears it if the link does not expire.
+func setLinkTTL(link *Link) error {
+	link.TTL = nil
+	if link.ExpirationDate == nil || *link.ExpirationDate == "" {
+		return nil
+	}
+
+	expTime, err := time.Parse(time.RFC3339, *link.ExpirationDate)
+	if err != nil {
+		return fmt.Errorf("invalid expiration date format: %v", err)
+	}
+	ttl := expTime.Add(ExpiredLinkRetention).Unix()
+	link.TTL = &ttl
+	return nil
+}
+
+// MaxRollupReferrers caps the referrer domains counted apart in each DynamoDB rollup
+// bucket of a link, whose counters are attributes of a single item bounded in size.
+// Clicks from further domains count towards "ref:" + OtherReferrer.
+const MaxRollupReferrers = 100
+
+// OtherReferrer is the referrer domain of the clicks past MaxRollupReferrers in a bucket.
+const OtherReferrer = "other"
+
+// rollupCounters lists the rollup counters a click adds one to in each of its buckets.
+// Human clicks count towards "total" and the "ref:", "dev:", "cty:" and "cmp:" (UTM
+// campaign) breakdowns, and towards "var:<variant>" when they went to a variant of the
+// link; clicks with a BotCategory only count towards "bots" and "bot:<category>", and human hits
+// on inactive links towards "inactive" and "inactive:<status>", so the human figures stay
+// untouched.
+func rollupCounters(event ClickEvent) []string {
+	if event.BotCategory != "" {
+		return []string{"bots", "bot:" + dimensionValue(event.BotCategory)}
+	}
+	if event.LinkStatus != "" {
+		return []string{"inactive", "inactive:" + dimensionValue(event.LinkStatus)}
+	}
+	counters := []string{
+		"total",
+		"ref:" + dimensionValue(event.ReferrerDomain),
+		"dev:" + dimensionValue(event.DeviceClass),
+		"cty:" + dimensionValue(event.Country),
+		"cmp:" + dimensionValue(event.UTMCampaign),
+	}
+	if event.Variant != "" {
+		counters = append(counters, "var:"+dimensionValue(event.Variant))
+	}
+	return counters
+}
+
+// dimensionValue normalizes a breakdown value for use in a rollup counter name.
+func dimensionValue(value string) string {
+	if value == "" {
+		return "unknown"
+	}
+	if len(value) > 255 {
+		return value[:255]
+	}
+	return value
+}
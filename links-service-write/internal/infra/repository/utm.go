@@ -0,0 +1,216 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"links-service-write/internal/logger"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"go.uber.org/zap"
+)
+
+// UTM holds the UTM parameters of a link, added to its original URL by
+// links-service-read when visitors are redirected. Empty fields are left out.
+type UTM struct {
+	Source   string `dynamodbav:"source,omitempty"`
+	Medium   string `dynamodbav:"medium,omitempty"`
+	Campaign string `dynamodbav:"campaign,omitempty"`
+	Term     string `dynamodbav:"term,omitempty"`
+	Content  string `dynamodbav:"content,omitempty"`
+}
+
+// IsZero reports whether no UTM parameter is set.
+func (u UTM) IsZero() bool {
+	return u == UTM{}
+}
+
+// Merge returns u with its empty fields filled in from defaults.
+func (u UTM) Merge(defaults UTM) UTM {
+	fill := func(value, fallback string) string {
+		if value == "" {
+			return fallback
+		}
+		return value
+	}
+	return UTM{
+		Source:   fill(u.Source, defaults.Source),
+		Medium:   fill(u.Medium, defaults.Medium),
+		Campaign: fill(u.Campaign, defaults.Campaign),
+		Term:     fill(u.Term, defaults.Term),
+		Content:  fill(u.Content, defaults.Content),
+	}
+}
+
+// UTMCampaign returns the UTM campaign of the link, "" if it has none.
+func (l *Link) UTMCampaign() string {
+	if l.UTM == nil {
+		return ""
+	}
+	return l.UTM.Campaign
+}
+
+// UTMTemplate is a named set of UTM parameters a customer reuses across links, so that
+// the links of a campaign carry exactly the same values. Links copy the parameters of
+// the template they are created or updated with: editing or deleting a template later
+// does not change them.
+type UTMTemplate struct {
+	ID         string `dynamodbav:"id"`
+	CustomerID string `dynamodbav:"customer_id"`
+	Name       string `dynamodbav:"name"`
+	UTM        UTM    `dynamodbav:"utm"`
+	CreatedAt  string `dynamodbav:"created_at"`
+}
+
+// CreateUTMTemplate stores a UTM template in the DynamoDB table "UTMTemplates".
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - template: The template to store, with its ID set.
+//
+// Returns:
+//   - A pointer to the stored UTMTemplate.
+//   - An error if the write fails.
+func (r *DynamoLinkStore) CreateUTMTemplate(ctx context.Context, template UTMTemplate) (*UTMTemplate, error) {
+	item, err := attributevalue.MarshalMap(template)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal utm template: %v", err)
+	}
+
+	_, err = r.db.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:           aws.String("UTMTemplates"),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(id)"),
+	})
+	if err != nil {
+		logger.Log.Error("failed to create utm template", zap.Error(err))
+		return nil, fmt.Errorf("failed to create utm template: %v", err)
+	}
+
+	logger.Log.Info("utm template created successfully", zap.String("id", template.ID))
+	return &template, nil
+}
+
+// GetUTMTemplate retrieves one of a customer's UTM templates from the DynamoDB table
+// "UTMTemplates", which is keyed by customer so that other customers' templates are
+// never found.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - customerID: The ID of the customer owning the template.
+//   - id: The ID of the template.
+//
+// Returns:
+//   - A pointer to the UTMTemplate.
+//   - An error if the template is not found or the read fails.
+func (r *DynamoLinkStore) GetUTMTemplate(ctx context.Context, customerID, id string) (*UTMTemplate, error) {
+	result, err := r.db.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String("UTMTemplates"),
+		Key:       utmTemplateKey(customerID, id),
+	})
+	if err != nil {
+		logger.Log.Error("failed to get utm template", zap.Error(err))
+		return nil, fmt.Errorf("failed to get utm template: %v", err)
+	}
+	if len(result.Item) == 0 {
+		logger.Log.Warn("utm template not found", zap.String("id", id))
+		return nil, fmt.Errorf("utm template not found")
+	}
+
+	var template UTMTemplate
+	if err := attributevalue.UnmarshalMap(result.Item, &template); err != nil {
+		logger.Log.Error("failed to unmarshal utm template", zap.Error(err))
+		return nil, fmt.Errorf("failed to unmarshal utm template: %v", err)
+	}
+	return &template, nil
+}
+
+// GetCustomerUTMTemplates retrieves the UTM templates of a customer from the DynamoDB
+// table "UTMTemplates", ordered by name.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - customerID: The ID of the customer.
+//
+// Returns:
+//   - The customer's templates, possibly none.
+//   - An error if the query fails.
+func (r *DynamoLinkStore) GetCustomerUTMTemplates(ctx context.Context, customerID string) ([]*UTMTemplate, error) {
+	paginator := dynamodb.NewQueryPaginator(r.db, &dynamodb.QueryInput{
+		TableName:              aws.String("UTMTemplates"),
+		KeyConditionExpression: aws.String("customer_id = :customer_id"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":customer_id": &types.AttributeValueMemberS{Value: customerID},
+		},
+	})
+
+	var templates []*UTMTemplate
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			logger.Log.Error("failed to query customer utm templates", zap.Error(err))
+			return nil, fmt.Errorf("failed to query customer utm templates: %v", err)
+		}
+
+		var pageTemplates []*UTMTemplate
+		if err := attributevalue.UnmarshalListOfMaps(page.Items, &pageTemplates); err != nil {
+			logger.Log.Error("failed to unmarshal utm templates", zap.Error(err))
+			return nil, fmt.Errorf("failed to unmarshal utm templates: %v", err)
+		}
+		templates = append(templates, pageTemplates...)
+	}
+
+	sortUTMTemplates(templates)
+	return templates, nil
+}
+
+// DeleteUTMTemplate deletes one of a customer's UTM templates from the DynamoDB table
+// "UTMTemplates". Links created with it keep their UTM parameters.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - customerID: The ID of the customer owning the template.
+//   - id: The ID of the template.
+//
+// Returns:
+//   - An error if the template is not found or the delete fails.
+func (r *DynamoLinkStore) DeleteUTMTemplate(ctx context.Context, customerID, id string) error {
+	_, err := r.db.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName:           aws.String("UTMTemplates"),
+		Key:                 utmTemplateKey(customerID, id),
+		ConditionExpression: aws.String("attribute_exists(id)"),
+	})
+	if err != nil {
+		var ccfe *types.ConditionalCheckFailedException
+		if errors.As(err, &ccfe) {
+			logger.Log.Warn("utm template not found", zap.String("id", id))
+			return fmt.Errorf("utm template not found")
+		}
+		logger.Log.Error("failed to delete utm template", zap.Error(err))
+		return fmt.Errorf("failed to delete utm template: %v", err)
+	}
+
+	logger.Log.Info("utm template deleted successfully", zap.String("id", id))
+	return nil
+}
+
+func utmTemplateKey(customerID, id string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"customer_id": &types.AttributeValueMemberS{Value: customerID},
+		"id":          &types.AttributeValueMemberS{Value: id},
+	}
+}
+
+// sortUTMTemplates orders templates by name, then by creation date.
+func sortUTMTemplates(templates []*UTMTemplate) {
+	sort.Slice(templates, func(i, j int) bool {
+		if templates[i].Name != templates[j].Name {
+			return templates[i].Name < templates[j].Name
+		}
+		return templates[i].CreatedAt < templates[j].CreatedAt
+	})
+}
@@ -0,0 +1,18 @@
+package repository
+
+// Variant is one of the destinations a link splits its visitors between, for A/B tests.
+// links-service-read assigns each visitor of a link with variants one of them at random,
+// in proportion to their weights, and records it with the click, so that clicks can be
+// compared per variant.
+//
+// Variants are stored as a list attribute of the link on DynamoDB, and as a JSON array
+// in the "variants" column on PostgreSQL.
+type Variant struct {
+	// ID names the variant in clicks, rollups and assignment cookies, e.g. "a" or "control".
+	ID  string `dynamodbav:"id" json:"id"`
+	URL string `dynamodbav:"url" json:"url"`
+
+	// Weight is the share of visitors sent to the variant, relative to the weights of the
+	// other variants of the link: 50 and 50, or 1 and 1, split them evenly.
+	Weight int32 `dynamodbav:"weight" json:"weight"`
+}
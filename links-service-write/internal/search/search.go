@@ -0,0 +1,50 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Normalize lowercases s and strips its diacritics, so that "Promoção" and "promocao"
+// are indexed alike. It must match the normalization applied to search queries by
+// links-service-read.
+func Normalize(s string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	normalized, _, err := transform.String(t, s)
+	if err != nil {
+		normalized = s
+	}
+	return strings.ToLower(normalized)
+}
+
+// Tokens splits the normalized form of s into words, breaking on every rune that is
+// neither a letter nor a digit: "https://Example.com/Promoção" yields "https",
+// "example", "com" and "promocao".
+func Tokens(s string) []string {
+	return strings.FieldsFunc(Normalize(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// Document builds the search_text attribute of a link from its searchable fields: the
+// distinct tokens of every field, each preceded by a space. The leading spaces let a
+// DynamoDB contains(search_text, " " + prefix) filter match words by prefix.
+func Document(fields ...string) string {
+	seen := make(map[string]bool)
+	var b strings.Builder
+	for _, field := range fields {
+		for _, token := range Tokens(field) {
+			if seen[token] {
+				continue
+			}
+			seen[token] = true
+			b.WriteByte(' ')
+			b.WriteString(token)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,25 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalize(t *testing.T) {
+	require.Equal(t, "promocao de verao", Normalize("Promoção de VERÃO"))
+	require.Equal(t, "acai", Normalize("açaí"))
+}
+
+func TestTokens(t *testing.T) {
+	require.Equal(t,
+		[]string{"https", "example", "com", "br", "promocao", "2025"},
+		Tokens("https://Example.com.br/Promoção-2025"),
+	)
+	require.Empty(t, Tokens(" /-_ "))
+}
+
+func TestDocument(t *testing.T) {
+	doc := Document("https://example.com/blog", "abc123", "", "Blog da Empresa", "Blog", "Lançamento")
+	require.Equal(t, " https example com blog abc123 da empresa lancamento", doc, "Tokens should be distinct and prefixed by a space")
+}
@@ -0,0 +1,206 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"links-service-write/internal/infra/repository"
+	"links-service-write/internal/logger"
+	pb "links-service-write/proto"
+	"strings"
+
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxBulkLinks is the maximum number of links of a BulkCreateLinks request.
+const maxBulkLinks = 1000
+
+// BulkCreateLinks creates many links of a customer at once, such as the links of a
+// campaign imported from a spreadsheet. Each link is validated like in CreateLink, and
+// a link that fails does not abort the others: its result carries the status code and
+// message CreateLink would have failed with.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - req: A pointer to pb.BulkCreateLinksRequest containing the customer ID, the links
+//     (whose own customer_id is ignored) and the dry_run flag.
+//
+// Returns:
+//   - A pointer to pb.BulkCreateLinksResponse with one result per link, in request order,
+//     and the number of links created (or, in a dry run, that would be) and failed.
+//   - An InvalidArgument status error if the customer ID is missing, or if there are no
+//     links or more than maxBulkLinks.
+//
+// Behavior:
+//   - Custom slugs repeated within the request fail every link but the first.
+//   - Valid links are written together, see repository.LinkStore.CreateLinks. Links whose
+//     generated slug turns out to be taken are given a new one and written again, up to
+//     the attempts the short code generator allows.
+//   - In a dry run, nothing is written and no slug is generated. Custom slugs are checked
+//     against the links holding them as short URL, so a link passing a dry run may still
+//     fail when the request is sent for real.
+func (s *GRPCServer) BulkCreateLinks(ctx context.Context, req *pb.BulkCreateLinksRequest) (*pb.BulkCreateLinksResponse, error) {
+	if req.CustomerId == "" {
+		logger.Log.Error("customer ID is required")
+		return nil, status.Error(codes.InvalidArgument, "customer_id is required")
+	}
+	if len(req.Links) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "links is required")
+	}
+	if len(req.Links) > maxBulkLinks {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d links can be created at once", maxBulkLinks)
+	}
+
+	response := &pb.BulkCreateLinksResponse{
+		Results: make([]*pb.BulkCreateLinkResult, len(req.Links)),
+		DryRun:  req.DryRun,
+	}
+
+	links := make([]*repository.Link, len(req.Links))
+	customSlugs := make(map[string]int)
+	for i, row := range req.Links {
+		response.Results[i] = &pb.BulkCreateLinkResult{Index: int32(i)}
+		row.CustomerId = req.CustomerId
+
+		link, err := s.newLink(ctx, row)
+		if err != nil {
+			setBulkError(response.Results[i], err)
+			continue
+		}
+		if row.CustomSlug != "" {
+			if first, ok := customSlugs[link.ShortURL]; ok {
+				setBulkError(response.Results[i], status.Errorf(codes.AlreadyExists, "custom slug already used by link %d", first))
+				continue
+			}
+			customSlugs[link.ShortURL] = i
+		}
+		links[i] = &link
+	}
+
+	if req.DryRun {
+		s.checkBulkLinks(ctx, req, links, response.Results)
+	} else {
+		s.createBulkLinks(ctx, req, links, response.Results)
+	}
+
+	for _, result := range response.Results {
+		if result.Code == "" {
+			response.Created++
+		} else {
+			response.Failed++
+		}
+	}
+
+	logger.Log.Info("links created in bulk",
+		zap.String("customer_id", req.CustomerId),
+		zap.Bool("dry_run", req.DryRun),
+		zap.Int32("created", response.Created),
+		zap.Int32("failed", response.Failed),
+	)
+	return response, nil
+}
+
+// createBulkLinks writes the valid links of a BulkCreateLinks request (links[i] is nil
+// for the invalid ones) and fills in their results.
+func (s *GRPCServer) createBulkLinks(ctx context.Context, req *pb.BulkCreateLinksRequest, links []*repository.Link, results []*pb.BulkCreateLinkResult) {
+	var pending []int
+	for i, link := range links {
+		if link == nil {
+			continue
+		}
+		if req.Links[i].CustomSlug == "" {
+			if err := s.generateSlug(ctx, link, 0); err != nil {
+				setBulkError(results[i], err)
+				continue
+			}
+		}
+		pending = append(pending, i)
+	}
+
+	for attempt := 0; len(pending) > 0; attempt++ {
+		batch := make([]repository.Link, len(pending))
+		for j, i := range pending {
+			batch[j] = *links[i]
+		}
+
+		created, errs := s.repo.CreateLinks(ctx, batch)
+
+		var retry []int
+		for j, i := range pending {
+			if errs[j] == nil {
+				results[i].Link = createLinkResponse(created[j])
+				continue
+			}
+
+			generated := req.Links[i].CustomSlug == ""
+			switch {
+			case !strings.Contains(errs[j].Error(), "already exists"):
+				logger.Log.Error("failed to create link", zap.Int("index", i), zap.Error(errs[j]))
+				setBulkError(results[i], status.Error(codes.Internal, fmt.Sprintf("failed to create link: %v", errs[j])))
+			case !generated:
+				setBulkError(results[i], status.Error(codes.AlreadyExists, "custom slug already exists"))
+			case attempt+1 >= s.slugs.MaxAttempts():
+				setBulkError(results[i], status.Error(codes.AlreadyExists, "generated slug already exists"))
+			default:
+				if err := s.generateSlug(ctx, links[i], attempt+1); err != nil {
+					setBulkError(results[i], err)
+					continue
+				}
+				retry = append(retry, i)
+			}
+		}
+		pending = retry
+	}
+}
+
+// checkBulkLinks fills in the results of the valid links of a BulkCreateLinks dry run
+// (links[i] is nil for the invalid ones), failing those whose custom slug is taken.
+func (s *GRPCServer) checkBulkLinks(ctx context.Context, req *pb.BulkCreateLinksRequest, links []*repository.Link, results []*pb.BulkCreateLinkResult) {
+	for i, link := range links {
+		if link == nil {
+			continue
+		}
+
+		if req.Links[i].CustomSlug != "" {
+			_, err := s.repo.GetLinkByShortURL(ctx, link.ShortURL)
+			if err == nil {
+				setBulkError(results[i], status.Error(codes.AlreadyExists, "custom slug already exists"))
+				continue
+			}
+			if !strings.Contains(err.Error(), "not found") {
+				setBulkError(results[i], status.Error(codes.Internal, fmt.Sprintf("failed to check custom slug: %v", err)))
+				continue
+			}
+		}
+
+		link.SlugType = repository.SlugTypeGenerated
+		if link.CustomSlug != "" {
+			link.SlugType = repository.SlugTypeCustom
+		}
+		results[i].Link = createLinkResponse(link)
+		if link.ShortURL == "" {
+			results[i].Link.ShortUrl = ""
+		}
+	}
+}
+
+// setBulkError records the status error a link of a BulkCreateLinks request failed
+// with. The descriptions of the field violations it carries, such as those of
+// checkCustomSlug, are appended to its message.
+func setBulkError(result *pb.BulkCreateLinkResult, err error) {
+	st := status.Convert(err)
+	message := st.Message()
+	for _, detail := range st.Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			for _, violation := range badRequest.FieldViolations {
+				message += "; " + violation.Description
+			}
+		}
+	}
+
+	result.Link = nil
+	result.Code = st.Code().String()
+	result.Error = message
+}
@@ -0,0 +1,265 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"links-service-write/internal/domains"
+	"links-service-write/internal/infra/repository"
+	"links-service-write/internal/logger"
+	pb "links-service-write/proto"
+	"links-service-write/utils"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// domainTokenLength is the length of the token a branded domain's TXT record must hold.
+const domainTokenLength = 32
+
+// RegisterDomain registers a branded domain for a customer. The domain cannot serve
+// links until its ownership is verified, see VerifyDomain: the response names the TXT
+// record the customer must publish for that. A registration that is not verified within
+// repository.UnverifiedDomainTTL expires, and the domain can then be registered again by
+// any customer, with a new token.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - req: A pointer to pb.RegisterDomainRequest containing the customer ID and the domain.
+//
+// Returns:
+//   - A pointer to pb.DomainResponse describing the unverified domain and its TXT record.
+//   - An error if the operation fails, with appropriate gRPC status codes:
+//   - codes.InvalidArgument: If a field is missing, the domain is not a valid host name or
+//     is the default domain of short links.
+//   - codes.AlreadyExists: If the domain is verified, or its registration by this or
+//     another customer has not expired.
+//   - codes.Internal: If the token cannot be generated or the repository fails.
+func (s *GRPCServer) RegisterDomain(ctx context.Context, req *pb.RegisterDomainRequest) (*pb.DomainResponse, error) {
+	if req.CustomerId == "" {
+		logger.Log.Error("customer ID is required")
+		return nil, status.Error(codes.InvalidArgument, "customer_id is required")
+	}
+
+	name, err := domains.Normalize(req.Domain)
+	if err != nil {
+		logger.Log.Error("invalid domain", zap.String("domain", req.Domain), zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if name == utils.FrontendHost() {
+		logger.Log.Error("default domain cannot be registered", zap.String("domain", name))
+		return nil, status.Error(codes.InvalidArgument, "the default domain cannot be registered")
+	}
+
+	token, err := utils.GenerateRandomSlug(domainTokenLength)
+	if err != nil {
+		logger.Log.Error("failed to generate domain token", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to generate domain token")
+	}
+
+	domain, err := s.repo.CreateDomain(ctx, repository.Domain{
+		Domain:     name,
+		CustomerID: req.CustomerId,
+		Token:      token,
+		CreatedAt:  time.Now().UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "already exists") {
+			return nil, status.Error(codes.AlreadyExists, "domain already registered")
+		}
+		logger.Log.Error("failed to register domain", zap.Error(err))
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to register domain: %v", err))
+	}
+
+	logger.Log.Info("domain registered successfully", zap.String("domain", name), zap.String("customer_id", req.CustomerId))
+	return domainResponse(domain), nil
+}
+
+// VerifyDomain checks the TXT record of a customer's branded domain and, if it holds
+// the domain's token, marks the domain verified so that links can be created on it.
+// Verifying a verified domain again succeeds without checking its record.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - req: A pointer to pb.VerifyDomainRequest containing the customer ID and the domain.
+//
+// Returns:
+//   - A pointer to pb.DomainResponse describing the verified domain.
+//   - An error if the operation fails, with appropriate gRPC status codes:
+//   - codes.InvalidArgument: If a field is missing or the domain is not a valid host name.
+//   - codes.NotFound: If the domain is not registered.
+//   - codes.PermissionDenied: If the domain belongs to another customer.
+//   - codes.FailedPrecondition: If the TXT record is missing or holds another value.
+//   - codes.Unavailable: If the DNS lookup fails.
+//   - codes.Internal: If the repository fails.
+func (s *GRPCServer) VerifyDomain(ctx context.Context, req *pb.VerifyDomainRequest) (*pb.DomainResponse, error) {
+	if req.CustomerId == "" {
+		logger.Log.Error("customer ID is required")
+		return nil, status.Error(codes.InvalidArgument, "customer_id is required")
+	}
+
+	domain, err := s.customerDomain(ctx, req.CustomerId, req.Domain)
+	if err != nil {
+		return nil, err
+	}
+	if domain.Verified() {
+		return domainResponse(domain), nil
+	}
+
+	verified, err := s.owners.Verify(ctx, domain.Domain, domain.Token)
+	if err != nil {
+		logger.Log.Error("failed to verify domain", zap.String("domain", domain.Domain), zap.Error(err))
+		return nil, status.Error(codes.Unavailable, "failed to look up the verification record")
+	}
+	if !verified {
+		logger.Log.Warn("domain verification record not found", zap.String("domain", domain.Domain))
+		return nil, status.Errorf(codes.FailedPrecondition, "TXT record %s does not hold %s",
+			domains.RecordName(domain.Domain), domains.RecordValue(domain.Token))
+	}
+
+	domain, err = s.repo.MarkDomainVerified(ctx, domain.Domain, time.Now().UTC().Format(time.RFC3339))
+	if err != nil {
+		logger.Log.Error("failed to mark domain verified", zap.Error(err))
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to verify domain: %v", err))
+	}
+
+	logger.Log.Info("domain verified successfully", zap.String("domain", domain.Domain))
+	return domainResponse(domain), nil
+}
+
+// GetCustomerDomains lists the branded domains registered by a customer, verified or not.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - req: A pointer to pb.GetCustomerDomainsRequest containing the customer ID.
+//
+// Returns:
+//   - A pointer to pb.GetCustomerDomainsResponse with the domains, ordered by name.
+//   - An error if the customer ID is missing (InvalidArgument) or the repository fails (Internal).
+func (s *GRPCServer) GetCustomerDomains(ctx context.Context, req *pb.GetCustomerDomainsRequest) (*pb.GetCustomerDomainsResponse, error) {
+	if req.CustomerId == "" {
+		logger.Log.Error("customer ID is required")
+		return nil, status.Error(codes.InvalidArgument, "customer_id is required")
+	}
+
+	customerDomains, err := s.repo.GetCustomerDomains(ctx, req.CustomerId)
+	if err != nil {
+		logger.Log.Error("failed to get customer domains", zap.Error(err))
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to get customer domains: %v", err))
+	}
+
+	response := &pb.GetCustomerDomainsResponse{}
+	for _, domain := range customerDomains {
+		response.Domains = append(response.Domains, domainResponse(domain))
+	}
+	return response, nil
+}
+
+// DeleteDomain deletes one of a customer's branded domains, verified or not, so that it
+// can be registered again.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - req: A pointer to pb.DeleteDomainRequest containing the customer ID and the domain.
+//
+// Returns:
+//   - A pointer to pb.DeleteDomainResponse indicating the success of the operation.
+//   - An error if the operation fails, with appropriate gRPC status codes:
+//   - codes.InvalidArgument: If a field is missing or the domain is not a valid host name.
+//   - codes.NotFound: If the domain is not registered.
+//   - codes.PermissionDenied: If the domain belongs to another customer.
+//   - codes.Internal: If the repository fails.
+//
+// Notes:
+//   - Links created on the domain are not deleted, and keep their short URLs.
+func (s *GRPCServer) DeleteDomain(ctx context.Context, req *pb.DeleteDomainRequest) (*pb.DeleteDomainResponse, error) {
+	if req.CustomerId == "" {
+		logger.Log.Error("customer ID is required")
+		return nil, status.Error(codes.InvalidArgument, "customer_id is required")
+	}
+
+	domain, err := s.customerDomain(ctx, req.CustomerId, req.Domain)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.DeleteDomain(ctx, req.CustomerId, domain.Domain); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, status.Error(codes.NotFound, "domain not found")
+		}
+		logger.Log.Error("failed to delete domain", zap.Error(err))
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to delete domain: %v", err))
+	}
+
+	logger.Log.Info("domain deleted successfully", zap.String("domain", domain.Domain), zap.String("customer_id", req.CustomerId))
+	return &pb.DeleteDomainResponse{Success: true}, nil
+}
+
+// customerDomain returns the registered domain named name, after checking that it
+// belongs to the customer.
+//
+// Returns:
+//   - A pointer to the Domain.
+//   - An InvalidArgument, NotFound, PermissionDenied or Internal status error otherwise.
+func (s *GRPCServer) customerDomain(ctx context.Context, customerID, name string) (*repository.Domain, error) {
+	normalized, err := domains.Normalize(name)
+	if err != nil {
+		logger.Log.Error("invalid domain", zap.String("domain", name), zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	domain, err := s.repo.GetDomain(ctx, normalized)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, status.Error(codes.NotFound, "domain not found")
+		}
+		logger.Log.Error("failed to get domain", zap.Error(err))
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to get domain: %v", err))
+	}
+	if domain.CustomerID != customerID {
+		logger.Log.Error("domain does not belong to this customer",
+			zap.String("domain", normalized),
+			zap.String("customer_id", customerID),
+		)
+		return nil, status.Error(codes.PermissionDenied, "domain does not belong to this customer")
+	}
+	return domain, nil
+}
+
+// linkDomain resolves the branded domain a new link is requested on.
+//
+// Returns:
+//   - The normalized domain name, or "" if name is empty (the default domain).
+//   - A status error if the domain is invalid, not registered, another customer's
+//     (see customerDomain) or not verified yet (FailedPrecondition).
+func (s *GRPCServer) linkDomain(ctx context.Context, customerID, name string) (string, error) {
+	if name == "" {
+		return "", nil
+	}
+
+	domain, err := s.customerDomain(ctx, customerID, name)
+	if err != nil {
+		return "", err
+	}
+	if !domain.Verified() {
+		logger.Log.Error("domain is not verified", zap.String("domain", domain.Domain))
+		return "", status.Error(codes.FailedPrecondition, "domain is not verified")
+	}
+	return domain.Domain, nil
+}
+
+// domainResponse converts a Domain into its gRPC representation, including the TXT
+// record proving its ownership.
+func domainResponse(domain *repository.Domain) *pb.DomainResponse {
+	return &pb.DomainResponse{
+		Domain:      domain.Domain,
+		CustomerId:  domain.CustomerID,
+		Verified:    domain.Verified(),
+		RecordName:  domains.RecordName(domain.Domain),
+		RecordValue: domains.RecordValue(domain.Token),
+		CreatedAt:   domain.CreatedAt,
+		VerifiedAt:  domain.VerifiedAt,
+	}
+}
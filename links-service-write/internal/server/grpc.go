@@ -3,38 +3,82 @@ package server
 import (
 	"context"
 	"fmt"
+	"links-service-write/internal/analytics"
+	"links-service-write/internal/botfilter"
+	"links-service-write/internal/clickstream"
+	"links-service-write/internal/domains"
+	"links-service-write/internal/geoip"
 	"links-service-write/internal/infra/repository"
 	"links-service-write/internal/logger"
+	"links-service-write/internal/shortcode"
+	"links-service-write/internal/slugpolicy"
+	"links-service-write/internal/visitors"
 	pb "links-service-write/proto"
 	"links-service-write/utils"
 	"net"
 	"net/url"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
 )
 
+const (
+	// maxTitleLength is the maximum length of a link title, in characters.
+	maxTitleLength = 200
+	// maxTags is the maximum number of tags of a link.
+	maxTags = 10
+	// maxTagLength is the maximum length of a tag, in characters.
+	maxTagLength = 32
+)
+
+// inactiveLinkStatuses are the statuses of the inactive links whose hits RecordClick
+// records, as reported by links-service-read.
+var inactiveLinkStatuses = map[string]bool{
+	"expired":   true,
+	"disabled":  true,
+	"scheduled": true,
+}
+
 type GRPCServer struct {
 	pb.UnimplementedLinksServiceWriteServer
-	repo *repository.LinksRepository
+	repo   repository.LinkStore
+	slugs  *shortcode.Generator
+	policy *slugpolicy.Policy
+	geo    *geoip.Resolver
+	asn    *geoip.Resolver
+	bots   *botfilter.Classifier
+	owners *domains.Verifier
+
+	visitors *visitors.Counter
+	clicks   *clickstream.Publisher
 }
 
-// NewGRPCServer creates a new instance of GRPCServer with the provided LinksRepository.
+// NewGRPCServer creates a new instance of GRPCServer with the provided LinkStore.
 // It initializes the server with the given repository to handle gRPC requests.
 //
 // Parameters:
-//   - repo: A pointer to a LinksRepository instance that provides access to the data layer.
+//   - repo: The LinkStore that provides access to the data layer.
+//   - slugs: A pointer to the generator of the short codes of links without a custom slug.
+//   - policy: A pointer to the policy custom slugs must follow.
+//   - geo: A pointer to the GeoIP resolver used to geolocate recorded clicks.
+//   - asn: A pointer to the GeoIP ASN resolver used to spot clicks from hosting networks.
+//   - bots: A pointer to the classifier that separates automated clicks from human ones.
+//   - owners: A pointer to the verifier checking the DNS records of branded domains.
+//   - visitors: A pointer to the counter of unique visitors per link.
+//   - clicks: A pointer to the publisher announcing recorded clicks to live dashboards.
 //
 // Returns:
 //
 //	A pointer to a GRPCServer instance configured with the provided repository.
-func NewGRPCServer(repo *repository.LinksRepository) *GRPCServer {
-	return &GRPCServer{repo: repo}
+func NewGRPCServer(repo repository.LinkStore, slugs *shortcode.Generator, policy *slugpolicy.Policy, geo *geoip.Resolver, asn *geoip.Resolver, bots *botfilter.Classifier, owners *domains.Verifier, visitors *visitors.Counter, clicks *clickstream.Publisher) *GRPCServer {
+	return &GRPCServer{repo: repo, slugs: slugs, policy: policy, geo: geo, asn: asn, bots: bots, owners: owners, visitors: visitors, clicks: clicks}
 }
 
 // CreateLink handles the creation of a new shortened link.
@@ -49,29 +93,144 @@ func NewGRPCServer(repo *repository.LinksRepository) *GRPCServer {
 //   - An error if the creation process fails due to invalid input, repository issues, or other internal errors.
 //
 // Validation:
-//   - Ensures the OriginalUrl field is not empty.
+//   - Ensures the OriginalUrl field is not empty, unless Variants are provided.
 //   - Validates the format of the OriginalUrl.
+//   - If Variants are provided, ensures they are valid, see destinationVariants. Visitors are
+//     split between them by weight, and the OriginalUrl is the URL of the first one, see
+//     variantOriginalURL. With StickyVariants, visitors keep the variant they were assigned.
 //   - If an ExpirationDate is provided, ensures it is in RFC3339 format and is a future date.
-//   - If a CustomSlug is provided, checks for its uniqueness in the repository.
+//   - If a CustomSlug is provided, ensures it follows the slug policy, see checkCustomSlug.
+//   - If a Domain is provided, ensures it is registered by the customer and verified, see linkDomain.
+//   - If Utm is provided, ensures its parameters are valid, see utmParams. With a UtmTemplateId, the
+//     parameters left empty are taken from the customer's template, see linkUTM.
+//   - If a Password is provided, ensures it fits the limits checked by linkPasswordHash. Only its
+//     bcrypt hash is stored.
+//   - If MaxClicks or OneTime is provided, ensures they are consistent, see clickLimit. Once its
+//     limit is reached, the link behaves as expired.
+//   - If an ActivatesAt is provided, ensures it is in RFC3339 format, is a future date and comes
+//     before the ExpirationDate, see activationDate. Until then, the link is "scheduled".
+//   - If a FallbackUrl is provided, ensures it is an absolute HTTP or HTTPS URL, see fallbackURL.
+//     Visitors are sent to it while the link is expired, at its click limit, disabled or not
+//     active yet, instead of to the customer's fallback URL.
+//   - If Rules are provided, ensures they are valid, see redirectRules. Visitors meeting the
+//     conditions of a rule are sent to its destination instead of the OriginalUrl.
 //
 // Behavior:
 //   - Generates a unique ID for the link.
-//   - If no CustomSlug is provided, generates a random slug and ensures its uniqueness.
-//   - Creates a new link record in the repository with the provided and generated details.
-//   - Constructs the short URL using the base frontend source URL.
+//   - If no CustomSlug is provided, generates a slug with the configured short code generator,
+//     drawing a new one each time the previous is found taken, up to its maximum attempts.
+//   - Creates a new link record in the repository with the provided and generated details. The
+//     repository reserves the slug atomically with the write, so concurrent creates of the same
+//     slug cannot both succeed.
+//   - Slugs are unique per domain: the same slug can be used once on the default domain and once
+//     on each branded domain.
+//   - Constructs the short URL using the branded domain, or the base frontend source URL.
 //
 // Possible Errors:
-//   - InvalidArgument: If required fields are missing or invalid (e.g., empty OriginalUrl, invalid URL format).
-//   - AlreadyExists: If the provided CustomSlug or generated slug already exists.
+//   - InvalidArgument: If required fields are missing or invalid (e.g., empty OriginalUrl, invalid URL format),
+//     or if the CustomSlug breaks the slug policy.
+//   - NotFound, PermissionDenied or FailedPrecondition: If the Domain is not registered, is another
+//     customer's or is not verified yet.
+//   - NotFound: If the UtmTemplateId does not match any template of the customer.
+//   - AlreadyExists: If the provided CustomSlug already exists on the domain, or every generated slug tried did.
 //   - Internal: If there are issues generating the ID/slug or interacting with the repository.
 func (s *GRPCServer) CreateLink(ctx context.Context, req *pb.CreateLinkRequest) (*pb.CreateLinkResponse, error) {
-	if req.OriginalUrl == "" {
-		return nil, status.Error(codes.InvalidArgument, "original_url is required")
+	link, err := s.newLink(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// A custom slug is tried once. A generated one is drawn again, up to the attempts
+	// the generator allows, when another link already holds it.
+	var createdLink *repository.Link
+	for attempt := 0; ; attempt++ {
+		if req.CustomSlug == "" {
+			if err := s.generateSlug(ctx, &link, attempt); err != nil {
+				return nil, err
+			}
+		}
+
+		createdLink, err = s.repo.CreateLink(ctx, link)
+		if err == nil {
+			break
+		}
+		if !strings.Contains(err.Error(), "already exists") {
+			logger.Log.Error("failed to create link", zap.Error(err))
+			return nil, status.Error(codes.Internal, fmt.Sprintf("failed to create link: %v", err))
+		}
+		if req.CustomSlug != "" {
+			logger.Log.Error("slug already exists", zap.String("short_url", link.ShortURL))
+			return nil, status.Error(codes.AlreadyExists, "custom slug already exists")
+		}
+		if attempt+1 >= s.slugs.MaxAttempts() {
+			logger.Log.Error("no free generated slug", zap.String("short_url", link.ShortURL), zap.Int("attempts", attempt+1))
+			return nil, status.Error(codes.AlreadyExists, "generated slug already exists")
+		}
+		logger.Log.Warn("generated slug collision", zap.String("short_url", link.ShortURL), zap.Int("attempt", attempt))
+	}
+
+	logger.Log.Info("link created successfully", zap.String("short_url", createdLink.ShortURL))
+	return createLinkResponse(createdLink), nil
+}
+
+// newLink validates a CreateLinkRequest (see CreateLink) and builds the link it asks
+// for, with a new ID. The short URL is only set for a custom slug: generated slugs are
+// drawn by generateSlug.
+//
+// Returns:
+//   - The link to create.
+//   - An InvalidArgument status error if a field is invalid, or the status error of
+//     checkCustomSlug, linkDomain or linkUTM.
+func (s *GRPCServer) newLink(ctx context.Context, req *pb.CreateLinkRequest) (repository.Link, error) {
+	variants, err := destinationVariants(req.Variants)
+	if err != nil {
+		return repository.Link{}, err
+	}
+
+	originalURL, err := variantOriginalURL(req.OriginalUrl, variants)
+	if err != nil {
+		return repository.Link{}, err
+	}
+	if originalURL == "" {
+		return repository.Link{}, status.Error(codes.InvalidArgument, "original_url is required")
 	}
 
-	if _, err := url.Parse(req.OriginalUrl); err != nil {
+	if _, err := url.Parse(originalURL); err != nil {
 		logger.Log.Error("invalid URL format", zap.Error(err))
-		return nil, status.Error(codes.InvalidArgument, "invalid URL format")
+		return repository.Link{}, status.Error(codes.InvalidArgument, "invalid URL format")
+	}
+
+	if req.CustomSlug != "" {
+		if err := s.checkCustomSlug(req.CustomerId, req.CustomSlug); err != nil {
+			logger.Log.Error("custom slug rejected by policy", zap.String("custom_slug", req.CustomSlug), zap.Error(err))
+			return repository.Link{}, err
+		}
+	}
+
+	title, tags, err := linkMetadata(req.Title, req.Tags)
+	if err != nil {
+		logger.Log.Error("invalid link metadata", zap.Error(err))
+		return repository.Link{}, err
+	}
+
+	domain, err := s.linkDomain(ctx, req.CustomerId, req.Domain)
+	if err != nil {
+		return repository.Link{}, err
+	}
+
+	utm, err := s.linkUTM(ctx, req.CustomerId, req.Utm, req.UtmTemplateId)
+	if err != nil {
+		return repository.Link{}, err
+	}
+
+	passwordHash, err := linkPasswordHash(req.Password)
+	if err != nil {
+		return repository.Link{}, err
+	}
+
+	maxClicks, err := clickLimit(req.MaxClicks, req.OneTime)
+	if err != nil {
+		return repository.Link{}, err
 	}
 
 	var expirationDate *string
@@ -79,93 +238,105 @@ func (s *GRPCServer) CreateLink(ctx context.Context, req *pb.CreateLinkRequest)
 		expirationTime, err := time.Parse(time.RFC3339, *req.ExpirationDate)
 		if err != nil {
 			logger.Log.Error("invalid expiration date format", zap.Error(err))
-			return nil, status.Error(codes.InvalidArgument,
+			return repository.Link{}, status.Error(codes.InvalidArgument,
 				"invalid expiration date format. Use RFC3339 format (e.g., 2024-12-31T23:59:59Z)")
 		}
 		if expirationTime.Before(time.Now()) {
 			logger.Log.Error("expiration date must be in the future", zap.Error(err))
-			return nil, status.Error(codes.InvalidArgument, "expiration date must be in the future")
+			return repository.Link{}, status.Error(codes.InvalidArgument, "expiration date must be in the future")
 		}
 		expirationDate = req.ExpirationDate
 	}
 
-	id, err := utils.GenerateRandomSlug(10)
+	activatesAt, err := activationDate(req.GetActivatesAt(), nil)
 	if err != nil {
-		logger.Log.Error("failed to generate ID", zap.Error(err))
-		return nil, status.Error(codes.Internal, "failed to generate ID")
+		return repository.Link{}, err
+	}
+	if err := checkActivationWindow(activatesAt, expirationDate); err != nil {
+		return repository.Link{}, err
 	}
 
-	var shortSlug string
-	var customSlug string = req.CustomSlug
+	fallback, err := fallbackURL(req.FallbackUrl)
+	if err != nil {
+		return repository.Link{}, err
+	}
 
-	if customSlug != "" {
-		existingLink, err := s.repo.GetLinkByCustomSlug(ctx, customSlug)
-		if err == nil && existingLink != nil {
-			logger.Log.Error("custom slug already exists", zap.String("custom_slug", customSlug))
-			return nil, status.Error(codes.AlreadyExists, "custom slug already exists")
-		} else if err != nil && !strings.Contains(err.Error(), "not found") {
-			logger.Log.Error("error checking custom slug", zap.Error(err))
-			return nil, status.Error(codes.Internal, fmt.Sprintf("error checking custom slug: %v", err))
-		}
+	rules, err := redirectRules(req.Rules)
+	if err != nil {
+		return repository.Link{}, err
+	}
 
-		shortSlug = customSlug
-	} else {
-		for {
-			generatedSlug, err := utils.GenerateRandomSlug(6)
-			if err != nil {
-				logger.Log.Error("failed to generate slug", zap.Error(err))
-				return nil, status.Error(codes.Internal, "failed to generate slug")
-			}
-			existingLink, err := s.repo.GetLinkByShortURL(ctx, generatedSlug)
-			if err != nil && strings.Contains(err.Error(), "not found") {
-				shortSlug = generatedSlug
-				break
-			} else if err == nil && existingLink != nil && existingLink.CustomSlug != customSlug {
-				logger.Log.Error("generated slug already exists", zap.String("generated_slug", generatedSlug))
-				return nil, status.Error(codes.AlreadyExists, "generated slug already exists")
-			} else if err == nil && existingLink != nil {
-				logger.Log.Error("generated slug already exists", zap.String("generated_slug", generatedSlug))
-				return nil, status.Error(codes.AlreadyExists, "generated slug already exists")
-			} else if err != nil {
-				logger.Log.Error("error checking slug", zap.Error(err))
-				return nil, status.Error(codes.Internal, fmt.Sprintf("error checking slug: %v", err))
-			}
-		}
+	id, err := utils.GenerateRandomSlug(10)
+	if err != nil {
+		logger.Log.Error("failed to generate ID", zap.Error(err))
+		return repository.Link{}, status.Error(codes.Internal, "failed to generate ID")
 	}
 
 	now := time.Now().UTC().Format(time.RFC3339)
 
 	link := repository.Link{
 		ID:             id,
-		ShortURL:       shortSlug,
-		OriginalURL:    req.OriginalUrl,
-		CustomSlug:     customSlug,
+		OriginalURL:    originalURL,
+		CustomSlug:     req.CustomSlug,
 		CustomerID:     req.CustomerId,
 		Clicks:         0,
 		CreatedAt:      now,
 		UpdatedAt:      now,
 		ExpirationDate: expirationDate,
+		ActivatesAt:    activatesAt,
+		FallbackURL:    fallback,
+		Rules:          rules,
+		Variants:       variants,
+		StickyVariants: req.StickyVariants && len(variants) > 0,
+		Title:          title,
+		Tags:           tags,
+		Domain:         domain,
+		UTM:            utm,
+		PasswordHash:   passwordHash,
+		MaxClicks:      maxClicks,
+	}
+	if req.CustomSlug != "" {
+		link.ShortURL = repository.LinkKey(domain, req.CustomSlug)
 	}
+	return link, nil
+}
 
-	createdLink, err := s.repo.CreateLink(ctx, link)
+// generateSlug draws the slug of a link without a custom slug, for the given attempt
+// at creating it (see shortcode.Generator), and sets the link's short URL to it.
+func (s *GRPCServer) generateSlug(ctx context.Context, link *repository.Link, attempt int) error {
+	slug, err := s.slugs.Generate(ctx, link.CustomerID, attempt)
 	if err != nil {
-		logger.Log.Error("failed to create link", zap.Error(err))
-		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to create link: %v", err))
+		logger.Log.Error("failed to generate slug", zap.Error(err))
+		return status.Error(codes.Internal, "failed to generate slug")
 	}
+	link.ShortURL = repository.LinkKey(link.Domain, slug)
+	return nil
+}
 
-	baseURL := utils.ConfigInstance.FrontendSource
-
-	logger.Log.Info("link created successfully", zap.String("short_url", createdLink.ShortURL))
+// createLinkResponse converts a created link into its gRPC representation.
+func createLinkResponse(link *repository.Link) *pb.CreateLinkResponse {
 	return &pb.CreateLinkResponse{
-		Id:             createdLink.ID,
-		ShortUrl:       baseURL + "/" + createdLink.ShortURL,
-		CustomSlug:     createdLink.CustomSlug,
-		Clicks:         createdLink.Clicks,
-		CreatedAt:      createdLink.CreatedAt,
-		UpdatedAt:      createdLink.UpdatedAt,
-		CustomerId:     createdLink.CustomerID,
-		ExpirationDate: createdLink.ExpirationDate,
-	}, nil
+		Id:                link.ID,
+		ShortUrl:          utils.ShortURL(link.Domain, link.Slug()),
+		CustomSlug:        link.CustomSlug,
+		Clicks:            link.Clicks,
+		CreatedAt:         link.CreatedAt,
+		UpdatedAt:         link.UpdatedAt,
+		CustomerId:        link.CustomerID,
+		ExpirationDate:    link.ExpirationDate,
+		SlugType:          link.SlugType,
+		Title:             link.Title,
+		Tags:              link.Tags,
+		Domain:            link.Domain,
+		Utm:               utmResponse(link.UTM),
+		PasswordProtected: link.PasswordHash != "",
+		MaxClicks:         optionalClicks(link.MaxClicks),
+		ActivatesAt:       link.ActivatesAt,
+		FallbackUrl:       link.FallbackURL,
+		Rules:             rulesResponse(link.Rules),
+		Variants:          variantsResponse(link.Variants),
+		StickyVariants:    link.StickyVariants,
+	}
 }
 
 // DeleteLink handles the deletion of a link based on the provided request.
@@ -228,14 +399,42 @@ func (s *GRPCServer) DeleteLink(ctx context.Context, req *pb.DeleteLinkRequest)
 //
 // Validation:
 //   - The `id` field in the request must not be empty.
-//   - The `original_url` field must not be empty and must be a valid URL format.
-//   - If `custom_slug` is provided, it must not conflict with an existing slug.
+//   - The `original_url` field must not be empty, unless the link has variants, and must be a
+//     valid URL format.
+//   - If `variants` is omitted, the link keeps its current variants, the first one taking the
+//     `original_url`. Otherwise they are replaced and must be valid, see destinationVariants;
+//     an empty list removes them. With variants, the `original_url` is the URL of the first
+//     one, see variantOriginalURL.
+//   - If `sticky_variants` is omitted, the link keeps its current setting.
+//   - If `custom_slug` is provided and differs from the current one, it must follow the slug
+//     policy, see checkCustomSlug.
+//   - If `custom_slug` is provided, it must not conflict with an existing slug; the repository
+//     checks this atomically with the update.
 //   - If `expiration_date` is provided, it must be in RFC3339 format and set to a future date.
+//   - The `title` and `tags` fields must fit the limits checked by linkMetadata; like the other
+//     fields, they replace the current ones.
 //   - The `customer_id` field must not be empty and cannot be changed from the original value.
+//   - If `disabled` is omitted, the link keeps its current disabled state.
+//   - If `utm` and `utm_template_id` are both omitted, the link keeps its current UTM parameters.
+//     Otherwise they are replaced, like in CreateLink; an empty `utm` removes them.
+//   - If `password` is omitted, the link keeps its current password. Otherwise it must fit the
+//     limits checked by linkPasswordHash, and an empty one removes the protection.
+//   - If `max_clicks` and `one_time` are both omitted, the link keeps its current click limit.
+//     Otherwise it is replaced, see clickLimit; a `max_clicks` of 0 removes it. Clicks already
+//     counted are kept, so a limit at or below them makes the link behave as expired.
+//   - If `activates_at` is omitted, the link keeps its current activation date. Otherwise it must
+//     be in RFC3339 format, set to a future date unless it is the current one, and come before
+//     the expiration date, see activationDate; an empty one activates the link right away.
+//   - If `fallback_url` is omitted, the link keeps its current one. Otherwise it must be an
+//     absolute HTTP or HTTPS URL, see fallbackURL, and an empty one removes it.
+//   - If `rules` is omitted, the link keeps its current redirect rules. Otherwise they are
+//     replaced and must be valid, see redirectRules; an empty list removes them.
 //
 // Errors:
-//   - codes.InvalidArgument: If required fields are missing or invalid.
-//   - codes.NotFound: If the link with the specified ID does not exist.
+//   - codes.InvalidArgument: If required fields are missing or invalid, or the custom slug breaks the
+//     slug policy.
+//   - codes.NotFound: If the link with the specified ID does not exist, or the customer has no
+//     UTM template with the specified `utm_template_id`.
 //   - codes.AlreadyExists: If the custom slug is already in use by another link.
 //   - codes.PermissionDenied: If the `customer_id` is modified.
 //   - codes.Internal: If there is an internal error during the update process.
@@ -245,16 +444,6 @@ func (s *GRPCServer) UpdateLink(ctx context.Context, req *pb.UpdateLinkRequest)
 		return nil, status.Error(codes.InvalidArgument, "id is required")
 	}
 
-	if req.OriginalUrl == "" {
-		logger.Log.Error("original_url is required")
-		return nil, status.Error(codes.InvalidArgument, "original_url is required")
-	}
-
-	if _, err := url.Parse(req.OriginalUrl); err != nil {
-		logger.Log.Error("invalid URL format", zap.Error(err))
-		return nil, status.Error(codes.InvalidArgument, "invalid URL format")
-	}
-
 	existingLink, err := s.repo.GetLinkByID(ctx, req.Id)
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
@@ -265,12 +454,39 @@ func (s *GRPCServer) UpdateLink(ctx context.Context, req *pb.UpdateLinkRequest)
 		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to get link: %v", err))
 	}
 
-	if req.CustomSlug != "" && req.CustomSlug != existingLink.CustomSlug {
-		existingSlugLink, err := s.repo.GetLinkByCustomSlug(ctx, req.CustomSlug)
-		if err == nil && existingSlugLink != nil && existingSlugLink.ID != req.Id {
-			logger.Log.Error("custom slug already in use", zap.String("custom_slug", req.CustomSlug))
-			return nil, status.Error(codes.AlreadyExists, "custom slug already in use")
+	// Kept variants follow the original URL, which is the URL of the first one.
+	variants := append([]repository.Variant(nil), existingLink.Variants...)
+	if req.Variants != nil {
+		if variants, err = destinationVariants(req.Variants.Variants); err != nil {
+			return nil, err
 		}
+	} else if len(variants) > 0 && req.OriginalUrl != "" {
+		variants[0].URL = req.OriginalUrl
+	}
+
+	originalURL, err := variantOriginalURL(req.OriginalUrl, variants)
+	if err != nil {
+		return nil, err
+	}
+	if originalURL == "" {
+		logger.Log.Error("original_url is required")
+		return nil, status.Error(codes.InvalidArgument, "original_url is required")
+	}
+
+	if _, err := url.Parse(originalURL); err != nil {
+		logger.Log.Error("invalid URL format", zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument, "invalid URL format")
+	}
+
+	stickyVariants := existingLink.StickyVariants
+	if req.StickyVariants != nil {
+		stickyVariants = *req.StickyVariants
+	}
+
+	title, tags, err := linkMetadata(req.Title, req.Tags)
+	if err != nil {
+		logger.Log.Error("invalid link metadata", zap.Error(err))
+		return nil, err
 	}
 
 	var expirationDate *string
@@ -300,35 +516,125 @@ func (s *GRPCServer) UpdateLink(ctx context.Context, req *pb.UpdateLinkRequest)
 		return nil, status.Error(codes.PermissionDenied, "customer_id cannot be changed")
 	}
 
+	// Slugs claimed before the policy existed, or before it last changed, stay valid
+	// as long as the link keeps them.
+	if req.CustomSlug != "" && req.CustomSlug != existingLink.CustomSlug {
+		if err := s.checkCustomSlug(req.CustomerId, req.CustomSlug); err != nil {
+			logger.Log.Error("custom slug rejected by policy", zap.String("custom_slug", req.CustomSlug), zap.Error(err))
+			return nil, err
+		}
+	}
+
+	disabled := existingLink.Disabled
+	if req.Disabled != nil {
+		disabled = *req.Disabled
+	}
+
+	utm := existingLink.UTM
+	if req.Utm != nil || req.UtmTemplateId != "" {
+		if utm, err = s.linkUTM(ctx, req.CustomerId, req.Utm, req.UtmTemplateId); err != nil {
+			return nil, err
+		}
+	}
+
+	passwordHash := existingLink.PasswordHash
+	if req.Password != nil {
+		if passwordHash, err = linkPasswordHash(*req.Password); err != nil {
+			return nil, err
+		}
+	}
+
+	maxClicks := existingLink.MaxClicks
+	if req.MaxClicks != nil || req.OneTime != nil {
+		if maxClicks, err = clickLimit(req.MaxClicks, req.GetOneTime()); err != nil {
+			return nil, err
+		}
+	}
+
+	activatesAt := existingLink.ActivatesAt
+	if req.ActivatesAt != nil {
+		if activatesAt, err = activationDate(*req.ActivatesAt, existingLink.ActivatesAt); err != nil {
+			return nil, err
+		}
+	}
+	if err := checkActivationWindow(activatesAt, expirationDate); err != nil {
+		return nil, err
+	}
+
+	fallback := existingLink.FallbackURL
+	if req.FallbackUrl != nil {
+		if fallback, err = fallbackURL(*req.FallbackUrl); err != nil {
+			return nil, err
+		}
+	}
+
+	rules := existingLink.Rules
+	if req.Rules != nil {
+		if rules, err = redirectRules(req.Rules.Rules); err != nil {
+			return nil, err
+		}
+	}
+
 	updatedLink := repository.Link{
 		ID:             req.Id,
 		ShortURL:       existingLink.ShortURL,
-		OriginalURL:    req.OriginalUrl,
+		OriginalURL:    originalURL,
 		CustomSlug:     req.CustomSlug,
 		CustomerID:     req.CustomerId,
 		Clicks:         existingLink.Clicks,
 		CreatedAt:      existingLink.CreatedAt,
 		ExpirationDate: expirationDate,
+		Disabled:       disabled,
+		ActivatesAt:    activatesAt,
+		FallbackURL:    fallback,
+		Rules:          rules,
+		Variants:       variants,
+		StickyVariants: stickyVariants && len(variants) > 0,
+		Title:          title,
+		Tags:           tags,
+		UTM:            utm,
+		PasswordHash:   passwordHash,
+		MaxClicks:      maxClicks,
 	}
 
 	result, err := s.repo.UpdateLink(ctx, updatedLink)
 	if err != nil {
+		if strings.Contains(err.Error(), "already exists") {
+			logger.Log.Error("custom slug already in use", zap.String("custom_slug", req.CustomSlug))
+			return nil, status.Error(codes.AlreadyExists, "custom slug already in use")
+		}
+		if strings.Contains(err.Error(), "not found") {
+			logger.Log.Error("link not found", zap.String("link_id", req.Id))
+			return nil, status.Error(codes.NotFound, "link not found")
+		}
 		logger.Log.Error("failed to update link", zap.Error(err))
 		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to update link: %v", err))
 	}
 
 	logger.Log.Info("link updated successfully", zap.String("link_id", result.ID))
-	baseURL := utils.ConfigInstance.FrontendSource
 	return &pb.UpdateLinkResponse{
-		Id:             result.ID,
-		OriginalUrl:    result.OriginalURL,
-		ShortUrl:       baseURL + "/" + result.ShortURL,
-		CustomSlug:     result.CustomSlug,
-		Clicks:         result.Clicks,
-		CreatedAt:      result.CreatedAt,
-		UpdatedAt:      result.UpdatedAt,
-		CustomerId:     result.CustomerID,
-		ExpirationDate: result.ExpirationDate,
+		Id:                result.ID,
+		OriginalUrl:       result.OriginalURL,
+		ShortUrl:          utils.ShortURL(result.Domain, result.Slug()),
+		CustomSlug:        result.CustomSlug,
+		Clicks:            result.Clicks,
+		CreatedAt:         result.CreatedAt,
+		UpdatedAt:         result.UpdatedAt,
+		CustomerId:        result.CustomerID,
+		ExpirationDate:    result.ExpirationDate,
+		Disabled:          result.Disabled,
+		SlugType:          result.SlugType,
+		Title:             result.Title,
+		Tags:              result.Tags,
+		Domain:            result.Domain,
+		Utm:               utmResponse(result.UTM),
+		PasswordProtected: result.PasswordHash != "",
+		MaxClicks:         optionalClicks(result.MaxClicks),
+		ActivatesAt:       result.ActivatesAt,
+		FallbackUrl:       result.FallbackURL,
+		Rules:             rulesResponse(result.Rules),
+		Variants:          variantsResponse(result.Variants),
+		StickyVariants:    result.StickyVariants,
 	}, nil
 }
 
@@ -337,14 +643,27 @@ func (s *GRPCServer) UpdateLink(ctx context.Context, req *pb.UpdateLinkRequest)
 // to update the click count. If the link is not found, it returns a NotFound error. For other
 // errors, it returns an Internal error with details.
 //
+// The click is only counted if the link is below its click limit, checked atomically with the
+// increment by the repository, so that two visitors racing for the last click of a link (such
+// as a one-time link) cannot both be let through. links-service-read calls it before
+// redirecting visitors of links with a limit, and a FailedPrecondition error is returned once
+// the limit is reached.
+//
+// When the request carries the visitor's user agent or IP address, the click is classified
+// like in RecordClick first, and clicks from bots, link previewers and headless browsers are
+// not counted, so that the unfurler of a chat app or a mail scanner cannot use up a one-time
+// link before its recipient opens it.
+//
 // Parameters:
 //   - ctx: The context for managing request deadlines and cancellations.
-//   - req: A pointer to pb.UpdateLinkClicksRequest containing the ID of the link to update.
+//   - req: A pointer to pb.UpdateLinkClicksRequest containing the ID of the link to update,
+//     and optionally the user agent and IP address of the visitor.
 //
 // Returns:
 //   - A pointer to pb.UpdateLinkClicksResponse containing the updated link details, including
 //     the ID, original URL, short URL, custom slug, click count, creation and update timestamps,
-//     customer ID, and expiration date.
+//     customer ID, and expiration date. For clicks classified as automated, only the ID and
+//     the bot category are set, and nothing is counted.
 //   - An error if the operation fails, with appropriate gRPC status codes.
 func (s *GRPCServer) UpdateLinkClicks(ctx context.Context, req *pb.UpdateLinkClicksRequest) (*pb.UpdateLinkClicksResponse, error) {
 	if req.Id == "" {
@@ -352,44 +671,304 @@ func (s *GRPCServer) UpdateLinkClicks(ctx context.Context, req *pb.UpdateLinkCli
 		return nil, status.Error(codes.InvalidArgument, "id is required")
 	}
 
+	if req.UserAgent != "" || req.IpAddress != "" {
+		classification := s.bots.Classify(req.UserAgent, s.asn.LookupASN(req.IpAddress))
+		if classification.IsBot() {
+			logger.Log.Info("automated click not counted",
+				zap.String("link_id", req.Id),
+				zap.String("category", classification.Category),
+				zap.String("reason", classification.Reason),
+			)
+			return &pb.UpdateLinkClicksResponse{Id: req.Id, BotCategory: &classification.Category}, nil
+		}
+	}
+
 	updatedLink, err := s.repo.UpdateLinkClicks(ctx, req.Id)
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			logger.Log.Error("link not found", zap.String("link_id", req.Id))
 			return nil, status.Error(codes.NotFound, "link not found")
 		}
+		if strings.Contains(err.Error(), "click limit reached") {
+			logger.Log.Info("link click limit reached", zap.String("link_id", req.Id))
+			return nil, status.Error(codes.FailedPrecondition, "link has reached its click limit")
+		}
 		logger.Log.Error("failed to update link clicks", zap.Error(err))
 		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to update link clicks: %v", err))
 	}
 
 	logger.Log.Info("link clicks updated successfully", zap.String("link_id", updatedLink.ID))
-	baseURL := utils.ConfigInstance.FrontendSource
 	return &pb.UpdateLinkClicksResponse{
 		Id:             updatedLink.ID,
 		OriginalUrl:    updatedLink.OriginalURL,
-		ShortUrl:       baseURL + "/" + updatedLink.ShortURL,
+		ShortUrl:       utils.ShortURL(updatedLink.Domain, updatedLink.Slug()),
 		CustomSlug:     updatedLink.CustomSlug,
 		Clicks:         updatedLink.Clicks,
 		CreatedAt:      updatedLink.CreatedAt,
 		UpdatedAt:      updatedLink.UpdatedAt,
 		CustomerId:     updatedLink.CustomerID,
 		ExpirationDate: updatedLink.ExpirationDate,
+		MaxClicks:      optionalClicks(updatedLink.MaxClicks),
 	}, nil
 }
 
+// RecordClick stores a click event for the link identified by the request's link ID,
+// capturing when, where from and with what client the visit happened. The link's
+// click counter is incremented in the same transaction as the event is stored.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - req: A pointer to pb.RecordClickRequest containing the link ID and the request
+//     metadata of the visit (referrer, user agent, IP address and accept-language).
+//
+// Returns:
+//   - A pointer to pb.RecordClickResponse containing the stored event ID and timestamp.
+//   - An error if the operation fails, with appropriate gRPC status codes.
+//
+// Notes:
+//   - The IP address is geolocated against the local GeoIP database, then discarded;
+//     only its keyed hash (see utils.HashIP) and the resolved location are kept.
+//   - Event IDs start with the RFC3339Nano timestamp so events sort chronologically per link.
+//   - Clicks from bots, link previewers and headless browsers are stored apart from human
+//     clicks and do not increment the link's click counter (see botfilter.Classifier).
+//   - Human clicks are also added to the link's unique visitor counters. Counting is best
+//     effort: a Redis failure is logged and does not fail the request.
+//   - Every recorded click is then published for live dashboards (see clickstream.Publisher),
+//     on the same best-effort basis.
+//   - Clicks marked as counted were already counted by UpdateLinkClicks; they are stored and
+//     added to the rollups without incrementing the link's counters again.
+//   - Hits on inactive links, which carry the status of the link ("expired", "disabled" or
+//     "scheduled"), are stored and added to the "inactive" rollup counters only (see
+//     repository.ClickEvent.LinkStatus). They are neither counted as unique visitors nor
+//     published for live dashboards.
+//   - Clicks on links with variants carry the ID of the variant the visitor was sent to,
+//     which is added to the "var:" rollup counters so that variants can be compared.
+func (s *GRPCServer) RecordClick(ctx context.Context, req *pb.RecordClickRequest) (*pb.RecordClickResponse, error) {
+	if req.LinkId == "" {
+		logger.Log.Error("link ID is required")
+		return nil, status.Error(codes.InvalidArgument, "link_id is required")
+	}
+	if req.LinkStatus != "" && !inactiveLinkStatuses[req.LinkStatus] {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid link_status %q", req.LinkStatus)
+	}
+	if req.Variant != "" && !variantIDPattern.MatchString(req.Variant) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid variant %q", req.Variant)
+	}
+
+	suffix, err := utils.GenerateRandomSlug(8)
+	if err != nil {
+		logger.Log.Error("failed to generate event ID", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to generate event ID")
+	}
+
+	location := s.geo.Lookup(req.IpAddress)
+	asn := s.asn.LookupASN(req.IpAddress)
+	classification := s.bots.Classify(req.UserAgent, asn)
+
+	now := time.Now().UTC()
+	event := repository.ClickEvent{
+		LinkID:         req.LinkId,
+		EventID:        now.Format(time.RFC3339Nano) + "#" + suffix,
+		ClickedAt:      now.Format(time.RFC3339),
+		Referrer:       req.Referrer,
+		ReferrerDomain: analytics.ReferrerDomain(req.Referrer),
+		UserAgent:      req.UserAgent,
+		DeviceClass:    analytics.DeviceClass(req.UserAgent),
+		IPHash:         utils.HashIP(req.IpAddress, utils.ConfigInstance.IPHashSecret),
+		AcceptLanguage: req.AcceptLanguage,
+		Country:        location.Country,
+		Region:         location.Region,
+		City:           location.City,
+		ASN:            asn,
+		BotCategory:    classification.Category,
+		Counted:        req.Counted,
+		LinkStatus:     req.LinkStatus,
+		Variant:        req.Variant,
+	}
+
+	recorded, err := s.repo.RecordClick(ctx, event)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			logger.Log.Error("link not found", zap.String("link_id", req.LinkId))
+			return nil, status.Error(codes.NotFound, "link not found")
+		}
+		logger.Log.Error("failed to record click", zap.Error(err))
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to record click: %v", err))
+	}
+
+	if classification.IsBot() {
+		logger.Log.Info("click classified as automated",
+			zap.String("link_id", req.LinkId),
+			zap.String("category", classification.Category),
+			zap.String("reason", classification.Reason),
+		)
+	} else if req.LinkStatus != "" {
+		logger.Log.Info("hit recorded on inactive link",
+			zap.String("link_id", req.LinkId),
+			zap.String("link_status", req.LinkStatus),
+		)
+	} else if err := s.visitors.Add(ctx, req.LinkId, req.IpAddress, req.UserAgent, now); err != nil {
+		logger.Log.Warn("failed to count unique visitor", zap.String("link_id", req.LinkId), zap.Error(err))
+	}
+
+	response := &pb.RecordClickResponse{
+		EventId:   recorded.EventID,
+		LinkId:    recorded.LinkID,
+		ClickedAt: recorded.ClickedAt,
+	}
+	if recorded.BotCategory != "" {
+		response.BotCategory = &recorded.BotCategory
+	}
+	if req.LinkStatus != "" {
+		return response, nil
+	}
+
+	notification := clickstream.Notification{
+		EventID:        recorded.EventID,
+		LinkID:         recorded.LinkID,
+		CustomerID:     recorded.CustomerID,
+		ClickedAt:      recorded.ClickedAt,
+		ReferrerDomain: recorded.ReferrerDomain,
+		DeviceClass:    recorded.DeviceClass,
+		Country:        recorded.Country,
+		Region:         recorded.Region,
+		City:           recorded.City,
+		BotCategory:    recorded.BotCategory,
+	}
+	if err := s.clicks.Publish(ctx, notification); err != nil {
+		logger.Log.Warn("failed to publish click", zap.String("link_id", req.LinkId), zap.Error(err))
+	}
+	return response, nil
+}
+
+// checkCustomSlug validates a custom slug claimed by a customer against the slug policy.
+//
+// Parameters:
+//   - customerID: The customer claiming the slug.
+//   - slug: The requested custom slug.
+//
+// Returns:
+//   - nil if the slug is allowed.
+//   - An InvalidArgument status error otherwise, carrying a BadRequest detail with one
+//     violation of the custom_slug field per broken rule, each with its reason.
+func (s *GRPCServer) checkCustomSlug(customerID, slug string) error {
+	violations := s.policy.Check(customerID, slug)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	badRequest := &errdetails.BadRequest{}
+	for _, violation := range violations {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       "custom_slug",
+			Description: violation.Description,
+			Reason:      violation.Reason,
+		})
+	}
+
+	st := status.New(codes.InvalidArgument, "custom slug violates the slug policy")
+	detailed, err := st.WithDetails(badRequest)
+	if err != nil {
+		logger.Log.Error("failed to attach slug policy violations", zap.Error(err))
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// linkMetadata validates the optional title and tags of a link, which are only used to
+// find links again through search. Surrounding whitespace is trimmed, and empty tags and
+// tags repeating an earlier one (ignoring case) are dropped.
+//
+// Parameters:
+//   - title: The requested title.
+//   - tags: The requested tags.
+//
+// Returns:
+//   - The cleaned up title and tags.
+//   - An InvalidArgument status error if the title or a tag is too long, or if there are too many tags.
+func linkMetadata(title string, tags []string) (string, []string, error) {
+	title = strings.TrimSpace(title)
+	if utf8.RuneCountInString(title) > maxTitleLength {
+		return "", nil, status.Errorf(codes.InvalidArgument, "title must be at most %d characters", maxTitleLength)
+	}
+
+	var cleaned []string
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[strings.ToLower(tag)] {
+			continue
+		}
+		if utf8.RuneCountInString(tag) > maxTagLength {
+			return "", nil, status.Errorf(codes.InvalidArgument, "tags must be at most %d characters", maxTagLength)
+		}
+		seen[strings.ToLower(tag)] = true
+		cleaned = append(cleaned, tag)
+	}
+	if len(cleaned) > maxTags {
+		return "", nil, status.Errorf(codes.InvalidArgument, "a link can have at most %d tags", maxTags)
+	}
+
+	return title, cleaned, nil
+}
+
+// clickLimit validates the click limit of a link (see repository.Link.MaxClicks). A
+// one-time link is a link with a limit of 1.
+//
+// Parameters:
+//   - maxClicks: The requested limit, nil or 0 for none.
+//   - oneTime: Whether the link is one-time.
+//
+// Returns:
+//   - The click limit of the link, 0 if unlimited.
+//   - An InvalidArgument status error if the limit is negative, or contradicts oneTime.
+func clickLimit(maxClicks *int32, oneTime bool) (int32, error) {
+	limit := int32(0)
+	if maxClicks != nil {
+		limit = *maxClicks
+	}
+	if limit < 0 {
+		return 0, status.Error(codes.InvalidArgument, "max_clicks must not be negative")
+	}
+
+	if oneTime {
+		if limit != 0 && limit != 1 {
+			return 0, status.Error(codes.InvalidArgument, "one-time links have a max_clicks of 1")
+		}
+		limit = 1
+	}
+	return limit, nil
+}
+
+// optionalClicks returns a click limit as an optional field, nil if unlimited.
+func optionalClicks(maxClicks int32) *int32 {
+	if maxClicks == 0 {
+		return nil
+	}
+	return &maxClicks
+}
+
 // StartGRPCServer starts a gRPC server on the specified port and registers the LinksServiceWriteServer.
 // It also enables server reflection for tools like grpcurl.
 //
 // Parameters:
 //   - port: The port on which the gRPC server will listen.
-//   - repo: A pointer to the LinksRepository, which provides the necessary data operations.
+//   - repo: The LinkStore, which provides the necessary data operations.
+//   - slugs: A pointer to the generator of the short codes of links without a custom slug.
+//   - policy: A pointer to the policy custom slugs must follow.
+//   - geo: A pointer to the GeoIP resolver used to geolocate recorded clicks.
+//   - asn: A pointer to the GeoIP ASN resolver used to spot clicks from hosting networks.
+//   - bots: A pointer to the classifier that separates automated clicks from human ones.
+//   - owners: A pointer to the verifier checking the DNS records of branded domains.
+//   - visitors: A pointer to the counter of unique visitors per link.
+//   - clicks: A pointer to the publisher announcing recorded clicks to live dashboards.
 //
 // Returns:
 //   - error: An error if the server fails to start or encounters an issue.
 //
 // This function sets up a TCP listener, initializes a gRPC server, registers the LinksServiceWriteServer
 // implementation, and enables reflection for debugging and testing purposes.
-func StartGRPCServer(port string, repo *repository.LinksRepository) error {
+func StartGRPCServer(port string, repo repository.LinkStore, slugs *shortcode.Generator, policy *slugpolicy.Policy, geo *geoip.Resolver, asn *geoip.Resolver, bots *botfilter.Classifier, owners *domains.Verifier, visitors *visitors.Counter, clicks *clickstream.Publisher) error {
 	lis, err := net.Listen("tcp", ":"+port)
 	if err != nil {
 		logger.Log.Error("failed to listen", zap.Error(err))
@@ -397,7 +976,7 @@ func StartGRPCServer(port string, repo *repository.LinksRepository) error {
 	}
 
 	server := grpc.NewServer()
-	pb.RegisterLinksServiceWriteServer(server, NewGRPCServer(repo))
+	pb.RegisterLinksServiceWriteServer(server, NewGRPCServer(repo, slugs, policy, geo, asn, bots, owners, visitors, clicks))
 
 	// Habilitar reflection para ferramentas como grpcurl
 	reflection.Register(server)
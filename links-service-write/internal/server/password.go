@@ -0,0 +1,43 @@
+package server
+
+import (
+	"links-service-write/internal/logger"
+	"links-service-write/utils"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// minLinkPasswordLength is the minimum length of a link password, in bytes.
+	minLinkPasswordLength = 4
+	// maxLinkPasswordLength is the maximum length of a link password, in bytes: bcrypt
+	// ignores anything past 72 bytes.
+	maxLinkPasswordLength = 72
+)
+
+// linkPasswordHash validates the password a link is protected with and hashes it with
+// bcrypt. Only the hash is stored; links-service-read checks the passwords visitors
+// enter against it before redirecting them.
+//
+// Returns:
+//   - The bcrypt hash of the password, or "" if password is "" (the link is not protected).
+//   - An InvalidArgument status error if the password is too short or too long, or an
+//     Internal one if it cannot be hashed.
+func linkPasswordHash(password string) (string, error) {
+	if password == "" {
+		return "", nil
+	}
+	if len(password) < minLinkPasswordLength || len(password) > maxLinkPasswordLength {
+		return "", status.Errorf(codes.InvalidArgument, "password must be between %d and %d bytes",
+			minLinkPasswordLength, maxLinkPasswordLength)
+	}
+
+	hash, err := utils.HashPassword(password)
+	if err != nil {
+		logger.Log.Error("failed to hash link password", zap.Error(err))
+		return "", status.Error(codes.Internal, "failed to hash password")
+	}
+	return hash, nil
+}
@@ -0,0 +1,178 @@
+package server
+
+import (
+	"links-service-write/internal/infra/repository"
+	pb "links-service-write/proto"
+	"slices"
+	"strings"
+	"time"
+	_ "time/tzdata" // rule time zones must load on images without zoneinfo
+
+	"golang.org/x/text/language"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxRedirectRules is the maximum number of redirect rules of a link.
+	maxRedirectRules = 10
+	// maxRuleValues is the maximum number of values of each condition of a redirect rule.
+	maxRuleValues = 100
+	// ruleTimeLayout is the layout of the time window of redirect rules.
+	ruleTimeLayout = "15:04"
+)
+
+// ruleDevices and ruleDays are the values accepted in the devices and days of redirect rules.
+var (
+	ruleDevices = []string{repository.DeviceIOS, repository.DeviceAndroid, repository.DeviceDesktop}
+	ruleDays    = []string{"mon", "tue", "wed", "thu", "fri", "sat", "sun"}
+)
+
+// redirectRules validates the redirect rules of a link (see repository.RedirectRule),
+// which links-service-read evaluates in order on every redirect to pick the destination
+// of each visitor, the original URL being the default.
+//
+// Returns:
+//   - The rules, with their values trimmed, deduplicated and in their canonical case, nil
+//     if there are none.
+//   - An InvalidArgument status error if there are more than maxRedirectRules, or one of
+//     them is invalid: its destination is not an absolute HTTP or HTTPS URL, it has no
+//     condition, or a condition has an unknown device, country code, language, day, time
+//     or time zone.
+func redirectRules(rules []*pb.RedirectRule) ([]repository.RedirectRule, error) {
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	if len(rules) > maxRedirectRules {
+		return nil, status.Errorf(codes.InvalidArgument, "a link can have at most %d rules", maxRedirectRules)
+	}
+
+	result := make([]repository.RedirectRule, len(rules))
+	for i, rule := range rules {
+		r, err := redirectRule(rule)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "rules[%d]: %s", i, status.Convert(err).Message())
+		}
+		result[i] = r
+	}
+	return result, nil
+}
+
+// redirectRule validates a single redirect rule, see redirectRules.
+func redirectRule(rule *pb.RedirectRule) (repository.RedirectRule, error) {
+	var r repository.RedirectRule
+	var err error
+
+	if r.Destination, err = absoluteURL("destination", rule.GetDestination()); err != nil {
+		return r, err
+	}
+	if r.Destination == "" {
+		return r, status.Error(codes.InvalidArgument, "destination is required")
+	}
+
+	if r.Devices, err = ruleValues("devices", rule.Devices, strings.ToLower, func(device string) bool {
+		return slices.Contains(ruleDevices, device)
+	}); err != nil {
+		return r, err
+	}
+	if r.Countries, err = ruleValues("countries", rule.Countries, strings.ToUpper, validCountry); err != nil {
+		return r, err
+	}
+	if r.Languages, err = ruleValues("languages", rule.Languages, strings.ToLower, func(lang string) bool {
+		_, err := language.Parse(lang)
+		return err == nil
+	}); err != nil {
+		return r, err
+	}
+	if r.Days, err = ruleValues("days", rule.Days, strings.ToLower, func(day string) bool {
+		return slices.Contains(ruleDays, day)
+	}); err != nil {
+		return r, err
+	}
+
+	r.StartTime = strings.TrimSpace(rule.GetStartTime())
+	r.EndTime = strings.TrimSpace(rule.GetEndTime())
+	if (r.StartTime == "") != (r.EndTime == "") {
+		return r, status.Error(codes.InvalidArgument, "start_time and end_time must be set together")
+	}
+	if r.StartTime != "" {
+		start, err := time.Parse(ruleTimeLayout, r.StartTime)
+		if err != nil {
+			return r, status.Error(codes.InvalidArgument, "invalid start_time format. Use HH:MM (e.g., 09:00)")
+		}
+		end, err := time.Parse(ruleTimeLayout, r.EndTime)
+		if err != nil {
+			return r, status.Error(codes.InvalidArgument, "invalid end_time format. Use HH:MM (e.g., 18:00)")
+		}
+		if start.Equal(end) {
+			return r, status.Error(codes.InvalidArgument, "start_time and end_time must differ")
+		}
+		r.StartTime, r.EndTime = start.Format(ruleTimeLayout), end.Format(ruleTimeLayout)
+	}
+
+	r.Timezone = strings.TrimSpace(rule.GetTimezone())
+	if r.Timezone != "" {
+		if len(r.Days) == 0 && r.StartTime == "" {
+			return r, status.Error(codes.InvalidArgument, "timezone requires days or a time window")
+		}
+		if _, err := time.LoadLocation(r.Timezone); err != nil {
+			return r, status.Errorf(codes.InvalidArgument, "unknown timezone %q", r.Timezone)
+		}
+	}
+
+	if len(r.Devices) == 0 && len(r.Countries) == 0 && len(r.Languages) == 0 && len(r.Days) == 0 && r.StartTime == "" {
+		return r, status.Error(codes.InvalidArgument, "a rule needs at least one condition")
+	}
+	return r, nil
+}
+
+// ruleValues trims, normalizes and deduplicates the values of a condition of a redirect
+// rule, keeping their order.
+//
+// Returns:
+//   - The values, nil if there are none.
+//   - An InvalidArgument status error if there are more than maxRuleValues, or one of
+//     them is empty or not valid.
+func ruleValues(field string, values []string, normalize func(string) string, valid func(string) bool) ([]string, error) {
+	if len(values) > maxRuleValues {
+		return nil, status.Errorf(codes.InvalidArgument, "%s can have at most %d values", field, maxRuleValues)
+	}
+
+	var result []string
+	for _, value := range values {
+		value = normalize(strings.TrimSpace(value))
+		if value == "" || !valid(value) {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid %s value %q", field, value)
+		}
+		if !slices.Contains(result, value) {
+			result = append(result, value)
+		}
+	}
+	return result, nil
+}
+
+// validCountry reports whether code looks like an upper case ISO 3166-1 alpha-2 code.
+func validCountry(code string) bool {
+	return len(code) == 2 && code[0] >= 'A' && code[0] <= 'Z' && code[1] >= 'A' && code[1] <= 'Z'
+}
+
+// rulesResponse converts the redirect rules of a link into their gRPC representation.
+func rulesResponse(rules []repository.RedirectRule) []*pb.RedirectRule {
+	if len(rules) == 0 {
+		return nil
+	}
+	result := make([]*pb.RedirectRule, len(rules))
+	for i, rule := range rules {
+		result[i] = &pb.RedirectRule{
+			Destination: rule.Destination,
+			Devices:     rule.Devices,
+			Countries:   rule.Countries,
+			Languages:   rule.Languages,
+			Days:        rule.Days,
+			StartTime:   rule.StartTime,
+			EndTime:     rule.EndTime,
+			Timezone:    rule.Timezone,
+		}
+	}
+	return result
+}
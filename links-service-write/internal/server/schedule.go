@@ -0,0 +1,100 @@
+package server
+
+import (
+	"links-service-write/internal/logger"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxURLLength is the maximum length, in bytes, of the URLs a link sends visitors to
+// besides its original URL: its fallback URL, and the destinations of its redirect rules
+// and variants.
+const maxURLLength = 2048
+
+// activationDate validates the date a link becomes active (see repository.Link.ActivatesAt).
+// Until then, links-service-read does not redirect its visitors to the original URL, but
+// to its fallback URL if it has one.
+//
+// Parameters:
+//   - activatesAt: The requested activation date, in RFC3339 format, "" for none.
+//   - current: The current activation date of the link, nil for a new link. Keeping it
+//     is allowed even once it has passed.
+//
+// Returns:
+//   - The activation date, nil if the link is active right away.
+//   - An InvalidArgument status error if the date is not in RFC3339 format, or is a new
+//     date in the past.
+func activationDate(activatesAt string, current *string) (*string, error) {
+	if activatesAt == "" {
+		return nil, nil
+	}
+
+	activationTime, err := time.Parse(time.RFC3339, activatesAt)
+	if err != nil {
+		logger.Log.Error("invalid activation date format", zap.Error(err))
+		return nil, status.Error(codes.InvalidArgument,
+			"invalid activation date format. Use RFC3339 format (e.g., 2024-12-31T23:59:59Z)")
+	}
+	if (current == nil || *current != activatesAt) && activationTime.Before(time.Now()) {
+		logger.Log.Error("activation date must be in the future", zap.String("activates_at", activatesAt))
+		return nil, status.Error(codes.InvalidArgument, "activation date must be in the future")
+	}
+	return &activatesAt, nil
+}
+
+// checkActivationWindow ensures a link becomes active before it expires, both dates
+// having been validated already.
+//
+// Returns:
+//   - An InvalidArgument status error if the link would expire before it is active.
+func checkActivationWindow(activatesAt, expirationDate *string) error {
+	if activatesAt == nil || expirationDate == nil || *expirationDate == "" {
+		return nil
+	}
+
+	activationTime, _ := time.Parse(time.RFC3339, *activatesAt)
+	expirationTime, _ := time.Parse(time.RFC3339, *expirationDate)
+	if !activationTime.Before(expirationTime) {
+		return status.Error(codes.InvalidArgument, "activation date must be before the expiration date")
+	}
+	return nil
+}
+
+// fallbackURL validates the URL visitors of a link are sent to while it is not active
+// (see repository.Link.FallbackURL and repository.LinkSettings).
+//
+// Returns:
+//   - The fallback URL with surrounding whitespace trimmed, "" for none.
+//   - An InvalidArgument status error if it is too long, or is not an absolute HTTP or
+//     HTTPS URL.
+func fallbackURL(value string) (string, error) {
+	return absoluteURL("fallback_url", value)
+}
+
+// absoluteURL validates a URL a link sends visitors to besides its original URL, in the
+// given request field.
+//
+// Returns:
+//   - The URL with surrounding whitespace trimmed, "" if it is empty.
+//   - An InvalidArgument status error if it is longer than maxURLLength, or is not an
+//     absolute HTTP or HTTPS URL.
+func absoluteURL(field, value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", nil
+	}
+	if len(value) > maxURLLength {
+		return "", status.Errorf(codes.InvalidArgument, "%s must be at most %d bytes", field, maxURLLength)
+	}
+
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", status.Errorf(codes.InvalidArgument, "%s must be an absolute http or https URL", field)
+	}
+	return value, nil
+}
@@ -0,0 +1,110 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"links-service-write/internal/infra/repository"
+	"links-service-write/internal/logger"
+	pb "links-service-write/proto"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetCustomerLinkSettings returns the settings a customer applies to all of their links.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - req: A pointer to pb.GetCustomerLinkSettingsRequest containing the customer ID.
+//
+// Returns:
+//   - A pointer to pb.CustomerLinkSettingsResponse with the settings, the defaults if the
+//     customer never saved any.
+//   - An error if the customer ID is missing (InvalidArgument) or the repository fails (Internal).
+func (s *GRPCServer) GetCustomerLinkSettings(ctx context.Context, req *pb.GetCustomerLinkSettingsRequest) (*pb.CustomerLinkSettingsResponse, error) {
+	if req.CustomerId == "" {
+		logger.Log.Error("customer ID is required")
+		return nil, status.Error(codes.InvalidArgument, "customer_id is required")
+	}
+
+	settings, err := s.repo.GetLinkSettings(ctx, req.CustomerId)
+	if err != nil {
+		logger.Log.Error("failed to get link settings", zap.Error(err))
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to get link settings: %v", err))
+	}
+	return linkSettingsResponse(settings), nil
+}
+
+// UpdateCustomerLinkSettings replaces the settings a customer applies to all of their
+// links.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - req: A pointer to pb.UpdateCustomerLinkSettingsRequest containing the customer ID,
+//     the fallback URL and the fallback mode.
+//
+// Returns:
+//   - A pointer to pb.CustomerLinkSettingsResponse describing the stored settings.
+//   - An error if the operation fails, with appropriate gRPC status codes:
+//   - codes.InvalidArgument: If the customer ID is missing, the fallback URL is invalid
+//     (see fallbackURL) or the fallback mode is neither "redirect" nor "page".
+//   - codes.Internal: If the repository fails.
+//
+// Notes:
+//   - The fallback URL is used for the customer's links that are expired, at their click
+//     limit, disabled or not active yet and have no fallback URL of their own. An empty
+//     one removes it.
+//   - The fallback mode decides how links-service-read answers their visitors when there
+//     is a fallback URL: "redirect" (the default) sends them to it, and "page" answers
+//     with a page saying the link is not available, which links to it.
+func (s *GRPCServer) UpdateCustomerLinkSettings(ctx context.Context, req *pb.UpdateCustomerLinkSettingsRequest) (*pb.CustomerLinkSettingsResponse, error) {
+	if req.CustomerId == "" {
+		logger.Log.Error("customer ID is required")
+		return nil, status.Error(codes.InvalidArgument, "customer_id is required")
+	}
+
+	fallback, err := fallbackURL(req.FallbackUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	mode := req.FallbackMode
+	if mode == "" {
+		mode = repository.FallbackRedirect
+	}
+	if mode != repository.FallbackRedirect && mode != repository.FallbackPage {
+		return nil, status.Errorf(codes.InvalidArgument, "fallback_mode must be %q or %q",
+			repository.FallbackRedirect, repository.FallbackPage)
+	}
+
+	settings, err := s.repo.PutLinkSettings(ctx, repository.LinkSettings{
+		CustomerID:   req.CustomerId,
+		FallbackURL:  fallback,
+		FallbackMode: mode,
+		UpdatedAt:    time.Now().UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		logger.Log.Error("failed to update link settings", zap.Error(err))
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to update link settings: %v", err))
+	}
+
+	logger.Log.Info("link settings updated successfully", zap.String("customer_id", req.CustomerId))
+	return linkSettingsResponse(settings), nil
+}
+
+// linkSettingsResponse converts LinkSettings into their gRPC representation, with the
+// default fallback mode filled in.
+func linkSettingsResponse(settings *repository.LinkSettings) *pb.CustomerLinkSettingsResponse {
+	mode := settings.FallbackMode
+	if mode == "" {
+		mode = repository.FallbackRedirect
+	}
+	return &pb.CustomerLinkSettingsResponse{
+		CustomerId:   settings.CustomerID,
+		FallbackUrl:  settings.FallbackURL,
+		FallbackMode: mode,
+		UpdatedAt:    settings.UpdatedAt,
+	}
+}
@@ -0,0 +1,248 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"links-service-write/internal/infra/repository"
+	"links-service-write/internal/logger"
+	pb "links-service-write/proto"
+	"links-service-write/utils"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxUTMValueLength is the maximum length of a UTM parameter, in characters.
+	maxUTMValueLength = 100
+	// maxUTMTemplateNameLength is the maximum length of a UTM template name, in characters.
+	maxUTMTemplateNameLength = 64
+	// utmTemplateIDLength is the length of the IDs of UTM templates.
+	utmTemplateIDLength = 10
+)
+
+// CreateUTMTemplate stores a named set of UTM parameters for a customer, which links can
+// then be created or updated with through their utm_template_id.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - req: A pointer to pb.CreateUTMTemplateRequest containing the customer ID, the name
+//     of the template and its UTM parameters.
+//
+// Returns:
+//   - A pointer to pb.UTMTemplateResponse describing the stored template.
+//   - An error if the operation fails, with appropriate gRPC status codes:
+//   - codes.InvalidArgument: If the customer ID or name is missing, the name is too long,
+//     no UTM parameter is set or one is invalid (see utmParams).
+//   - codes.Internal: If the ID cannot be generated or the repository fails.
+func (s *GRPCServer) CreateUTMTemplate(ctx context.Context, req *pb.CreateUTMTemplateRequest) (*pb.UTMTemplateResponse, error) {
+	if req.CustomerId == "" {
+		logger.Log.Error("customer ID is required")
+		return nil, status.Error(codes.InvalidArgument, "customer_id is required")
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	if utf8.RuneCountInString(name) > maxUTMTemplateNameLength {
+		return nil, status.Errorf(codes.InvalidArgument, "name must be at most %d characters", maxUTMTemplateNameLength)
+	}
+
+	utm, err := utmParams(req.Utm)
+	if err != nil {
+		logger.Log.Error("invalid utm parameters", zap.Error(err))
+		return nil, err
+	}
+	if utm.IsZero() {
+		return nil, status.Error(codes.InvalidArgument, "a utm template needs at least one parameter")
+	}
+
+	id, err := utils.GenerateRandomSlug(utmTemplateIDLength)
+	if err != nil {
+		logger.Log.Error("failed to generate ID", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to generate ID")
+	}
+
+	template, err := s.repo.CreateUTMTemplate(ctx, repository.UTMTemplate{
+		ID:         id,
+		CustomerID: req.CustomerId,
+		Name:       name,
+		UTM:        utm,
+		CreatedAt:  time.Now().UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		logger.Log.Error("failed to create utm template", zap.Error(err))
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to create utm template: %v", err))
+	}
+
+	logger.Log.Info("utm template created successfully", zap.String("id", template.ID), zap.String("customer_id", req.CustomerId))
+	return utmTemplateResponse(template), nil
+}
+
+// GetCustomerUTMTemplates lists the UTM templates of a customer.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - req: A pointer to pb.GetCustomerUTMTemplatesRequest containing the customer ID.
+//
+// Returns:
+//   - A pointer to pb.GetCustomerUTMTemplatesResponse with the templates, ordered by name.
+//   - An error if the customer ID is missing (InvalidArgument) or the repository fails (Internal).
+func (s *GRPCServer) GetCustomerUTMTemplates(ctx context.Context, req *pb.GetCustomerUTMTemplatesRequest) (*pb.GetCustomerUTMTemplatesResponse, error) {
+	if req.CustomerId == "" {
+		logger.Log.Error("customer ID is required")
+		return nil, status.Error(codes.InvalidArgument, "customer_id is required")
+	}
+
+	templates, err := s.repo.GetCustomerUTMTemplates(ctx, req.CustomerId)
+	if err != nil {
+		logger.Log.Error("failed to get customer utm templates", zap.Error(err))
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to get customer utm templates: %v", err))
+	}
+
+	response := &pb.GetCustomerUTMTemplatesResponse{}
+	for _, template := range templates {
+		response.Templates = append(response.Templates, utmTemplateResponse(template))
+	}
+	return response, nil
+}
+
+// DeleteUTMTemplate deletes one of a customer's UTM templates. Links created or updated
+// with it keep the UTM parameters they were given.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - req: A pointer to pb.DeleteUTMTemplateRequest containing the template ID and the
+//     customer ID.
+//
+// Returns:
+//   - A pointer to pb.DeleteUTMTemplateResponse indicating the success of the operation.
+//   - An error if the operation fails, with appropriate gRPC status codes:
+//   - codes.InvalidArgument: If the template ID or customer ID is missing.
+//   - codes.NotFound: If the customer has no template with this ID.
+//   - codes.Internal: If the repository fails.
+func (s *GRPCServer) DeleteUTMTemplate(ctx context.Context, req *pb.DeleteUTMTemplateRequest) (*pb.DeleteUTMTemplateResponse, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	if req.CustomerId == "" {
+		logger.Log.Error("customer ID is required")
+		return nil, status.Error(codes.InvalidArgument, "customer_id is required")
+	}
+
+	if err := s.repo.DeleteUTMTemplate(ctx, req.CustomerId, req.Id); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, status.Error(codes.NotFound, "utm template not found")
+		}
+		logger.Log.Error("failed to delete utm template", zap.Error(err))
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to delete utm template: %v", err))
+	}
+
+	logger.Log.Info("utm template deleted successfully", zap.String("id", req.Id))
+	return &pb.DeleteUTMTemplateResponse{Success: true}, nil
+}
+
+// linkUTM resolves the UTM parameters a link is created or updated with: the requested
+// parameters, with the ones left empty filled in from the customer's template if a
+// template ID is given.
+//
+// Returns:
+//   - The UTM parameters of the link, or nil if none is set.
+//   - An InvalidArgument status error if a parameter is invalid (see utmParams), a
+//     NotFound one if the customer has no template with this ID, or an Internal one if
+//     the repository fails.
+func (s *GRPCServer) linkUTM(ctx context.Context, customerID string, params *pb.UTMParams, templateID string) (*repository.UTM, error) {
+	utm, err := utmParams(params)
+	if err != nil {
+		logger.Log.Error("invalid utm parameters", zap.Error(err))
+		return nil, err
+	}
+
+	if templateID != "" {
+		template, err := s.repo.GetUTMTemplate(ctx, customerID, templateID)
+		if err != nil {
+			if strings.Contains(err.Error(), "not found") {
+				logger.Log.Error("utm template not found", zap.String("utm_template_id", templateID))
+				return nil, status.Error(codes.NotFound, "utm template not found")
+			}
+			logger.Log.Error("failed to get utm template", zap.Error(err))
+			return nil, status.Error(codes.Internal, fmt.Sprintf("failed to get utm template: %v", err))
+		}
+		utm = utm.Merge(template.UTM)
+	}
+
+	if utm.IsZero() {
+		return nil, nil
+	}
+	return &utm, nil
+}
+
+// utmParams validates and normalizes requested UTM parameters, so that the same campaign
+// is always reported under the same values: surrounding whitespace is trimmed, and the
+// source, medium and campaign are lowercased. The term and content keep their case, as
+// they often carry keywords or ad copy.
+//
+// Returns:
+//   - The normalized parameters, all empty if params is nil.
+//   - An InvalidArgument status error if a parameter is too long or holds control characters.
+func utmParams(params *pb.UTMParams) (repository.UTM, error) {
+	if params == nil {
+		return repository.UTM{}, nil
+	}
+
+	utm := repository.UTM{
+		Source:   strings.ToLower(strings.TrimSpace(params.Source)),
+		Medium:   strings.ToLower(strings.TrimSpace(params.Medium)),
+		Campaign: strings.ToLower(strings.TrimSpace(params.Campaign)),
+		Term:     strings.TrimSpace(params.Term),
+		Content:  strings.TrimSpace(params.Content),
+	}
+
+	for _, field := range []struct{ name, value string }{
+		{"utm.source", utm.Source},
+		{"utm.medium", utm.Medium},
+		{"utm.campaign", utm.Campaign},
+		{"utm.term", utm.Term},
+		{"utm.content", utm.Content},
+	} {
+		if utf8.RuneCountInString(field.value) > maxUTMValueLength {
+			return repository.UTM{}, status.Errorf(codes.InvalidArgument, "%s must be at most %d characters", field.name, maxUTMValueLength)
+		}
+		if strings.IndexFunc(field.value, unicode.IsControl) >= 0 {
+			return repository.UTM{}, status.Errorf(codes.InvalidArgument, "%s must not contain control characters", field.name)
+		}
+	}
+	return utm, nil
+}
+
+// utmResponse converts the UTM parameters of a link into their gRPC representation,
+// nil if the link has none.
+func utmResponse(utm *repository.UTM) *pb.UTMParams {
+	if utm == nil {
+		return nil
+	}
+	return &pb.UTMParams{
+		Source:   utm.Source,
+		Medium:   utm.Medium,
+		Campaign: utm.Campaign,
+		Term:     utm.Term,
+		Content:  utm.Content,
+	}
+}
+
+// utmTemplateResponse converts a UTMTemplate into its gRPC representation.
+func utmTemplateResponse(template *repository.UTMTemplate) *pb.UTMTemplateResponse {
+	return &pb.UTMTemplateResponse{
+		Id:         template.ID,
+		CustomerId: template.CustomerID,
+		Name:       template.Name,
+		Utm:        utmResponse(&template.UTM),
+		CreatedAt:  template.CreatedAt,
+	}
+}
@@ -0,0 +1,105 @@
+package server
+
+import (
+	"links-service-write/internal/infra/repository"
+	pb "links-service-write/proto"
+	"regexp"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxVariants is the maximum number of variants of a link.
+	maxVariants = 10
+	// maxVariantWeight is the maximum weight of a variant.
+	maxVariantWeight = 1000
+)
+
+// variantIDPattern matches the IDs of variants, which end up in rollup counter names and
+// cookies.
+var variantIDPattern = regexp.MustCompile(`^[a-z0-9_-]{1,32}$`)
+
+// destinationVariants validates the variants a link splits its visitors between (see
+// repository.Variant).
+//
+// Returns:
+//   - The variants, with their URLs trimmed and their IDs in lower case, nil if there are
+//     none. Variants without an ID are named after their position: "a", "b", and so on.
+//   - An InvalidArgument status error if there is a single variant or more than
+//     maxVariants, or one of them has an invalid or duplicate ID, a URL that is not an
+//     absolute HTTP or HTTPS URL, or a weight outside 1 to maxVariantWeight.
+func destinationVariants(variants []*pb.DestinationVariant) ([]repository.Variant, error) {
+	if len(variants) == 0 {
+		return nil, nil
+	}
+	if len(variants) < 2 {
+		return nil, status.Error(codes.InvalidArgument, "a link needs at least 2 variants, or none")
+	}
+	if len(variants) > maxVariants {
+		return nil, status.Errorf(codes.InvalidArgument, "a link can have at most %d variants", maxVariants)
+	}
+
+	result := make([]repository.Variant, len(variants))
+	seen := make(map[string]bool, len(variants))
+	for i, variant := range variants {
+		id := strings.ToLower(strings.TrimSpace(variant.GetId()))
+		if id == "" {
+			id = string(rune('a' + i))
+		}
+		if !variantIDPattern.MatchString(id) {
+			return nil, status.Errorf(codes.InvalidArgument,
+				"variants[%d]: id must be 1 to 32 lowercase letters, digits, '-' or '_'", i)
+		}
+		if seen[id] {
+			return nil, status.Errorf(codes.InvalidArgument, "variants[%d]: duplicate id %q", i, id)
+		}
+		seen[id] = true
+
+		u, err := absoluteURL("url", variant.GetUrl())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "variants[%d]: %s", i, status.Convert(err).Message())
+		}
+		if u == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "variants[%d]: url is required", i)
+		}
+
+		if variant.GetWeight() < 1 || variant.GetWeight() > maxVariantWeight {
+			return nil, status.Errorf(codes.InvalidArgument,
+				"variants[%d]: weight must be between 1 and %d", i, maxVariantWeight)
+		}
+
+		result[i] = repository.Variant{ID: id, URL: u, Weight: variant.GetWeight()}
+	}
+	return result, nil
+}
+
+// variantOriginalURL returns the original URL of a link with the given variants, which
+// is the URL of the first one, or else the requested original URL.
+//
+// Returns:
+//   - The original URL.
+//   - An InvalidArgument status error if an original URL is requested along with variants
+//     and differs from the URL of the first one.
+func variantOriginalURL(originalURL string, variants []repository.Variant) (string, error) {
+	if len(variants) == 0 {
+		return originalURL, nil
+	}
+	if originalURL != "" && originalURL != variants[0].URL {
+		return "", status.Error(codes.InvalidArgument, "original_url must be the url of the first variant")
+	}
+	return variants[0].URL, nil
+}
+
+// variantsResponse converts the variants of a link into their gRPC representation.
+func variantsResponse(variants []repository.Variant) []*pb.DestinationVariant {
+	if len(variants) == 0 {
+		return nil
+	}
+	result := make([]*pb.DestinationVariant, len(variants))
+	for i, variant := range variants {
+		result[i] = &pb.DestinationVariant{Id: variant.ID, Url: variant.URL, Weight: variant.Weight}
+	}
+	return result
+}
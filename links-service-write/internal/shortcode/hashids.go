@@ -0,0 +1,91 @@
+package shortcode
+
+import (
+	"fmt"
+	"links-service-write/utils"
+	"strings"
+)
+
+// Hashids encodes numbers into short codes that do not look sequential, after the
+// hashids scheme: the first character of a code, the lottery, is picked from the number
+// and, together with the salt, shuffles the alphabet the rest of the code is written
+// in. Consecutive numbers thus yield unrelated codes, and codes can be decoded back
+// into their numbers by whoever knows the salt.
+type Hashids struct {
+	salt     string
+	alphabet string
+}
+
+// NewHashids creates a Hashids encoder over the base62 alphabet shuffled by salt.
+func NewHashids(salt string) *Hashids {
+	return &Hashids{salt: salt, alphabet: shuffle(utils.Base62, salt)}
+}
+
+// Encode returns the code of n, padded to at least minLength characters.
+func (h *Hashids) Encode(n uint64, minLength int) string {
+	base := uint64(len(h.alphabet))
+	lottery := h.alphabet[n%base]
+	alphabet := shuffle(h.alphabet, string(lottery)+h.salt)
+
+	var digits []byte
+	for {
+		digits = append(digits, alphabet[n%base])
+		n /= base
+		if n == 0 {
+			break
+		}
+	}
+	// Leading zero digits pad the code without changing its value.
+	for len(digits)+1 < minLength {
+		digits = append(digits, alphabet[0])
+	}
+
+	var code strings.Builder
+	code.WriteByte(lottery)
+	for i := len(digits) - 1; i >= 0; i-- {
+		code.WriteByte(digits[i])
+	}
+	return code.String()
+}
+
+// Decode returns the number a code was encoded from.
+func (h *Hashids) Decode(code string) (uint64, error) {
+	if len(code) < 2 || strings.IndexByte(h.alphabet, code[0]) < 0 {
+		return 0, fmt.Errorf("invalid code '%s'", code)
+	}
+	alphabet := shuffle(h.alphabet, code[:1]+h.salt)
+	base := uint64(len(alphabet))
+
+	var n uint64
+	for i := 1; i < len(code); i++ {
+		digit := strings.IndexByte(alphabet, code[i])
+		if digit < 0 {
+			return 0, fmt.Errorf("invalid code '%s'", code)
+		}
+		next := n*base + uint64(digit)
+		if next/base != n {
+			return 0, fmt.Errorf("invalid code '%s'", code)
+		}
+		n = next
+	}
+	if h.alphabet[n%base] != code[0] {
+		return 0, fmt.Errorf("invalid code '%s'", code)
+	}
+	return n, nil
+}
+
+// shuffle permutes alphabet deterministically by salt; an empty salt keeps it as is.
+func shuffle(alphabet, salt string) string {
+	if salt == "" {
+		return alphabet
+	}
+	shuffled := []byte(alphabet)
+	for i, v, p := len(shuffled)-1, 0, 0; i > 0; i-- {
+		v %= len(salt)
+		p += int(salt[v])
+		j := (int(salt[v]) + v + p) % i
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+		v++
+	}
+	return string(shuffled)
+}
@@ -0,0 +1,36 @@
+package shortcode
+
+import "links-service-write/utils"
+
+const (
+	consonants = "bcdfghjklmnprstvz"
+	vowels     = "aeiou"
+)
+
+// randomCode draws a base62 code of the given length.
+func randomCode(length int) (string, error) {
+	return utils.GenerateRandomSlug(length)
+}
+
+// wordCode draws a lowercase code of the given length alternating consonants and
+// vowels, such as "bodaku", which is easy to read out and type.
+func wordCode(length int) (string, error) {
+	c, err := utils.RandomString(consonants, (length+1)/2)
+	if err != nil {
+		return "", err
+	}
+	v, err := utils.RandomString(vowels, length/2)
+	if err != nil {
+		return "", err
+	}
+
+	code := make([]byte, length)
+	for i := range code {
+		if i%2 == 0 {
+			code[i] = c[i/2]
+		} else {
+			code[i] = v[i/2]
+		}
+	}
+	return string(code), nil
+}
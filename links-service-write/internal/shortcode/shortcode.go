@@ -0,0 +1,293 @@
+package shortcode
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Generation strategies of short codes.
+const (
+	// StrategyRandom draws base62 codes uniformly at random.
+	StrategyRandom = "random"
+	// StrategyGrowing draws random codes that grow by one character every GrowEvery
+	// collisions, so a crowded code space does not make creates fail.
+	StrategyGrowing = "growing"
+	// StrategyHashids encodes an increasing counter into a non-sequential looking code,
+	// see Hashids.
+	StrategyHashids = "hashids"
+	// StrategyWords builds pronounceable codes from consonant-vowel syllables.
+	StrategyWords = "words"
+)
+
+const (
+	// MinLength and MaxLength bound the length of generated codes.
+	MinLength = 4
+	MaxLength = 32
+
+	// maxBlockedDraws caps how many blocked codes are redrawn for a single attempt.
+	maxBlockedDraws = 100
+
+	// HashidsCounter is the name of the persisted counter of the hashids strategy.
+	HashidsCounter = "hashids"
+	// counterBlock is how many counter values a Generator reserves at a time.
+	counterBlock = 100
+)
+
+//go:embed settings.json
+var defaultSettings []byte
+
+// CustomerSettings overrides the default strategy and length for one customer. Zero
+// values keep the defaults.
+type CustomerSettings struct {
+	Strategy string `json:"strategy,omitempty"`
+	Length   int    `json:"length,omitempty"`
+}
+
+// Settings is the serialized configuration of a Generator.
+type Settings struct {
+	Strategy    string                      `json:"strategy"`
+	Length      int                         `json:"length"`
+	GrowEvery   int                         `json:"grow_every"`
+	MaxAttempts int                         `json:"max_attempts"`
+	Salt        string                      `json:"salt"`
+	Customers   map[string]CustomerSettings `json:"customers"`
+	Reserved    []string                    `json:"reserved"`
+	Profanity   []string                    `json:"profanity"`
+}
+
+// Counter is the persisted counter the hashids strategy encodes, shared by every
+// instance of the service, so that codes are not issued twice across instances and
+// restarts. It is implemented by the link stores.
+type Counter interface {
+	// ReserveCounter adds n to the named counter, which starts at 0, and returns its new
+	// value: the caller owns the n values up to it, that value included.
+	ReserveCounter(ctx context.Context, name string, n uint64) (uint64, error)
+}
+
+// Generator produces candidate short codes for new links. It does not check that a code
+// is free: the caller writes the link and, when the code turns out to be taken, asks for
+// another one with the next attempt number, up to MaxAttempts. A Generator is safe for
+// concurrent use.
+type Generator struct {
+	settings  Settings
+	blocklist *Blocklist
+	hashids   *Hashids
+	counter   Counter
+
+	// mu guards the block of counter values reserved from counter: next is the next
+	// value to encode, and end the last one of the block.
+	mu   sync.Mutex
+	next uint64
+	end  uint64
+}
+
+// NewGenerator validates Settings and builds a Generator from them.
+//
+// Parameters:
+//   - settings: The strategy, lengths, blocklist and per-customer overrides to apply.
+//   - counter: The persisted counter of the hashids strategy, only required when it is
+//     used. Values are reserved from it counterBlock at a time, so the values left in a
+//     block when an instance stops are skipped rather than issued twice.
+//
+// Returns:
+//   - A pointer to the Generator.
+//   - An error if a strategy is unknown, a length is out of bounds, or the hashids
+//     strategy is used without a counter.
+func NewGenerator(settings Settings, counter Counter) (*Generator, error) {
+	if err := validate(settings.Strategy, settings.Length); err != nil {
+		return nil, err
+	}
+	hashids := settings.Strategy == StrategyHashids
+	for customerID, customer := range settings.Customers {
+		hashids = hashids || customer.Strategy == StrategyHashids
+		if customer.Strategy == "" && customer.Length == 0 {
+			continue
+		}
+		strategy, length := customer.Strategy, customer.Length
+		if strategy == "" {
+			strategy = settings.Strategy
+		}
+		if length == 0 {
+			length = settings.Length
+		}
+		if err := validate(strategy, length); err != nil {
+			return nil, fmt.Errorf("customer %s: %v", customerID, err)
+		}
+	}
+	if settings.GrowEvery <= 0 {
+		settings.GrowEvery = 1
+	}
+	if settings.MaxAttempts <= 0 {
+		settings.MaxAttempts = 1
+	}
+	if hashids && counter == nil {
+		return nil, fmt.Errorf("the %s strategy requires a counter", StrategyHashids)
+	}
+
+	return &Generator{
+		settings:  settings,
+		blocklist: NewBlocklist(settings.Reserved, settings.Profanity),
+		hashids:   NewHashids(settings.Salt),
+		counter:   counter,
+	}, nil
+}
+
+// LoadGenerator builds a Generator from the JSON settings at path. An empty path loads
+// the settings embedded in the binary, which can be used as a template for custom files.
+//
+// Parameters:
+//   - path: Location of a JSON settings file on disk, or "" for the default settings.
+//   - counter: The persisted counter of the hashids strategy, see NewGenerator.
+//
+// Returns:
+//   - A pointer to the Generator.
+//   - An error if the file cannot be read, parsed or validated.
+func LoadGenerator(path string, counter Counter) (*Generator, error) {
+	data := defaultSettings
+	if path != "" {
+		var err error
+		data, err = os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read slug settings: %v", err)
+		}
+	}
+
+	var settings Settings
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return nil, fmt.Errorf("failed to parse slug settings: %v", err)
+	}
+	return NewGenerator(settings, counter)
+}
+
+// MaxAttempts returns how many codes a caller should try before giving up on a link.
+func (g *Generator) MaxAttempts() int {
+	return g.settings.MaxAttempts
+}
+
+// Blocklist returns the reserved words and profanity generated codes never contain.
+func (g *Generator) Blocklist() *Blocklist {
+	return g.blocklist
+}
+
+// Generate returns a candidate short code for a link of the given customer. Codes that
+// hit the blocklist are redrawn.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - customerID: The customer creating the link, whose settings apply.
+//   - attempt: The number of codes already found taken for this link, starting at 0.
+//
+// Returns:
+//   - The candidate code.
+//   - An error if the random number generator or the counter fails, or no allowed code
+//     could be drawn.
+func (g *Generator) Generate(ctx context.Context, customerID string, attempt int) (string, error) {
+	strategy, length := g.settings.Strategy, g.settings.Length
+	if customer, ok := g.settings.Customers[customerID]; ok {
+		if customer.Strategy != "" {
+			strategy = customer.Strategy
+		}
+		if customer.Length != 0 {
+			length = customer.Length
+		}
+	}
+
+	for range maxBlockedDraws {
+		code, err := g.draw(ctx, strategy, length, attempt)
+		if err != nil {
+			return "", err
+		}
+		if !g.blocklist.Blocks(code) {
+			return code, nil
+		}
+	}
+	return "", fmt.Errorf("no allowed %s code of length %d after %d draws", strategy, length, maxBlockedDraws)
+}
+
+func (g *Generator) draw(ctx context.Context, strategy string, length, attempt int) (string, error) {
+	switch strategy {
+	case StrategyGrowing:
+		return randomCode(min(length+attempt/g.settings.GrowEvery, MaxLength))
+	case StrategyHashids:
+		n, err := g.nextCount(ctx)
+		if err != nil {
+			return "", err
+		}
+		return g.hashids.Encode(n, length), nil
+	case StrategyWords:
+		return wordCode(length)
+	default:
+		return randomCode(length)
+	}
+}
+
+// nextCount returns the next value of the hashids counter, reserving a new block of
+// counterBlock values from the persisted counter when the current one is used up.
+func (g *Generator) nextCount(ctx context.Context) (uint64, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.next == 0 || g.next > g.end {
+		end, err := g.counter.ReserveCounter(ctx, HashidsCounter, counterBlock)
+		if err != nil {
+			return 0, fmt.Errorf("failed to reserve counter values: %v", err)
+		}
+		g.next, g.end = end-counterBlock+1, end
+	}
+	n := g.next
+	g.next++
+	return n, nil
+}
+
+func validate(strategy string, length int) error {
+	switch strategy {
+	case StrategyRandom, StrategyGrowing, StrategyHashids, StrategyWords:
+	default:
+		return fmt.Errorf("unknown slug strategy %q", strategy)
+	}
+	if length < MinLength || length > MaxLength {
+		return fmt.Errorf("slug length %d out of range [%d, %d]", length, MinLength, MaxLength)
+	}
+	return nil
+}
+
+// Blocklist holds the words generated codes must not be or contain. Reserved words,
+// such as application routes, block codes equal to them; profanity blocks codes that
+// contain it anywhere. Matching ignores case.
+type Blocklist struct {
+	reserved  map[string]bool
+	profanity []string
+}
+
+// NewBlocklist creates a Blocklist from reserved words and profanity.
+func NewBlocklist(reserved, profanity []string) *Blocklist {
+	blocklist := &Blocklist{reserved: make(map[string]bool, len(reserved))}
+	for _, word := range reserved {
+		blocklist.reserved[strings.ToLower(word)] = true
+	}
+	for _, word := range profanity {
+		if word != "" {
+			blocklist.profanity = append(blocklist.profanity, strings.ToLower(word))
+		}
+	}
+	return blocklist
+}
+
+// Blocks reports whether code is a reserved word or contains profanity.
+func (b *Blocklist) Blocks(code string) bool {
+	code = strings.ToLower(code)
+	if b.reserved[code] {
+		return true
+	}
+	for _, word := range b.profanity {
+		if strings.Contains(code, word) {
+			return true
+		}
+	}
+	return false
+}
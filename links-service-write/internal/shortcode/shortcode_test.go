@@ -0,0 +1,221 @@
+package shortcode
+
+import (
+	"context"
+	"errors"
+	"links-service-write/utils"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// memoryCounter is an in-memory Counter.
+type memoryCounter struct {
+	values   map[string]uint64
+	reserved int
+	err      error
+}
+
+func (c *memoryCounter) ReserveCounter(_ context.Context, name string, n uint64) (uint64, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	if c.values == nil {
+		c.values = map[string]uint64{}
+	}
+	c.reserved++
+	c.values[name] += n
+	return c.values[name], nil
+}
+
+func TestDefaultGenerator(t *testing.T) {
+	generator, err := LoadGenerator("", nil)
+	require.NoError(t, err)
+	require.Equal(t, 10, generator.MaxAttempts())
+
+	for range 200 {
+		code, err := generator.Generate(context.Background(), "customer", 0)
+		require.NoError(t, err)
+		require.Len(t, code, 6)
+		require.Empty(t, strings.Trim(code, utils.Base62), "Codes are base62")
+		require.False(t, generator.Blocklist().Blocks(code))
+	}
+}
+
+func TestGeneratorStrategies(t *testing.T) {
+	t.Run("Growing codes lengthen every GrowEvery attempts", func(t *testing.T) {
+		generator, err := NewGenerator(Settings{Strategy: StrategyGrowing, Length: 6, GrowEvery: 2, MaxAttempts: 10}, nil)
+		require.NoError(t, err)
+
+		for attempt, length := range []int{6, 6, 7, 7, 8} {
+			code, err := generator.Generate(context.Background(), "customer", attempt)
+			require.NoError(t, err)
+			require.Len(t, code, length, "attempt %d", attempt)
+		}
+	})
+
+	t.Run("Hashids codes are distinct and decode to increasing counters", func(t *testing.T) {
+		counter := &memoryCounter{}
+		generator, err := NewGenerator(Settings{Strategy: StrategyHashids, Length: 6, Salt: "pepper"}, counter)
+		require.NoError(t, err)
+
+		seen := map[string]bool{}
+		var last uint64
+		for i := range 250 {
+			code, err := generator.Generate(context.Background(), "customer", 0)
+			require.NoError(t, err)
+			require.GreaterOrEqual(t, len(code), 6)
+			require.False(t, seen[code])
+			seen[code] = true
+
+			n, err := generator.hashids.Decode(code)
+			require.NoError(t, err)
+			if i > 0 {
+				require.Greater(t, n, last)
+			}
+			last = n
+		}
+		require.Equal(t, 3, counter.reserved, "Counter values are reserved a block at a time")
+	})
+
+	t.Run("Hashids codes are not issued again after a restart", func(t *testing.T) {
+		counter := &memoryCounter{}
+		settings := Settings{Strategy: StrategyHashids, Length: 6, Salt: "pepper"}
+
+		seen := map[string]bool{}
+		for range 3 {
+			generator, err := NewGenerator(settings, counter)
+			require.NoError(t, err)
+			for range 10 {
+				code, err := generator.Generate(context.Background(), "customer", 0)
+				require.NoError(t, err)
+				require.False(t, seen[code])
+				seen[code] = true
+			}
+		}
+	})
+
+	t.Run("Hashids codes need a working counter", func(t *testing.T) {
+		_, err := NewGenerator(Settings{Strategy: StrategyHashids, Length: 6}, nil)
+		require.Error(t, err)
+		_, err = NewGenerator(Settings{
+			Strategy:  StrategyRandom,
+			Length:    6,
+			Customers: map[string]CustomerSettings{"c": {Strategy: StrategyHashids}},
+		}, nil)
+		require.Error(t, err)
+
+		generator, err := NewGenerator(Settings{Strategy: StrategyHashids, Length: 6}, &memoryCounter{err: errors.New("unavailable")})
+		require.NoError(t, err)
+		_, err = generator.Generate(context.Background(), "customer", 0)
+		require.Error(t, err)
+	})
+
+	t.Run("Word codes alternate consonants and vowels", func(t *testing.T) {
+		generator, err := NewGenerator(Settings{Strategy: StrategyWords, Length: 7}, nil)
+		require.NoError(t, err)
+
+		code, err := generator.Generate(context.Background(), "customer", 0)
+		require.NoError(t, err)
+		require.Len(t, code, 7)
+		for i := range code {
+			if i%2 == 0 {
+				require.Contains(t, consonants, string(code[i]))
+			} else {
+				require.Contains(t, vowels, string(code[i]))
+			}
+		}
+	})
+
+	t.Run("Customer settings override the defaults", func(t *testing.T) {
+		generator, err := NewGenerator(Settings{
+			Strategy:  StrategyRandom,
+			Length:    6,
+			Customers: map[string]CustomerSettings{"vip": {Length: 4}, "brand": {Strategy: StrategyWords}},
+		}, nil)
+		require.NoError(t, err)
+
+		code, err := generator.Generate(context.Background(), "vip", 0)
+		require.NoError(t, err)
+		require.Len(t, code, 4)
+
+		code, err = generator.Generate(context.Background(), "brand", 0)
+		require.NoError(t, err)
+		require.Len(t, code, 6)
+		require.Equal(t, strings.ToLower(code), code)
+
+		code, err = generator.Generate(context.Background(), "other", 0)
+		require.NoError(t, err)
+		require.Len(t, code, 6)
+	})
+}
+
+func TestBlocklist(t *testing.T) {
+	blocklist := NewBlocklist([]string{"Admin"}, []string{"darn"})
+
+	require.True(t, blocklist.Blocks("admin"))
+	require.True(t, blocklist.Blocks("ADMIN"))
+	require.False(t, blocklist.Blocks("admin2"), "Reserved words only block exact matches")
+	require.True(t, blocklist.Blocks("xDaRnx"), "Profanity blocks codes containing it")
+	require.False(t, blocklist.Blocks("d4rn"))
+
+	t.Run("Blocked codes are redrawn", func(t *testing.T) {
+		generator, err := NewGenerator(Settings{Strategy: StrategyWords, Length: 4, Profanity: []string{"a", "e", "i", "o"}}, nil)
+		require.NoError(t, err)
+
+		for range 20 {
+			code, err := generator.Generate(context.Background(), "customer", 0)
+			require.NoError(t, err)
+			require.Equal(t, byte('u'), code[1])
+		}
+	})
+
+	t.Run("Generation fails when every code is blocked", func(t *testing.T) {
+		generator, err := NewGenerator(Settings{Strategy: StrategyWords, Length: 4, Profanity: []string{"a", "e", "i", "o", "u"}}, nil)
+		require.NoError(t, err)
+
+		_, err = generator.Generate(context.Background(), "customer", 0)
+		require.Error(t, err)
+	})
+}
+
+func TestHashids(t *testing.T) {
+	hashids := NewHashids("salt")
+
+	for _, n := range []uint64{0, 1, 61, 62, 12345, 1 << 40} {
+		code := hashids.Encode(n, 8)
+		require.GreaterOrEqual(t, len(code), 8)
+
+		decoded, err := hashids.Decode(code)
+		require.NoError(t, err)
+		require.Equal(t, n, decoded)
+	}
+
+	require.NotEqual(t, hashids.Encode(42, 6), NewHashids("other").Encode(42, 6), "The salt changes the codes")
+
+	_, err := hashids.Decode("!")
+	require.Error(t, err)
+}
+
+func TestLoadGenerator(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"strategy":"words","length":8,"max_attempts":3}`), 0o644))
+
+	generator, err := LoadGenerator(path, nil)
+	require.NoError(t, err)
+	require.Equal(t, 3, generator.MaxAttempts())
+
+	code, err := generator.Generate(context.Background(), "customer", 0)
+	require.NoError(t, err)
+	require.Len(t, code, 8)
+
+	_, err = NewGenerator(Settings{Strategy: "sequential", Length: 6}, nil)
+	require.Error(t, err)
+	_, err = NewGenerator(Settings{Strategy: StrategyRandom, Length: 2}, nil)
+	require.Error(t, err)
+	_, err = NewGenerator(Settings{Strategy: StrategyRandom, Length: 6, Customers: map[string]CustomerSettings{"c": {Length: 99}}}, nil)
+	require.Error(t, err)
+}
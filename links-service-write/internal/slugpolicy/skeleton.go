@@ -0,0 +1,90 @@
+package slugpolicy
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// homoglyphs maps Cyrillic and Greek letters to the Latin letters they are drawn like,
+// after the most common entries of the Unicode confusables table.
+var homoglyphs = map[rune]rune{
+	'а': 'a', 'в': 'b', 'с': 'c', 'ԁ': 'd', 'е': 'e', 'һ': 'h', 'і': 'i', 'ј': 'j',
+	'к': 'k', 'м': 'm', 'н': 'h', 'о': 'o', 'р': 'p', 'ԛ': 'q', 'ѕ': 's', 'т': 't',
+	'у': 'y', 'ԝ': 'w', 'х': 'x', 'ɡ': 'g',
+	'α': 'a', 'β': 'b', 'ε': 'e', 'η': 'n', 'ι': 'i', 'κ': 'k', 'ν': 'v', 'ο': 'o',
+	'ρ': 'p', 'τ': 't', 'υ': 'u', 'χ': 'x', 'ω': 'w',
+}
+
+// lookalikes folds ASCII characters that read alike, such as "0" and "o", into one.
+var lookalikes = map[rune]rune{
+	'0': 'o', '1': 'l', 'i': 'l', '|': 'l', '3': 'e', '4': 'a', '@': 'a', '5': 's',
+	'$': 's', '7': 't', '8': 'b',
+}
+
+// Skeleton reduces a slug to the form it is read as: compatibility characters such as
+// fullwidth letters are normalized (NFKC), letters are lowercased, homoglyphs and
+// lookalike digits are folded into one Latin letter and separators are dropped. Two
+// slugs with the same skeleton are easily mistaken for one another.
+func Skeleton(slug string) string {
+	var b strings.Builder
+	for _, r := range norm.NFKC.String(slug) {
+		r = unicode.ToLower(r)
+		if latin, ok := homoglyphs[r]; ok {
+			r = latin
+		}
+		if folded, ok := lookalikes[r]; ok {
+			r = folded
+		}
+		switch r {
+		case '-', '_', '.', ' ':
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// words splits a slug into the skeletons of its words, see Skeleton. Words are separated
+// by '-', '_', '.' or spaces, and by a lowercase letter followed by an uppercase one, so
+// that "BigDeal" is read as "big" and "deal".
+func words(slug string) []string {
+	var result []string
+	var word []rune
+	flush := func() {
+		if skeleton := Skeleton(string(word)); skeleton != "" {
+			result = append(result, skeleton)
+		}
+		word = word[:0]
+	}
+
+	prev := rune(0)
+	for _, r := range norm.NFKC.String(slug) {
+		switch {
+		case r == '-' || r == '_' || r == '.' || r == ' ':
+			flush()
+		case unicode.IsUpper(r) && unicode.IsLower(prev):
+			flush()
+			word = append(word, r)
+		default:
+			word = append(word, r)
+		}
+		prev = r
+	}
+	flush()
+	return result
+}
+
+// confusableOf returns the ASCII character r imitates, if any: the Latin letter of a
+// homoglyph, or the ASCII form of a compatibility character such as a fullwidth letter.
+func confusableOf(r rune) (rune, bool) {
+	if latin, ok := homoglyphs[unicode.ToLower(r)]; ok {
+		return latin, true
+	}
+	folded := []rune(norm.NFKC.String(string(r)))
+	if len(folded) == 1 && folded[0] < unicode.MaxASCII && folded[0] != r {
+		return folded[0], true
+	}
+	return 0, false
+}
@@ -0,0 +1,257 @@
+package slugpolicy
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// Reasons of policy violations, reported in the Reason of each violation so that
+// clients can tell them apart without parsing descriptions.
+const (
+	ReasonTooShort          = "SLUG_TOO_SHORT"
+	ReasonTooLong           = "SLUG_TOO_LONG"
+	ReasonInvalidCharacters = "SLUG_INVALID_CHARACTERS"
+	ReasonConfusable        = "SLUG_CONFUSABLE"
+	ReasonReserved          = "SLUG_RESERVED"
+	ReasonOffensive         = "SLUG_OFFENSIVE"
+	ReasonPremium           = "SLUG_PREMIUM"
+)
+
+//go:embed policy.json
+var defaultRules []byte
+
+// PremiumRule restricts slugs to customers on some plans. A slug is premium when it is
+// at most MaxLength characters long, or when it is one of Words; rules with neither
+// match no slug.
+type PremiumRule struct {
+	Name      string   `json:"name"`
+	MaxLength int      `json:"max_length"`
+	Words     []string `json:"words"`
+	Plans     []string `json:"plans"`
+}
+
+// Rules is the serialized form of the policy custom slugs must follow. Customers maps
+// customer IDs to their plan; customers not listed are on DefaultPlan.
+//
+// Offensive words match whole words of a slug, or the whole slug, so that "dick" rejects
+// "big-dick" but not "dickens". OffensiveSubstrings match anywhere in a slug, and must
+// only list words that are not part of ordinary words in any language slugs are written in.
+type Rules struct {
+	MinLength           int               `json:"min_length"`
+	MaxLength           int               `json:"max_length"`
+	Charset             string            `json:"charset"`
+	Reserved            []string          `json:"reserved"`
+	Offensive           []string          `json:"offensive"`
+	OffensiveSubstrings []string          `json:"offensive_substrings"`
+	DefaultPlan         string            `json:"default_plan"`
+	Customers           map[string]string `json:"customers"`
+	Premium             []PremiumRule     `json:"premium"`
+}
+
+// Violation describes one way a slug breaks the policy.
+type Violation struct {
+	Reason      string
+	Description string
+}
+
+type premiumMatcher struct {
+	name      string
+	maxLength int
+	words     map[string]bool
+	plans     map[string]bool
+}
+
+// Policy validates custom slugs. Reserved words, offensive words and premium words are
+// compared by skeleton, see Skeleton, so that "Log-In", "l0gin" or a Cyrillic "lоgin"
+// cannot stand in for "login". A Policy is immutable and safe for concurrent use.
+type Policy struct {
+	rules               Rules
+	charset             map[rune]bool
+	reserved            map[string]string
+	offensive           map[string]bool
+	offensiveSubstrings []string
+	premium             []premiumMatcher
+}
+
+// NewPolicy validates Rules and builds a Policy from them.
+//
+// Parameters:
+//   - rules: The length bounds, charset, word lists and premium rules to apply.
+//
+// Returns:
+//   - A pointer to the Policy.
+//   - An error if the length bounds are inconsistent, the charset is empty or a
+//     premium rule names no plan.
+func NewPolicy(rules Rules) (*Policy, error) {
+	if rules.MinLength < 1 || rules.MaxLength < rules.MinLength {
+		return nil, fmt.Errorf("invalid slug length bounds [%d, %d]", rules.MinLength, rules.MaxLength)
+	}
+	if rules.Charset == "" {
+		return nil, fmt.Errorf("slug charset is empty")
+	}
+
+	policy := &Policy{
+		rules:     rules,
+		charset:   make(map[rune]bool, len(rules.Charset)),
+		reserved:  make(map[string]string, len(rules.Reserved)),
+		offensive: make(map[string]bool, len(rules.Offensive)),
+	}
+	for _, r := range rules.Charset {
+		policy.charset[r] = true
+	}
+	for _, word := range rules.Reserved {
+		policy.reserved[Skeleton(word)] = word
+	}
+	for _, word := range rules.Offensive {
+		if skeleton := Skeleton(word); skeleton != "" {
+			policy.offensive[skeleton] = true
+		}
+	}
+	for _, word := range rules.OffensiveSubstrings {
+		if skeleton := Skeleton(word); skeleton != "" {
+			policy.offensiveSubstrings = append(policy.offensiveSubstrings, skeleton)
+		}
+	}
+	for i, rule := range rules.Premium {
+		if len(rule.Plans) == 0 {
+			return nil, fmt.Errorf("premium rule %d (%s) names no plan", i, rule.Name)
+		}
+		matcher := premiumMatcher{
+			name:      rule.Name,
+			maxLength: rule.MaxLength,
+			words:     make(map[string]bool, len(rule.Words)),
+			plans:     make(map[string]bool, len(rule.Plans)),
+		}
+		for _, word := range rule.Words {
+			matcher.words[Skeleton(word)] = true
+		}
+		for _, plan := range rule.Plans {
+			matcher.plans[plan] = true
+		}
+		policy.premium = append(policy.premium, matcher)
+	}
+
+	return policy, nil
+}
+
+// LoadPolicy builds a Policy from the JSON rules at path. An empty path loads the rules
+// embedded in the binary, which can be used as a template for custom files.
+//
+// Parameters:
+//   - path: Location of a JSON rules file on disk, or "" for the default rules.
+//
+// Returns:
+//   - A pointer to the Policy.
+//   - An error if the file cannot be read, parsed or validated.
+func LoadPolicy(path string) (*Policy, error) {
+	data := defaultRules
+	if path != "" {
+		var err error
+		data, err = os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read slug policy: %v", err)
+		}
+	}
+
+	var rules Rules
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse slug policy: %v", err)
+	}
+	return NewPolicy(rules)
+}
+
+// Check validates a custom slug claimed by a customer.
+//
+// Parameters:
+//   - customerID: The customer claiming the slug, whose plan decides on premium slugs.
+//   - slug: The custom slug to validate.
+//
+// Returns:
+//   - Every violation found, or nil if the slug is allowed.
+func (p *Policy) Check(customerID, slug string) []Violation {
+	var violations []Violation
+
+	length := utf8.RuneCountInString(slug)
+	if length < p.rules.MinLength {
+		violations = append(violations, Violation{ReasonTooShort,
+			fmt.Sprintf("slug must be at least %d characters long", p.rules.MinLength)})
+	}
+	if length > p.rules.MaxLength {
+		violations = append(violations, Violation{ReasonTooLong,
+			fmt.Sprintf("slug must be at most %d characters long", p.rules.MaxLength)})
+	}
+
+	var invalid, confusable []string
+	for _, r := range slug {
+		if p.charset[r] {
+			continue
+		}
+		if lookalike, ok := confusableOf(r); ok {
+			confusable = append(confusable, fmt.Sprintf("'%c' (%U) looks like '%c'", r, r, lookalike))
+		} else {
+			invalid = append(invalid, fmt.Sprintf("'%c' (%U)", r, r))
+		}
+	}
+	if len(confusable) > 0 {
+		violations = append(violations, Violation{ReasonConfusable,
+			"slug contains characters that imitate allowed ones: " + strings.Join(confusable, ", ")})
+	}
+	if len(invalid) > 0 {
+		violations = append(violations, Violation{ReasonInvalidCharacters,
+			"slug contains characters that are not allowed: " + strings.Join(invalid, ", ")})
+	}
+
+	skeleton := Skeleton(slug)
+	if word, ok := p.reserved[skeleton]; ok {
+		violations = append(violations, Violation{ReasonReserved,
+			fmt.Sprintf("slug is reserved: it matches '%s'", word)})
+	}
+	if p.isOffensive(slug, skeleton) {
+		violations = append(violations, Violation{ReasonOffensive, "slug contains offensive language"})
+	}
+
+	plan := p.Plan(customerID)
+	for _, rule := range p.premium {
+		if rule.plans[plan] {
+			continue
+		}
+		if (rule.maxLength > 0 && length <= rule.maxLength) || rule.words[skeleton] {
+			violations = append(violations, Violation{ReasonPremium,
+				fmt.Sprintf("slug is premium (%s) and not available on the %s plan", rule.name, plan)})
+		}
+	}
+
+	return violations
+}
+
+// isOffensive reports whether the slug, or one of its words, is an offensive word, or
+// whether its skeleton contains one of the offensive substrings.
+//
+// Notes:
+//   - Words are matched with an optional plural "s" or "es", so "dicks" is offensive but
+//     "dickens" is not.
+func (p *Policy) isOffensive(slug, skeleton string) bool {
+	for _, word := range append(words(slug), skeleton) {
+		if p.offensive[word] || p.offensive[strings.TrimSuffix(word, "s")] || p.offensive[strings.TrimSuffix(word, "es")] {
+			return true
+		}
+	}
+	for _, word := range p.offensiveSubstrings {
+		if strings.Contains(skeleton, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// Plan returns the plan of a customer.
+func (p *Policy) Plan(customerID string) string {
+	if plan, ok := p.rules.Customers[customerID]; ok {
+		return plan
+	}
+	return p.rules.DefaultPlan
+}
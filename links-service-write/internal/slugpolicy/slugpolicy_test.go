@@ -0,0 +1,127 @@
+package slugpolicy
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func reasons(violations []Violation) []string {
+	var out []string
+	for _, violation := range violations {
+		out = append(out, violation.Reason)
+	}
+	return out
+}
+
+func TestDefaultPolicy(t *testing.T) {
+	policy, err := LoadPolicy("")
+	require.NoError(t, err)
+
+	t.Run("Ordinary slugs are allowed", func(t *testing.T) {
+		require.Empty(t, policy.Check("customer", "summer-sale_2025"))
+		require.Empty(t, policy.Check("customer", "abc"))
+	})
+
+	t.Run("Length bounds", func(t *testing.T) {
+		require.Equal(t, []string{ReasonTooShort}, reasons(policy.Check("customer", "ab")))
+		require.Equal(t, []string{ReasonTooLong}, reasons(policy.Check("customer", strings.Repeat("a", 65))))
+	})
+
+	t.Run("Frontend routes are reserved, however they are spelled", func(t *testing.T) {
+		for _, slug := range []string{"login", "Dashboard", "email-verification", "emailverification", "l0gin", "LOG_IN"} {
+			require.Equal(t, []string{ReasonReserved}, reasons(policy.Check("customer", slug)), slug)
+		}
+		require.Empty(t, policy.Check("customer", "login-help-page"))
+	})
+
+	t.Run("API routes under /v1/links are reserved", func(t *testing.T) {
+		for _, slug := range []string{"live", "export", "import"} {
+			require.Equal(t, []string{ReasonReserved}, reasons(policy.Check("customer", slug)), slug)
+		}
+	})
+
+	t.Run("Characters outside the charset are rejected", func(t *testing.T) {
+		violations := policy.Check("customer", "hello world!")
+		require.Equal(t, []string{ReasonInvalidCharacters}, reasons(violations))
+		require.Contains(t, violations[0].Description, "U+0021")
+	})
+
+	t.Run("Confusable characters are named", func(t *testing.T) {
+		violations := policy.Check("customer", "lоgin") // Cyrillic о
+		require.Equal(t, []string{ReasonConfusable, ReasonReserved}, reasons(violations))
+		require.Contains(t, violations[0].Description, "looks like 'o'")
+
+		violations = policy.Check("customer", "ｐｒｏｍｏ") // Fullwidth letters
+		require.Equal(t, []string{ReasonConfusable}, reasons(violations))
+	})
+
+	t.Run("Offensive words are rejected as words of the slug", func(t *testing.T) {
+		for _, slug := range []string{"my-sh1t-deal", "MyShitDeal", "s-h-i-t", "big_dicks", "fuckyeah"} {
+			require.Equal(t, []string{ReasonOffensive}, reasons(policy.Check("customer", slug)), slug)
+		}
+	})
+
+	t.Run("Ordinary words containing offensive ones are allowed", func(t *testing.T) {
+		for _, slug := range []string{"reputation", "computador", "disputa", "computacao", "grape", "drapes", "scrape", "dickens", "scunthorpe"} {
+			require.Empty(t, policy.Check("customer", slug), slug)
+		}
+	})
+}
+
+func TestPremiumRules(t *testing.T) {
+	policy, err := NewPolicy(Rules{
+		MinLength:   1,
+		MaxLength:   64,
+		Charset:     "abcdefghijklmnopqrstuvwxyz0123456789-",
+		DefaultPlan: "free",
+		Customers:   map[string]string{"acme": "pro"},
+		Premium: []PremiumRule{
+			{Name: "short", MaxLength: 3, Plans: []string{"pro"}},
+			{Name: "words", Words: []string{"sale"}, Plans: []string{"pro"}},
+		},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, "pro", policy.Plan("acme"))
+	require.Equal(t, "free", policy.Plan("other"))
+
+	require.Equal(t, []string{ReasonPremium}, reasons(policy.Check("other", "go")))
+	require.Equal(t, []string{ReasonPremium}, reasons(policy.Check("other", "s4le")))
+	require.Empty(t, policy.Check("other", "summer"))
+	require.Empty(t, policy.Check("acme", "go"))
+	require.Empty(t, policy.Check("acme", "sale"))
+}
+
+func TestLoadPolicy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"min_length":5,"max_length":10,"charset":"abcdefghijklmnopqrstuvwxyz","reserved":["Cabba"]}`), 0o644))
+
+	policy, err := LoadPolicy(path)
+	require.NoError(t, err)
+	require.Empty(t, policy.Check("customer", "abcab"))
+	require.Equal(t, []string{ReasonReserved}, reasons(policy.Check("customer", "cabba")))
+	require.Empty(t, policy.Check("customer", "login"), "Custom rules replace the defaults")
+
+	_, err = NewPolicy(Rules{MinLength: 5, MaxLength: 4, Charset: "a"})
+	require.Error(t, err)
+	_, err = NewPolicy(Rules{MinLength: 1, MaxLength: 4})
+	require.Error(t, err)
+	_, err = NewPolicy(Rules{MinLength: 1, MaxLength: 4, Charset: "a", Premium: []PremiumRule{{Name: "x", MaxLength: 2}}})
+	require.Error(t, err)
+}
+
+func TestWords(t *testing.T) {
+	require.Equal(t, []string{Skeleton("big"), Skeleton("deal"), Skeleton("summer")}, words("BigDeal-summer"))
+	require.Empty(t, words("--"))
+}
+
+func TestSkeleton(t *testing.T) {
+	require.Equal(t, Skeleton("login"), Skeleton("L0G-1N"))
+	require.Equal(t, Skeleton("promo"), Skeleton("ｐｒｏｍｏ"))
+	require.Equal(t, Skeleton("paypal"), Skeleton("раураl"))
+	require.NotEqual(t, Skeleton("login"), Skeleton("logon"))
+}
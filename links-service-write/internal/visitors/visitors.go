@@ -0,0 +1,141 @@
+package visitors
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	// dayRetention is how long the per-day HyperLogLog of a link is kept.
+	dayRetention = 400 * 24 * time.Hour
+	// saltRetention keeps a day's salt around a little longer than the day itself, so
+	// clicks recorded around midnight on instances with skewed clocks still agree on it.
+	saltRetention = 48 * time.Hour
+)
+
+// DayKey returns the Redis key of the HyperLogLog counting the visitors of a link on the
+// UTC day containing t. It must match the keys read by links-service-read.
+func DayKey(linkID string, t time.Time) string {
+	return "visitors:" + linkID + ":day:" + t.UTC().Format("2006-01-02")
+}
+
+// TotalKey returns the Redis key of the HyperLogLog counting all visitors of a link.
+func TotalKey(linkID string) string {
+	return "visitors:" + linkID + ":total"
+}
+
+func saltKey(t time.Time) string {
+	return "visitors:salt:" + t.UTC().Format("2006-01-02")
+}
+
+// Counter records approximate unique visitors per link in Redis HyperLogLogs.
+//
+// Visitors are identified by a fingerprint hashing their IP address and user agent
+// together with a random salt that changes every UTC day. Salts live in Redis so every
+// instance agrees on them, and expire shortly after their day ends: once gone, no
+// fingerprint can be linked back to an IP address, not even by the operators. As a consequence the
+// same person visiting on two different days counts as two visitors overall.
+//
+// A Counter without a Redis client is disabled and silently records nothing.
+type Counter struct {
+	rdb *redis.Client
+
+	mu   sync.Mutex
+	day  string
+	salt string
+}
+
+// NewCounter creates a Counter backed by the given Redis client.
+//
+// Parameters:
+//   - rdb: The Redis client holding the HyperLogLogs, or nil to disable counting.
+//
+// Returns:
+//   - A pointer to the initialized Counter.
+func NewCounter(rdb *redis.Client) *Counter {
+	return &Counter{rdb: rdb}
+}
+
+// Add records a visit to a link. Visits without an IP address or user agent cannot be
+// fingerprinted reliably and are ignored.
+//
+// Parameters:
+//   - ctx: The context for managing request deadlines and cancellations.
+//   - linkID: The ID of the visited link.
+//   - ip: The IP address of the visitor. It is only hashed, never stored.
+//   - userAgent: The User-Agent header of the visit.
+//   - at: When the visit happened; selects the daily salt and counter.
+//
+// Returns:
+//   - An error if Redis cannot be reached.
+func (c *Counter) Add(ctx context.Context, linkID, ip, userAgent string, at time.Time) error {
+	if c.rdb == nil || ip == "" || userAgent == "" {
+		return nil
+	}
+
+	salt, err := c.dailySalt(ctx, at)
+	if err != nil {
+		return err
+	}
+
+	fingerprint := Fingerprint(salt, ip, userAgent)
+	dayKey := DayKey(linkID, at)
+
+	_, err = c.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.PFAdd(ctx, dayKey, fingerprint)
+		pipe.Expire(ctx, dayKey, dayRetention)
+		pipe.PFAdd(ctx, TotalKey(linkID), fingerprint)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to count visitor: %v", err)
+	}
+	return nil
+}
+
+// Fingerprint derives the visitor fingerprint added to the HyperLogLogs.
+func Fingerprint(salt, ip, userAgent string) string {
+	sum := sha256.Sum256([]byte(salt + "\x00" + ip + "\x00" + userAgent))
+	return hex.EncodeToString(sum[:])
+}
+
+// dailySalt returns the salt of the UTC day containing t, creating it on first use.
+// The current day's salt is cached in memory to spare a round trip per click.
+func (c *Counter) dailySalt(ctx context.Context, t time.Time) (string, error) {
+	day := t.UTC().Format("2006-01-02")
+
+	c.mu.Lock()
+	if c.day == day {
+		salt := c.salt
+		c.mu.Unlock()
+		return salt, nil
+	}
+	c.mu.Unlock()
+
+	candidate := make([]byte, 32)
+	if _, err := rand.Read(candidate); err != nil {
+		return "", fmt.Errorf("failed to generate visitor salt: %v", err)
+	}
+
+	key := saltKey(t)
+	if err := c.rdb.SetNX(ctx, key, hex.EncodeToString(candidate), saltRetention).Err(); err != nil {
+		return "", fmt.Errorf("failed to store visitor salt: %v", err)
+	}
+	salt, err := c.rdb.Get(ctx, key).Result()
+	if err != nil {
+		return "", fmt.Errorf("failed to get visitor salt: %v", err)
+	}
+
+	c.mu.Lock()
+	c.day = day
+	c.salt = salt
+	c.mu.Unlock()
+	return salt, nil
+}
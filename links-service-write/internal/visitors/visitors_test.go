@@ -0,0 +1,67 @@
+package visitors
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCounter(t *testing.T) (*Counter, *redis.Client, *miniredis.Miniredis) {
+	s, err := miniredis.Run()
+	require.NoError(t, err, "Failed to start miniredis")
+	t.Cleanup(s.Close)
+
+	rdb := redis.NewClient(&redis.Options{Addr: s.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+	return NewCounter(rdb), rdb, s
+}
+
+func TestCounterAdd(t *testing.T) {
+	ctx := context.Background()
+	day := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Repeated visits count once", func(t *testing.T) {
+		counter, rdb, _ := newTestCounter(t)
+
+		for i := 0; i < 50; i++ {
+			require.NoError(t, counter.Add(ctx, "link1", "203.0.113.7", "Mozilla/5.0", day))
+		}
+		for i := 0; i < 10; i++ {
+			require.NoError(t, counter.Add(ctx, "link1", fmt.Sprintf("198.51.100.%d", i), "Mozilla/5.0", day))
+		}
+
+		require.Equal(t, int64(11), rdb.PFCount(ctx, DayKey("link1", day)).Val())
+		require.Equal(t, int64(11), rdb.PFCount(ctx, TotalKey("link1")).Val())
+		require.Equal(t, int64(0), rdb.PFCount(ctx, TotalKey("link2")).Val())
+	})
+
+	t.Run("Salts rotate daily and expire", func(t *testing.T) {
+		counter, rdb, s := newTestCounter(t)
+
+		require.NoError(t, counter.Add(ctx, "link1", "203.0.113.7", "Mozilla/5.0", day))
+		require.NoError(t, counter.Add(ctx, "link1", "203.0.113.7", "Mozilla/5.0", day.AddDate(0, 0, 1)))
+
+		require.Equal(t, int64(2), rdb.PFCount(ctx, TotalKey("link1")).Val(), "The same visitor on another day is a new fingerprint")
+		salt := rdb.Get(ctx, saltKey(day)).Val()
+		require.NotEmpty(t, salt, "Salt should be stored")
+		require.NotEqual(t, salt, rdb.Get(ctx, saltKey(day.AddDate(0, 0, 1))).Val())
+		require.Equal(t, saltRetention, s.TTL(saltKey(day)))
+	})
+
+	t.Run("Visits without IP or user agent are ignored", func(t *testing.T) {
+		counter, rdb, _ := newTestCounter(t)
+
+		require.NoError(t, counter.Add(ctx, "link1", "", "Mozilla/5.0", day))
+		require.NoError(t, counter.Add(ctx, "link1", "203.0.113.7", "", day))
+		require.Equal(t, int64(0), rdb.PFCount(ctx, TotalKey("link1")).Val())
+	})
+
+	t.Run("Disabled counter records nothing", func(t *testing.T) {
+		require.NoError(t, NewCounter(nil).Add(ctx, "link1", "203.0.113.7", "Mozilla/5.0", day))
+	})
+}
@@ -19,10 +19,21 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	LinksServiceWrite_CreateLink_FullMethodName       = "/links_write.LinksServiceWrite/CreateLink"
-	LinksServiceWrite_DeleteLink_FullMethodName       = "/links_write.LinksServiceWrite/DeleteLink"
-	LinksServiceWrite_UpdateLink_FullMethodName       = "/links_write.LinksServiceWrite/UpdateLink"
-	LinksServiceWrite_UpdateLinkClicks_FullMethodName = "/links_write.LinksServiceWrite/UpdateLinkClicks"
+	LinksServiceWrite_CreateLink_FullMethodName                 = "/links_write.LinksServiceWrite/CreateLink"
+	LinksServiceWrite_DeleteLink_FullMethodName                 = "/links_write.LinksServiceWrite/DeleteLink"
+	LinksServiceWrite_UpdateLink_FullMethodName                 = "/links_write.LinksServiceWrite/UpdateLink"
+	LinksServiceWrite_UpdateLinkClicks_FullMethodName           = "/links_write.LinksServiceWrite/UpdateLinkClicks"
+	LinksServiceWrite_RecordClick_FullMethodName                = "/links_write.LinksServiceWrite/RecordClick"
+	LinksServiceWrite_RegisterDomain_FullMethodName             = "/links_write.LinksServiceWrite/RegisterDomain"
+	LinksServiceWrite_VerifyDomain_FullMethodName               = "/links_write.LinksServiceWrite/VerifyDomain"
+	LinksServiceWrite_GetCustomerDomains_FullMethodName         = "/links_write.LinksServiceWrite/GetCustomerDomains"
+	LinksServiceWrite_BulkCreateLinks_FullMethodName            = "/links_write.LinksServiceWrite/BulkCreateLinks"
+	LinksServiceWrite_CreateUTMTemplate_FullMethodName          = "/links_write.LinksServiceWrite/CreateUTMTemplate"
+	LinksServiceWrite_GetCustomerUTMTemplates_FullMethodName    = "/links_write.LinksServiceWrite/GetCustomerUTMTemplates"
+	LinksServiceWrite_DeleteUTMTemplate_FullMethodName          = "/links_write.LinksServiceWrite/DeleteUTMTemplate"
+	LinksServiceWrite_GetCustomerLinkSettings_FullMethodName    = "/links_write.LinksServiceWrite/GetCustomerLinkSettings"
+	LinksServiceWrite_UpdateCustomerLinkSettings_FullMethodName = "/links_write.LinksServiceWrite/UpdateCustomerLinkSettings"
+	LinksServiceWrite_DeleteDomain_FullMethodName               = "/links_write.LinksServiceWrite/DeleteDomain"
 )
 
 // LinksServiceWriteClient is the client API for LinksServiceWrite service.
@@ -33,6 +44,17 @@ type LinksServiceWriteClient interface {
 	DeleteLink(ctx context.Context, in *DeleteLinkRequest, opts ...grpc.CallOption) (*DeleteLinkResponse, error)
 	UpdateLink(ctx context.Context, in *UpdateLinkRequest, opts ...grpc.CallOption) (*UpdateLinkResponse, error)
 	UpdateLinkClicks(ctx context.Context, in *UpdateLinkClicksRequest, opts ...grpc.CallOption) (*UpdateLinkClicksResponse, error)
+	RecordClick(ctx context.Context, in *RecordClickRequest, opts ...grpc.CallOption) (*RecordClickResponse, error)
+	RegisterDomain(ctx context.Context, in *RegisterDomainRequest, opts ...grpc.CallOption) (*DomainResponse, error)
+	VerifyDomain(ctx context.Context, in *VerifyDomainRequest, opts ...grpc.CallOption) (*DomainResponse, error)
+	GetCustomerDomains(ctx context.Context, in *GetCustomerDomainsRequest, opts ...grpc.CallOption) (*GetCustomerDomainsResponse, error)
+	BulkCreateLinks(ctx context.Context, in *BulkCreateLinksRequest, opts ...grpc.CallOption) (*BulkCreateLinksResponse, error)
+	CreateUTMTemplate(ctx context.Context, in *CreateUTMTemplateRequest, opts ...grpc.CallOption) (*UTMTemplateResponse, error)
+	GetCustomerUTMTemplates(ctx context.Context, in *GetCustomerUTMTemplatesRequest, opts ...grpc.CallOption) (*GetCustomerUTMTemplatesResponse, error)
+	DeleteUTMTemplate(ctx context.Context, in *DeleteUTMTemplateRequest, opts ...grpc.CallOption) (*DeleteUTMTemplateResponse, error)
+	GetCustomerLinkSettings(ctx context.Context, in *GetCustomerLinkSettingsRequest, opts ...grpc.CallOption) (*CustomerLinkSettingsResponse, error)
+	UpdateCustomerLinkSettings(ctx context.Context, in *UpdateCustomerLinkSettingsRequest, opts ...grpc.CallOption) (*CustomerLinkSettingsResponse, error)
+	DeleteDomain(ctx context.Context, in *DeleteDomainRequest, opts ...grpc.CallOption) (*DeleteDomainResponse, error)
 }
 
 type linksServiceWriteClient struct {
@@ -83,6 +105,116 @@ func (c *linksServiceWriteClient) UpdateLinkClicks(ctx context.Context, in *Upda
 	return out, nil
 }
 
+func (c *linksServiceWriteClient) RecordClick(ctx context.Context, in *RecordClickRequest, opts ...grpc.CallOption) (*RecordClickResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecordClickResponse)
+	err := c.cc.Invoke(ctx, LinksServiceWrite_RecordClick_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *linksServiceWriteClient) RegisterDomain(ctx context.Context, in *RegisterDomainRequest, opts ...grpc.CallOption) (*DomainResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DomainResponse)
+	err := c.cc.Invoke(ctx, LinksServiceWrite_RegisterDomain_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *linksServiceWriteClient) VerifyDomain(ctx context.Context, in *VerifyDomainRequest, opts ...grpc.CallOption) (*DomainResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DomainResponse)
+	err := c.cc.Invoke(ctx, LinksServiceWrite_VerifyDomain_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *linksServiceWriteClient) GetCustomerDomains(ctx context.Context, in *GetCustomerDomainsRequest, opts ...grpc.CallOption) (*GetCustomerDomainsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCustomerDomainsResponse)
+	err := c.cc.Invoke(ctx, LinksServiceWrite_GetCustomerDomains_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *linksServiceWriteClient) BulkCreateLinks(ctx context.Context, in *BulkCreateLinksRequest, opts ...grpc.CallOption) (*BulkCreateLinksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkCreateLinksResponse)
+	err := c.cc.Invoke(ctx, LinksServiceWrite_BulkCreateLinks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *linksServiceWriteClient) CreateUTMTemplate(ctx context.Context, in *CreateUTMTemplateRequest, opts ...grpc.CallOption) (*UTMTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UTMTemplateResponse)
+	err := c.cc.Invoke(ctx, LinksServiceWrite_CreateUTMTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *linksServiceWriteClient) GetCustomerUTMTemplates(ctx context.Context, in *GetCustomerUTMTemplatesRequest, opts ...grpc.CallOption) (*GetCustomerUTMTemplatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCustomerUTMTemplatesResponse)
+	err := c.cc.Invoke(ctx, LinksServiceWrite_GetCustomerUTMTemplates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *linksServiceWriteClient) DeleteUTMTemplate(ctx context.Context, in *DeleteUTMTemplateRequest, opts ...grpc.CallOption) (*DeleteUTMTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteUTMTemplateResponse)
+	err := c.cc.Invoke(ctx, LinksServiceWrite_DeleteUTMTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *linksServiceWriteClient) GetCustomerLinkSettings(ctx context.Context, in *GetCustomerLinkSettingsRequest, opts ...grpc.CallOption) (*CustomerLinkSettingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CustomerLinkSettingsResponse)
+	err := c.cc.Invoke(ctx, LinksServiceWrite_GetCustomerLinkSettings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *linksServiceWriteClient) UpdateCustomerLinkSettings(ctx context.Context, in *UpdateCustomerLinkSettingsRequest, opts ...grpc.CallOption) (*CustomerLinkSettingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CustomerLinkSettingsResponse)
+	err := c.cc.Invoke(ctx, LinksServiceWrite_UpdateCustomerLinkSettings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *linksServiceWriteClient) DeleteDomain(ctx context.Context, in *DeleteDomainRequest, opts ...grpc.CallOption) (*DeleteDomainResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteDomainResponse)
+	err := c.cc.Invoke(ctx, LinksServiceWrite_DeleteDomain_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // LinksServiceWriteServer is the server API for LinksServiceWrite service.
 // All implementations must embed UnimplementedLinksServiceWriteServer
 // for forward compatibility.
@@ -91,6 +223,17 @@ type LinksServiceWriteServer interface {
 	DeleteLink(context.Context, *DeleteLinkRequest) (*DeleteLinkResponse, error)
 	UpdateLink(context.Context, *UpdateLinkRequest) (*UpdateLinkResponse, error)
 	UpdateLinkClicks(context.Context, *UpdateLinkClicksRequest) (*UpdateLinkClicksResponse, error)
+	RecordClick(context.Context, *RecordClickRequest) (*RecordClickResponse, error)
+	RegisterDomain(context.Context, *RegisterDomainRequest) (*DomainResponse, error)
+	VerifyDomain(context.Context, *VerifyDomainRequest) (*DomainResponse, error)
+	GetCustomerDomains(context.Context, *GetCustomerDomainsRequest) (*GetCustomerDomainsResponse, error)
+	BulkCreateLinks(context.Context, *BulkCreateLinksRequest) (*BulkCreateLinksResponse, error)
+	CreateUTMTemplate(context.Context, *CreateUTMTemplateRequest) (*UTMTemplateResponse, error)
+	GetCustomerUTMTemplates(context.Context, *GetCustomerUTMTemplatesRequest) (*GetCustomerUTMTemplatesResponse, error)
+	DeleteUTMTemplate(context.Context, *DeleteUTMTemplateRequest) (*DeleteUTMTemplateResponse, error)
+	GetCustomerLinkSettings(context.Context, *GetCustomerLinkSettingsRequest) (*CustomerLinkSettingsResponse, error)
+	UpdateCustomerLinkSettings(context.Context, *UpdateCustomerLinkSettingsRequest) (*CustomerLinkSettingsResponse, error)
+	DeleteDomain(context.Context, *DeleteDomainRequest) (*DeleteDomainResponse, error)
 	mustEmbedUnimplementedLinksServiceWriteServer()
 }
 
@@ -113,6 +256,39 @@ func (UnimplementedLinksServiceWriteServer) UpdateLink(context.Context, *UpdateL
 func (UnimplementedLinksServiceWriteServer) UpdateLinkClicks(context.Context, *UpdateLinkClicksRequest) (*UpdateLinkClicksResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateLinkClicks not implemented")
 }
+func (UnimplementedLinksServiceWriteServer) RecordClick(context.Context, *RecordClickRequest) (*RecordClickResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordClick not implemented")
+}
+func (UnimplementedLinksServiceWriteServer) RegisterDomain(context.Context, *RegisterDomainRequest) (*DomainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterDomain not implemented")
+}
+func (UnimplementedLinksServiceWriteServer) VerifyDomain(context.Context, *VerifyDomainRequest) (*DomainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyDomain not implemented")
+}
+func (UnimplementedLinksServiceWriteServer) GetCustomerDomains(context.Context, *GetCustomerDomainsRequest) (*GetCustomerDomainsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCustomerDomains not implemented")
+}
+func (UnimplementedLinksServiceWriteServer) BulkCreateLinks(context.Context, *BulkCreateLinksRequest) (*BulkCreateLinksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkCreateLinks not implemented")
+}
+func (UnimplementedLinksServiceWriteServer) CreateUTMTemplate(context.Context, *CreateUTMTemplateRequest) (*UTMTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUTMTemplate not implemented")
+}
+func (UnimplementedLinksServiceWriteServer) GetCustomerUTMTemplates(context.Context, *GetCustomerUTMTemplatesRequest) (*GetCustomerUTMTemplatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCustomerUTMTemplates not implemented")
+}
+func (UnimplementedLinksServiceWriteServer) DeleteUTMTemplate(context.Context, *DeleteUTMTemplateRequest) (*DeleteUTMTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteUTMTemplate not implemented")
+}
+func (UnimplementedLinksServiceWriteServer) GetCustomerLinkSettings(context.Context, *GetCustomerLinkSettingsRequest) (*CustomerLinkSettingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCustomerLinkSettings not implemented")
+}
+func (UnimplementedLinksServiceWriteServer) UpdateCustomerLinkSettings(context.Context, *UpdateCustomerLinkSettingsRequest) (*CustomerLinkSettingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCustomerLinkSettings not implemented")
+}
+func (UnimplementedLinksServiceWriteServer) DeleteDomain(context.Context, *DeleteDomainRequest) (*DeleteDomainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteDomain not implemented")
+}
 func (UnimplementedLinksServiceWriteServer) mustEmbedUnimplementedLinksServiceWriteServer() {}
 func (UnimplementedLinksServiceWriteServer) testEmbeddedByValue()                           {}
 
@@ -206,6 +382,204 @@ func _LinksServiceWrite_UpdateLinkClicks_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LinksServiceWrite_RecordClick_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordClickRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LinksServiceWriteServer).RecordClick(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LinksServiceWrite_RecordClick_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LinksServiceWriteServer).RecordClick(ctx, req.(*RecordClickRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LinksServiceWrite_RegisterDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterDomainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LinksServiceWriteServer).RegisterDomain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LinksServiceWrite_RegisterDomain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LinksServiceWriteServer).RegisterDomain(ctx, req.(*RegisterDomainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LinksServiceWrite_VerifyDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyDomainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LinksServiceWriteServer).VerifyDomain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LinksServiceWrite_VerifyDomain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LinksServiceWriteServer).VerifyDomain(ctx, req.(*VerifyDomainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LinksServiceWrite_GetCustomerDomains_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCustomerDomainsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LinksServiceWriteServer).GetCustomerDomains(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LinksServiceWrite_GetCustomerDomains_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LinksServiceWriteServer).GetCustomerDomains(ctx, req.(*GetCustomerDomainsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LinksServiceWrite_BulkCreateLinks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkCreateLinksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LinksServiceWriteServer).BulkCreateLinks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LinksServiceWrite_BulkCreateLinks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LinksServiceWriteServer).BulkCreateLinks(ctx, req.(*BulkCreateLinksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LinksServiceWrite_CreateUTMTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateUTMTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LinksServiceWriteServer).CreateUTMTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LinksServiceWrite_CreateUTMTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LinksServiceWriteServer).CreateUTMTemplate(ctx, req.(*CreateUTMTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LinksServiceWrite_GetCustomerUTMTemplates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCustomerUTMTemplatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LinksServiceWriteServer).GetCustomerUTMTemplates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LinksServiceWrite_GetCustomerUTMTemplates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LinksServiceWriteServer).GetCustomerUTMTemplates(ctx, req.(*GetCustomerUTMTemplatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LinksServiceWrite_DeleteUTMTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteUTMTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LinksServiceWriteServer).DeleteUTMTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LinksServiceWrite_DeleteUTMTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LinksServiceWriteServer).DeleteUTMTemplate(ctx, req.(*DeleteUTMTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LinksServiceWrite_GetCustomerLinkSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCustomerLinkSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LinksServiceWriteServer).GetCustomerLinkSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LinksServiceWrite_GetCustomerLinkSettings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LinksServiceWriteServer).GetCustomerLinkSettings(ctx, req.(*GetCustomerLinkSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LinksServiceWrite_UpdateCustomerLinkSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCustomerLinkSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LinksServiceWriteServer).UpdateCustomerLinkSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LinksServiceWrite_UpdateCustomerLinkSettings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LinksServiceWriteServer).UpdateCustomerLinkSettings(ctx, req.(*UpdateCustomerLinkSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LinksServiceWrite_DeleteDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteDomainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LinksServiceWriteServer).DeleteDomain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LinksServiceWrite_DeleteDomain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LinksServiceWriteServer).DeleteDomain(ctx, req.(*DeleteDomainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // LinksServiceWrite_ServiceDesc is the grpc.ServiceDesc for LinksServiceWrite service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -229,6 +603,50 @@ var LinksServiceWrite_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateLinkClicks",
 			Handler:    _LinksServiceWrite_UpdateLinkClicks_Handler,
 		},
+		{
+			MethodName: "RecordClick",
+			Handler:    _LinksServiceWrite_RecordClick_Handler,
+		},
+		{
+			MethodName: "RegisterDomain",
+			Handler:    _LinksServiceWrite_RegisterDomain_Handler,
+		},
+		{
+			MethodName: "VerifyDomain",
+			Handler:    _LinksServiceWrite_VerifyDomain_Handler,
+		},
+		{
+			MethodName: "GetCustomerDomains",
+			Handler:    _LinksServiceWrite_GetCustomerDomains_Handler,
+		},
+		{
+			MethodName: "BulkCreateLinks",
+			Handler:    _LinksServiceWrite_BulkCreateLinks_Handler,
+		},
+		{
+			MethodName: "CreateUTMTemplate",
+			Handler:    _LinksServiceWrite_CreateUTMTemplate_Handler,
+		},
+		{
+			MethodName: "GetCustomerUTMTemplates",
+			Handler:    _LinksServiceWrite_GetCustomerUTMTemplates_Handler,
+		},
+		{
+			MethodName: "DeleteUTMTemplate",
+			Handler:    _LinksServiceWrite_DeleteUTMTemplate_Handler,
+		},
+		{
+			MethodName: "GetCustomerLinkSettings",
+			Handler:    _LinksServiceWrite_GetCustomerLinkSettings_Handler,
+		},
+		{
+			MethodName: "UpdateCustomerLinkSettings",
+			Handler:    _LinksServiceWrite_UpdateCustomerLinkSettings_Handler,
+		},
+		{
+			MethodName: "DeleteDomain",
+			Handler:    _LinksServiceWrite_DeleteDomain_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/links_write.proto",
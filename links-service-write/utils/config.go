@@ -2,11 +2,24 @@ package utils
 
 import (
 	"os"
+	"time"
 )
 
 type Config struct {
 	FrontendSource string
 	DynamoEndpoint string
+	IPHashSecret   string
+	GeoIPPath      string
+	GeoIPReload    time.Duration
+	GeoIPASNPath   string
+	BotRulesPath   string
+	SlugSettings   string
+	SlugPolicyPath string
+	DNSResolver    string
+	RedisHost      string
+	RedisPort      string
+	LinksStore     string
+	DBSource       string
 }
 
 var (
@@ -17,10 +30,48 @@ var (
 // It retrieves the following environment variables:
 // - FRONTEND_SOURCE: The source URL for the frontend.
 // - DYNAMODB_ENDPOINT: The endpoint URL for DynamoDB.
+// - IP_HASH_SECRET: The key used to hash visitor IP addresses before they are stored.
+// - GEOIP_DATABASE_PATH: The local GeoLite2/GeoIP2 City (.mmdb) file used to geolocate clicks.
+// - GEOIP_RELOAD_INTERVAL: How often the GeoIP files are checked for changes (defaults to 1m).
+// - GEOIP_ASN_DATABASE_PATH: The local GeoLite2 ASN (.mmdb) file used to spot clicks from hosting networks.
+// - BOT_RULES_PATH: A JSON ruleset replacing the built-in bot filtering rules (optional).
+// - SLUG_SETTINGS_PATH: A JSON file replacing the built-in short code generator settings (optional).
+// - SLUG_POLICY_PATH: A JSON file replacing the built-in policy custom slugs must follow (optional).
+// - DNS_RESOLVER: The host:port of the DNS server checking branded domain TXT records (defaults to the system resolver).
+// - REDIS_HOST / REDIS_PORT: The Redis server holding unique visitor counters and live clicks (optional).
+// - LINKS_STORE: The storage backend of links and clicks: dynamodb (default), postgres or memory.
+// - DB_SOURCE: The PostgreSQL connection string, used when LINKS_STORE is postgres.
 // These values are used to populate the Config struct.
 func LoadEnvInstance() {
 	ConfigInstance = Config{
 		FrontendSource: os.Getenv("FRONTEND_SOURCE"),
 		DynamoEndpoint: os.Getenv("DYNAMODB_ENDPOINT"),
+		IPHashSecret:   os.Getenv("IP_HASH_SECRET"),
+		GeoIPPath:      os.Getenv("GEOIP_DATABASE_PATH"),
+		GeoIPReload:    durationEnv("GEOIP_RELOAD_INTERVAL", time.Minute),
+		GeoIPASNPath:   os.Getenv("GEOIP_ASN_DATABASE_PATH"),
+		BotRulesPath:   os.Getenv("BOT_RULES_PATH"),
+		SlugSettings:   os.Getenv("SLUG_SETTINGS_PATH"),
+		SlugPolicyPath: os.Getenv("SLUG_POLICY_PATH"),
+		DNSResolver:    os.Getenv("DNS_RESOLVER"),
+		RedisHost:      os.Getenv("REDIS_HOST"),
+		RedisPort:      os.Getenv("REDIS_PORT"),
+		LinksStore:     stringEnv("LINKS_STORE", "dynamodb"),
+		DBSource:       os.Getenv("DB_SOURCE"),
 	}
 }
+
+func durationEnv(key string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+func stringEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,26 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// HashIP returns a keyed SHA-256 digest of the given IP address so that click events
+// can be correlated without storing the raw address.
+//
+// Parameters:
+//   - ip: The visitor IP address. An empty string yields an empty hash.
+//   - secret: The HMAC key; rotating it makes previously stored hashes unlinkable.
+//
+// Returns:
+//   - The hex-encoded HMAC-SHA256 of the IP address.
+func HashIP(ip, secret string) string {
+	if ip == "" {
+		return ""
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ip))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -2,8 +2,11 @@ package utils
 
 import "crypto/rand"
 
+// Base62 is the charset of generated slugs and IDs.
+const Base62 = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
 // GenerateRandomSlug generates a random alphanumeric string of the specified length.
-// The generated string is composed of characters from the charset "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789".
+// The generated string is composed of characters from the Base62 charset.
 // It uses a cryptographically secure random number generator to ensure randomness.
 //
 // Parameters:
@@ -13,14 +16,38 @@ import "crypto/rand"
 //   - A randomly generated alphanumeric string of the specified length.
 //   - An error if the random number generator fails.
 func GenerateRandomSlug(length int) (string, error) {
-	const charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
-	b := make([]byte, length)
-	if _, err := rand.Read(b); err != nil {
-		return "", err
-	}
+	return RandomString(Base62, length)
+}
 
-	for i := range b {
-		b[i] = charset[int(b[i])%len(charset)]
+// RandomString generates a random string of the specified length from the characters of
+// charset, each of them equally likely. Random bytes that would map unevenly onto the
+// charset are rejected rather than reduced modulo its size, which would favor its
+// first characters.
+//
+// Parameters:
+//   - charset: The characters to draw from; it must hold between 1 and 256 bytes.
+//   - length: The desired length of the generated string.
+//
+// Returns:
+//   - A random string of the specified length.
+//   - An error if the random number generator fails.
+func RandomString(charset string, length int) (string, error) {
+	limit := 256 - 256%len(charset)
+	b := make([]byte, 0, length)
+	buf := make([]byte, length+length/4+1)
+	for len(b) < length {
+		if _, err := rand.Read(buf); err != nil {
+			return "", err
+		}
+		for _, r := range buf {
+			if int(r) >= limit {
+				continue
+			}
+			b = append(b, charset[int(r)%len(charset)])
+			if len(b) == length {
+				break
+			}
+		}
 	}
 	return string(b), nil
 }
@@ -0,0 +1,28 @@
+package utils
+
+import "net/url"
+
+// ShortURL returns the public URL of a slug: on the branded domain when there is one,
+// otherwise on the frontend.
+//
+// Parameters:
+//   - domain: The branded domain of the link, or "" for the default one.
+//   - slug: The slug of the link.
+//
+// Returns:
+//   - The short URL, such as "https://go.example.com/sale" or "<FRONTEND_SOURCE>/sale".
+func ShortURL(domain, slug string) string {
+	if domain == "" {
+		return ConfigInstance.FrontendSource + "/" + slug
+	}
+	return "https://" + domain + "/" + slug
+}
+
+// FrontendHost returns the host name of FRONTEND_SOURCE, or "" if it is not a URL.
+func FrontendHost() string {
+	u, err := url.Parse(ConfigInstance.FrontendSource)
+	if err != nil {
+		return ""
+	}
+	return u.Hostname()
+}